@@ -59,13 +59,7 @@ func NewSyncerCommand() *cobra.Command {
 			}
 
 			ctx := genericapiserver.SetupSignalContext()
-			if err := Run(ctx, options); err != nil {
-				return err
-			}
-
-			<-ctx.Done()
-
-			return nil
+			return Run(ctx, options)
 		},
 	}
 
@@ -116,7 +110,7 @@ func Run(ctx context.Context, options *synceroptions.Options) error {
 		return errors.New("missing environment variable: NAMESPACE")
 	}
 
-	return syncer.StartSyncer(
+	stopped, err := syncer.StartSyncer(
 		ctx,
 		&syncer.SyncerConfig{
 			UpstreamConfig:                upstreamConfig,
@@ -127,9 +121,18 @@ func Run(ctx context.Context, options *synceroptions.Options) error {
 			SyncTargetUID:                 options.SyncTargetUID,
 			DNSImage:                      options.DNSImage,
 			DownstreamNamespaceCleanDelay: options.DownstreamNamespaceCleanDelay,
+			EndpointDrainGracePeriod:      options.EndpointDrainGracePeriod,
 		},
 		numThreads,
 		options.APIImportPollInterval,
 		namespace,
 	)
+	if err != nil {
+		return err
+	}
+
+	// wait for the syncer to stop, e.g. for the Endpoints being updated to be finished.
+	<-stopped
+
+	return nil
 }
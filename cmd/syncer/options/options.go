@@ -45,6 +45,7 @@ type Options struct {
 	SyncedResourceTypes           []string
 	DNSImage                      string
 	DownstreamNamespaceCleanDelay time.Duration
+	EndpointDrainGracePeriod      time.Duration
 
 	APIImportPollInterval time.Duration
 }
@@ -61,6 +62,7 @@ func NewOptions() *Options {
 		Logs:                          logs,
 		APIImportPollInterval:         1 * time.Minute,
 		DownstreamNamespaceCleanDelay: 30 * time.Second,
+		EndpointDrainGracePeriod:      10 * time.Second,
 	}
 }
 
@@ -82,6 +84,7 @@ func (options *Options) AddFlags(fs *pflag.FlagSet) {
 		"Options are:\n"+strings.Join(kcpfeatures.KnownFeatures(), "\n")) // hide kube-only gates
 	fs.StringVar(&options.DNSImage, "dns-image", options.DNSImage, "kcp DNS server image.")
 	fs.DurationVar(&options.DownstreamNamespaceCleanDelay, "downstream-namespace-clean-delay", options.DownstreamNamespaceCleanDelay, "Time to wait before deleting a downstream namespace, defaults to 30s.")
+	fs.DurationVar(&options.EndpointDrainGracePeriod, "endpoint-drain-grace-period", options.EndpointDrainGracePeriod, "Time to wait on shutdown for Endpoints being updated to be finished, defaults to 10s.")
 
 	options.Logs.AddFlags(fs)
 }
@@ -100,5 +103,8 @@ func (options *Options) Validate() error {
 	if options.SyncTargetUID == "" {
 		return errors.New("--sync-target-uid is required")
 	}
+	if options.EndpointDrainGracePeriod < 0 {
+		return errors.New("--endpoint-drain-grace-period must not be negative")
+	}
 	return nil
 }
@@ -0,0 +1,261 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// gen-client-expansions generates the client-gen expansions of the typed clients, i.e. the
+// ListFrom, Count and WatchObject methods of the typed clients and their fakes, and the adapters
+// from scoped listers to cluster listers. It has to run after client-gen and the kcp
+// code-generator. As client-gen is run with --trim-path-prefix, it does not find the expansion
+// files and declares empty expansion interfaces for all types, which are removed here.
+package main
+
+import (
+	"bytes"
+	"embed"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// pluralExceptions are the plural exceptions client-gen uses by default.
+var pluralExceptions = map[string]string{"Endpoints": "Endpoints"}
+
+// kind is a type client-gen generates a client for.
+type kind struct {
+	Header string
+
+	// Name is the name of the type, e.g. APIExport.
+	Name string
+	// Plural is the plural of the type, e.g. APIExports.
+	Plural string
+	// PrivatePlural is the name of the typed client struct, e.g. aPIExports.
+	PrivatePlural string
+	// Resource is the resource of the type, e.g. apiexports.
+	Resource string
+
+	// Group is the directory of the API group of the type, e.g. apis.
+	Group string
+	// Version is the version of the type, and the package name of the clients and listers.
+	Version string
+	// APIPackage is the import path of the package of the type.
+	APIPackage string
+	// APIAlias is the import alias of the package of the type, e.g. apisv1alpha1.
+	APIAlias string
+}
+
+// output is a file generated per kind.
+type output struct {
+	template string
+	path     func(k kind) string
+}
+
+func main() {
+	var (
+		apiDir         = flag.String("api-dir", "./pkg/apis", "Directory of the API groups.")
+		apiPackagePath = flag.String("api-package-path", "github.com/kcp-dev/kcp/pkg/apis", "Import path of the API groups.")
+		groups         = flag.String("groups", "", "Space separated group versions to generate for, e.g. \"apis:v1alpha1 tenancy:v1alpha1\".")
+		clientsetDir   = flag.String("clientset-dir", "./pkg/client/clientset/versioned", "Directory of the clientset generated by client-gen and the kcp code-generator.")
+		listersDir     = flag.String("listers-dir", "./pkg/client/listers", "Directory of the listers generated by the kcp code-generator.")
+		headerFile     = flag.String("go-header-file", "./hack/boilerplate/boilerplate.generatego.txt", "File with the header of the generated files.")
+	)
+	flag.Parse()
+
+	header, err := os.ReadFile(*headerFile)
+	if err != nil {
+		log.Fatalf("Failed to read header file: %v", err)
+	}
+
+	outputs := []output{
+		{template: "expansion.go.tmpl", path: func(k kind) string {
+			return filepath.Join(*clientsetDir, "typed", k.Group, k.Version, strings.ToLower(k.Name)+"_expansion.go")
+		}},
+		{template: "fake_expansion.go.tmpl", path: func(k kind) string {
+			return filepath.Join(*clientsetDir, "typed", k.Group, k.Version, "fake", "fake_"+strings.ToLower(k.Name)+"_expansion.go")
+		}},
+		{template: "cluster_fake_expansion.go.tmpl", path: func(k kind) string {
+			return filepath.Join(*clientsetDir, "cluster", "typed", k.Group, k.Version, "fake", strings.ToLower(k.Name)+"_expansion.go")
+		}},
+		{template: "lister_adapter.go.tmpl", path: func(k kind) string {
+			return filepath.Join(*listersDir, k.Group, k.Version, strings.ToLower(k.Name)+"_adapter.go")
+		}},
+	}
+
+	tmpl, err := template.ParseFS(templateFS, "templates/*.tmpl")
+	if err != nil {
+		log.Fatalf("Failed to parse templates: %v", err)
+	}
+
+	for _, gv := range strings.Fields(*groups) {
+		group, version, found := strings.Cut(gv, ":")
+		if !found {
+			log.Fatalf("Invalid group version %q, expected <group>:<version>", gv)
+		}
+
+		kinds, err := kindsOf(filepath.Join(*apiDir, group, version))
+		if err != nil {
+			log.Fatalf("Failed to find the types of %s: %v", gv, err)
+		}
+		for _, k := range kinds {
+			k.Header = string(header)
+			k.Group = group
+			k.Version = version
+			k.APIPackage = *apiPackagePath + "/" + group + "/" + version
+			k.APIAlias = group + version
+
+			for _, o := range outputs {
+				if err := generate(tmpl, o.template, o.path(k), k); err != nil {
+					log.Fatalf("Failed to generate %s: %v", o.path(k), err)
+				}
+			}
+		}
+
+		generatedExpansions := filepath.Join(*clientsetDir, "typed", group, version, "generated_expansion.go")
+		if err := removeExpansions(generatedExpansions, kinds); err != nil {
+			log.Fatalf("Failed to remove expansions from %s: %v", generatedExpansions, err)
+		}
+	}
+}
+
+// kindsOf returns the types of the given API package client-gen generates clients for, i.e.
+// those tagged with +genclient and not with +genclient:noVerbs.
+func kindsOf(dir string) ([]kind, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	publicPlural := namer.NewPublicPluralNamer(pluralExceptions)
+	privatePlural := namer.NewPrivatePluralNamer(pluralExceptions)
+	lowercasePlural := namer.NewAllLowercasePluralNamer(pluralExceptions)
+
+	var kinds []kind
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.TYPE {
+					continue
+				}
+				comments := tagComments(fset, file, genDecl)
+				if !hasTag(comments, "+genclient") || hasTag(comments, "+genclient:noVerbs") {
+					continue
+				}
+				for _, spec := range genDecl.Specs {
+					name := spec.(*ast.TypeSpec).Name.Name
+					t := &types.Type{Name: types.Name{Name: name}}
+					kinds = append(kinds, kind{
+						Name:          name,
+						Plural:        publicPlural.Name(t),
+						PrivatePlural: privatePlural.Name(t),
+						Resource:      lowercasePlural.Name(t),
+					})
+				}
+			}
+		}
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i].Name < kinds[j].Name })
+	return kinds, nil
+}
+
+// tagComments returns the comments tags of the given declaration are read from, like client-gen
+// does, i.e. its doc comment and the comment block separated from it by an empty line.
+func tagComments(fset *token.FileSet, file *ast.File, decl *ast.GenDecl) []*ast.Comment {
+	var comments []*ast.Comment
+	line := fset.Position(decl.Pos()).Line
+	if decl.Doc != nil {
+		comments = append(comments, decl.Doc.List...)
+		line = fset.Position(decl.Doc.Pos()).Line
+	}
+	for _, group := range file.Comments {
+		if fset.Position(group.End()).Line == line-2 {
+			comments = append(comments, group.List...)
+		}
+	}
+	return comments
+}
+
+func hasTag(comments []*ast.Comment, tag string) bool {
+	for _, c := range comments {
+		if strings.TrimSpace(strings.TrimPrefix(c.Text, "//")) == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// removeExpansions removes the expansion interfaces of the given kinds from the given file
+// generated by client-gen, and the file if no declarations are left.
+func removeExpansions(path string, kinds []kind) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	generated := map[string]bool{}
+	for _, k := range kinds {
+		generated[k.Name+"Expansion"] = true
+	}
+	var decls []ast.Decl
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.TYPE && len(genDecl.Specs) == 1 && generated[genDecl.Specs[0].(*ast.TypeSpec).Name.Name] {
+			continue
+		}
+		decls = append(decls, decl)
+	}
+	if len(decls) == 0 {
+		return os.Remove(path)
+	}
+	file.Decls = decls
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+func generate(tmpl *template.Template, name, path string, k kind) error {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, k); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format: %w", err)
+	}
+	return os.WriteFile(path, src, 0644)
+}
@@ -27,22 +27,47 @@ BOILERPLATE_HEADER="$( pwd )/hack/boilerplate/boilerplate.go.txt"
 popd
 CODEGEN_PKG=${CODEGEN_PKG:-$(cd "${SCRIPT_ROOT}"; go list -f '{{.Dir}}' -m k8s.io/code-generator)}
 
-bash "${CODEGEN_PKG}"/generate-groups.sh "deepcopy,client" \
+bash "${CODEGEN_PKG}"/generate-groups.sh "deepcopy" \
   github.com/kcp-dev/kcp/pkg/client github.com/kcp-dev/kcp/pkg/apis \
   "core:v1alpha1 workload:v1alpha1 apiresource:v1alpha1 tenancy:v1alpha1 apis:v1alpha1 scheduling:v1alpha1 topology:v1alpha1" \
   --go-header-file "${SCRIPT_ROOT}"/hack/boilerplate/boilerplate.generatego.txt \
   --output-base "${SCRIPT_ROOT}" \
   --trim-path-prefix github.com/kcp-dev/kcp
 
+go install "${CODEGEN_PKG}"/cmd/applyconfiguration-gen
+go install "${CODEGEN_PKG}"/cmd/client-gen
+
+# apiresource is left out: its types embed external structs without apply configurations.
+"$GOPATH"/bin/applyconfiguration-gen \
+  --input-dirs github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1,github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1,github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1,github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1,github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1,github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1 \
+  --output-package github.com/kcp-dev/kcp/pkg/client/applyconfiguration \
+  --go-header-file "${SCRIPT_ROOT}"/hack/boilerplate/boilerplate.generatego.txt \
+  --output-base "${SCRIPT_ROOT}" \
+  --trim-path-prefix github.com/kcp-dev/kcp
+
+"$GOPATH"/bin/client-gen \
+  --clientset-name versioned \
+  --input-base "" \
+  --input github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1,github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1,github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1,github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1,github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1,github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1,github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1 \
+  --output-package github.com/kcp-dev/kcp/pkg/client/clientset \
+  --apply-configuration-package github.com/kcp-dev/kcp/pkg/client/applyconfiguration \
+  --go-header-file "${SCRIPT_ROOT}"/hack/boilerplate/boilerplate.generatego.txt \
+  --output-base "${SCRIPT_ROOT}" \
+  --trim-path-prefix github.com/kcp-dev/kcp
+
 pushd ./pkg/apis
 ${CODE_GENERATOR} \
-  "client:outputPackagePath=github.com/kcp-dev/kcp/pkg/client,apiPackagePath=github.com/kcp-dev/kcp/pkg/apis,singleClusterClientPackagePath=github.com/kcp-dev/kcp/pkg/client/clientset/versioned,headerFile=${BOILERPLATE_HEADER}" \
+  "client:outputPackagePath=github.com/kcp-dev/kcp/pkg/client,apiPackagePath=github.com/kcp-dev/kcp/pkg/apis,singleClusterClientPackagePath=github.com/kcp-dev/kcp/pkg/client/clientset/versioned,singleClusterApplyConfigurationsPackagePath=github.com/kcp-dev/kcp/pkg/client/applyconfiguration,headerFile=${BOILERPLATE_HEADER}" \
   "lister:apiPackagePath=github.com/kcp-dev/kcp/pkg/apis,headerFile=${BOILERPLATE_HEADER}" \
   "informer:outputPackagePath=github.com/kcp-dev/kcp/pkg/client,singleClusterClientPackagePath=github.com/kcp-dev/kcp/pkg/client/clientset/versioned,apiPackagePath=github.com/kcp-dev/kcp/pkg/apis,headerFile=${BOILERPLATE_HEADER}" \
   "paths=./..." \
   "output:dir=./../client"
 popd
 
+go run ./hack/generate/client-expansions \
+  --groups "core:v1alpha1 workload:v1alpha1 apiresource:v1alpha1 tenancy:v1alpha1 apis:v1alpha1 scheduling:v1alpha1 topology:v1alpha1" \
+  --go-header-file "${SCRIPT_ROOT}"/hack/boilerplate/boilerplate.generatego.txt
+
 bash "${CODEGEN_PKG}"/generate-groups.sh "deepcopy" \
   github.com/kcp-dev/kcp/third_party/conditions/client github.com/kcp-dev/kcp/third_party/conditions/apis \
   "conditions:v1alpha1" \
@@ -35,6 +35,7 @@ import (
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
 
 	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
@@ -51,10 +52,16 @@ const (
 
 func Register(plugins *admission.Plugins) {
 	plugins.Register(PluginName,
-		func(_ io.Reader) (admission.Interface, error) {
+		func(config io.Reader) (admission.Interface, error) {
+			cfg, err := ReadConfiguration(config)
+			if err != nil {
+				return nil, err
+			}
+
 			p := &apiBindingAdmission{
-				Handler:          admission.NewHandler(admission.Create, admission.Update),
-				createAuthorizer: delegated.NewDelegatedAuthorizer,
+				Handler:                     admission.NewHandler(admission.Create, admission.Update),
+				createAuthorizer:            delegated.NewDelegatedAuthorizer,
+				maxAcceptedPermissionClaims: cfg.MaxAcceptedPermissionClaims,
 			}
 			p.getAPIExport = func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
 				export, err := indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), p.apiExportIndexer, path, name)
@@ -78,6 +85,39 @@ type apiBindingAdmission struct {
 
 	deepSARClient    kcpkubernetesclientset.ClusterInterface
 	createAuthorizer delegated.DelegatedAuthorizerFactory
+
+	// maxAcceptedPermissionClaims bounds the number of accepted permission claims. Zero means no limit.
+	maxAcceptedPermissionClaims int
+}
+
+// Configuration is the configuration of the APIBinding admission plugin, passed via the
+// admission control config file.
+type Configuration struct {
+	// MaxAcceptedPermissionClaims is the maximum number of permission claims an APIBinding
+	// may accept. This bounds the cost of evaluating claims in the authorizers. Zero means
+	// no limit.
+	MaxAcceptedPermissionClaims int `json:"maxAcceptedPermissionClaims,omitempty"`
+}
+
+// ReadConfiguration reads the plugin configuration. An empty configuration yields the defaults.
+func ReadConfiguration(config io.Reader) (*Configuration, error) {
+	cfg := &Configuration{}
+	if config == nil || reflect.ValueOf(config).IsNil() {
+		return cfg, nil
+	}
+
+	data, err := io.ReadAll(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s admission configuration: %w", PluginName, err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode %s admission configuration: %w", PluginName, err)
+	}
+	if cfg.MaxAcceptedPermissionClaims < 0 {
+		return nil, fmt.Errorf("invalid %s admission configuration: maxAcceptedPermissionClaims must not be negative", PluginName)
+	}
+
+	return cfg, nil
 }
 
 // Ensure that the required admission interfaces are implemented.
@@ -215,6 +255,7 @@ func (o *apiBindingAdmission) Validate(ctx context.Context, a admission.Attribut
 
 		errs = ValidateAPIBindingUpdate(oldAPIBinding, apiBinding)
 	}
+	errs = append(errs, ValidateAcceptedPermissionClaimsLimit(oldAPIBinding, apiBinding, o.maxAcceptedPermissionClaims)...)
 	if len(errs) > 0 {
 		return admission.NewForbidden(a, fmt.Errorf("%v", errs))
 	}
@@ -205,11 +205,12 @@ func TestAdmit(t *testing.T) {
 
 func TestValidate(t *testing.T) {
 	tests := []struct {
-		name           string
-		attr           admission.Attributes
-		authzDecision  authorizer.Decision
-		authzError     error
-		expectedErrors []string
+		name                        string
+		attr                        admission.Attributes
+		authzDecision               authorizer.Decision
+		authzError                  error
+		maxAcceptedPermissionClaims int
+		expectedErrors              []string
 	}{
 		{
 			name: "Create: fails without reference",
@@ -428,12 +429,105 @@ func TestValidate(t *testing.T) {
 			),
 			authzDecision: authorizer.DecisionAllow,
 		},
+		{
+			name: "Create: accepted permission claims at the limit pass",
+			attr: createAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root:org:workspaceName"), "someExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-workspaceName:someExport")).
+					withPermissionClaim("configmaps", apisv1alpha1.ClaimAccepted).
+					withPermissionClaim("secrets", apisv1alpha1.ClaimAccepted).
+					withPermissionClaim("services", apisv1alpha1.ClaimRejected).APIBinding,
+			),
+			authzDecision:               authorizer.DecisionAllow,
+			maxAcceptedPermissionClaims: 2,
+		},
+		{
+			name: "Create: accepted permission claims above the limit fail",
+			attr: createAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root:org:workspaceName"), "someExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-workspaceName:someExport")).
+					withPermissionClaim("configmaps", apisv1alpha1.ClaimAccepted).
+					withPermissionClaim("secrets", apisv1alpha1.ClaimAccepted).
+					withPermissionClaim("services", apisv1alpha1.ClaimAccepted).APIBinding,
+			),
+			authzDecision:               authorizer.DecisionAllow,
+			maxAcceptedPermissionClaims: 2,
+			expectedErrors:              []string{"spec.permissionClaims: Too many: 3: must have at most 2 items"},
+		},
+		{
+			name: "Create: accepted permission claims are not limited by default",
+			attr: createAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root:org:workspaceName"), "someExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-workspaceName:someExport")).
+					withPermissionClaim("configmaps", apisv1alpha1.ClaimAccepted).
+					withPermissionClaim("secrets", apisv1alpha1.ClaimAccepted).
+					withPermissionClaim("services", apisv1alpha1.ClaimAccepted).APIBinding,
+			),
+			authzDecision: authorizer.DecisionAllow,
+		},
+		{
+			name: "Update: accepting permission claims above the limit fails",
+			attr: updateAttr(
+				newAPIBinding().
+					withReference(logicalcluster.NewPath("root:org:workspaceName"), "someExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-workspaceName:someExport")).
+					withPermissionClaim("configmaps", apisv1alpha1.ClaimAccepted).
+					withPermissionClaim("secrets", apisv1alpha1.ClaimAccepted).APIBinding,
+				newAPIBinding().
+					withReference(logicalcluster.NewPath("root:org:workspaceName"), "someExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-workspaceName:someExport")).
+					withPermissionClaim("configmaps", apisv1alpha1.ClaimAccepted).
+					withPermissionClaim("secrets", apisv1alpha1.ClaimRejected).APIBinding,
+			),
+			authzDecision:               authorizer.DecisionAllow,
+			maxAcceptedPermissionClaims: 1,
+			expectedErrors:              []string{"spec.permissionClaims: Too many: 2: must have at most 1 items"},
+		},
+		{
+			name: "Update: binding already above the limit passes when not accepting more claims",
+			attr: updateAttr(
+				newAPIBinding().
+					withReference(logicalcluster.NewPath("root:org:workspaceName"), "someExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-workspaceName:someExport")).
+					withPermissionClaim("configmaps", apisv1alpha1.ClaimAccepted).
+					withPermissionClaim("secrets", apisv1alpha1.ClaimAccepted).
+					withPhase(apisv1alpha1.APIBindingPhaseBound).APIBinding,
+				newAPIBinding().
+					withReference(logicalcluster.NewPath("root:org:workspaceName"), "someExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-workspaceName:someExport")).
+					withPermissionClaim("configmaps", apisv1alpha1.ClaimAccepted).
+					withPermissionClaim("secrets", apisv1alpha1.ClaimAccepted).
+					withPhase(apisv1alpha1.APIBindingPhaseBinding).APIBinding,
+			),
+			authzDecision:               authorizer.DecisionAllow,
+			maxAcceptedPermissionClaims: 1,
+		},
+		{
+			name: "Create: accepted claim with expiry passes",
+			attr: createAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root:org:workspaceName"), "someExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-workspaceName:someExport")).
+					withPermissionClaim("configmaps", apisv1alpha1.ClaimAccepted).withExpiry(metav1.Now()).APIBinding,
+			),
+			authzDecision: authorizer.DecisionAllow,
+		},
+		{
+			name: "Create: rejected claim with expiry fails",
+			attr: createAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root:org:workspaceName"), "someExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-workspaceName:someExport")).
+					withPermissionClaim("configmaps", apisv1alpha1.ClaimRejected).withExpiry(metav1.Now()).APIBinding,
+			),
+			authzDecision:  authorizer.DecisionAllow,
+			expectedErrors: []string{"spec.permissionClaims[0].expiresAt: Forbidden: only accepted permission claims can expire"},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			o := &apiBindingAdmission{
-				Handler: admission.NewHandler(admission.Create, admission.Update),
+				Handler:                     admission.NewHandler(admission.Create, admission.Update),
+				maxAcceptedPermissionClaims: tc.maxAcceptedPermissionClaims,
 				createAuthorizer: func(clusterName logicalcluster.Name, client kcpkubernetesclientset.ClusterInterface, opts delegated.Options) (authorizer.Authorizer, error) {
 					return &fakeAuthorizer{
 						tc.authzDecision,
@@ -527,6 +621,30 @@ func (b *bindingBuilder) withPhase(phase apisv1alpha1.APIBindingPhaseType) *bind
 	return b
 }
 
+func (b *bindingBuilder) withPermissionClaim(resource string, state apisv1alpha1.AcceptablePermissionClaimState) *bindingBuilder {
+	b.Spec.PermissionClaims = append(b.Spec.PermissionClaims, apisv1alpha1.AcceptablePermissionClaim{
+		PermissionClaim: apisv1alpha1.PermissionClaim{
+			GroupResource: apisv1alpha1.GroupResource{Resource: resource},
+			All:           true,
+		},
+		State: state,
+	})
+	return b
+}
+
+func TestReadConfiguration(t *testing.T) {
+	cfg, err := ReadConfiguration(nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, cfg.MaxAcceptedPermissionClaims)
+
+	cfg, err = ReadConfiguration(strings.NewReader("maxAcceptedPermissionClaims: 5\n"))
+	require.NoError(t, err)
+	require.Equal(t, 5, cfg.MaxAcceptedPermissionClaims)
+
+	_, err = ReadConfiguration(strings.NewReader("maxAcceptedPermissionClaims: -1\n"))
+	require.Error(t, err)
+}
+
 func toSha224Base62(s string) string {
 	return toBase62(sha256.Sum224([]byte(s)))
 }
@@ -553,3 +671,9 @@ func newExport(path logicalcluster.Path, name string) apiExportBuilder {
 		},
 	}}
 }
+
+// withExpiry sets the expiry of the last permission claim.
+func (b *bindingBuilder) withExpiry(expiresAt metav1.Time) *bindingBuilder {
+	b.Spec.PermissionClaims[len(b.Spec.PermissionClaims)-1].ExpiresAt = &expiresAt
+	return b
+}
@@ -30,6 +30,12 @@ func ValidateAPIBinding(apiBinding *apisv1alpha1.APIBinding) field.ErrorList {
 
 	allErrs = append(allErrs, ValidateAPIBindingReference(apiBinding.Spec.Reference, field.NewPath("spec", "reference"))...)
 
+	for i, claim := range apiBinding.Spec.PermissionClaims {
+		if claim.ExpiresAt != nil && claim.State != apisv1alpha1.ClaimAccepted {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "permissionClaims").Index(i).Child("expiresAt"), "only accepted permission claims can expire"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -64,3 +70,33 @@ func ValidateAPIBindingReference(reference apisv1alpha1.BindingReference, path *
 
 	return allErrs
 }
+
+// ValidateAcceptedPermissionClaimsLimit validates that an APIBinding does not accept more than max
+// permission claims. Zero means no limit. On update, oldBinding is not nil and bindings already
+// exceeding the limit, e.g. after it has been lowered, are only rejected if they accept more claims
+// than before.
+func ValidateAcceptedPermissionClaimsLimit(oldBinding, newBinding *apisv1alpha1.APIBinding, max int) field.ErrorList {
+	if max == 0 {
+		return nil
+	}
+
+	accepted := acceptedPermissionClaims(newBinding)
+	if accepted <= max {
+		return nil
+	}
+	if oldBinding != nil && accepted <= acceptedPermissionClaims(oldBinding) {
+		return nil
+	}
+
+	return field.ErrorList{field.TooMany(field.NewPath("spec", "permissionClaims"), accepted, max)}
+}
+
+func acceptedPermissionClaims(apiBinding *apisv1alpha1.APIBinding) int {
+	accepted := 0
+	for _, claim := range apiBinding.Spec.PermissionClaims {
+		if claim.State == apisv1alpha1.ClaimAccepted {
+			accepted++
+		}
+	}
+	return accepted
+}
@@ -33,10 +33,10 @@ import (
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 
 	"github.com/kcp-dev/kcp/pkg/admission/initializers"
+	"github.com/kcp-dev/kcp/pkg/apis/apis"
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
 	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
 	"github.com/kcp-dev/kcp/pkg/conversion"
-	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibinding"
 )
 
 const (
@@ -76,7 +76,7 @@ func (o *apiConversionAdmission) Validate(ctx context.Context, a admission.Attri
 		return admission.NewForbidden(a, fmt.Errorf("error determining workspace: %w", err))
 	}
 
-	if cluster.Name == apibinding.SystemBoundCRDsClusterName {
+	if cluster.Name == apis.SystemBoundCRDsClusterName {
 		// TODO(ncdc): do we also want to validate these conversions? They've already been validated once (in their
 		// original logical cluster).
 		return nil
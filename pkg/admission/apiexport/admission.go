@@ -20,12 +20,18 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/admission"
 
+	"github.com/kcp-dev/kcp/pkg/admission/initializers"
+	"github.com/kcp-dev/kcp/pkg/admission/webhook"
 	"github.com/kcp-dev/kcp/pkg/apis/apis"
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
 	builtinapiexport "github.com/kcp-dev/kcp/pkg/virtual/apiexport/schemas/builtin"
@@ -34,6 +40,12 @@ import (
 // PluginName is the name used to identify this admission webhook.
 const PluginName = "apis.kcp.io/APIExport"
 
+// claimVerbs are the verbs a permission claim can be restricted to.
+var claimVerbs = sets.NewString("*", "get", "list", "watch", "create", "update", "patch", "delete", "deletecollection")
+
+// readOnlyClaimVerbs are the verbs a read-only permission claim can be restricted to.
+var readOnlyClaimVerbs = sets.NewString("get", "list", "watch")
+
 // Register registers the reserved name admission webhook.
 func Register(plugins *admission.Plugins) {
 	plugins.Register(PluginName,
@@ -46,19 +58,63 @@ func Register(plugins *admission.Plugins) {
 type APIExportAdmission struct {
 	*admission.Handler
 
-	isBuiltIn func(apisv1alpha1.GroupResource) bool
+	isBuiltIn      func(apisv1alpha1.GroupResource) bool
+	claimTemplates map[string][]apisv1alpha1.PermissionClaim
+
+	webhooksAllowPrivateHosts bool
 }
 
 // NewAPIExportAdmission constructs a new APIExportAdmission admission plugin.
 func NewAPIExportAdmission(isBuiltIn func(apisv1alpha1.GroupResource) bool) *APIExportAdmission {
 	return &APIExportAdmission{
-		Handler:   admission.NewHandler(admission.Create, admission.Update),
-		isBuiltIn: isBuiltIn,
+		Handler:        admission.NewHandler(admission.Create, admission.Update),
+		isBuiltIn:      isBuiltIn,
+		claimTemplates: permissionClaimTemplates,
 	}
 }
 
 // Ensure that the required admission interfaces are implemented.
+var _ = admission.MutationInterface(&APIExportAdmission{})
 var _ = admission.ValidationInterface(&APIExportAdmission{})
+var _ = initializers.WantsAPIExportWebhooksAllowPrivateHosts(&APIExportAdmission{})
+
+// SetAPIExportWebhooksAllowPrivateHosts implements the WantsAPIExportWebhooksAllowPrivateHosts interface.
+func (e *APIExportAdmission) SetAPIExportWebhooksAllowPrivateHosts(allow bool) {
+	e.webhooksAllowPrivateHosts = allow
+}
+
+// Admit expands the permission claim templates of the APIExport into its permission claims.
+func (e *APIExportAdmission) Admit(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) (err error) {
+	if a.GetResource().GroupResource() != apisv1alpha1.Resource("apiexports") {
+		return nil
+	}
+
+	if a.GetKind().GroupKind() != apisv1alpha1.Kind("APIExport") {
+		return nil
+	}
+
+	u, ok := a.GetObject().(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unexpected type %T", a.GetObject())
+	}
+	ae := &apisv1alpha1.APIExport{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, ae); err != nil {
+		return fmt.Errorf("failed to convert unstructured to APIExport: %w", err)
+	}
+
+	if len(ae.Spec.PermissionClaimTemplates) == 0 {
+		return nil
+	}
+	expandPermissionClaimTemplates(e.claimTemplates, &ae.Spec)
+
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ae)
+	if err != nil {
+		return err
+	}
+	u.Object = raw
+
+	return nil
+}
 
 // Validate ensures that the APIExport is valid.
 func (e *APIExportAdmission) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) (err error) {
@@ -79,6 +135,18 @@ func (e *APIExportAdmission) Validate(ctx context.Context, a admission.Attribute
 		return fmt.Errorf("failed to convert unstructured to APIExport: %w", err)
 	}
 
+	for i, name := range ae.Spec.PermissionClaimTemplates {
+		if _, found := e.claimTemplates[name]; !found {
+			return admission.NewForbidden(a,
+				field.NotSupported(
+					field.NewPath("spec").
+						Child("permissionClaimTemplates").
+						Index(i),
+					name,
+					templateNames(e.claimTemplates)))
+		}
+	}
+
 	for i, pc := range ae.Spec.PermissionClaims {
 		if pc.IdentityHash == "" && !e.isBuiltIn(pc.GroupResource) && pc.Group != apis.GroupName {
 			return admission.NewForbidden(a,
@@ -90,6 +158,65 @@ func (e *APIExportAdmission) Validate(ctx context.Context, a admission.Attribute
 					"",
 					"identityHash is required for API types that are not built-in"))
 		}
+		for j, ns := range pc.Namespaces {
+			if errs := apivalidation.ValidateNamespaceName(ns, false); len(errs) > 0 {
+				return admission.NewForbidden(a,
+					field.Invalid(
+						field.NewPath("spec").
+							Child("permissionClaims").
+							Index(i).
+							Child("namespaces").
+							Index(j),
+						ns,
+						strings.Join(errs, ", ")))
+			}
+		}
+		for j, verb := range pc.Verbs {
+			if !claimVerbs.Has(verb) {
+				return admission.NewForbidden(a,
+					field.NotSupported(
+						field.NewPath("spec").
+							Child("permissionClaims").
+							Index(i).
+							Child("verbs").
+							Index(j),
+						verb,
+						claimVerbs.List()))
+			}
+			if pc.ReadOnly && !readOnlyClaimVerbs.Has(verb) {
+				return admission.NewForbidden(a,
+					field.Invalid(
+						field.NewPath("spec").
+							Child("permissionClaims").
+							Index(i).
+							Child("verbs").
+							Index(j),
+						verb,
+						"mutating verbs are not allowed for read-only permission claims"))
+			}
+		}
+		if pc.NamespaceSelector != nil {
+			if errs := metav1validation.ValidateLabelSelector(pc.NamespaceSelector,
+				field.NewPath("spec").
+					Child("permissionClaims").
+					Index(i).
+					Child("namespaceSelector")); len(errs) > 0 {
+				return admission.NewForbidden(a, errs.ToAggregate())
+			}
+		}
+	}
+
+	for i, wh := range ae.Spec.Webhooks {
+		if errs := webhook.ValidateAPIExportWebhookURL(
+			field.NewPath("spec").
+				Child("webhooks").
+				Index(i).
+				Child("clientConfig").
+				Child("url"),
+			wh.ClientConfig.URL,
+			e.webhooksAllowPrivateHosts); len(errs) > 0 {
+			return admission.NewForbidden(a, errs.ToAggregate())
+		}
 	}
 
 	return nil
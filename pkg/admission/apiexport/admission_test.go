@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/admission"
@@ -73,7 +74,10 @@ func TestAdmission(t *testing.T) {
 		hasIdentity bool
 		isBuiltIn   bool
 		modifyPCs   func([]apisv1alpha1.PermissionClaim) []apisv1alpha1.PermissionClaim
-		want        error
+		webhooks    []apisv1alpha1.APIExportWebhook
+		// allowPrivateHosts allows webhooks to call private hosts.
+		allowPrivateHosts bool
+		want              error
 	}{
 		"NotAPIExportKind": {
 			kind:      "Something",
@@ -179,6 +183,197 @@ func TestAdmission(t *testing.T) {
 			hasIdentity: true,
 			isBuiltIn:   false,
 		},
+		"ValidNamespaces": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			modifyPCs: func(pcs []apisv1alpha1.PermissionClaim) []apisv1alpha1.PermissionClaim {
+				pcs[0].Namespaces = []string{"default", "kube-system"}
+				return pcs
+			},
+		},
+		"ForbiddenInvalidNamespace": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			modifyPCs: func(pcs []apisv1alpha1.PermissionClaim) []apisv1alpha1.PermissionClaim {
+				pcs[0].Namespaces = []string{"default", "Not_A_Namespace"}
+				return pcs
+			},
+			want: field.Invalid(
+				field.NewPath("spec").
+					Child("permissionClaims").
+					Index(0).
+					Child("namespaces").
+					Index(1),
+				"Not_A_Namespace",
+				""),
+		},
+		"ValidWebhook": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			webhooks: []apisv1alpha1.APIExportWebhook{
+				{Name: "validate", Type: apisv1alpha1.APIExportWebhookValidating, ClientConfig: apisv1alpha1.WebhookClientConfig{URL: "https://webhook.example.com/validate"}},
+			},
+		},
+		"ForbiddenWebhookWithoutHTTPS": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			webhooks: []apisv1alpha1.APIExportWebhook{
+				{Name: "validate", Type: apisv1alpha1.APIExportWebhookValidating, ClientConfig: apisv1alpha1.WebhookClientConfig{URL: "https://webhook.example.com/validate"}},
+				{Name: "mutate", Type: apisv1alpha1.APIExportWebhookMutating, ClientConfig: apisv1alpha1.WebhookClientConfig{URL: "http://webhook.example.com/mutate"}},
+			},
+			want: field.Invalid(
+				field.NewPath("spec").
+					Child("webhooks").
+					Index(1).
+					Child("clientConfig").
+					Child("url"),
+				"http",
+				"'https' is the only allowed URL scheme"),
+		},
+		"ForbiddenWebhookWithQuery": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			webhooks: []apisv1alpha1.APIExportWebhook{
+				{Name: "validate", Type: apisv1alpha1.APIExportWebhookValidating, ClientConfig: apisv1alpha1.WebhookClientConfig{URL: "https://webhook.example.com/validate?foo=bar"}},
+			},
+			want: field.Invalid(
+				field.NewPath("spec").
+					Child("webhooks").
+					Index(0).
+					Child("clientConfig").
+					Child("url"),
+				"foo=bar",
+				"query parameters are not permitted in the URL"),
+		},
+		"ForbiddenWebhookWithLoopbackAddress": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			webhooks: []apisv1alpha1.APIExportWebhook{
+				{Name: "validate", Type: apisv1alpha1.APIExportWebhookValidating, ClientConfig: apisv1alpha1.WebhookClientConfig{URL: "https://127.0.0.1:6443/validate"}},
+			},
+			want: field.Invalid(
+				field.NewPath("spec").
+					Child("webhooks").
+					Index(0).
+					Child("clientConfig").
+					Child("url"),
+				"127.0.0.1",
+				"must not be a loopback, link-local, private or unspecified IP address"),
+		},
+		"ForbiddenWebhookWithServiceName": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			webhooks: []apisv1alpha1.APIExportWebhook{
+				{Name: "validate", Type: apisv1alpha1.APIExportWebhookValidating, ClientConfig: apisv1alpha1.WebhookClientConfig{URL: "https://kubernetes.default.svc/validate"}},
+			},
+			want: field.Invalid(
+				field.NewPath("spec").
+					Child("webhooks").
+					Index(0).
+					Child("clientConfig").
+					Child("url"),
+				"kubernetes.default.svc",
+				"must not be the local host or a cluster-internal service name"),
+		},
+		"ValidWebhookWithLocalHostWhenPrivateHostsAllowed": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			webhooks: []apisv1alpha1.APIExportWebhook{
+				{Name: "validate", Type: apisv1alpha1.APIExportWebhookValidating, ClientConfig: apisv1alpha1.WebhookClientConfig{URL: "https://localhost:8443/validate"}},
+			},
+			allowPrivateHosts: true,
+		},
+		"ValidVerbs": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			modifyPCs: func(pcs []apisv1alpha1.PermissionClaim) []apisv1alpha1.PermissionClaim {
+				pcs[0].Verbs = []string{"get", "list", "watch", "create", "update", "patch"}
+				return pcs
+			},
+		},
+		"ForbiddenUnknownVerb": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			modifyPCs: func(pcs []apisv1alpha1.PermissionClaim) []apisv1alpha1.PermissionClaim {
+				pcs[0].Verbs = []string{"get", "escalate"}
+				return pcs
+			},
+			want: field.NotSupported(
+				field.NewPath("spec").
+					Child("permissionClaims").
+					Index(0).
+					Child("verbs").
+					Index(1),
+				"escalate",
+				[]string{"*", "create", "delete", "deletecollection", "get", "list", "patch", "update", "watch"}),
+		},
+		"ValidReadOnly": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			modifyPCs: func(pcs []apisv1alpha1.PermissionClaim) []apisv1alpha1.PermissionClaim {
+				pcs[0].ReadOnly = true
+				pcs[0].Verbs = []string{"get", "list"}
+				return pcs
+			},
+		},
+		"ForbiddenReadOnlyWithMutatingVerb": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			modifyPCs: func(pcs []apisv1alpha1.PermissionClaim) []apisv1alpha1.PermissionClaim {
+				pcs[0].ReadOnly = true
+				pcs[0].Verbs = []string{"get", "*"}
+				return pcs
+			},
+			want: field.Invalid(
+				field.NewPath("spec").
+					Child("permissionClaims").
+					Index(0).
+					Child("verbs").
+					Index(1),
+				"*",
+				"mutating verbs are not allowed for read-only permission claims"),
+		},
+		"ValidNamespaceSelector": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			modifyPCs: func(pcs []apisv1alpha1.PermissionClaim) []apisv1alpha1.PermissionClaim {
+				pcs[0].NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}}
+				return pcs
+			},
+		},
+		"ForbiddenInvalidNamespaceSelector": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			modifyPCs: func(pcs []apisv1alpha1.PermissionClaim) []apisv1alpha1.PermissionClaim {
+				pcs[0].NamespaceSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "tenant", Operator: metav1.LabelSelectorOpIn},
+				}}
+				return pcs
+			},
+			want: field.Required(
+				field.NewPath("spec").
+					Child("permissionClaims").
+					Index(0).
+					Child("namespaceSelector").
+					Child("matchExpressions").
+					Index(0).
+					Child("values"),
+				"must be specified when `operator` is 'In' or 'NotIn'"),
+		},
 		"ValidNoPermissionClaims": {
 			kind:     "APIExport",
 			resource: "apiexports",
@@ -210,6 +405,7 @@ func TestAdmission(t *testing.T) {
 			if tc.modifyPCs != nil {
 				ae.Spec.PermissionClaims = tc.modifyPCs(ae.Spec.PermissionClaims)
 			}
+			ae.Spec.Webhooks = tc.webhooks
 			var attr admission.Attributes
 			if tc.update {
 				attr = updateAttr("cool-something", ae, tc.kind, tc.resource)
@@ -219,6 +415,7 @@ func TestAdmission(t *testing.T) {
 			plugin := NewAPIExportAdmission(func(apisv1alpha1.GroupResource) bool {
 				return tc.isBuiltIn
 			})
+			plugin.SetAPIExportWebhooksAllowPrivateHosts(tc.allowPrivateHosts)
 			if err := plugin.Validate(context.Background(), attr, nil); err != nil {
 				require.Contains(t, err.Error(), tc.want.Error())
 				return
@@ -229,3 +426,93 @@ func TestAdmission(t *testing.T) {
 		})
 	}
 }
+
+func TestAdmitPermissionClaimTemplates(t *testing.T) {
+	configMaps := apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true}
+	secrets := apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"}, All: true}
+	events := apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "events"}, All: true}
+	readOnlySecrets := apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"}, All: true, ReadOnly: true, Verbs: []string{"get"}}
+
+	cases := map[string]struct {
+		templates       []string
+		claims          []apisv1alpha1.PermissionClaim
+		claimTemplates  map[string][]apisv1alpha1.PermissionClaim
+		wantClaims      []apisv1alpha1.PermissionClaim
+		wantValidateErr error
+	}{
+		"NoTemplates": {
+			claims:     []apisv1alpha1.PermissionClaim{events},
+			wantClaims: []apisv1alpha1.PermissionClaim{events},
+		},
+		"TemplatesAreExpanded": {
+			templates:  []string{"configuration", "events"},
+			wantClaims: []apisv1alpha1.PermissionClaim{configMaps, secrets, events},
+		},
+		"ExistingClaimsTakePrecedence": {
+			templates:  []string{"configuration"},
+			claims:     []apisv1alpha1.PermissionClaim{readOnlySecrets},
+			wantClaims: []apisv1alpha1.PermissionClaim{readOnlySecrets, configMaps},
+		},
+		"UnknownTemplateIsRejected": {
+			templates:  []string{"events", "horses"},
+			wantClaims: []apisv1alpha1.PermissionClaim{events},
+			wantValidateErr: field.NotSupported(
+				field.NewPath("spec").
+					Child("permissionClaimTemplates").
+					Index(1),
+				"horses",
+				[]string{"configuration", "events", "rbac"}),
+		},
+		"ExpandedClaimsAreValidated": {
+			templates: []string{"cowboys"},
+			claimTemplates: map[string][]apisv1alpha1.PermissionClaim{
+				"cowboys": {{GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "cowboys"}, All: true}},
+			},
+			wantClaims: []apisv1alpha1.PermissionClaim{
+				{GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "cowboys"}, All: true},
+			},
+			wantValidateErr: field.Invalid(
+				field.NewPath("spec").
+					Child("permissionClaims").
+					Index(0).
+					Child("identityHash"),
+				"",
+				"identityHash is required for API types that are not built-in"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ae := &apisv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool-something",
+				},
+				Spec: apisv1alpha1.APIExportSpec{
+					PermissionClaims:         tc.claims,
+					PermissionClaimTemplates: tc.templates,
+				},
+			}
+			attr := createAttr("cool-something", ae, "APIExport", "apiexports")
+			plugin := NewAPIExportAdmission(func(gr apisv1alpha1.GroupResource) bool {
+				return gr.Group == ""
+			})
+			if tc.claimTemplates != nil {
+				plugin.claimTemplates = tc.claimTemplates
+			}
+
+			require.NoError(t, plugin.Admit(context.Background(), attr, nil))
+
+			got := &apisv1alpha1.APIExport{}
+			require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(attr.GetObject().(*unstructured.Unstructured).Object, got))
+			require.Equal(t, tc.wantClaims, got.Spec.PermissionClaims)
+			require.Equal(t, tc.templates, got.Spec.PermissionClaimTemplates)
+
+			err := plugin.Validate(context.Background(), attr, nil)
+			if tc.wantValidateErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.wantValidateErr.Error())
+		})
+	}
+}
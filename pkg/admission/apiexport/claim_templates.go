@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexport
+
+import (
+	"sort"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+)
+
+// permissionClaimTemplates are the bundles of permission claims an APIExport can reference by name
+// in spec.permissionClaimTemplates. Keep in sync with the documentation of the field.
+var permissionClaimTemplates = map[string][]apisv1alpha1.PermissionClaim{
+	"configuration": {
+		{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true},
+		{GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"}, All: true},
+	},
+	"events": {
+		{GroupResource: apisv1alpha1.GroupResource{Resource: "events"}, All: true},
+	},
+	"rbac": {
+		{GroupResource: apisv1alpha1.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "roles"}, All: true},
+		{GroupResource: apisv1alpha1.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "rolebindings"}, All: true},
+	},
+}
+
+// expandPermissionClaimTemplates adds the claims of the referenced templates to the permission claims
+// of the given APIExport spec, unless there is a claim for the same group and resource already.
+// Unknown templates are skipped.
+func expandPermissionClaimTemplates(templates map[string][]apisv1alpha1.PermissionClaim, spec *apisv1alpha1.APIExportSpec) {
+	claimed := map[apisv1alpha1.GroupResource]bool{}
+	for _, claim := range spec.PermissionClaims {
+		claimed[claim.GroupResource] = true
+	}
+
+	for _, name := range spec.PermissionClaimTemplates {
+		for _, claim := range templates[name] {
+			if claimed[claim.GroupResource] {
+				continue
+			}
+			claimed[claim.GroupResource] = true
+			spec.PermissionClaims = append(spec.PermissionClaims, *claim.DeepCopy())
+		}
+	}
+}
+
+func templateNames(templates map[string][]apisv1alpha1.PermissionClaim) []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -21,11 +21,19 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/admission"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
 )
 
 const (
@@ -36,24 +44,36 @@ func Register(plugins *admission.Plugins) {
 	plugins.Register(PluginName,
 		func(_ io.Reader) (admission.Interface, error) {
 			return &apiResourceSchemaValidation{
-				Handler: admission.NewHandler(admission.Create, admission.Update),
+				Handler: admission.NewHandler(admission.Create, admission.Update, admission.Delete),
 			}, nil
 		})
 }
 
 type apiResourceSchemaValidation struct {
 	*admission.Handler
+
+	getAPIExports     func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExport, error)
+	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
 }
 
 // Ensure that the required admission interfaces are implemented.
-var _ = admission.ValidationInterface(&apiResourceSchemaValidation{})
+var (
+	_ = admission.ValidationInterface(&apiResourceSchemaValidation{})
+	_ = admission.InitializationValidator(&apiResourceSchemaValidation{})
+	_ = kcpinitializers.WantsKcpInformers(&apiResourceSchemaValidation{})
+)
 
-// Validate does validation of a APIResourceSchema for create and update.
+// Validate does validation of a APIResourceSchema for create and update, and
+// forbids the deletion of APIResourceSchemas referenced by an APIExport.
 func (o *apiResourceSchemaValidation) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) (err error) {
 	if a.GetResource().GroupResource() != apisv1alpha1.Resource("apiresourceschemas") {
 		return nil
 	}
 
+	if a.GetOperation() == admission.Delete {
+		return o.validateDelete(ctx, a)
+	}
+
 	u, ok := a.GetObject().(*unstructured.Unstructured)
 	if !ok {
 		return fmt.Errorf("unexpected type %T", a.GetObject())
@@ -88,3 +108,61 @@ func (o *apiResourceSchemaValidation) Validate(ctx context.Context, a admission.
 
 	return nil
 }
+
+// validateDelete forbids the deletion of an APIResourceSchema that is referenced by
+// an APIExport in the same logical cluster, unless the logical cluster is being deleted.
+func (o *apiResourceSchemaValidation) validateDelete(ctx context.Context, a admission.Attributes) error {
+	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	logicalCluster, err := o.getLogicalCluster(clusterName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return apierrors.NewInternalError(err)
+	}
+	if apierrors.IsNotFound(err) || !logicalCluster.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	exports, err := o.getAPIExports(clusterName)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	for _, export := range exports {
+		for _, schemaName := range export.Spec.LatestResourceSchemas {
+			if schemaName == a.GetName() {
+				return admission.NewForbidden(a, fmt.Errorf("APIResourceSchema is referenced by APIExport %q", export.Name))
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetKcpInformers implements the WantsKcpInformers interface.
+func (o *apiResourceSchemaValidation) SetKcpInformers(local, global kcpinformers.SharedInformerFactory) {
+	apiExportsReady := local.Apis().V1alpha1().APIExports().Informer().HasSynced
+	logicalClustersReady := local.Core().V1alpha1().LogicalClusters().Informer().HasSynced
+	o.SetReadyFunc(func() bool {
+		return apiExportsReady() && logicalClustersReady()
+	})
+
+	o.getAPIExports = func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExport, error) {
+		return local.Apis().V1alpha1().APIExports().Lister().Cluster(clusterName).List(labels.Everything())
+	}
+	o.getLogicalCluster = func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+		return local.Core().V1alpha1().LogicalClusters().Lister().Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+	}
+}
+
+// ValidateInitialization implements the InitializationValidator interface.
+func (o *apiResourceSchemaValidation) ValidateInitialization() error {
+	if o.getAPIExports == nil {
+		return fmt.Errorf(PluginName + " plugin needs an APIExport lister")
+	}
+	if o.getLogicalCluster == nil {
+		return fmt.Errorf(PluginName + " plugin needs a LogicalCluster lister")
+	}
+	return nil
+}
@@ -21,6 +21,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/apiserver/pkg/authentication/user"
@@ -29,6 +33,7 @@ import (
 
 	"github.com/kcp-dev/kcp/pkg/admission/helpers"
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
 )
 
 func createAttr(s *apisv1alpha1.APIResourceSchema) admission.Attributes {
@@ -161,6 +166,103 @@ spec:
 				"spec.group: Invalid value: \"core\": must be empty string for the core group",
 			},
 		},
+		{
+			name: "supported schema extensions are allowed",
+			attr: createAttr(unmarshalOrDie(`
+apiVersion: apis.kcp.sh/v1alpha1
+kind: APIResourceSchema
+metadata:
+  name: july.cowboys.wild.west
+spec:
+  group: wild.west
+  names:
+    plural: cowboys
+    singular: cowboy
+    kind: Cowboy
+    listKind: CowboyList
+  scope: Cluster
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      type: object
+      properties:
+        spec:
+          type: object
+          x-kubernetes-preserve-unknown-fields: true
+        horses:
+          type: array
+          x-kubernetes-list-type: set
+          items:
+            type: string
+            `)),
+		},
+		{
+			name: "unsupported schema extensions are rejected",
+			attr: createAttr(unmarshalOrDie(`
+apiVersion: apis.kcp.sh/v1alpha1
+kind: APIResourceSchema
+metadata:
+  name: july.cowboys.wild.west
+spec:
+  group: wild.west
+  names:
+    plural: cowboys
+    singular: cowboy
+    kind: Cowboy
+    listKind: CowboyList
+  scope: Cluster
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      type: object
+      x-kubernetes-lasso: true
+      properties:
+        horses:
+          type: array
+          items:
+            type: string
+            x-kubernetes-saddle: western
+            `)),
+			expectedErrors: []string{
+				"spec.versions[0].schema.x-kubernetes-lasso: Unsupported value: \"x-kubernetes-lasso\"",
+				"spec.versions[0].schema.properties[horses].items.x-kubernetes-saddle: Unsupported value: \"x-kubernetes-saddle\"",
+			},
+		},
+		{
+			name: "malformed schema is rejected",
+			attr: createAttr(unmarshalOrDie(`
+apiVersion: apis.kcp.sh/v1alpha1
+kind: APIResourceSchema
+metadata:
+  name: july.cowboys.wild.west
+spec:
+  group: wild.west
+  names:
+    plural: cowboys
+    singular: cowboy
+    kind: Cowboy
+    listKind: CowboyList
+  scope: Cluster
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      type: object
+      properties:
+        spec:
+          properties:
+            name:
+              type: string
+            `)),
+			expectedErrors: []string{
+				"spec.versions[0].schema.openAPIV3Schema.properties[spec].type: Required value",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -185,6 +287,85 @@ spec:
 	}
 }
 
+func TestValidateDelete(t *testing.T) {
+	export := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "wild.west"},
+		Spec: apisv1alpha1.APIExportSpec{
+			LatestResourceSchemas: []string{"today.cowboys.wild.west"},
+		},
+	}
+	now := metav1.Now()
+
+	tests := []struct {
+		name           string
+		schemaName     string
+		exports        []*apisv1alpha1.APIExport
+		logicalCluster *corev1alpha1.LogicalCluster
+		wantErr        string
+	}{
+		{
+			name:           "unreferenced schema can be deleted",
+			schemaName:     "yesterday.cowboys.wild.west",
+			exports:        []*apisv1alpha1.APIExport{export},
+			logicalCluster: &corev1alpha1.LogicalCluster{},
+		},
+		{
+			name:           "referenced schema cannot be deleted",
+			schemaName:     "today.cowboys.wild.west",
+			exports:        []*apisv1alpha1.APIExport{export},
+			logicalCluster: &corev1alpha1.LogicalCluster{},
+			wantErr:        `APIResourceSchema is referenced by APIExport "wild.west"`,
+		},
+		{
+			name:           "referenced schema can be deleted when the logical cluster is deleted",
+			schemaName:     "today.cowboys.wild.west",
+			exports:        []*apisv1alpha1.APIExport{export},
+			logicalCluster: &corev1alpha1.LogicalCluster{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now}},
+		},
+		{
+			name:       "referenced schema can be deleted when the logical cluster is gone",
+			schemaName: "today.cowboys.wild.west",
+			exports:    []*apisv1alpha1.APIExport{export},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &apiResourceSchemaValidation{
+				Handler: admission.NewHandler(admission.Create, admission.Update, admission.Delete),
+				getAPIExports: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExport, error) {
+					return tt.exports, nil
+				},
+				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					if tt.logicalCluster == nil {
+						return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
+					}
+					return tt.logicalCluster, nil
+				},
+			}
+			attr := admission.NewAttributesRecord(
+				nil,
+				nil,
+				apisv1alpha1.Kind("APIResourceSchema").WithVersion("v1alpha1"),
+				"",
+				tt.schemaName,
+				apisv1alpha1.Resource("apiresourceschemas").WithVersion("v1alpha1"),
+				"",
+				admission.Delete,
+				&metav1.DeleteOptions{},
+				false,
+				&user.DefaultInfo{},
+			)
+			ctx := request.WithCluster(context.Background(), request.Cluster{Name: "root:org"})
+			err := o.Validate(ctx, attr, nil)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func unmarshalOrDie(yml string) *apisv1alpha1.APIResourceSchema {
 	s := apisv1alpha1.APIResourceSchema{}
 	if err := yaml.Unmarshal([]byte(strings.ReplaceAll(yml, "\t", "    ")), &s); err != nil {
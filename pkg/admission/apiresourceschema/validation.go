@@ -39,6 +39,18 @@ import (
 var (
 	namePrefixRE                 = regexp.MustCompile("^[a-z]([-a-z0-9]*[a-z0-9])?$")
 	singleSegmentGroupExceptions = sets.NewString("apps", "batch", "extensions", "policy", "autoscaling") // these are the sins of Kubernetes of single-word group names
+
+	// supportedSchemaExtensions are the x-kubernetes-* extensions known to apiextensions. Unknown ones
+	// would be dropped silently when decoding the schema.
+	supportedSchemaExtensions = sets.NewString(
+		"x-kubernetes-preserve-unknown-fields",
+		"x-kubernetes-embedded-resource",
+		"x-kubernetes-int-or-string",
+		"x-kubernetes-list-map-keys",
+		"x-kubernetes-list-type",
+		"x-kubernetes-map-type",
+		"x-kubernetes-validations",
+	)
 )
 
 // ValidateAPIResourceSchema validates an APIResourceSchema.
@@ -197,6 +209,7 @@ func ValidateAPIResourceVersion(ctx context.Context, version *apisv1alpha1.APIRe
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("schema"), string(version.Schema.Raw), fmt.Sprintf("invalid schema: %v", err)))
 		} else {
 			allErrs = append(allErrs, crdvalidation.ValidateCustomResourceDefinitionValidation(ctx, &crdSchemaInternal, statusEnabled, defaultValidationOpts, fldPath.Child("schema"))...)
+			allErrs = append(allErrs, validateSchemaExtensions(version.Schema.Raw, fldPath.Child("schema"))...)
 		}
 	}
 
@@ -219,6 +232,54 @@ func ValidateAPIResourceVersion(ctx context.Context, version *apisv1alpha1.APIRe
 	return allErrs
 }
 
+// validateSchemaExtensions rejects x-kubernetes-* extensions in the given raw OpenAPI v3 schema that
+// are not supported by apiextensions.
+func validateSchemaExtensions(raw []byte, fldPath *field.Path) field.ErrorList {
+	var schema interface{}
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, string(raw), fmt.Sprintf("invalid JSON: %v", err))}
+	}
+	return validateSchemaPropsExtensions(schema, fldPath)
+}
+
+func validateSchemaPropsExtensions(schema interface{}, fldPath *field.Path) field.ErrorList {
+	props, ok := schema.(map[string]interface{})
+	if !ok {
+		// e.g. a boolean additionalProperties
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+	for key, value := range props {
+		switch key {
+		case "properties", "patternProperties", "definitions", "dependencies":
+			named, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for name, s := range named {
+				allErrs = append(allErrs, validateSchemaPropsExtensions(s, fldPath.Child(key).Key(name))...)
+			}
+		case "items", "allOf", "anyOf", "oneOf":
+			if schemas, ok := value.([]interface{}); ok {
+				for i, s := range schemas {
+					allErrs = append(allErrs, validateSchemaPropsExtensions(s, fldPath.Child(key).Index(i))...)
+				}
+				continue
+			}
+			allErrs = append(allErrs, validateSchemaPropsExtensions(value, fldPath.Child(key))...)
+		case "additionalProperties", "additionalItems", "not":
+			allErrs = append(allErrs, validateSchemaPropsExtensions(value, fldPath.Child(key))...)
+		default:
+			if strings.HasPrefix(key, "x-kubernetes-") && !supportedSchemaExtensions.Has(key) {
+				allErrs = append(allErrs, field.NotSupported(fldPath.Child(key), key, supportedSchemaExtensions.List()))
+			}
+		}
+	}
+
+	return allErrs
+}
+
 // ValidateAPIResourceSchemaUpdate validates an APIResourceSchema on update.
 func ValidateAPIResourceSchemaUpdate(ctx context.Context, s, old *apisv1alpha1.APIResourceSchema) field.ErrorList {
 	allErrs := ValidateAPIResourceSchema(ctx, s)
@@ -165,3 +165,39 @@ func (i *serverShutdownChannelInitializer) Initialize(plugin admission.Interface
 		wants.SetServerShutdownChannel(i.ch)
 	}
 }
+
+// NewKubeQuotaDeletionMonitorWorkersInitializer returns an admission plugin initializer that injects the number of
+// workers tearing down quota admission of deleted logical clusters into admission plugins.
+func NewKubeQuotaDeletionMonitorWorkersInitializer(workers int) *kubeQuotaDeletionMonitorWorkersInitializer {
+	return &kubeQuotaDeletionMonitorWorkersInitializer{
+		workers: workers,
+	}
+}
+
+type kubeQuotaDeletionMonitorWorkersInitializer struct {
+	workers int
+}
+
+func (i *kubeQuotaDeletionMonitorWorkersInitializer) Initialize(plugin admission.Interface) {
+	if wants, ok := plugin.(WantsKubeQuotaDeletionMonitorWorkers); ok {
+		wants.SetKubeQuotaDeletionMonitorWorkers(i.workers)
+	}
+}
+
+// NewAPIExportWebhooksAllowPrivateHostsInitializer returns an admission plugin initializer that injects whether
+// the webhooks of APIExports may call private hosts into admission plugins.
+func NewAPIExportWebhooksAllowPrivateHostsInitializer(allow bool) *apiExportWebhooksAllowPrivateHostsInitializer {
+	return &apiExportWebhooksAllowPrivateHostsInitializer{
+		allow: allow,
+	}
+}
+
+type apiExportWebhooksAllowPrivateHostsInitializer struct {
+	allow bool
+}
+
+func (i *apiExportWebhooksAllowPrivateHostsInitializer) Initialize(plugin admission.Interface) {
+	if wants, ok := plugin.(WantsAPIExportWebhooksAllowPrivateHosts); ok {
+		wants.SetAPIExportWebhooksAllowPrivateHosts(i.allow)
+	}
+}
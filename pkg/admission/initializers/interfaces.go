@@ -60,3 +60,15 @@ type WantsDeepSARClient interface {
 type WantsServerShutdownChannel interface {
 	SetServerShutdownChannel(<-chan struct{})
 }
+
+// WantsKubeQuotaDeletionMonitorWorkers interface should be implemented by admission plugins that want to know
+// how many logical clusters they may tear down quota admission for concurrently.
+type WantsKubeQuotaDeletionMonitorWorkers interface {
+	SetKubeQuotaDeletionMonitorWorkers(workers int)
+}
+
+// WantsAPIExportWebhooksAllowPrivateHosts interface should be implemented by admission plugins that want to know
+// whether the webhooks of APIExports may call private hosts.
+type WantsAPIExportWebhooksAllowPrivateHosts interface {
+	SetAPIExportWebhooksAllowPrivateHosts(allow bool)
+}
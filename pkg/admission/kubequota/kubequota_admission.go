@@ -77,6 +77,8 @@ func NewKubeResourceQuota(config *resourcequotaapi.Configuration) *KubeResourceQ
 		userSuppliedConfiguration: config,
 
 		delegates: map[logicalcluster.Name]*stoppableQuotaAdmission{},
+
+		deletionMonitorWorkers: 1,
 	}
 }
 
@@ -91,6 +93,7 @@ type KubeResourceQuota struct {
 	scopingResourceQuotaInformer kcpcorev1informers.ResourceQuotaClusterInformer
 	quotaConfiguration           quota.Configuration
 	serverDone                   <-chan struct{}
+	deletionMonitorWorkers       int
 
 	// Manually set
 	userSuppliedConfiguration *resourcequotaapi.Configuration
@@ -128,6 +131,7 @@ var _ = initializer.WantsExternalKubeInformerFactory(&KubeResourceQuota{})
 var _ = initializers.WantsKubeClusterClient(&KubeResourceQuota{})
 var _ = initializer.WantsQuotaConfiguration(&KubeResourceQuota{})
 var _ = initializers.WantsServerShutdownChannel(&KubeResourceQuota{})
+var _ = initializers.WantsKubeQuotaDeletionMonitorWorkers(&KubeResourceQuota{})
 
 // Validate gets or creates a resourcequota.QuotaAdmission plugin for the logical cluster in the request and then
 // delegates validation to it.
@@ -144,7 +148,7 @@ func (k *KubeResourceQuota) Validate(ctx context.Context, a admission.Attributes
 	}
 
 	k.workspaceDeletionMonitorStarter.Do(func() {
-		m := newLogicalClusterDeletionMonitor(k.logicalClusterInformer, k.stopQuotaAdmissionForCluster)
+		m := newLogicalClusterDeletionMonitor(k.logicalClusterInformer, k.deletionMonitorWorkers, k.stopQuotaAdmissionForCluster)
 		go m.Start(k.serverDone)
 	})
 
@@ -221,7 +225,9 @@ type stoppableQuotaAdmission struct {
 	stop func()
 }
 
-func (k *KubeResourceQuota) stopQuotaAdmissionForCluster(clusterName logicalcluster.Name) {
+// stopQuotaAdmissionForCluster stops the quota admission of the given logical cluster, if there is one. It returns
+// whether quota admission was stopped.
+func (k *KubeResourceQuota) stopQuotaAdmissionForCluster(clusterName logicalcluster.Name) bool {
 	k.lock.Lock()
 	defer k.lock.Unlock()
 
@@ -231,13 +237,14 @@ func (k *KubeResourceQuota) stopQuotaAdmissionForCluster(clusterName logicalclus
 
 	if delegate == nil {
 		logger.V(3).Info("received event to stop quota admission for logical cluster, but it wasn't in the map")
-		return
+		return false
 	}
 
 	logger.V(2).Info("stopping quota admission for logical cluster")
 
 	delete(k.delegates, clusterName)
 	delegate.stop()
+	return true
 }
 
 func (k *KubeResourceQuota) SetKubeClusterClient(kubeClusterClient kcpkubernetesclientset.ClusterInterface) {
@@ -263,3 +270,7 @@ func (k *KubeResourceQuota) SetQuotaConfiguration(quotaConfiguration quota.Confi
 func (k *KubeResourceQuota) SetServerShutdownChannel(ch <-chan struct{}) {
 	k.serverDone = ch
 }
+
+func (k *KubeResourceQuota) SetKubeQuotaDeletionMonitorWorkers(workers int) {
+	k.deletionMonitorWorkers = workers
+}
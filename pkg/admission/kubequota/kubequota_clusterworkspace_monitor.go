@@ -36,18 +36,24 @@ import (
 const logicalClusterDeletionMonitorControllerName = "kcp-kubequota-logical-cluster-deletion-monitor"
 
 // logicalClusterDeletionMonitor monitors LogicalClusters and terminates QuotaAdmission for a logical cluster
-// when its corresponding workspace is deleted.
+// when its corresponding workspace is deleted. At most workers logical clusters are torn down concurrently,
+// such that deleting many workspaces at once, e.g. when draining a shard, does not stop all of them at once.
 type logicalClusterDeletionMonitor struct {
-	queue    workqueue.RateLimitingInterface
-	stopFunc func(name logicalcluster.Name)
+	queue   workqueue.RateLimitingInterface
+	workers int
+	// stopFunc stops quota admission for the logical cluster and returns whether it was running. It must be
+	// idempotent, as the informer might deliver duplicate delete events.
+	stopFunc func(name logicalcluster.Name) bool
 }
 
 func newLogicalClusterDeletionMonitor(
 	workspaceInformer corev1alpha1informers.LogicalClusterClusterInformer,
-	stopFunc func(logicalcluster.Name),
+	workers int,
+	stopFunc func(logicalcluster.Name) bool,
 ) *logicalClusterDeletionMonitor {
 	m := &logicalClusterDeletionMonitor{
 		queue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), logicalClusterDeletionMonitorControllerName),
+		workers:  workers,
 		stopFunc: stopFunc,
 	}
 
@@ -78,7 +84,9 @@ func (m *logicalClusterDeletionMonitor) Start(stop <-chan struct{}) {
 	logger.Info("Starting controller")
 	defer logger.Info("Shutting down controller")
 
-	go wait.Until(m.startWorker, time.Second, stop)
+	for i := 0; i < m.workers; i++ {
+		go wait.Until(m.startWorker, time.Second, stop)
+	}
 
 	<-stop
 }
@@ -121,7 +129,9 @@ func (m *logicalClusterDeletionMonitor) process(key string) error {
 		return nil
 	}
 
-	m.stopFunc(clusterName)
+	if m.stopFunc(clusterName) {
+		quotaAdmissionTeardowns.Inc()
+	}
 
 	return nil
 }
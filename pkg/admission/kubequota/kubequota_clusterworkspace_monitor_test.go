@@ -0,0 +1,51 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubequota
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestLogicalClusterDeletionMonitorProcess(t *testing.T) {
+	running := map[logicalcluster.Name]bool{"one": true, "two": true}
+	m := &logicalClusterDeletionMonitor{
+		stopFunc: func(name logicalcluster.Name) bool {
+			stopped := running[name]
+			delete(running, name)
+			return stopped
+		},
+	}
+
+	teardownsBefore, err := testutil.GetCounterMetricValue(quotaAdmissionTeardowns)
+	require.NoError(t, err)
+
+	require.NoError(t, m.process("one|cluster"))
+	t.Log("A duplicate delete event is a no-op")
+	require.NoError(t, m.process("one|cluster"))
+	t.Log("A logical cluster without quota admission is no teardown")
+	require.NoError(t, m.process("three|cluster"))
+
+	teardownsAfter, err := testutil.GetCounterMetricValue(quotaAdmissionTeardowns)
+	require.NoError(t, err)
+	require.Equal(t, float64(1), teardownsAfter-teardownsBefore)
+	require.Equal(t, map[logicalcluster.Name]bool{"two": true}, running)
+}
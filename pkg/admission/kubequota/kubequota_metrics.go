@@ -0,0 +1,34 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubequota
+
+import (
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+func init() {
+	legacyregistry.MustRegister(quotaAdmissionTeardowns)
+}
+
+var quotaAdmissionTeardowns = compbasemetrics.NewCounter(
+	&compbasemetrics.CounterOpts{
+		Name:           "kcp_kubequota_admission_teardowns_total",
+		Help:           "Number of logical clusters whose resource quota admission was torn down after their deletion.",
+		StabilityLevel: compbasemetrics.ALPHA,
+	},
+)
@@ -54,6 +54,7 @@ var (
 	_ = admission.InitializationValidator(&Plugin{})
 	_ = kcpinitializers.WantsKcpInformers(&Plugin{})
 	_ = kcpinitializers.WantsKubeInformers(&Plugin{})
+	_ = kcpinitializers.WantsAPIExportWebhooksAllowPrivateHosts(&Plugin{})
 )
 
 func NewValidatingAdmissionWebhook(configfile io.Reader) (*Plugin, error) {
@@ -90,6 +91,7 @@ func NewValidatingAdmissionWebhook(configfile io.Reader) (*Plugin, error) {
 	cm.SetServiceResolver(webhookutil.NewDefaultServiceResolver())
 
 	p.WebhookDispatcher.SetDispatcher(dispatcherFactory(&cm))
+	p.WebhookDispatcher.SetAPIExportWebhooks(webhook.ValidatingAPIExportWebhooks)
 	// Need to do this, to make sure that the underlying objects for the call to ShouldCallHook have the right values
 	p.Plugin.Webhook, err = generic.NewWebhook(p.Handler, configfile, configuration.NewValidatingWebhookConfigurationManager, dispatcherFactory)
 	if err != nil {
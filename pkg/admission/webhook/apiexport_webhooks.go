@@ -0,0 +1,182 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/admission/plugin/webhook"
+	webhookutil "k8s.io/apiserver/pkg/util/webhook"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+)
+
+const apiExportWebhookTimeoutSeconds int32 = 10
+
+// ValidateAPIExportWebhookURL validates the URL of a webhook of an APIExport.
+//
+// APIExport webhooks are defined by service providers, but called by kcp with its own network
+// access. Unless allowPrivateHosts is set, the host of the URL must therefore not be the local
+// host, a cluster-internal service name, or a loopback, link-local, private or unspecified IP
+// address. Host names are not resolved, i.e. public names resolving to such addresses are not
+// rejected. Operators have to restrict the egress of kcp to keep those from being called.
+func ValidateAPIExportWebhookURL(fldPath *field.Path, webhookURL string, allowPrivateHosts bool) field.ErrorList {
+	if errs := webhookutil.ValidateWebhookURL(fldPath, webhookURL, true); len(errs) > 0 || allowPrivateHosts {
+		return errs
+	}
+
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, webhookURL, err.Error())}
+	}
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsPrivate() || ip.IsUnspecified() {
+			return field.ErrorList{field.Invalid(fldPath, host, "must not be a loopback, link-local, private or unspecified IP address")}
+		}
+		return nil
+	}
+	if isPrivateHostName(host) {
+		return field.ErrorList{field.Invalid(fldPath, host, "must not be the local host or a cluster-internal service name")}
+	}
+	return nil
+}
+
+// isPrivateHostName returns whether the given host name refers to the local host or to a
+// cluster-internal service, including single labels resolved through DNS search domains.
+func isPrivateHostName(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	return host == "localhost" ||
+		strings.HasSuffix(host, ".localhost") ||
+		!strings.Contains(host, ".") ||
+		strings.HasSuffix(host, ".svc") ||
+		strings.Contains(host, ".svc.") ||
+		strings.HasSuffix(host, ".cluster.local")
+}
+
+// ValidatingAPIExportWebhooks returns the validating webhooks of the APIExport for the resources
+// bound by the APIBinding.
+func ValidatingAPIExportWebhooks(apiBinding *apisv1alpha1.APIBinding, apiExport *apisv1alpha1.APIExport) []webhook.WebhookAccessor {
+	var accessors []webhook.WebhookAccessor
+	for _, wh := range apiExport.Spec.Webhooks {
+		if wh.Type != apisv1alpha1.APIExportWebhookValidating {
+			continue
+		}
+		c := newWebhookCommon(apiBinding, wh)
+		accessors = append(accessors, webhook.NewValidatingWebhookAccessor(apiExportWebhookUID(apiExport, wh), apiExportWebhookConfigurationName(apiExport), &admissionregistrationv1.ValidatingWebhook{
+			Name:                    wh.Name,
+			ClientConfig:            c.clientConfig,
+			Rules:                   c.rules,
+			FailurePolicy:           &c.failurePolicy,
+			MatchPolicy:             &c.matchPolicy,
+			NamespaceSelector:       &metav1.LabelSelector{},
+			ObjectSelector:          &metav1.LabelSelector{},
+			SideEffects:             &c.sideEffects,
+			TimeoutSeconds:          &c.timeoutSeconds,
+			AdmissionReviewVersions: []string{"v1"},
+		}))
+	}
+	return accessors
+}
+
+// MutatingAPIExportWebhooks returns the mutating webhooks of the APIExport for the resources
+// bound by the APIBinding.
+func MutatingAPIExportWebhooks(apiBinding *apisv1alpha1.APIBinding, apiExport *apisv1alpha1.APIExport) []webhook.WebhookAccessor {
+	var accessors []webhook.WebhookAccessor
+	for _, wh := range apiExport.Spec.Webhooks {
+		if wh.Type != apisv1alpha1.APIExportWebhookMutating {
+			continue
+		}
+		c := newWebhookCommon(apiBinding, wh)
+		reinvocationPolicy := admissionregistrationv1.NeverReinvocationPolicy
+		accessors = append(accessors, webhook.NewMutatingWebhookAccessor(apiExportWebhookUID(apiExport, wh), apiExportWebhookConfigurationName(apiExport), &admissionregistrationv1.MutatingWebhook{
+			Name:                    wh.Name,
+			ClientConfig:            c.clientConfig,
+			Rules:                   c.rules,
+			FailurePolicy:           &c.failurePolicy,
+			MatchPolicy:             &c.matchPolicy,
+			NamespaceSelector:       &metav1.LabelSelector{},
+			ObjectSelector:          &metav1.LabelSelector{},
+			SideEffects:             &c.sideEffects,
+			TimeoutSeconds:          &c.timeoutSeconds,
+			AdmissionReviewVersions: []string{"v1"},
+			ReinvocationPolicy:      &reinvocationPolicy,
+		}))
+	}
+	return accessors
+}
+
+// webhookCommon holds the fields shared by validating and mutating webhooks.
+type webhookCommon struct {
+	clientConfig   admissionregistrationv1.WebhookClientConfig
+	rules          []admissionregistrationv1.RuleWithOperations
+	failurePolicy  admissionregistrationv1.FailurePolicyType
+	matchPolicy    admissionregistrationv1.MatchPolicyType
+	sideEffects    admissionregistrationv1.SideEffectClass
+	timeoutSeconds int32
+}
+
+func newWebhookCommon(apiBinding *apisv1alpha1.APIBinding, wh apisv1alpha1.APIExportWebhook) webhookCommon {
+	url := wh.ClientConfig.URL
+	c := webhookCommon{
+		clientConfig: admissionregistrationv1.WebhookClientConfig{
+			URL:      &url,
+			CABundle: wh.ClientConfig.CABundle,
+		},
+		failurePolicy:  admissionregistrationv1.Fail,
+		matchPolicy:    admissionregistrationv1.Exact,
+		sideEffects:    admissionregistrationv1.SideEffectClassNone,
+		timeoutSeconds: apiExportWebhookTimeoutSeconds,
+	}
+	if wh.FailurePolicy == apisv1alpha1.APIExportWebhookFailurePolicyIgnore {
+		c.failurePolicy = admissionregistrationv1.Ignore
+	}
+
+	// only the resources of the APIExport, not the claimed ones, are subject to its webhooks.
+	for _, br := range apiBinding.Status.BoundResources {
+		c.rules = append(c.rules, admissionregistrationv1.RuleWithOperations{
+			Operations: []admissionregistrationv1.OperationType{
+				admissionregistrationv1.Create,
+				admissionregistrationv1.Update,
+				admissionregistrationv1.Delete,
+			},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{br.Group},
+				APIVersions: []string{"*"},
+				Resources:   []string{br.Resource},
+			},
+		})
+	}
+
+	return c
+}
+
+func apiExportWebhookConfigurationName(apiExport *apisv1alpha1.APIExport) string {
+	return fmt.Sprintf("%s|%s", logicalcluster.From(apiExport), apiExport.Name)
+}
+
+func apiExportWebhookUID(apiExport *apisv1alpha1.APIExport, wh apisv1alpha1.APIExportWebhook) string {
+	return fmt.Sprintf("%s|%s/%s", logicalcluster.From(apiExport), apiExport.Name, wh.Name)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateAPIExportWebhookURL(t *testing.T) {
+	tests := map[string]struct {
+		url               string
+		allowPrivateHosts bool
+		wantErr           string
+	}{
+		"public host name":            {url: "https://webhook.example.com/validate"},
+		"public IP address":           {url: "https://203.0.113.10:8443/validate"},
+		"public IPv6 address":         {url: "https://[2001:db8::1]/validate"},
+		"http is invalid":             {url: "http://webhook.example.com/validate", wantErr: "'https' is the only allowed URL scheme"},
+		"localhost":                   {url: "https://localhost:8443/validate", wantErr: "must not be the local host or a cluster-internal service name"},
+		"localhost with trailing dot": {url: "https://LocalHost./validate", wantErr: "must not be the local host or a cluster-internal service name"},
+		"single label":                {url: "https://kubernetes/validate", wantErr: "must not be the local host or a cluster-internal service name"},
+		"service":                     {url: "https://webhook.default.svc/validate", wantErr: "must not be the local host or a cluster-internal service name"},
+		"service with cluster domain": {url: "https://webhook.default.svc.cluster.local:443/validate", wantErr: "must not be the local host or a cluster-internal service name"},
+		"loopback":                    {url: "https://127.0.0.1/validate", wantErr: "must not be a loopback, link-local, private or unspecified IP address"},
+		"IPv6 loopback":               {url: "https://[::1]:8443/validate", wantErr: "must not be a loopback, link-local, private or unspecified IP address"},
+		"link-local metadata service": {url: "https://169.254.169.254/latest", wantErr: "must not be a loopback, link-local, private or unspecified IP address"},
+		"private":                     {url: "https://10.96.0.1/validate", wantErr: "must not be a loopback, link-local, private or unspecified IP address"},
+		"IPv6 unique local":           {url: "https://[fd00::1]/validate", wantErr: "must not be a loopback, link-local, private or unspecified IP address"},
+		"unspecified":                 {url: "https://0.0.0.0/validate", wantErr: "must not be a loopback, link-local, private or unspecified IP address"},
+		"localhost when private hosts are allowed": {url: "https://localhost:8443/validate", allowPrivateHosts: true},
+		"private when private hosts are allowed":   {url: "https://10.96.0.1/validate", allowPrivateHosts: true},
+		"http when private hosts are allowed":      {url: "http://localhost:8443/validate", allowPrivateHosts: true, wantErr: "'https' is the only allowed URL scheme"},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			errs := ValidateAPIExportWebhookURL(field.NewPath("url"), tc.url, tc.allowPrivateHosts)
+			if tc.wantErr == "" {
+				require.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			require.Contains(t, errs[0].Error(), tc.wantErr)
+		})
+	}
+}
@@ -23,6 +23,8 @@ import (
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/apiserver/pkg/admission/plugin/webhook"
@@ -78,6 +80,7 @@ func (c *clusterAwareSource) HasSynced() bool {
 }
 
 var _ initializers.WantsKcpInformers = &WebhookDispatcher{}
+var _ initializers.WantsAPIExportWebhooksAllowPrivateHosts = &WebhookDispatcher{}
 
 type WebhookDispatcher struct {
 	*admission.Handler
@@ -86,6 +89,13 @@ type WebhookDispatcher struct {
 	hookSource ClusterAwareSource
 
 	getAPIBindings func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error)
+	getAPIExport   func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error)
+
+	// apiExportWebhooks returns the webhooks defined in the given APIExport for the resources
+	// bound by the given APIBinding. If nil, APIExport webhooks are not called.
+	apiExportWebhooks func(apiBinding *apisv1alpha1.APIBinding, apiExport *apisv1alpha1.APIExport) []webhook.WebhookAccessor
+	// apiExportWebhooksAllowPrivateHosts allows APIExport webhooks to call private hosts.
+	apiExportWebhooksAllowPrivateHosts bool
 
 	informersHaveSynced func() bool
 }
@@ -123,10 +133,18 @@ func (p *WebhookDispatcher) Dispatch(ctx context.Context, attr admission.Attribu
 	var whAccessor []webhook.WebhookAccessor
 
 	// Determine the type of request, is it api binding or not.
-	if workspace, err := p.getAPIExportCluster(attr, lcluster); err != nil {
+	if apiBinding, err := p.getAPIBinding(attr, lcluster); err != nil {
 		return err
-	} else if !workspace.Empty() {
+	} else if workspace := apiBindingExportCluster(apiBinding); !workspace.Empty() {
 		whAccessor = p.hookSource.Webhooks(workspace)
+		exportWebhooks, err := p.getAPIExportWebhooks(apiBinding, workspace)
+		if err != nil {
+			return err
+		}
+		if len(exportWebhooks) > 0 {
+			// copy to not modify the slice of the hook source
+			whAccessor = append(append(make([]webhook.WebhookAccessor, 0, len(whAccessor)+len(exportWebhooks)), whAccessor...), exportWebhooks...)
+		}
 		attr.SetCluster(workspace)
 		klog.FromContext(ctx).V(7).WithValues("cluster", workspace).Info("restricting call to api registration hooks in cluster")
 	} else {
@@ -138,19 +156,66 @@ func (p *WebhookDispatcher) Dispatch(ctx context.Context, attr admission.Attribu
 	return p.dispatcher.Dispatch(ctx, attr, o, whAccessor)
 }
 
-func (p *WebhookDispatcher) getAPIExportCluster(attr admission.Attributes, clusterName logicalcluster.Name) (logicalcluster.Name, error) {
+func (p *WebhookDispatcher) getAPIBinding(attr admission.Attributes, clusterName logicalcluster.Name) (*apisv1alpha1.APIBinding, error) {
 	objs, err := p.getAPIBindings(clusterName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	for _, apiBinding := range objs {
 		for _, br := range apiBinding.Status.BoundResources {
 			if br.Group == attr.GetResource().Group && br.Resource == attr.GetResource().Resource {
-				return logicalcluster.Name(apiBinding.Status.APIExportClusterName), nil
+				return apiBinding, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func apiBindingExportCluster(apiBinding *apisv1alpha1.APIBinding) logicalcluster.Name {
+	if apiBinding == nil {
+		return ""
+	}
+	return logicalcluster.Name(apiBinding.Status.APIExportClusterName)
+}
+
+func (p *WebhookDispatcher) getAPIExportWebhooks(apiBinding *apisv1alpha1.APIBinding, exportClusterName logicalcluster.Name) ([]webhook.WebhookAccessor, error) {
+	if p.apiExportWebhooks == nil || apiBinding.Spec.Reference.Export == nil {
+		return nil, nil
+	}
+	apiExport, err := p.getAPIExport(exportClusterName, apiBinding.Spec.Reference.Export.Name)
+	if apierrors.IsNotFound(err) {
+		// the APIBinding reconciler reports a missing APIExport
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var accessors []webhook.WebhookAccessor
+	for _, accessor := range p.apiExportWebhooks(apiBinding, apiExport) {
+		// the URL has been validated on admission of the APIExport, but maybe by a shard with
+		// different settings or before the validation existed. Handle it like an unreachable
+		// webhook, i.e. according to the failure policy.
+		if errs := ValidateAPIExportWebhookURL(nil, *accessor.GetClientConfig().URL, p.apiExportWebhooksAllowPrivateHosts); len(errs) > 0 {
+			if policy := accessor.GetFailurePolicy(); policy != nil && *policy == admissionregistrationv1.Ignore {
+				continue
 			}
+			return nil, fmt.Errorf("failed calling webhook %q of APIExport %s|%s: %w", accessor.GetName(), exportClusterName, apiExport.Name, errs.ToAggregate())
 		}
+		accessors = append(accessors, accessor)
 	}
-	return "", nil
+	return accessors, nil
+}
+
+// SetAPIExportWebhooks sets the function returning the webhooks of an APIExport that are called
+// for requests against the resources bound by an APIBinding.
+func (p *WebhookDispatcher) SetAPIExportWebhooks(apiExportWebhooks func(apiBinding *apisv1alpha1.APIBinding, apiExport *apisv1alpha1.APIExport) []webhook.WebhookAccessor) {
+	p.apiExportWebhooks = apiExportWebhooks
+}
+
+// SetAPIExportWebhooksAllowPrivateHosts implements the WantsAPIExportWebhooksAllowPrivateHosts interface.
+func (p *WebhookDispatcher) SetAPIExportWebhooksAllowPrivateHosts(allow bool) {
+	p.apiExportWebhooksAllowPrivateHosts = allow
 }
 
 func (p *WebhookDispatcher) SetHookSource(factory func(cluster logicalcluster.Name) generic.Source, hasSynced func() bool) {
@@ -168,6 +233,13 @@ func (p *WebhookDispatcher) SetKcpInformers(local, global kcpinformers.SharedInf
 	p.getAPIBindings = func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error) {
 		return local.Apis().V1alpha1().APIBindings().Lister().Cluster(clusterName).List(labels.Everything())
 	}
+	p.getAPIExport = func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+		apiExport, err := local.Apis().V1alpha1().APIExports().Lister().Cluster(clusterName).Get(name)
+		if apierrors.IsNotFound(err) {
+			return global.Apis().V1alpha1().APIExports().Lister().Cluster(clusterName).Get(name)
+		}
+		return apiExport, err
+	}
 
 	synced := func() bool {
 		return local.Apis().V1alpha1().APIBindings().Informer().HasSynced() &&
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -285,6 +286,103 @@ func TestDispatch(t *testing.T) {
 	}
 }
 
+type recordingDispatcher struct {
+	hooks []webhook.WebhookAccessor
+}
+
+func (d *recordingDispatcher) Dispatch(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces, hooks []webhook.WebhookAccessor) error {
+	d.hooks = hooks
+	return nil
+}
+
+func TestDispatchAPIExportWebhooks(t *testing.T) {
+	apiBinding := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cowboys",
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root-org-dest",
+			},
+		},
+		Spec: apisv1alpha1.APIBindingSpec{
+			Reference: apisv1alpha1.BindingReference{
+				Export: &apisv1alpha1.ExportBindingReference{
+					Path: "root:org:source",
+					Name: "cowboys",
+				},
+			},
+		},
+		Status: apisv1alpha1.APIBindingStatus{
+			BoundResources: []apisv1alpha1.BoundAPIResource{
+				{Group: "wildwest.dev", Resource: "cowboys"},
+			},
+			APIExportClusterName: "root-org-source",
+		},
+	}
+	apiExport := newAPIExport(logicalcluster.NewPath("root:org:source"), "cowboys").APIExport
+	apiExport.Spec.Webhooks = []apisv1alpha1.APIExportWebhook{
+		{Name: "validate-cowboys", Type: apisv1alpha1.APIExportWebhookValidating, ClientConfig: apisv1alpha1.WebhookClientConfig{URL: "https://webhook.example.com/validate"}},
+		{Name: "mutate-cowboys", Type: apisv1alpha1.APIExportWebhookMutating, ClientConfig: apisv1alpha1.WebhookClientConfig{URL: "https://webhook.example.com/mutate"}},
+	}
+
+	sourceHooks := []webhook.WebhookAccessor{webhook.NewValidatingWebhookAccessor("1", "api-registration-hook", nil)}
+	dispatcher := &recordingDispatcher{}
+	o := &WebhookDispatcher{
+		Handler:             admission.NewHandler(admission.Connect, admission.Create, admission.Delete, admission.Update),
+		dispatcher:          dispatcher,
+		hookSource:          &fakeHookSource{hooks: map[logicalcluster.Name][]webhook.WebhookAccessor{"root-org-source": sourceHooks}, hasSynced: true},
+		informersHaveSynced: func() bool { return true },
+		getAPIBindings: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error) {
+			return []*apisv1alpha1.APIBinding{apiBinding}, nil
+		},
+		getAPIExport: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+			require.Equal(t, logicalcluster.Name("root-org-source"), clusterName)
+			require.Equal(t, "cowboys", name)
+			return apiExport, nil
+		},
+		apiExportWebhooks: ValidatingAPIExportWebhooks,
+	}
+	o.SetReadyFunc(func() bool { return true })
+
+	ctx := request.WithCluster(context.Background(), request.Cluster{Name: "root-org-dest"})
+	err := o.Dispatch(ctx, attr(schema.GroupVersionKind{Kind: "Cowboy", Group: "wildwest.dev", Version: "v1"}, "bound-resource", "cowboys", admission.Create), nil)
+	require.NoError(t, err)
+
+	require.Len(t, dispatcher.hooks, 2, "expected the hook of the source cluster and the validating hook of the APIExport")
+	require.Equal(t, "1", dispatcher.hooks[0].GetUID())
+	require.Equal(t, "root-org-source|cowboys/validate-cowboys", dispatcher.hooks[1].GetUID())
+	require.Equal(t, "validate-cowboys", dispatcher.hooks[1].GetName())
+	require.Equal(t, "https://webhook.example.com/validate", *dispatcher.hooks[1].GetClientConfig().URL)
+	require.Len(t, dispatcher.hooks[1].GetRules(), 1)
+	require.Equal(t, []string{"wildwest.dev"}, dispatcher.hooks[1].GetRules()[0].APIGroups)
+	require.Equal(t, []string{"cowboys"}, dispatcher.hooks[1].GetRules()[0].Resources)
+	require.Len(t, sourceHooks, 1, "hooks of the source must not be modified")
+
+	t.Log("Requests for resources not bound do not call APIExport webhooks")
+	err = o.Dispatch(ctx, attr(schema.GroupVersionKind{Kind: "Horse", Group: "wildwest.dev", Version: "v1"}, "horse", "horses", admission.Create), nil)
+	require.NoError(t, err)
+	require.Empty(t, dispatcher.hooks)
+
+	t.Log("Webhooks of the APIExport calling private hosts fail requests, unless their failure policy is Ignore")
+	apiExport.Spec.Webhooks = []apisv1alpha1.APIExportWebhook{
+		{Name: "validate-cowboys", Type: apisv1alpha1.APIExportWebhookValidating, ClientConfig: apisv1alpha1.WebhookClientConfig{URL: "https://127.0.0.1/validate"}},
+	}
+	err = o.Dispatch(ctx, attr(schema.GroupVersionKind{Kind: "Cowboy", Group: "wildwest.dev", Version: "v1"}, "bound-resource", "cowboys", admission.Create), nil)
+	require.ErrorContains(t, err, `failed calling webhook "validate-cowboys" of APIExport root-org-source|cowboys`)
+
+	apiExport.Spec.Webhooks[0].FailurePolicy = apisv1alpha1.APIExportWebhookFailurePolicyIgnore
+	err = o.Dispatch(ctx, attr(schema.GroupVersionKind{Kind: "Cowboy", Group: "wildwest.dev", Version: "v1"}, "bound-resource", "cowboys", admission.Create), nil)
+	require.NoError(t, err)
+	require.Len(t, dispatcher.hooks, 1, "expected only the hook of the source cluster")
+
+	t.Log("Webhooks of the APIExport calling private hosts are called when private hosts are allowed")
+	apiExport.Spec.Webhooks[0].FailurePolicy = apisv1alpha1.APIExportWebhookFailurePolicyFail
+	o.SetAPIExportWebhooksAllowPrivateHosts(true)
+	err = o.Dispatch(ctx, attr(schema.GroupVersionKind{Kind: "Cowboy", Group: "wildwest.dev", Version: "v1"}, "bound-resource", "cowboys", admission.Create), nil)
+	require.NoError(t, err)
+	require.Len(t, dispatcher.hooks, 2, "expected the hook of the source cluster and the validating hook of the APIExport")
+	require.Equal(t, "https://127.0.0.1/validate", *dispatcher.hooks[1].GetClientConfig().URL)
+}
+
 type apiExportBuilder struct {
 	APIExport *apisv1alpha1.APIExport
 }
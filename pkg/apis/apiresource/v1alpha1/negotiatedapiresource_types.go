@@ -27,6 +27,7 @@ import (
 // +crd
 // +genclient
 // +genclient:nonNamespaced
+// +genclient:skipVerbs=apply,applyStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories=kcp
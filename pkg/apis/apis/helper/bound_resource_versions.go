@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"fmt"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+)
+
+// BoundResourceVersions are the versions of a bound resource as seen by a consumer.
+type BoundResourceVersions struct {
+	// Served are the versions served to the consumer, in the order of the schema.
+	Served []string
+	// Storage is the version objects are currently persisted in.
+	Storage string
+	// StoredVersions are all versions objects of the resource were ever persisted in.
+	StoredVersions []string
+}
+
+// GetBoundResourceVersions returns the served and storage versions of the given group and
+// resource bound by the APIBinding. The APIResourceSchemas of the bound APIExport are passed
+// in schemas. An error is returned if the resource is not bound, or if its schema is missing.
+func GetBoundResourceVersions(binding *apisv1alpha1.APIBinding, schemas []*apisv1alpha1.APIResourceSchema, group, resource string) (*BoundResourceVersions, error) {
+	var bound *apisv1alpha1.BoundAPIResource
+	for i := range binding.Status.BoundResources {
+		if br := &binding.Status.BoundResources[i]; br.Group == group && br.Resource == resource {
+			bound = br
+			break
+		}
+	}
+	if bound == nil {
+		return nil, fmt.Errorf("resource %q is not bound by APIBinding %s", resource+"."+group, binding.Name)
+	}
+
+	var sch *apisv1alpha1.APIResourceSchema
+	for _, s := range schemas {
+		if s.Name == bound.Schema.Name && string(s.UID) == bound.Schema.UID {
+			sch = s
+			break
+		}
+	}
+	if sch == nil {
+		return nil, fmt.Errorf("APIResourceSchema %s with UID %s bound by APIBinding %s for resource %q not found", bound.Schema.Name, bound.Schema.UID, binding.Name, resource+"."+group)
+	}
+
+	ret := &BoundResourceVersions{
+		StoredVersions: append([]string(nil), bound.StorageVersions...),
+	}
+	for _, v := range sch.Spec.Versions {
+		if v.Served {
+			ret.Served = append(ret.Served, v.Name)
+		}
+		if v.Storage {
+			ret.Storage = v.Name
+		}
+	}
+
+	return ret, nil
+}
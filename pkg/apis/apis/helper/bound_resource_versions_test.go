@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"reflect"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+)
+
+func TestGetBoundResourceVersions(t *testing.T) {
+	schema := &apisv1alpha1.APIResourceSchema{
+		ObjectMeta: metav1.ObjectMeta{Name: "today.sheriffs.wild.wild.west", UID: "uid-1"},
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Group: "wild.wild.west",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "sheriffs"},
+			Versions: []apisv1alpha1.APIResourceVersion{
+				{Name: "v1alpha1", Served: false},
+				{Name: "v1beta1", Served: true},
+				{Name: "v1", Served: true, Storage: true},
+			},
+		},
+	}
+	other := &apisv1alpha1.APIResourceSchema{
+		ObjectMeta: metav1.ObjectMeta{Name: "today.cowboys.wild.wild.west", UID: "uid-2"},
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Group:    "wild.wild.west",
+			Names:    apiextensionsv1.CustomResourceDefinitionNames{Plural: "cowboys"},
+			Versions: []apisv1alpha1.APIResourceVersion{{Name: "v1", Served: true, Storage: true}},
+		},
+	}
+	binding := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "west"},
+		Status: apisv1alpha1.APIBindingStatus{
+			BoundResources: []apisv1alpha1.BoundAPIResource{
+				{
+					Group:           "wild.wild.west",
+					Resource:        "sheriffs",
+					Schema:          apisv1alpha1.BoundAPIResourceSchema{Name: "today.sheriffs.wild.wild.west", UID: "uid-1"},
+					StorageVersions: []string{"v1beta1", "v1"},
+				},
+				{
+					Group:    "wild.wild.west",
+					Resource: "cowboys",
+					Schema:   apisv1alpha1.BoundAPIResourceSchema{Name: "today.cowboys.wild.wild.west", UID: "uid-outdated"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		resource string
+		want     *BoundResourceVersions
+		wantErr  bool
+	}{
+		{
+			name:     "multiple versions",
+			resource: "sheriffs",
+			want: &BoundResourceVersions{
+				Served:         []string{"v1beta1", "v1"},
+				Storage:        "v1",
+				StoredVersions: []string{"v1beta1", "v1"},
+			},
+		},
+		{
+			name:     "schema UID mismatch",
+			resource: "cowboys",
+			wantErr:  true,
+		},
+		{
+			name:     "not bound",
+			resource: "horses",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetBoundResourceVersions(binding, []*apisv1alpha1.APIResourceSchema{other, schema}, "wild.wild.west", tt.resource)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetBoundResourceVersions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBoundResourceVersions() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+)
+
+// ClaimsEqual returns true if both lists contain the same permission claims,
+// independently of their order. Group, resource and identity hash are compared
+// exactly. Resource selectors, namespaces, verbs and namespace selectors of a
+// claim are compared independently of their order too.
+func ClaimsEqual(a, b []apisv1alpha1.PermissionClaim) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	as, bs := claimKeys(a), claimKeys(b)
+	for i := range as {
+		if as[i] != bs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// claimKeys returns the sorted keys of the given claims.
+func claimKeys(claims []apisv1alpha1.PermissionClaim) []string {
+	ret := make([]string, 0, len(claims))
+	for _, c := range claims {
+		ret = append(ret, claimKey(c))
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// claimKey returns a string representation of the given claim that is equal for equal claims.
+func claimKey(c apisv1alpha1.PermissionClaim) string {
+	selectors := make([]string, 0, len(c.ResourceSelector))
+	for _, s := range c.ResourceSelector {
+		selectors = append(selectors, s.Namespace+"/"+s.Name)
+	}
+	sort.Strings(selectors)
+
+	namespaces := append([]string(nil), c.Namespaces...)
+	sort.Strings(namespaces)
+
+	verbs := append([]string(nil), c.Verbs...)
+	sort.Strings(verbs)
+
+	// label selectors are canonicalized by FormatLabelSelector, i.e. independently of the order
+	// of their labels and expressions.
+	namespaceSelector := ""
+	if c.NamespaceSelector != nil {
+		namespaceSelector = metav1.FormatLabelSelector(c.NamespaceSelector)
+	}
+
+	all := "false"
+	if c.All {
+		all = "true"
+	}
+
+	readOnly := "false"
+	if c.ReadOnly {
+		readOnly = "true"
+	}
+
+	auditAnnotations := "false"
+	if c.AuditAnnotations {
+		auditAnnotations = "true"
+	}
+
+	identityAnnotation := "false"
+	if c.IdentityAnnotation {
+		identityAnnotation = "true"
+	}
+
+	maxObjects := ""
+	if c.MaxObjects != nil {
+		maxObjects = strconv.FormatInt(*c.MaxObjects, 10)
+	}
+
+	return strings.Join([]string{
+		c.Group,
+		c.Resource,
+		c.IdentityHash,
+		all,
+		strings.Join(selectors, ","),
+		strings.Join(namespaces, ","),
+		strings.Join(verbs, ","),
+		namespaceSelector,
+		readOnly,
+		auditAnnotations,
+		identityAnnotation,
+		maxObjects,
+	}, "|")
+}
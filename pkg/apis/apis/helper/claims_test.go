@@ -0,0 +1,273 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+)
+
+func TestClaimsEqual(t *testing.T) {
+	ten, twenty := int64(10), int64(20)
+	configmaps := apisv1alpha1.PermissionClaim{
+		GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"},
+		All:           true,
+	}
+	sheriffs := apisv1alpha1.PermissionClaim{
+		GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"},
+		IdentityHash:  "abc123",
+		ResourceSelector: []apisv1alpha1.ResourceSelector{
+			{Namespace: "a"},
+			{Namespace: "b", Name: "foo"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		a, b []apisv1alpha1.PermissionClaim
+		want bool
+	}{
+		{
+			name: "both empty",
+			want: true,
+		},
+		{
+			name: "nil and empty",
+			a:    nil,
+			b:    []apisv1alpha1.PermissionClaim{},
+			want: true,
+		},
+		{
+			name: "same order",
+			a:    []apisv1alpha1.PermissionClaim{configmaps, sheriffs},
+			b:    []apisv1alpha1.PermissionClaim{configmaps, sheriffs},
+			want: true,
+		},
+		{
+			name: "reordered",
+			a:    []apisv1alpha1.PermissionClaim{configmaps, sheriffs},
+			b:    []apisv1alpha1.PermissionClaim{sheriffs, configmaps},
+			want: true,
+		},
+		{
+			name: "reordered resource selectors",
+			a:    []apisv1alpha1.PermissionClaim{sheriffs},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource: sheriffs.GroupResource,
+				IdentityHash:  sheriffs.IdentityHash,
+				ResourceSelector: []apisv1alpha1.ResourceSelector{
+					{Namespace: "b", Name: "foo"},
+					{Namespace: "a"},
+				},
+			}},
+			want: true,
+		},
+		{
+			name: "core group spelled out",
+			a:    []apisv1alpha1.PermissionClaim{configmaps},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource: apisv1alpha1.GroupResource{Group: "core", Resource: "configmaps"},
+				All:           true,
+			}},
+			want: false,
+		},
+		{
+			name: "different case and whitespace",
+			a:    []apisv1alpha1.PermissionClaim{sheriffs},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource:    apisv1alpha1.GroupResource{Group: "Wild.Wild.West", Resource: " sheriffs"},
+				IdentityHash:     "ABC123 ",
+				ResourceSelector: sheriffs.ResourceSelector,
+			}},
+			want: false,
+		},
+		{
+			name: "different length",
+			a:    []apisv1alpha1.PermissionClaim{configmaps, sheriffs},
+			b:    []apisv1alpha1.PermissionClaim{configmaps},
+			want: false,
+		},
+		{
+			name: "duplicates are not collapsed",
+			a:    []apisv1alpha1.PermissionClaim{configmaps, configmaps},
+			b:    []apisv1alpha1.PermissionClaim{configmaps, sheriffs},
+			want: false,
+		},
+		{
+			name: "different identity",
+			a:    []apisv1alpha1.PermissionClaim{sheriffs},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource:    sheriffs.GroupResource,
+				IdentityHash:     "def456",
+				ResourceSelector: sheriffs.ResourceSelector,
+			}},
+			want: false,
+		},
+		{
+			name: "different resource selectors",
+			a:    []apisv1alpha1.PermissionClaim{sheriffs},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource:    sheriffs.GroupResource,
+				IdentityHash:     sheriffs.IdentityHash,
+				ResourceSelector: []apisv1alpha1.ResourceSelector{{Namespace: "a"}},
+			}},
+			want: false,
+		},
+		{
+			name: "verbs in different order",
+			a: []apisv1alpha1.PermissionClaim{{
+				GroupResource: configmaps.GroupResource,
+				All:           true,
+				Verbs:         []string{"get", "create"},
+			}},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource: configmaps.GroupResource,
+				All:           true,
+				Verbs:         []string{"create", "get"},
+			}},
+			want: true,
+		},
+		{
+			name: "different verbs",
+			a: []apisv1alpha1.PermissionClaim{{
+				GroupResource: configmaps.GroupResource,
+				All:           true,
+				Verbs:         []string{"get", "create"},
+			}},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource: configmaps.GroupResource,
+				All:           true,
+				Verbs:         []string{"get", "create", "delete"},
+			}},
+			want: false,
+		},
+		{
+			name: "namespace selector labels in different order",
+			a: []apisv1alpha1.PermissionClaim{{
+				GroupResource:     configmaps.GroupResource,
+				All:               true,
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a", "tier": "gold"}},
+			}},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource:     configmaps.GroupResource,
+				All:               true,
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold", "tenant": "a"}},
+			}},
+			want: true,
+		},
+		{
+			name: "namespace selector vs. none",
+			a:    []apisv1alpha1.PermissionClaim{configmaps},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource:     configmaps.GroupResource,
+				All:               true,
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+			}},
+			want: false,
+		},
+		{
+			name: "read-only vs. writable",
+			a:    []apisv1alpha1.PermissionClaim{configmaps},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource: configmaps.GroupResource,
+				All:           true,
+				ReadOnly:      true,
+			}},
+			want: false,
+		},
+		{
+			name: "audit annotations vs. none",
+			a:    []apisv1alpha1.PermissionClaim{configmaps},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource:    configmaps.GroupResource,
+				All:              true,
+				AuditAnnotations: true,
+			}},
+			want: false,
+		},
+		{
+			name: "identity annotation vs. none",
+			a:    []apisv1alpha1.PermissionClaim{configmaps},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource:      configmaps.GroupResource,
+				All:                true,
+				IdentityAnnotation: true,
+			}},
+			want: false,
+		},
+		{
+			name: "object limit vs. none",
+			a:    []apisv1alpha1.PermissionClaim{configmaps},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource: configmaps.GroupResource,
+				All:           true,
+				MaxObjects:    &ten,
+			}},
+			want: false,
+		},
+		{
+			name: "different object limits",
+			a: []apisv1alpha1.PermissionClaim{{
+				GroupResource: configmaps.GroupResource,
+				All:           true,
+				MaxObjects:    &ten,
+			}},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource: configmaps.GroupResource,
+				All:           true,
+				MaxObjects:    &twenty,
+			}},
+			want: false,
+		},
+		{
+			name: "same object limit",
+			a: []apisv1alpha1.PermissionClaim{{
+				GroupResource: configmaps.GroupResource,
+				All:           true,
+				MaxObjects:    &ten,
+			}},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource: configmaps.GroupResource,
+				All:           true,
+				MaxObjects:    &ten,
+			}},
+			want: true,
+		},
+		{
+			name: "all vs. selector",
+			a:    []apisv1alpha1.PermissionClaim{configmaps},
+			b: []apisv1alpha1.PermissionClaim{{
+				GroupResource:    configmaps.GroupResource,
+				ResourceSelector: []apisv1alpha1.ResourceSelector{{Namespace: "a"}},
+			}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClaimsEqual(tt.a, tt.b); got != tt.want {
+				t.Errorf("ClaimsEqual() = %v, want %v", got, tt.want)
+			}
+			if got := ClaimsEqual(tt.b, tt.a); got != tt.want {
+				t.Errorf("ClaimsEqual() with swapped arguments = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,5 @@
 /*
-Copyright The KCP Authors.
+Copyright 2023 The KCP Authors.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -14,10 +14,12 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Code generated by client-gen. DO NOT EDIT.
+package apis
 
-package v1alpha1
+import (
+	"github.com/kcp-dev/logicalcluster/v3"
+)
 
-type APIResourceImportExpansion interface{}
-
-type NegotiatedAPIResourceExpansion interface{}
+// SystemBoundCRDsClusterName is the logical cluster holding the CustomResourceDefinitions that
+// serve the resources bound by APIBindings.
+var SystemBoundCRDsClusterName = logicalcluster.Name("system:bound-crds")
@@ -79,8 +79,28 @@ type APIBindingSpec struct {
 	//
 	// +optional
 	PermissionClaims []AcceptablePermissionClaim `json:"permissionClaims,omitempty"`
+
+	// conflictResolution defines how resources of the APIExport are handled that overlap with a
+	// CustomResourceDefinition in the workspace of the APIBinding. With "Error", the binding fails
+	// with a naming conflict. With "Skip", overlapping resources stay served by the CustomResourceDefinition,
+	// and only the other resources of the APIExport are bound.
+	//
+	// +optional
+	// +kubebuilder:default=Error
+	// +kubebuilder:validation:Enum=Error;Skip
+	ConflictResolution ConflictResolutionType `json:"conflictResolution,omitempty"`
 }
 
+// ConflictResolutionType defines how an APIBinding handles resources overlapping with CustomResourceDefinitions.
+type ConflictResolutionType string
+
+const (
+	// ConflictResolutionError fails the binding if a resource overlaps with a CustomResourceDefinition.
+	ConflictResolutionError ConflictResolutionType = "Error"
+	// ConflictResolutionSkip skips binding resources that overlap with a CustomResourceDefinition.
+	ConflictResolutionSkip ConflictResolutionType = "Skip"
+)
+
 // AcceptablePermissionClaim is a PermissionClaim that records if the user accepts or rejects it.
 type AcceptablePermissionClaim struct {
 	PermissionClaim `json:",inline"`
@@ -91,6 +111,28 @@ type AcceptablePermissionClaim struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Enum=Accepted;Rejected
 	State AcceptablePermissionClaimState `json:"state"`
+
+	// expiresAt is the time until which an accepted claim is accepted. After that time, the claim
+	// is reverted to rejected and the access granted by it is revoked. It must only be set on
+	// accepted claims.
+	//
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// AppliedPermissionClaim is a PermissionClaim that has been applied to the objects in the
+// workspace of the APIBinding.
+type AppliedPermissionClaim struct {
+	PermissionClaim `json:",inline"`
+
+	// authorized indicates whether the maximal permission policies of the APIExports providing
+	// the claimed resource currently authorize access to it, i.e. whether such an APIExport
+	// exists and the ClusterRoles bound by its policies grant the claimed verbs on the claimed
+	// resource. Claims of resources without an identity hash, like core Kubernetes resources,
+	// are not subject to a maximal permission policy and are always authorized.
+	//
+	// +optional
+	Authorized bool `json:"authorized"`
 }
 
 type AcceptablePermissionClaimState string
@@ -170,7 +212,7 @@ type APIBindingStatus struct {
 	// state in spec.permissionClaims.
 	//
 	// +optional
-	AppliedPermissionClaims []PermissionClaim `json:"appliedPermissionClaims,omitempty"`
+	AppliedPermissionClaims []AppliedPermissionClaim `json:"appliedPermissionClaims,omitempty"`
 
 	// exportPermissionClaims records the permissions that the export provider is asking for
 	// the binding to grant.
@@ -206,10 +248,14 @@ const (
 	// the binding's desired export.
 	BindingUpToDate conditionsv1alpha1.ConditionType = "BindingUpToDate"
 
-	// NamingConflictsReason is a reason for the BindingUpToDate condition that at least one API coming in from the APIBinding
-	// has a naming conflict with other APIs.
+	// NamingConflictsReason is a reason for the BindingUpToDate and NoConflictingBindings conditions that at least one API
+	// coming in from the APIBinding has a naming conflict with other APIs.
 	NamingConflictsReason = "NamingConflicts"
 
+	// NoConflictingBindings is a condition for APIBinding that indicates that no other APIBinding in the same workspace
+	// binds APIs whose names conflict with the APIs of this APIBinding, independent of which of them got bound first.
+	NoConflictingBindings conditionsv1alpha1.ConditionType = "NoConflictingBindings"
+
 	// BindingResourceDeleteSuccess is a condition for APIBinding that indicates the resources relating this binding are deleted
 	// successfully when the APIBinding is deleting
 	BindingResourceDeleteSuccess conditionsv1alpha1.ConditionType = "BindingResourceDeleteSuccess"
@@ -224,6 +270,31 @@ const (
 	// PermissionClaimsApplied is a condition for APIBinding that indicates that all the accepted permission claims
 	// have been applied.
 	PermissionClaimsApplied conditionsv1alpha1.ConditionType = "PermissionClaimsApplied"
+
+	// PermissionClaimNamespaceSelectorsSatisfied is a condition for APIBinding that indicates that for every
+	// accepted permission claim with a namespace selector, at least one namespace in the workspace of the
+	// APIBinding matches the selector.
+	PermissionClaimNamespaceSelectorsSatisfied conditionsv1alpha1.ConditionType = "PermissionClaimNamespaceSelectorsSatisfied"
+
+	// NoMatchingNamespacesReason is a reason for the PermissionClaimNamespaceSelectorsSatisfied condition that
+	// no namespace matches the namespace selector of at least one accepted permission claim.
+	NoMatchingNamespacesReason = "NoMatchingNamespaces"
+
+	// BoundVersionsNotDeprecated is a condition for APIBinding that indicates that none of the versions the bound
+	// resources are stored in is deprecated by the APIExport.
+	BoundVersionsNotDeprecated conditionsv1alpha1.ConditionType = "BoundVersionsNotDeprecated"
+
+	// DeprecatedVersionsBoundReason is a reason for the BoundVersionsNotDeprecated condition that at least one bound
+	// resource is stored in a version that is deprecated by the APIExport.
+	DeprecatedVersionsBoundReason = "DeprecatedVersionsBound"
+
+	// NoResourcesSkipped is a condition for APIBinding that indicates that no resource of the APIExport was skipped
+	// because it overlaps with a CustomResourceDefinition in the workspace, see spec.conflictResolution.
+	NoResourcesSkipped conditionsv1alpha1.ConditionType = "NoResourcesSkipped"
+
+	// ResourcesSkippedReason is a reason for the NoResourcesSkipped condition that at least one resource of the
+	// APIExport is not bound because it overlaps with a CustomResourceDefinition in the workspace.
+	ResourcesSkippedReason = "ResourcesSkipped"
 )
 
 // These are annotations for bound CRDs
@@ -35,6 +35,24 @@ const (
 	APIExportVirtualWorkspaceURLsReady conditionsv1alpha1.ConditionType = "VirtualWorkspaceURLsReady"
 
 	ErrorGeneratingURLsReason = "ErrorGeneratingURLs"
+
+	APIExportPermissionClaimsValid conditionsv1alpha1.ConditionType = "PermissionClaimsValid"
+
+	PermissionClaimCycleReason         = "PermissionClaimCycle"
+	PermissionClaimSelfReferenceReason = "PermissionClaimSelfReference"
+	// PermissionClaimIdentityNotFoundReason is used for the PermissionClaimsValid condition of APIExports and
+	// APIBindings when claims reference an identity hash no APIExport has, e.g. because it was rotated.
+	PermissionClaimIdentityNotFoundReason = "PermissionClaimIdentityNotFound"
+	// PermissionClaimIdentityAmbiguousReason is used for the PermissionClaimsValid condition of APIExports
+	// when claims without identity hash are for a resource that APIExports export with different identities.
+	PermissionClaimIdentityAmbiguousReason = "PermissionClaimIdentityAmbiguous"
+
+	// APIExportMaximalPermissionPolicySatisfiable is false if the RBAC backing the maximal permission
+	// policy is missing, i.e. no ClusterRoleBinding or RoleBinding grants permissions to the prefixed
+	// users and groups, or a role referenced by such a binding does not exist.
+	APIExportMaximalPermissionPolicySatisfiable conditionsv1alpha1.ConditionType = "MaximalPermissionPolicySatisfiable"
+
+	MaximalPermissionPolicyRBACMissingReason = "MaximalPermissionPolicyRBACMissing"
 )
 
 // These are for APIExport identity.
@@ -76,6 +94,30 @@ const (
 	// this APIExport. If the annotation is removed from the APIExport, it will also be removed from
 	// all APIBindings bound to this APIExport.
 	AnnotationAPIExportExtraKeyPrefix = "extra.apis.kcp.io/"
+
+	// AnnotationClaimAuditAPIExportKey is set on objects of claimed resources created or updated
+	// through the APIExport virtual workspace if the permission claim has auditAnnotations enabled.
+	// The value is the logical cluster and the name of the APIExport, in the form <cluster>:<name>.
+	AnnotationClaimAuditAPIExportKey = "apis.kcp.io/audit-apiexport"
+
+	// AnnotationClaimAuditUserKey is set next to AnnotationClaimAuditAPIExportKey. The value is
+	// the name of the user that created or updated the object through the APIExport virtual workspace.
+	AnnotationClaimAuditUserKey = "apis.kcp.io/audit-user"
+
+	// AnnotationClaimAPIExportIdentityKey is set on objects of claimed resources created or updated
+	// through the APIExport virtual workspace if the permission claim has identityAnnotation enabled.
+	// The value is the identity hash of the APIExport.
+	AnnotationClaimAPIExportIdentityKey = "apis.kcp.io/apiexport-identity"
+
+	// AnnotationAPIExportEndpointSliceKey can be set to "true" on an APIExport to have an
+	// APIExportEndpointSlice with the name of the APIExport created in its workspace. The slice
+	// is recreated when deleted, as long as the annotation is set.
+	AnnotationAPIExportEndpointSliceKey = "apis.kcp.io/create-endpointslice"
+
+	// AnnotationAPIExportRotateIdentityKey can be set on an APIExport to rotate its identity after the
+	// identity secret was changed. The value must be the identity hash of the changed secret, as reported
+	// by the IdentityValid condition. Objects stored under the previous identity are not migrated.
+	AnnotationAPIExportRotateIdentityKey = "apis.kcp.io/rotate-identity"
 )
 
 func (in *APIExport) GetConditions() conditionsv1alpha1.Conditions {
@@ -118,8 +160,9 @@ type APIExportSpec struct {
 	// The identity is a secret of the API provider. The APIBindings referencing this APIExport
 	// will store a derived, non-sensitive value of this identity.
 	//
-	// The identity of an APIExport cannot be changed. A derived, non-sensitive value of
-	// the identity key is stored in the APIExport status and this value is immutable.
+	// A derived, non-sensitive value of the identity key is stored in the APIExport status.
+	// It only changes when the identity is rotated explicitly with the apis.kcp.io/rotate-identity
+	// annotation. Objects stored under the previous identity are not served anymore after a rotation.
 	//
 	// The identity is defaulted. A secret with the name of the APIExport is automatically
 	// created.
@@ -156,13 +199,35 @@ type APIExportSpec struct {
 	// PermissionClaims must be accepted by the user's explicit acknowledgement. Hence, when claims
 	// change, the respecting objects are not visible immediately.
 	//
-	// PermissionClaims overlapping with the APIExport resources are ignored.
+	// PermissionClaims overlapping with the APIExport resources are ignored, and reported
+	// in the PermissionClaimsValid condition.
 	//
 	// +optional
 	// +listType=map
 	// +listMapKey=group
 	// +listMapKey=resource
 	PermissionClaims []PermissionClaim `json:"permissionClaims,omitempty"`
+
+	// permissionClaimTemplates are names of built-in bundles of permission claims. On admission,
+	// the claims of every template are added to permissionClaims, unless permissionClaims already
+	// has a claim for the same group and resource. The supported templates are:
+	//
+	// - "configuration": configmaps and secrets.
+	// - "events": events.
+	// - "rbac": roles and rolebindings.
+	//
+	// +optional
+	// +listType=set
+	PermissionClaimTemplates []string `json:"permissionClaimTemplates,omitempty"`
+
+	// webhooks are admission webhooks of the service provider. They are called for create,
+	// update and delete requests of the resources of this APIExport in workspaces that bind
+	// to it, in addition to the webhook configurations in the workspace of the APIExport.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Webhooks []APIExportWebhook `json:"webhooks,omitempty"`
 }
 
 // Identity defines the identity of an APIExport, i.e. determines the etcd prefix
@@ -217,6 +282,66 @@ type PermissionClaim struct {
 	// Note that one must look this up for a particular KCP instance.
 	// +optional
 	IdentityHash string `json:"identityHash,omitempty"`
+
+	// namespaces is an allowlist of namespaces the service provider may create or
+	// update claimed objects in through the APIExport virtual workspace.
+	// If empty, claimed objects can be created in any namespace. It has no effect
+	// on cluster-scoped resources.
+	//
+	// +optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// namespaceSelector restricts the claim to objects in namespaces whose labels match the
+	// selector. Claimed objects in other namespaces are invisible through the APIExport virtual
+	// workspace. It has no effect on cluster-scoped resources.
+	//
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// verbs is an allowlist of verbs the service provider may use on claimed objects
+	// through the APIExport virtual workspace, e.g. to create and read claimed objects
+	// without being able to delete them. Every verb is checked on its own, i.e.
+	// "deletecollection" is not implied by "delete". "*" allows all verbs.
+	// If empty, all verbs are allowed.
+	//
+	// +optional
+	// +listType=set
+	Verbs []string `json:"verbs,omitempty"`
+
+	// readOnly projects claimed objects read-only through the APIExport virtual workspace:
+	// all requests other than get, list and watch are rejected, independently of verbs and
+	// of any RBAC granted to the service provider. It cannot be combined with verbs allowing
+	// mutating requests.
+	//
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// auditAnnotations enables stamping objects of the claimed resource that the service
+	// provider creates or updates through the APIExport virtual workspace with the
+	// apis.kcp.io/audit-apiexport and apis.kcp.io/audit-user annotations.
+	//
+	// +optional
+	AuditAnnotations bool `json:"auditAnnotations,omitempty"`
+
+	// identityAnnotation enables stamping objects of the claimed resource that the service
+	// provider creates or updates through the APIExport virtual workspace with the
+	// apis.kcp.io/apiexport-identity annotation, holding the identity hash of the APIExport.
+	// This allows the service provider to attribute objects to the APIExport across
+	// identity rotations.
+	//
+	// +optional
+	IdentityAnnotation bool `json:"identityAnnotation,omitempty"`
+
+	// maxObjects limits the number of objects of the claimed resource in a consumer workspace
+	// that are visible through the claim, beyond which the service provider cannot create further
+	// objects through the APIExport virtual workspace. This protects consumers from service
+	// providers exhausting their quota. Objects created otherwise count as well, but are never
+	// rejected. If unset, the number of objects is not limited.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxObjects *int64 `json:"maxObjects,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="has(self.__namespace__) || has(self.name)",message="at least one field must be set"
@@ -278,6 +403,81 @@ type GroupResource struct {
 	Resource string `json:"resource"`
 }
 
+// APIExportWebhookType is the type of an APIExport admission webhook.
+//
+// +kubebuilder:validation:Enum=Validating;Mutating
+type APIExportWebhookType string
+
+const (
+	// APIExportWebhookValidating webhooks are called in the validating admission phase.
+	APIExportWebhookValidating APIExportWebhookType = "Validating"
+	// APIExportWebhookMutating webhooks are called in the mutating admission phase.
+	APIExportWebhookMutating APIExportWebhookType = "Mutating"
+)
+
+// APIExportWebhookFailurePolicy defines how errors calling an APIExport webhook are handled.
+//
+// +kubebuilder:validation:Enum=Fail;Ignore
+type APIExportWebhookFailurePolicy string
+
+const (
+	// APIExportWebhookFailurePolicyFail rejects the request if the webhook call fails.
+	APIExportWebhookFailurePolicyFail APIExportWebhookFailurePolicy = "Fail"
+	// APIExportWebhookFailurePolicyIgnore ignores errors calling the webhook.
+	APIExportWebhookFailurePolicyIgnore APIExportWebhookFailurePolicy = "Ignore"
+)
+
+// APIExportWebhook is an admission webhook of the service provider for the
+// resources of an APIExport.
+type APIExportWebhook struct {
+	// name is the name of the webhook. It must be unique within the APIExport.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// type is either "Validating" or "Mutating".
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Type APIExportWebhookType `json:"type"`
+
+	// clientConfig defines how to communicate with the webhook.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	ClientConfig WebhookClientConfig `json:"clientConfig"`
+
+	// failurePolicy defines how errors calling the webhook are handled.
+	// Allowed values are "Fail" and "Ignore". Defaults to "Fail".
+	//
+	// +optional
+	// +kubebuilder:default=Fail
+	FailurePolicy APIExportWebhookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// WebhookClientConfig contains the information to make a TLS connection with a webhook.
+type WebhookClientConfig struct {
+	// url gives the location of the webhook, in standard URL form (`https://host:port/path`).
+	// It must use the https scheme, and must not contain a user, a query or a fragment.
+	// It is called by kcp with its network access, not by the service provider. Hence, unless
+	// kcp is started with --apiexport-webhooks-allow-private-hosts, the host must not be the local
+	// host, a cluster-internal service name, or a loopback, link-local, private or unspecified
+	// IP address.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// caBundle is a PEM encoded CA bundle which is used to validate the webhook's server certificate.
+	// If unspecified, system trust roots are used.
+	//
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
 // APIExportStatus defines the observed state of APIExport.
 type APIExportStatus struct {
 	// identityHash is the hash of the API identity key of this APIExport. This value
@@ -297,6 +497,14 @@ type APIExportStatus struct {
 	//
 	// +optional
 	VirtualWorkspaces []VirtualWorkspace `json:"virtualWorkspaces,omitempty"`
+
+	// virtualWorkspaceURLs contains the APIExport virtual workspace URL of every shard
+	// serving the APIExport, e.g. to select the endpoint of a nearby shard.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=shard
+	VirtualWorkspaceURLs []APIExportVirtualWorkspaceReference `json:"virtualWorkspaceURLs,omitempty"`
 }
 
 type VirtualWorkspace struct {
@@ -308,6 +516,22 @@ type VirtualWorkspace struct {
 	URL string `json:"url"`
 }
 
+// APIExportVirtualWorkspaceReference is the APIExport virtual workspace URL of a shard.
+type APIExportVirtualWorkspaceReference struct {
+	// shard is the name of the shard serving the virtual workspace.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Shard string `json:"shard"`
+
+	// url is the APIExport virtual workspace URL of the shard.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:format:URL
+	// +required
+	URL string `json:"url"`
+}
+
 // APIExportList is a list of APIExport resources
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -23,7 +23,8 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
-	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
@@ -133,7 +134,7 @@ func (in *APIBindingStatus) DeepCopyInto(out *APIBindingStatus) {
 	}
 	if in.AppliedPermissionClaims != nil {
 		in, out := &in.AppliedPermissionClaims, &out.AppliedPermissionClaims
-		*out = make([]PermissionClaim, len(*in))
+		*out = make([]AppliedPermissionClaim, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -465,6 +466,18 @@ func (in *APIExportSpec) DeepCopyInto(out *APIExportSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PermissionClaimTemplates != nil {
+		in, out := &in.PermissionClaimTemplates, &out.PermissionClaimTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]APIExportWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -493,6 +506,11 @@ func (in *APIExportStatus) DeepCopyInto(out *APIExportStatus) {
 		*out = make([]VirtualWorkspace, len(*in))
 		copy(*out, *in)
 	}
+	if in.VirtualWorkspaceURLs != nil {
+		in, out := &in.VirtualWorkspaceURLs, &out.VirtualWorkspaceURLs
+		*out = make([]APIExportVirtualWorkspaceReference, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -506,6 +524,39 @@ func (in *APIExportStatus) DeepCopy() *APIExportStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIExportVirtualWorkspaceReference) DeepCopyInto(out *APIExportVirtualWorkspaceReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIExportVirtualWorkspaceReference.
+func (in *APIExportVirtualWorkspaceReference) DeepCopy() *APIExportVirtualWorkspaceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(APIExportVirtualWorkspaceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIExportWebhook) DeepCopyInto(out *APIExportWebhook) {
+	*out = *in
+	in.ClientConfig.DeepCopyInto(&out.ClientConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIExportWebhook.
+func (in *APIExportWebhook) DeepCopy() *APIExportWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(APIExportWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIResourceSchema) DeepCopyInto(out *APIResourceSchema) {
 	*out = *in
@@ -602,7 +653,7 @@ func (in *APIResourceVersion) DeepCopyInto(out *APIResourceVersion) {
 	in.Subresources.DeepCopyInto(&out.Subresources)
 	if in.AdditionalPrinterColumns != nil {
 		in, out := &in.AdditionalPrinterColumns, &out.AdditionalPrinterColumns
-		*out = make([]v1.CustomResourceColumnDefinition, len(*in))
+		*out = make([]apiextensionsv1.CustomResourceColumnDefinition, len(*in))
 		copy(*out, *in)
 	}
 	return
@@ -648,6 +699,10 @@ func (in *APIVersionConversion) DeepCopy() *APIVersionConversion {
 func (in *AcceptablePermissionClaim) DeepCopyInto(out *AcceptablePermissionClaim) {
 	*out = *in
 	in.PermissionClaim.DeepCopyInto(&out.PermissionClaim)
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -661,6 +716,23 @@ func (in *AcceptablePermissionClaim) DeepCopy() *AcceptablePermissionClaim {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedPermissionClaim) DeepCopyInto(out *AppliedPermissionClaim) {
+	*out = *in
+	in.PermissionClaim.DeepCopyInto(&out.PermissionClaim)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedPermissionClaim.
+func (in *AppliedPermissionClaim) DeepCopy() *AppliedPermissionClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedPermissionClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BindingReference) DeepCopyInto(out *BindingReference) {
 	*out = *in
@@ -819,6 +891,26 @@ func (in *PermissionClaim) DeepCopyInto(out *PermissionClaim) {
 		*out = make([]ResourceSelector, len(*in))
 		copy(*out, *in)
 	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxObjects != nil {
+		in, out := &in.MaxObjects, &out.MaxObjects
+		*out = new(int64)
+		**out = **in
+	}
 	return
 }
 
@@ -863,3 +955,24 @@ func (in *VirtualWorkspace) DeepCopy() *VirtualWorkspace {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookClientConfig) DeepCopyInto(out *WebhookClientConfig) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookClientConfig.
+func (in *WebhookClientConfig) DeepCopy() *WebhookClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -31,6 +31,12 @@ const (
 	// Its value is a comma-seperated list of words. Every controller setting this has to choose
 	// a unique word, and preserve other controllers' words in the comma separated list.
 	ReplicateAnnotationKey = "internal.kcp.io/replicate"
+
+	// DeletionOrderAnnotationKey is the annotation key on CustomResourceDefinitions and APIResourceSchemas
+	// to order the deletion of their resources when a logical cluster is deleted. Its value is an integer.
+	// Resources are deleted in ascending order, and before resources without the annotation. This includes
+	// namespaced resources, which are deleted before namespaces instead of by the deletion of their namespace.
+	DeletionOrderAnnotationKey = "deletion.kcp.io/order"
 )
 
 // RootCluster is the root of workspace based logical clusters.
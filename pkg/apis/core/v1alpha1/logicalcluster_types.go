@@ -168,6 +168,17 @@ type LogicalClusterStatus struct {
 	Initializers []LogicalClusterInitializer `json:"initializers,omitempty"`
 }
 
+const (
+	// LogicalClusterOwnerLost is set to True on a deleted LogicalCluster whose owner does not exist
+	// anymore or was recreated with another UID. The owner is not finalized in that case.
+	LogicalClusterOwnerLost conditionsv1alpha1.ConditionType = "LogicalClusterOwnerLost"
+
+	// LogicalClusterOwnerNotFoundReason is the reason for LogicalClusterOwnerLost when the owner does not exist.
+	LogicalClusterOwnerNotFoundReason = "OwnerNotFound"
+	// LogicalClusterOwnerUIDMismatchReason is the reason for LogicalClusterOwnerLost when the owner was recreated with another UID.
+	LogicalClusterOwnerUIDMismatchReason = "OwnerUIDMismatch"
+)
+
 func (in *LogicalCluster) SetConditions(c conditionsv1alpha1.Conditions) {
 	in.Status.Conditions = c
 }
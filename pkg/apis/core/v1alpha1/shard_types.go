@@ -115,6 +115,19 @@ type ShardStatus struct {
 	Conditions v1alpha1.Conditions `json:"conditions,omitempty"`
 }
 
+const (
+	// ShardReachable is set to True when the /healthz endpoint of the shard answered successfully
+	// at its baseURL. It is maintained by the root shard, which probes all shards periodically.
+	ShardReachable v1alpha1.ConditionType = "ShardReachable"
+
+	// ShardNotReachableReason is the reason for ShardReachable False when the shard could not be
+	// connected to at its baseURL.
+	ShardNotReachableReason = "NotReachable"
+	// ShardNotHealthyReason is the reason for ShardReachable False when the shard answered with a
+	// status code other than 200 OK.
+	ShardNotHealthyReason = "NotHealthy"
+)
+
 // ShardList is a list of shard instances
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
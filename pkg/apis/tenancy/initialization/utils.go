@@ -62,6 +62,27 @@ func EnsureInitializerAbsent(initializer corev1alpha1.LogicalClusterInitializer,
 	return initializers
 }
 
+// CompletedInitializers returns the initializers the logical cluster was created with
+// that have been removed from its status since, i.e. those that have finished.
+func CompletedInitializers(logicalCluster *corev1alpha1.LogicalCluster) []corev1alpha1.LogicalClusterInitializer {
+	var completed []corev1alpha1.LogicalClusterInitializer
+	for _, initializer := range logicalCluster.Spec.Initializers {
+		if !InitializerPresent(initializer, logicalCluster.Status.Initializers) {
+			completed = append(completed, initializer)
+		}
+	}
+	return completed
+}
+
+// InitializersMessage describes the pending and the completed initializers of the logical cluster.
+func InitializersMessage(logicalCluster *corev1alpha1.LogicalCluster) string {
+	message := fmt.Sprintf("Initializers still exist: %v", logicalCluster.Status.Initializers)
+	if completed := CompletedInitializers(logicalCluster); len(completed) > 0 {
+		message += fmt.Sprintf(", completed: %v", completed)
+	}
+	return message
+}
+
 // InitializerForType determines the identifier for the implicit initializer associated with the WorkspaceType.
 func InitializerForType(wt *tenancyv1alpha1.WorkspaceType) corev1alpha1.LogicalClusterInitializer {
 	return corev1alpha1.LogicalClusterInitializer(logicalcluster.From(wt).Path().Join(wt.Name).String())
@@ -20,6 +20,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"k8s.io/apimachinery/pkg/util/validation"
 
 	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
@@ -44,3 +46,45 @@ func TestInitializerToLabel(t *testing.T) {
 		}
 	}
 }
+
+func TestInitializersMessage(t *testing.T) {
+	for _, testCase := range []struct {
+		name              string
+		spec, status      []corev1alpha1.LogicalClusterInitializer
+		expectedCompleted []corev1alpha1.LogicalClusterInitializer
+		expectedMessage   string
+	}{
+		{
+			name:            "none started",
+			spec:            []corev1alpha1.LogicalClusterInitializer{"root:a", "root:b"},
+			status:          []corev1alpha1.LogicalClusterInitializer{"root:a", "root:b"},
+			expectedMessage: "Initializers still exist: [root:a root:b]",
+		},
+		{
+			name:              "some completed",
+			spec:              []corev1alpha1.LogicalClusterInitializer{"root:a", "root:b", "root:c"},
+			status:            []corev1alpha1.LogicalClusterInitializer{"root:b"},
+			expectedCompleted: []corev1alpha1.LogicalClusterInitializer{"root:a", "root:c"},
+			expectedMessage:   "Initializers still exist: [root:b], completed: [root:a root:c]",
+		},
+		{
+			name:              "all completed",
+			spec:              []corev1alpha1.LogicalClusterInitializer{"root:a"},
+			expectedCompleted: []corev1alpha1.LogicalClusterInitializer{"root:a"},
+			expectedMessage:   "Initializers still exist: [], completed: [root:a]",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			logicalCluster := &corev1alpha1.LogicalCluster{
+				Spec:   corev1alpha1.LogicalClusterSpec{Initializers: testCase.spec},
+				Status: corev1alpha1.LogicalClusterStatus{Initializers: testCase.status},
+			}
+			if diff := cmp.Diff(testCase.expectedCompleted, CompletedInitializers(logicalCluster)); diff != "" {
+				t.Errorf("unexpected completed initializers: %s", diff)
+			}
+			if actual := InitializersMessage(logicalCluster); actual != testCase.expectedMessage {
+				t.Errorf("expected message %q, got %q", testCase.expectedMessage, actual)
+			}
+		})
+	}
+}
@@ -89,6 +89,9 @@ const (
 	// WorkspaceInitializedAPIBindingErrors is a reason for the APIBindingsInitialized condition that indicates there
 	// were errors trying to initialize APIBindings for the workspace.
 	WorkspaceInitializedAPIBindingErrors = "APIBindingErrors"
+	// WorkspaceInitializedAPIExportsNotFound is a reason for the APIBindingsInitialized condition that indicates
+	// that APIExports referenced by the default APIBindings of the WorkspaceTypes do not exist (yet).
+	WorkspaceInitializedAPIExportsNotFound = "APIExportsNotFound"
 )
 
 // LogicalClusterTypeAnnotationKey is the annotation key used to indicate
@@ -70,13 +70,68 @@ func Set(to Setter, condition *conditionsapi.Condition) {
 	}
 
 	// Sorts conditions for convenience of the consumer, i.e. kubectl.
-	sort.Slice(conditions, func(i, j int) bool {
-		return lexicographicLess(&conditions[i], &conditions[j])
-	})
+	Sort(conditions)
 
 	to.SetConditions(conditions)
 }
 
+// SetAll sets all the given conditions with a single call to SetConditions, and
+// returns true if any of them were added or changed state. If nothing changed, the
+// conditions on the object are left untouched, so callers can skip the status update.
+//
+// NOTE: LastTransitionTime is handled like in Set.
+func SetAll(to Setter, conditions ...*conditionsapi.Condition) bool {
+	if to == nil {
+		return false
+	}
+
+	existing := to.GetConditions()
+	updated := make(conditionsapi.Conditions, len(existing), len(existing)+len(conditions))
+	copy(updated, existing)
+
+	changed := false
+	now := metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+	for _, condition := range conditions {
+		if condition == nil {
+			continue
+		}
+
+		exists := false
+		for i := range updated {
+			if updated[i].Type != condition.Type {
+				continue
+			}
+			exists = true
+			if hasSameState(&updated[i], condition) {
+				condition.LastTransitionTime = updated[i].LastTransitionTime
+				break
+			}
+			condition.LastTransitionTime = now
+			updated[i] = *condition
+			changed = true
+			break
+		}
+
+		if !exists {
+			if condition.LastTransitionTime.IsZero() {
+				condition.LastTransitionTime = now
+			}
+			updated = append(updated, *condition)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return false
+	}
+
+	// Sorts conditions for convenience of the consumer, i.e. kubectl.
+	Sort(updated)
+
+	to.SetConditions(updated)
+	return true
+}
+
 // TrueCondition returns a condition with Status=True and the given type.
 func TrueCondition(t conditionsapi.ConditionType) *conditionsapi.Condition {
 	return &conditionsapi.Condition{
@@ -157,6 +212,15 @@ func Delete(to Setter, t conditionsapi.ConditionType) {
 	to.SetConditions(newConditions)
 }
 
+// Sort sorts the given conditions in place in the canonical order, i.e. the Ready condition
+// first, followed by all the other conditions sorted by Type. Equal sets of conditions are
+// always in the same order after sorting.
+func Sort(conditions conditionsapi.Conditions) {
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return lexicographicLess(&conditions[i], &conditions[j])
+	})
+}
+
 // lexicographicLess returns true if a condition is less than another with regards to the
 // to order of conditions designed for convenience of the consumer, i.e. kubectl.
 // According to this order the Ready condition always goes first, followed by all the other
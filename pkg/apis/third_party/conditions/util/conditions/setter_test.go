@@ -87,6 +87,22 @@ func TestLexicographicLess(t *testing.T) {
 	g.Expect(lexicographicLess(a, b)).To(BeFalse())
 }
 
+func TestSort(t *testing.T) {
+	g := NewWithT(t)
+
+	ready := TrueCondition(conditionsapi.ReadyCondition)
+	a := TrueCondition("A")
+	b := FalseCondition("B", "reason", conditionsapi.ConditionSeverityInfo, "")
+
+	// equal sets of conditions end up in the same order
+	x := conditionsapi.Conditions{*b, *a, *ready}
+	y := conditionsapi.Conditions{*a, *ready, *b}
+	Sort(x)
+	Sort(y)
+	g.Expect(x).To(Equal(conditionsapi.Conditions{*ready, *a, *b}))
+	g.Expect(y).To(Equal(x))
+}
+
 func TestSet(t *testing.T) {
 	a := TrueCondition("a")
 	b := TrueCondition("b")
@@ -135,6 +151,99 @@ func TestSet(t *testing.T) {
 	}
 }
 
+func TestSetAll(t *testing.T) {
+	a := TrueCondition("a")
+	b := TrueCondition("b")
+	bFalse := FalseCondition("b", "reason b", conditionsapi.ConditionSeverityInfo, "message b")
+	ready := TrueCondition(conditionsapi.ReadyCondition)
+
+	tests := []struct {
+		name        string
+		to          Setter
+		conditions  []*conditionsapi.Condition
+		want        conditionsapi.Conditions
+		wantChanged bool
+	}{
+		{
+			name:        "SetAll with no conditions does not change anything",
+			to:          setterWithConditions(a),
+			want:        conditionList(a),
+			wantChanged: false,
+		},
+		{
+			name:        "SetAll adds conditions",
+			to:          setterWithConditions(),
+			conditions:  []*conditionsapi.Condition{b, a},
+			want:        conditionList(a, b),
+			wantChanged: true,
+		},
+		{
+			name:        "SetAll with only unchanged conditions reports no change",
+			to:          setterWithConditions(a, b),
+			conditions:  []*conditionsapi.Condition{a, b},
+			want:        conditionList(a, b),
+			wantChanged: false,
+		},
+		{
+			name:        "SetAll with mixed changed and unchanged conditions reports a change",
+			to:          setterWithConditions(a, b),
+			conditions:  []*conditionsapi.Condition{a, bFalse},
+			want:        conditionList(a, bFalse),
+			wantChanged: true,
+		},
+		{
+			name:        "SetAll with mixed new and unchanged conditions sorts in lexicographic order",
+			to:          setterWithConditions(b, a),
+			conditions:  []*conditionsapi.Condition{a, ready},
+			want:        conditionList(ready, a, b),
+			wantChanged: true,
+		},
+		{
+			name:        "SetAll ignores nil conditions",
+			to:          setterWithConditions(a),
+			conditions:  []*conditionsapi.Condition{nil, a},
+			want:        conditionList(a),
+			wantChanged: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			conditions := make([]*conditionsapi.Condition, 0, len(tt.conditions))
+			for _, c := range tt.conditions {
+				conditions = append(conditions, c.DeepCopy())
+			}
+
+			changed := SetAll(tt.to, conditions...)
+
+			g.Expect(changed).To(Equal(tt.wantChanged))
+			g.Expect(tt.to.GetConditions()).To(haveSameConditionsOf(tt.want))
+		})
+	}
+}
+
+func TestSetAllPreservesLastTransitionTime(t *testing.T) {
+	g := NewWithT(t)
+	x := metav1.Date(2012, time.January, 1, 12, 15, 30, 5e8, time.UTC)
+
+	foo := FalseCondition("foo", "reason foo", conditionsapi.ConditionSeverityInfo, "message foo")
+	foo.LastTransitionTime = x
+	bar := FalseCondition("bar", "reason bar", conditionsapi.ConditionSeverityInfo, "message bar")
+	bar.LastTransitionTime = x
+	target := setterWithConditions(foo, bar)
+
+	changed := SetAll(target,
+		FalseCondition("foo", "reason foo", conditionsapi.ConditionSeverityInfo, "message foo"),
+		TrueCondition("bar"),
+	)
+
+	g.Expect(changed).To(BeTrue())
+	g.Expect(Get(target, "foo").LastTransitionTime).To(Equal(x))
+	g.Expect(Get(target, "bar").LastTransitionTime).ToNot(Equal(x))
+}
+
 func TestSetLastTransitionTime(t *testing.T) {
 	x := metav1.Date(2012, time.January, 1, 12, 15, 30, 5e8, time.UTC)
 
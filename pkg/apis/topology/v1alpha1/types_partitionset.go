@@ -58,10 +58,71 @@ type PartitionSetSpec struct {
 	// dimensions (optional) are used to group shards into partitions
 	Dimensions []string `json:"dimensions,omitempty"`
 
+	// +optional
+	// +kubebuilder:validation:Enum=Zone
+
+	// topology (optional) is a shortcut for the dimensions of a well-known topology. "Zone" groups shards
+	// by region and availability zone, i.e. it is equivalent to the dimensions "region" and "az".
+	// Dimensions are added to the ones of the topology.
+	Topology PartitionSetTopology `json:"topology,omitempty"`
+
 	// +optional
 
 	// shardSelector (optional) specifies filtering for shard targets.
 	ShardSelector *metav1.LabelSelector `json:"shardSelector,omitempty"`
+
+	// +optional
+
+	// normalization (optional) normalizes the label values of dimensions before shards are grouped
+	// into partitions, e.g. such that shards labelled "region: Europe" and "region: europe" end up in
+	// the same partition. The partitions select all the original label values of their shards.
+	Normalization *PartitionSetNormalization `json:"normalization,omitempty"`
+
+	// +optional
+
+	// excludeUnreachableShards (optional) leaves shards out of the partitions whose ShardReachable
+	// condition is False, i.e. which the root shard could not probe successfully. Shards are added
+	// back when they are reachable again.
+	ExcludeUnreachableShards bool `json:"excludeUnreachableShards,omitempty"`
+}
+
+// PartitionSetNormalization specifies how the label values of dimensions are normalized.
+type PartitionSetNormalization struct {
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+
+	// dimensions are the dimensions whose label values are normalized.
+	Dimensions []string `json:"dimensions"`
+
+	// +optional
+
+	// caseFold (optional) lower-cases the label values.
+	CaseFold bool `json:"caseFold,omitempty"`
+
+	// +optional
+
+	// trim (optional) removes leading and trailing "-", "_" and "." characters from the label values.
+	// Shards with a label value that is empty after trimming are not part of any partition.
+	Trim bool `json:"trim,omitempty"`
+}
+
+// PartitionSetTopology is a well-known topology shards can be partitioned by.
+type PartitionSetTopology string
+
+const (
+	// PartitionSetTopologyZone partitions shards by region and, within a region, by availability zone.
+	PartitionSetTopologyZone PartitionSetTopology = "Zone"
+)
+
+// Dimensions returns the dimensions the given topology expands to.
+func (t PartitionSetTopology) Dimensions() []string {
+	switch t {
+	case PartitionSetTopologyZone:
+		return []string{"region", "az"}
+	default:
+		return nil
+	}
 }
 
 // PartitionSetStatus records the status of the PartitionSet.
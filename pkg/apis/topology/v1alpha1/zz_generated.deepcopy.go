@@ -149,6 +149,27 @@ func (in *PartitionSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionSetNormalization) DeepCopyInto(out *PartitionSetNormalization) {
+	*out = *in
+	if in.Dimensions != nil {
+		in, out := &in.Dimensions, &out.Dimensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionSetNormalization.
+func (in *PartitionSetNormalization) DeepCopy() *PartitionSetNormalization {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionSetNormalization)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PartitionSetSpec) DeepCopyInto(out *PartitionSetSpec) {
 	*out = *in
@@ -162,6 +183,11 @@ func (in *PartitionSetSpec) DeepCopyInto(out *PartitionSetSpec) {
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Normalization != nil {
+		in, out := &in.Normalization, &out.Normalization
+		*out = new(PartitionSetNormalization)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
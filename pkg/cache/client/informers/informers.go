@@ -0,0 +1,49 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package informers provides typed informers for objects replicated to the cache server.
+package informers
+
+import (
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	cacheclient "github.com/kcp-dev/kcp/pkg/cache/client"
+	"github.com/kcp-dev/kcp/pkg/cache/client/shard"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
+)
+
+// NewKcpSharedInformerFactory returns a shared informer factory for the kcp objects replicated to the cache
+// server by the given shard, or by all shards if shardName is shard.Wildcard. The shard is injected into all
+// list and watch calls, such that the informers can be used like the informers of a kcp shard, e.g.
+//
+//	cacheInformers.Apis().V1alpha1().APIExports().Lister().Cluster(clusterName).Get(name)
+//
+// The given config must point to the cache server. It is copied.
+func NewKcpSharedInformerFactory(config *rest.Config, shardName shard.Name, defaultResync time.Duration, options ...kcpinformers.SharedInformerOption) (kcpinformers.SharedInformerFactory, error) {
+	config = rest.CopyConfig(config)
+	config = cacheclient.WithCacheServiceRoundTripper(config)
+	config = cacheclient.WithShardNameFromContextRoundTripper(config)
+	config = cacheclient.WithDefaultShardRoundTripper(config, shardName)
+
+	client, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return kcpinformers.NewSharedInformerFactoryWithOptions(client, defaultResync, options...), nil
+}
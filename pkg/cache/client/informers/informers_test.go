@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/kcp-dev/kcp/pkg/cache/client/shard"
+)
+
+func TestNewKcpSharedInformerFactory(t *testing.T) {
+	var lock sync.Mutex
+	var paths []string
+	stop := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		paths = append(paths, r.URL.Path)
+		lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("watch") == "true" {
+			select {
+			case <-r.Context().Done():
+			case <-stop:
+			}
+			return
+		}
+		w.Write([]byte(`{"kind":"APIExportList","apiVersion":"apis.kcp.io/v1alpha1","metadata":{"resourceVersion":"1"},"items":[` + //nolint:errcheck
+			`{"kind":"APIExport","apiVersion":"apis.kcp.io/v1alpha1","metadata":{"name":"today-cowboys","annotations":{"kcp.io/cluster":"provider"}}}]}`))
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(stop) })
+
+	factory, err := NewKcpSharedInformerFactory(&rest.Config{Host: server.URL}, shard.New("amber"), 0)
+	require.NoError(t, err)
+	lister := factory.Apis().V1alpha1().APIExports().Lister()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+	factory.Start(ctx.Done())
+	for informer, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		require.True(t, synced, "informer %v did not sync", informer)
+	}
+
+	export, err := lister.Cluster(logicalcluster.Name("provider")).Get("today-cowboys")
+	require.NoError(t, err)
+	require.Equal(t, "today-cowboys", export.Name)
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.NotEmpty(t, paths)
+	for _, path := range paths {
+		require.Equal(t, "/services/cache/shards/amber/clusters/*/apis/apis.kcp.io/v1alpha1/apiexports", path)
+	}
+}
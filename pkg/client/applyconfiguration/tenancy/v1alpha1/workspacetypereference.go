@@ -0,0 +1,52 @@
+/*
+Copyright The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+)
+
+// WorkspaceTypeReferenceApplyConfiguration represents an declarative configuration of the WorkspaceTypeReference type for use
+// with apply.
+type WorkspaceTypeReferenceApplyConfiguration struct {
+	Name *v1alpha1.WorkspaceTypeName `json:"name,omitempty"`
+	Path *string                     `json:"path,omitempty"`
+}
+
+// WorkspaceTypeReferenceApplyConfiguration constructs an declarative configuration of the WorkspaceTypeReference type for use with
+// apply.
+func WorkspaceTypeReference() *WorkspaceTypeReferenceApplyConfiguration {
+	return &WorkspaceTypeReferenceApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *WorkspaceTypeReferenceApplyConfiguration) WithName(value v1alpha1.WorkspaceTypeName) *WorkspaceTypeReferenceApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *WorkspaceTypeReferenceApplyConfiguration) WithPath(value string) *WorkspaceTypeReferenceApplyConfiguration {
+	b.Path = &value
+	return b
+}
@@ -0,0 +1,53 @@
+/*
+Copyright The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// WorkspaceTypeSelectorApplyConfiguration represents an declarative configuration of the WorkspaceTypeSelector type for use
+// with apply.
+type WorkspaceTypeSelectorApplyConfiguration struct {
+	None  *bool                                      `json:"none,omitempty"`
+	Types []WorkspaceTypeReferenceApplyConfiguration `json:"types,omitempty"`
+}
+
+// WorkspaceTypeSelectorApplyConfiguration constructs an declarative configuration of the WorkspaceTypeSelector type for use with
+// apply.
+func WorkspaceTypeSelector() *WorkspaceTypeSelectorApplyConfiguration {
+	return &WorkspaceTypeSelectorApplyConfiguration{}
+}
+
+// WithNone sets the None field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the None field is set to the value of the last call.
+func (b *WorkspaceTypeSelectorApplyConfiguration) WithNone(value bool) *WorkspaceTypeSelectorApplyConfiguration {
+	b.None = &value
+	return b
+}
+
+// WithTypes adds the given value to the Types field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Types field.
+func (b *WorkspaceTypeSelectorApplyConfiguration) WithTypes(values ...*WorkspaceTypeReferenceApplyConfiguration) *WorkspaceTypeSelectorApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTypes")
+		}
+		b.Types = append(b.Types, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	kcptesting "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/testing"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1apply "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster/fake"
+)
+
+func TestApplyStatus(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("patch", "apiexports", func(action kcptesting.Action) (bool, runtime.Object, error) {
+		return true, &apisv1alpha1.APIExport{ObjectMeta: metav1.ObjectMeta{Name: action.(kcptesting.PatchAction).GetName()}}, nil
+	})
+
+	clusterPath := logicalcluster.NewPath("root:org")
+	applyConfig := apisv1alpha1apply.APIExport("export").
+		WithStatus(apisv1alpha1apply.APIExportStatus().WithIdentityHash("hash"))
+
+	_, err := client.Cluster(clusterPath).ApisV1alpha1().APIExports().ApplyStatus(context.Background(), applyConfig, metav1.ApplyOptions{FieldManager: "test", Force: true})
+	require.NoError(t, err)
+
+	require.Len(t, client.Actions(), 1)
+	action, ok := client.Actions()[0].(kcptesting.PatchAction)
+	require.True(t, ok, "expected a patch action, got %T", client.Actions()[0])
+	require.Equal(t, clusterPath, action.GetCluster())
+	require.Equal(t, "status", action.GetSubresource())
+	require.Equal(t, "export", action.GetName())
+	require.Equal(t, types.ApplyPatchType, action.GetPatchType())
+
+	var patched apisv1alpha1.APIExport
+	require.NoError(t, json.Unmarshal(action.GetPatch(), &patched))
+	require.Equal(t, "hash", patched.Status.IdentityHash)
+	require.Equal(t, "APIExport", patched.Kind)
+}
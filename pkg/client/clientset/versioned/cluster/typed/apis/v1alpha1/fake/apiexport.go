@@ -23,6 +23,8 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
@@ -35,6 +37,7 @@ import (
 	"k8s.io/client-go/testing"
 
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	applyconfigurationsapisv1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/apis/v1alpha1"
 	apisv1alpha1client "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/apis/v1alpha1"
 )
 
@@ -159,3 +162,41 @@ func (c *aPIExportsClient) Patch(ctx context.Context, name string, pt types.Patc
 	}
 	return obj.(*apisv1alpha1.APIExport), err
 }
+
+func (c *aPIExportsClient) Apply(ctx context.Context, applyConfiguration *applyconfigurationsapisv1alpha1.APIExportApplyConfiguration, opts metav1.ApplyOptions) (*apisv1alpha1.APIExport, error) {
+	if applyConfiguration == nil {
+		return nil, fmt.Errorf("applyConfiguration provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(applyConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	name := applyConfiguration.Name
+	if name == nil {
+		return nil, fmt.Errorf("applyConfiguration.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.Invokes(kcptesting.NewRootPatchSubresourceAction(aPIExportsResource, c.ClusterPath, *name, types.ApplyPatchType, data), &apisv1alpha1.APIExport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*apisv1alpha1.APIExport), err
+}
+
+func (c *aPIExportsClient) ApplyStatus(ctx context.Context, applyConfiguration *applyconfigurationsapisv1alpha1.APIExportApplyConfiguration, opts metav1.ApplyOptions) (*apisv1alpha1.APIExport, error) {
+	if applyConfiguration == nil {
+		return nil, fmt.Errorf("applyConfiguration provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(applyConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	name := applyConfiguration.Name
+	if name == nil {
+		return nil, fmt.Errorf("applyConfiguration.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.Invokes(kcptesting.NewRootPatchSubresourceAction(aPIExportsResource, c.ClusterPath, *name, types.ApplyPatchType, data, "status"), &apisv1alpha1.APIExport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*apisv1alpha1.APIExport), err
+}
@@ -23,6 +23,8 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
@@ -35,6 +37,7 @@ import (
 	"k8s.io/client-go/testing"
 
 	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	applyconfigurationscorev1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/core/v1alpha1"
 	corev1alpha1client "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/core/v1alpha1"
 )
 
@@ -159,3 +162,41 @@ func (c *shardsClient) Patch(ctx context.Context, name string, pt types.PatchTyp
 	}
 	return obj.(*corev1alpha1.Shard), err
 }
+
+func (c *shardsClient) Apply(ctx context.Context, applyConfiguration *applyconfigurationscorev1alpha1.ShardApplyConfiguration, opts metav1.ApplyOptions) (*corev1alpha1.Shard, error) {
+	if applyConfiguration == nil {
+		return nil, fmt.Errorf("applyConfiguration provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(applyConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	name := applyConfiguration.Name
+	if name == nil {
+		return nil, fmt.Errorf("applyConfiguration.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.Invokes(kcptesting.NewRootPatchSubresourceAction(shardsResource, c.ClusterPath, *name, types.ApplyPatchType, data), &corev1alpha1.Shard{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*corev1alpha1.Shard), err
+}
+
+func (c *shardsClient) ApplyStatus(ctx context.Context, applyConfiguration *applyconfigurationscorev1alpha1.ShardApplyConfiguration, opts metav1.ApplyOptions) (*corev1alpha1.Shard, error) {
+	if applyConfiguration == nil {
+		return nil, fmt.Errorf("applyConfiguration provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(applyConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	name := applyConfiguration.Name
+	if name == nil {
+		return nil, fmt.Errorf("applyConfiguration.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.Invokes(kcptesting.NewRootPatchSubresourceAction(shardsResource, c.ClusterPath, *name, types.ApplyPatchType, data, "status"), &corev1alpha1.Shard{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*corev1alpha1.Shard), err
+}
@@ -23,6 +23,8 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
@@ -35,6 +37,7 @@ import (
 	"k8s.io/client-go/testing"
 
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	applyconfigurationstenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/tenancy/v1alpha1"
 	tenancyv1alpha1client "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/tenancy/v1alpha1"
 )
 
@@ -159,3 +162,41 @@ func (c *workspaceTypesClient) Patch(ctx context.Context, name string, pt types.
 	}
 	return obj.(*tenancyv1alpha1.WorkspaceType), err
 }
+
+func (c *workspaceTypesClient) Apply(ctx context.Context, applyConfiguration *applyconfigurationstenancyv1alpha1.WorkspaceTypeApplyConfiguration, opts metav1.ApplyOptions) (*tenancyv1alpha1.WorkspaceType, error) {
+	if applyConfiguration == nil {
+		return nil, fmt.Errorf("applyConfiguration provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(applyConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	name := applyConfiguration.Name
+	if name == nil {
+		return nil, fmt.Errorf("applyConfiguration.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.Invokes(kcptesting.NewRootPatchSubresourceAction(workspaceTypesResource, c.ClusterPath, *name, types.ApplyPatchType, data), &tenancyv1alpha1.WorkspaceType{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*tenancyv1alpha1.WorkspaceType), err
+}
+
+func (c *workspaceTypesClient) ApplyStatus(ctx context.Context, applyConfiguration *applyconfigurationstenancyv1alpha1.WorkspaceTypeApplyConfiguration, opts metav1.ApplyOptions) (*tenancyv1alpha1.WorkspaceType, error) {
+	if applyConfiguration == nil {
+		return nil, fmt.Errorf("applyConfiguration provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(applyConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	name := applyConfiguration.Name
+	if name == nil {
+		return nil, fmt.Errorf("applyConfiguration.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.Invokes(kcptesting.NewRootPatchSubresourceAction(workspaceTypesResource, c.ClusterPath, *name, types.ApplyPatchType, data, "status"), &tenancyv1alpha1.WorkspaceType{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*tenancyv1alpha1.WorkspaceType), err
+}
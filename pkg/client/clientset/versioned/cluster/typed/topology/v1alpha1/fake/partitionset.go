@@ -23,6 +23,8 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
@@ -35,6 +37,7 @@ import (
 	"k8s.io/client-go/testing"
 
 	topologyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1"
+	applyconfigurationstopologyv1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/topology/v1alpha1"
 	topologyv1alpha1client "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/topology/v1alpha1"
 )
 
@@ -159,3 +162,41 @@ func (c *partitionSetsClient) Patch(ctx context.Context, name string, pt types.P
 	}
 	return obj.(*topologyv1alpha1.PartitionSet), err
 }
+
+func (c *partitionSetsClient) Apply(ctx context.Context, applyConfiguration *applyconfigurationstopologyv1alpha1.PartitionSetApplyConfiguration, opts metav1.ApplyOptions) (*topologyv1alpha1.PartitionSet, error) {
+	if applyConfiguration == nil {
+		return nil, fmt.Errorf("applyConfiguration provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(applyConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	name := applyConfiguration.Name
+	if name == nil {
+		return nil, fmt.Errorf("applyConfiguration.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.Invokes(kcptesting.NewRootPatchSubresourceAction(partitionSetsResource, c.ClusterPath, *name, types.ApplyPatchType, data), &topologyv1alpha1.PartitionSet{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*topologyv1alpha1.PartitionSet), err
+}
+
+func (c *partitionSetsClient) ApplyStatus(ctx context.Context, applyConfiguration *applyconfigurationstopologyv1alpha1.PartitionSetApplyConfiguration, opts metav1.ApplyOptions) (*topologyv1alpha1.PartitionSet, error) {
+	if applyConfiguration == nil {
+		return nil, fmt.Errorf("applyConfiguration provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(applyConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	name := applyConfiguration.Name
+	if name == nil {
+		return nil, fmt.Errorf("applyConfiguration.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.Invokes(kcptesting.NewRootPatchSubresourceAction(partitionSetsResource, c.ClusterPath, *name, types.ApplyPatchType, data, "status"), &topologyv1alpha1.PartitionSet{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*topologyv1alpha1.PartitionSet), err
+}
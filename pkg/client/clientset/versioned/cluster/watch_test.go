@@ -0,0 +1,96 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientset_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+)
+
+// TestClusterWatchLabelSelector asserts that the label selector of a wildcard watch is sent to the
+// server instead of being applied client-side, i.e. the client delivers exactly what the server sends.
+func TestClusterWatchLabelSelector(t *testing.T) {
+	exports := []*apisv1alpha1.APIExport{
+		{ObjectMeta: metav1.ObjectMeta{Name: "matching", Labels: map[string]string{"app": "foo"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other", Labels: map[string]string{"app": "bar"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unlabeled"}},
+	}
+
+	requests := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+
+		selector, err := labels.Parse(r.URL.Query().Get("labelSelector"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		enc := json.NewEncoder(w)
+		for _, export := range exports {
+			if !selector.Matches(labels.Set(export.Labels)) {
+				continue
+			}
+			obj := export.DeepCopy()
+			obj.APIVersion = apisv1alpha1.SchemeGroupVersion.String()
+			obj.Kind = "APIExport"
+			raw, err := json.Marshal(obj)
+			if err != nil {
+				t.Errorf("failed to marshal %s: %v", obj.Name, err)
+				return
+			}
+			if err := enc.Encode(metav1.WatchEvent{Type: string(watch.Added), Object: runtime.RawExtension{Raw: raw}}); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := kcpclientset.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	w, err := client.ApisV1alpha1().APIExports().Watch(context.Background(), metav1.ListOptions{LabelSelector: "app=foo"})
+	require.NoError(t, err)
+	defer w.Stop()
+
+	r := <-requests
+	require.Equal(t, "/clusters/*/apis/apis.kcp.io/v1alpha1/apiexports", r.URL.Path)
+	require.Equal(t, "true", r.URL.Query().Get("watch"))
+	require.Equal(t, "app=foo", r.URL.Query().Get("labelSelector"), "expected the label selector to be sent to the server")
+
+	var names []string
+	for e := range w.ResultChan() {
+		require.Equal(t, watch.Added, e.Type)
+		names = append(names, e.Object.(*apisv1alpha1.APIExport).Name)
+	}
+	require.Equal(t, []string{"matching"}, names, "expected only objects matching the label selector")
+}
@@ -20,6 +20,8 @@ package v1alpha1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +30,7 @@ import (
 	rest "k8s.io/client-go/rest"
 
 	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/apis/v1alpha1"
 	scheme "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
 )
 
@@ -48,6 +51,8 @@ type APIBindingInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.APIBindingList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.APIBinding, err error)
+	Apply(ctx context.Context, aPIBinding *apisv1alpha1.APIBindingApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.APIBinding, err error)
+	ApplyStatus(ctx context.Context, aPIBinding *apisv1alpha1.APIBindingApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.APIBinding, err error)
 	APIBindingExpansion
 }
 
@@ -183,3 +188,57 @@ func (c *aPIBindings) Patch(ctx context.Context, name string, pt types.PatchType
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied aPIBinding.
+func (c *aPIBindings) Apply(ctx context.Context, aPIBinding *apisv1alpha1.APIBindingApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.APIBinding, err error) {
+	if aPIBinding == nil {
+		return nil, fmt.Errorf("aPIBinding provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(aPIBinding)
+	if err != nil {
+		return nil, err
+	}
+	name := aPIBinding.Name
+	if name == nil {
+		return nil, fmt.Errorf("aPIBinding.Name must be provided to Apply")
+	}
+	result = &v1alpha1.APIBinding{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("apibindings").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *aPIBindings) ApplyStatus(ctx context.Context, aPIBinding *apisv1alpha1.APIBindingApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.APIBinding, err error) {
+	if aPIBinding == nil {
+		return nil, fmt.Errorf("aPIBinding provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(aPIBinding)
+	if err != nil {
+		return nil, err
+	}
+
+	name := aPIBinding.Name
+	if name == nil {
+		return nil, fmt.Errorf("aPIBinding.Name must be provided to Apply")
+	}
+
+	result = &v1alpha1.APIBinding{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("apibindings").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -20,6 +20,8 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
@@ -29,6 +31,7 @@ import (
 	testing "k8s.io/client-go/testing"
 
 	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/apis/v1alpha1"
 )
 
 // FakeAPIResourceSchemas implements APIResourceSchemaInterface
@@ -121,3 +124,24 @@ func (c *FakeAPIResourceSchemas) Patch(ctx context.Context, name string, pt type
 	}
 	return obj.(*v1alpha1.APIResourceSchema), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied aPIResourceSchema.
+func (c *FakeAPIResourceSchemas) Apply(ctx context.Context, aPIResourceSchema *apisv1alpha1.APIResourceSchemaApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.APIResourceSchema, err error) {
+	if aPIResourceSchema == nil {
+		return nil, fmt.Errorf("aPIResourceSchema provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(aPIResourceSchema)
+	if err != nil {
+		return nil, err
+	}
+	name := aPIResourceSchema.Name
+	if name == nil {
+		return nil, fmt.Errorf("aPIResourceSchema.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(apiresourceschemasResource, *name, types.ApplyPatchType, data), &v1alpha1.APIResourceSchema{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.APIResourceSchema), err
+}
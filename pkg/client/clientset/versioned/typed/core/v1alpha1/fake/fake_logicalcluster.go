@@ -20,6 +20,8 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
@@ -29,6 +31,7 @@ import (
 	testing "k8s.io/client-go/testing"
 
 	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/core/v1alpha1"
 )
 
 // FakeLogicalClusters implements LogicalClusterInterface
@@ -132,3 +135,46 @@ func (c *FakeLogicalClusters) Patch(ctx context.Context, name string, pt types.P
 	}
 	return obj.(*v1alpha1.LogicalCluster), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied logicalCluster.
+func (c *FakeLogicalClusters) Apply(ctx context.Context, logicalCluster *corev1alpha1.LogicalClusterApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.LogicalCluster, err error) {
+	if logicalCluster == nil {
+		return nil, fmt.Errorf("logicalCluster provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(logicalCluster)
+	if err != nil {
+		return nil, err
+	}
+	name := logicalCluster.Name
+	if name == nil {
+		return nil, fmt.Errorf("logicalCluster.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(logicalclustersResource, *name, types.ApplyPatchType, data), &v1alpha1.LogicalCluster{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.LogicalCluster), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeLogicalClusters) ApplyStatus(ctx context.Context, logicalCluster *corev1alpha1.LogicalClusterApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.LogicalCluster, err error) {
+	if logicalCluster == nil {
+		return nil, fmt.Errorf("logicalCluster provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(logicalCluster)
+	if err != nil {
+		return nil, err
+	}
+	name := logicalCluster.Name
+	if name == nil {
+		return nil, fmt.Errorf("logicalCluster.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(logicalclustersResource, *name, types.ApplyPatchType, data, "status"), &v1alpha1.LogicalCluster{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.LogicalCluster), err
+}
@@ -20,6 +20,8 @@ package v1alpha1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +30,7 @@ import (
 	rest "k8s.io/client-go/rest"
 
 	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/core/v1alpha1"
 	scheme "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
 )
 
@@ -48,6 +51,8 @@ type LogicalClusterInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.LogicalClusterList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.LogicalCluster, err error)
+	Apply(ctx context.Context, logicalCluster *corev1alpha1.LogicalClusterApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.LogicalCluster, err error)
+	ApplyStatus(ctx context.Context, logicalCluster *corev1alpha1.LogicalClusterApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.LogicalCluster, err error)
 	LogicalClusterExpansion
 }
 
@@ -183,3 +188,57 @@ func (c *logicalClusters) Patch(ctx context.Context, name string, pt types.Patch
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied logicalCluster.
+func (c *logicalClusters) Apply(ctx context.Context, logicalCluster *corev1alpha1.LogicalClusterApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.LogicalCluster, err error) {
+	if logicalCluster == nil {
+		return nil, fmt.Errorf("logicalCluster provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(logicalCluster)
+	if err != nil {
+		return nil, err
+	}
+	name := logicalCluster.Name
+	if name == nil {
+		return nil, fmt.Errorf("logicalCluster.Name must be provided to Apply")
+	}
+	result = &v1alpha1.LogicalCluster{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("logicalclusters").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *logicalClusters) ApplyStatus(ctx context.Context, logicalCluster *corev1alpha1.LogicalClusterApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.LogicalCluster, err error) {
+	if logicalCluster == nil {
+		return nil, fmt.Errorf("logicalCluster provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(logicalCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	name := logicalCluster.Name
+	if name == nil {
+		return nil, fmt.Errorf("logicalCluster.Name must be provided to Apply")
+	}
+
+	result = &v1alpha1.LogicalCluster{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("logicalclusters").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -20,6 +20,8 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
@@ -29,6 +31,7 @@ import (
 	testing "k8s.io/client-go/testing"
 
 	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1"
+	schedulingv1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/scheduling/v1alpha1"
 )
 
 // FakePlacements implements PlacementInterface
@@ -132,3 +135,46 @@ func (c *FakePlacements) Patch(ctx context.Context, name string, pt types.PatchT
 	}
 	return obj.(*v1alpha1.Placement), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied placement.
+func (c *FakePlacements) Apply(ctx context.Context, placement *schedulingv1alpha1.PlacementApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Placement, err error) {
+	if placement == nil {
+		return nil, fmt.Errorf("placement provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(placement)
+	if err != nil {
+		return nil, err
+	}
+	name := placement.Name
+	if name == nil {
+		return nil, fmt.Errorf("placement.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(placementsResource, *name, types.ApplyPatchType, data), &v1alpha1.Placement{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Placement), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakePlacements) ApplyStatus(ctx context.Context, placement *schedulingv1alpha1.PlacementApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Placement, err error) {
+	if placement == nil {
+		return nil, fmt.Errorf("placement provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(placement)
+	if err != nil {
+		return nil, err
+	}
+	name := placement.Name
+	if name == nil {
+		return nil, fmt.Errorf("placement.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(placementsResource, *name, types.ApplyPatchType, data, "status"), &v1alpha1.Placement{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Placement), err
+}
@@ -20,6 +20,8 @@ package v1alpha1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +30,7 @@ import (
 	rest "k8s.io/client-go/rest"
 
 	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1"
+	schedulingv1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/scheduling/v1alpha1"
 	scheme "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
 )
 
@@ -48,6 +51,8 @@ type PlacementInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.PlacementList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Placement, err error)
+	Apply(ctx context.Context, placement *schedulingv1alpha1.PlacementApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Placement, err error)
+	ApplyStatus(ctx context.Context, placement *schedulingv1alpha1.PlacementApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Placement, err error)
 	PlacementExpansion
 }
 
@@ -183,3 +188,57 @@ func (c *placements) Patch(ctx context.Context, name string, pt types.PatchType,
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied placement.
+func (c *placements) Apply(ctx context.Context, placement *schedulingv1alpha1.PlacementApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Placement, err error) {
+	if placement == nil {
+		return nil, fmt.Errorf("placement provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(placement)
+	if err != nil {
+		return nil, err
+	}
+	name := placement.Name
+	if name == nil {
+		return nil, fmt.Errorf("placement.Name must be provided to Apply")
+	}
+	result = &v1alpha1.Placement{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("placements").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *placements) ApplyStatus(ctx context.Context, placement *schedulingv1alpha1.PlacementApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Placement, err error) {
+	if placement == nil {
+		return nil, fmt.Errorf("placement provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(placement)
+	if err != nil {
+		return nil, err
+	}
+
+	name := placement.Name
+	if name == nil {
+		return nil, fmt.Errorf("placement.Name must be provided to Apply")
+	}
+
+	result = &v1alpha1.Placement{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("placements").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
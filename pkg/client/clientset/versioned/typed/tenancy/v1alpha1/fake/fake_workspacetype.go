@@ -20,6 +20,8 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
@@ -29,6 +31,7 @@ import (
 	testing "k8s.io/client-go/testing"
 
 	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/tenancy/v1alpha1"
 )
 
 // FakeWorkspaceTypes implements WorkspaceTypeInterface
@@ -132,3 +135,46 @@ func (c *FakeWorkspaceTypes) Patch(ctx context.Context, name string, pt types.Pa
 	}
 	return obj.(*v1alpha1.WorkspaceType), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied workspaceType.
+func (c *FakeWorkspaceTypes) Apply(ctx context.Context, workspaceType *tenancyv1alpha1.WorkspaceTypeApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.WorkspaceType, err error) {
+	if workspaceType == nil {
+		return nil, fmt.Errorf("workspaceType provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(workspaceType)
+	if err != nil {
+		return nil, err
+	}
+	name := workspaceType.Name
+	if name == nil {
+		return nil, fmt.Errorf("workspaceType.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(workspacetypesResource, *name, types.ApplyPatchType, data), &v1alpha1.WorkspaceType{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WorkspaceType), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeWorkspaceTypes) ApplyStatus(ctx context.Context, workspaceType *tenancyv1alpha1.WorkspaceTypeApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.WorkspaceType, err error) {
+	if workspaceType == nil {
+		return nil, fmt.Errorf("workspaceType provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(workspaceType)
+	if err != nil {
+		return nil, err
+	}
+	name := workspaceType.Name
+	if name == nil {
+		return nil, fmt.Errorf("workspaceType.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(workspacetypesResource, *name, types.ApplyPatchType, data, "status"), &v1alpha1.WorkspaceType{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WorkspaceType), err
+}
@@ -20,6 +20,8 @@ package v1alpha1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +30,7 @@ import (
 	rest "k8s.io/client-go/rest"
 
 	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/tenancy/v1alpha1"
 	scheme "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
 )
 
@@ -48,6 +51,8 @@ type WorkspaceInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.WorkspaceList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Workspace, err error)
+	Apply(ctx context.Context, workspace *tenancyv1alpha1.WorkspaceApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Workspace, err error)
+	ApplyStatus(ctx context.Context, workspace *tenancyv1alpha1.WorkspaceApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Workspace, err error)
 	WorkspaceExpansion
 }
 
@@ -183,3 +188,57 @@ func (c *workspaces) Patch(ctx context.Context, name string, pt types.PatchType,
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied workspace.
+func (c *workspaces) Apply(ctx context.Context, workspace *tenancyv1alpha1.WorkspaceApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Workspace, err error) {
+	if workspace == nil {
+		return nil, fmt.Errorf("workspace provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(workspace)
+	if err != nil {
+		return nil, err
+	}
+	name := workspace.Name
+	if name == nil {
+		return nil, fmt.Errorf("workspace.Name must be provided to Apply")
+	}
+	result = &v1alpha1.Workspace{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("workspaces").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *workspaces) ApplyStatus(ctx context.Context, workspace *tenancyv1alpha1.WorkspaceApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Workspace, err error) {
+	if workspace == nil {
+		return nil, fmt.Errorf("workspace provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	name := workspace.Name
+	if name == nil {
+		return nil, fmt.Errorf("workspace.Name must be provided to Apply")
+	}
+
+	result = &v1alpha1.Workspace{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("workspaces").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -20,6 +20,8 @@ package v1alpha1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +30,7 @@ import (
 	rest "k8s.io/client-go/rest"
 
 	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/tenancy/v1alpha1"
 	scheme "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
 )
 
@@ -48,6 +51,8 @@ type WorkspaceTypeInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.WorkspaceTypeList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.WorkspaceType, err error)
+	Apply(ctx context.Context, workspaceType *tenancyv1alpha1.WorkspaceTypeApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.WorkspaceType, err error)
+	ApplyStatus(ctx context.Context, workspaceType *tenancyv1alpha1.WorkspaceTypeApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.WorkspaceType, err error)
 	WorkspaceTypeExpansion
 }
 
@@ -183,3 +188,57 @@ func (c *workspaceTypes) Patch(ctx context.Context, name string, pt types.PatchT
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied workspaceType.
+func (c *workspaceTypes) Apply(ctx context.Context, workspaceType *tenancyv1alpha1.WorkspaceTypeApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.WorkspaceType, err error) {
+	if workspaceType == nil {
+		return nil, fmt.Errorf("workspaceType provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(workspaceType)
+	if err != nil {
+		return nil, err
+	}
+	name := workspaceType.Name
+	if name == nil {
+		return nil, fmt.Errorf("workspaceType.Name must be provided to Apply")
+	}
+	result = &v1alpha1.WorkspaceType{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("workspacetypes").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *workspaceTypes) ApplyStatus(ctx context.Context, workspaceType *tenancyv1alpha1.WorkspaceTypeApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.WorkspaceType, err error) {
+	if workspaceType == nil {
+		return nil, fmt.Errorf("workspaceType provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(workspaceType)
+	if err != nil {
+		return nil, err
+	}
+
+	name := workspaceType.Name
+	if name == nil {
+		return nil, fmt.Errorf("workspaceType.Name must be provided to Apply")
+	}
+
+	result = &v1alpha1.WorkspaceType{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("workspacetypes").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -20,6 +20,8 @@ package v1alpha1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +30,7 @@ import (
 	rest "k8s.io/client-go/rest"
 
 	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1"
+	topologyv1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/topology/v1alpha1"
 	scheme "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
 )
 
@@ -48,6 +51,8 @@ type PartitionSetInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.PartitionSetList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.PartitionSet, err error)
+	Apply(ctx context.Context, partitionSet *topologyv1alpha1.PartitionSetApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.PartitionSet, err error)
+	ApplyStatus(ctx context.Context, partitionSet *topologyv1alpha1.PartitionSetApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.PartitionSet, err error)
 	PartitionSetExpansion
 }
 
@@ -183,3 +188,57 @@ func (c *partitionSets) Patch(ctx context.Context, name string, pt types.PatchTy
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied partitionSet.
+func (c *partitionSets) Apply(ctx context.Context, partitionSet *topologyv1alpha1.PartitionSetApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.PartitionSet, err error) {
+	if partitionSet == nil {
+		return nil, fmt.Errorf("partitionSet provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(partitionSet)
+	if err != nil {
+		return nil, err
+	}
+	name := partitionSet.Name
+	if name == nil {
+		return nil, fmt.Errorf("partitionSet.Name must be provided to Apply")
+	}
+	result = &v1alpha1.PartitionSet{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("partitionsets").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *partitionSets) ApplyStatus(ctx context.Context, partitionSet *topologyv1alpha1.PartitionSetApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.PartitionSet, err error) {
+	if partitionSet == nil {
+		return nil, fmt.Errorf("partitionSet provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(partitionSet)
+	if err != nil {
+		return nil, err
+	}
+
+	name := partitionSet.Name
+	if name == nil {
+		return nil, fmt.Errorf("partitionSet.Name must be provided to Apply")
+	}
+
+	result = &v1alpha1.PartitionSet{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("partitionsets").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -20,6 +20,8 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
@@ -29,6 +31,7 @@ import (
 	testing "k8s.io/client-go/testing"
 
 	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
+	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/workload/v1alpha1"
 )
 
 // FakeSyncTargets implements SyncTargetInterface
@@ -132,3 +135,46 @@ func (c *FakeSyncTargets) Patch(ctx context.Context, name string, pt types.Patch
 	}
 	return obj.(*v1alpha1.SyncTarget), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied syncTarget.
+func (c *FakeSyncTargets) Apply(ctx context.Context, syncTarget *workloadv1alpha1.SyncTargetApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.SyncTarget, err error) {
+	if syncTarget == nil {
+		return nil, fmt.Errorf("syncTarget provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(syncTarget)
+	if err != nil {
+		return nil, err
+	}
+	name := syncTarget.Name
+	if name == nil {
+		return nil, fmt.Errorf("syncTarget.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(synctargetsResource, *name, types.ApplyPatchType, data), &v1alpha1.SyncTarget{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SyncTarget), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeSyncTargets) ApplyStatus(ctx context.Context, syncTarget *workloadv1alpha1.SyncTargetApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.SyncTarget, err error) {
+	if syncTarget == nil {
+		return nil, fmt.Errorf("syncTarget provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(syncTarget)
+	if err != nil {
+		return nil, err
+	}
+	name := syncTarget.Name
+	if name == nil {
+		return nil, fmt.Errorf("syncTarget.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(synctargetsResource, *name, types.ApplyPatchType, data, "status"), &v1alpha1.SyncTarget{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SyncTarget), err
+}
@@ -20,6 +20,8 @@ package v1alpha1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +30,7 @@ import (
 	rest "k8s.io/client-go/rest"
 
 	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
+	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/client/applyconfiguration/workload/v1alpha1"
 	scheme "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
 )
 
@@ -48,6 +51,8 @@ type SyncTargetInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.SyncTargetList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.SyncTarget, err error)
+	Apply(ctx context.Context, syncTarget *workloadv1alpha1.SyncTargetApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.SyncTarget, err error)
+	ApplyStatus(ctx context.Context, syncTarget *workloadv1alpha1.SyncTargetApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.SyncTarget, err error)
 	SyncTargetExpansion
 }
 
@@ -183,3 +188,57 @@ func (c *syncTargets) Patch(ctx context.Context, name string, pt types.PatchType
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied syncTarget.
+func (c *syncTargets) Apply(ctx context.Context, syncTarget *workloadv1alpha1.SyncTargetApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.SyncTarget, err error) {
+	if syncTarget == nil {
+		return nil, fmt.Errorf("syncTarget provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(syncTarget)
+	if err != nil {
+		return nil, err
+	}
+	name := syncTarget.Name
+	if name == nil {
+		return nil, fmt.Errorf("syncTarget.Name must be provided to Apply")
+	}
+	result = &v1alpha1.SyncTarget{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("synctargets").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *syncTargets) ApplyStatus(ctx context.Context, syncTarget *workloadv1alpha1.SyncTargetApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.SyncTarget, err error) {
+	if syncTarget == nil {
+		return nil, fmt.Errorf("syncTarget provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(syncTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	name := syncTarget.Name
+	if name == nil {
+		return nil, fmt.Errorf("syncTarget.Name must be provided to Apply")
+	}
+
+	result = &v1alpha1.SyncTarget{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("synctargets").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -17,12 +17,62 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Code generated by kcp code-generator. DO NOT EDIT.
-
 package v1alpha1
 
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+)
+
 // WorkspaceTypeClusterListerExpansion allows custom methods to be added to WorkspaceTypeClusterLister.
-type WorkspaceTypeClusterListerExpansion interface{}
+type WorkspaceTypeClusterListerExpansion interface {
+	// ListPaged lists up to limit WorkspaceTypes across all workspaces, ordered by their cluster-aware key,
+	// starting after the given continue token. A limit of zero or less means no limit. The returned continue
+	// token is empty if there are no more WorkspaceTypes to list.
+	// Objects returned here must be treated as read-only.
+	ListPaged(selector labels.Selector, continueToken string, limit int64) (ret []*tenancyv1alpha1.WorkspaceType, nextContinueToken string, err error)
+}
+
+// ListPaged lists up to limit WorkspaceTypes in the indexer across all workspaces, starting after the
+// given continue token, which is the cluster-aware key of the last WorkspaceType of the previous page.
+func (s *workspaceTypeClusterLister) ListPaged(selector labels.Selector, continueToken string, limit int64) ([]*tenancyv1alpha1.WorkspaceType, string, error) {
+	keys := s.indexer.ListKeys()
+	sort.Strings(keys)
+	start := sort.SearchStrings(keys, continueToken)
+	if start < len(keys) && keys[start] == continueToken {
+		start++
+	}
+
+	var ret []*tenancyv1alpha1.WorkspaceType
+	var lastKey string
+	for _, key := range keys[start:] {
+		obj, exists, err := s.indexer.GetByKey(key)
+		if err != nil {
+			return nil, "", err
+		}
+		if !exists {
+			continue
+		}
+		metadata, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, "", err
+		}
+		if !selector.Matches(labels.Set(metadata.GetLabels())) {
+			continue
+		}
+		if limit > 0 && int64(len(ret)) == limit {
+			// there is at least one more matching WorkspaceType
+			return ret, lastKey, nil
+		}
+		ret = append(ret, obj.(*tenancyv1alpha1.WorkspaceType))
+		lastKey = key
+	}
+	return ret, "", nil
+}
 
 // WorkspaceTypeListerExpansion allows custom methods to be added to WorkspaceTypeLister.
 type WorkspaceTypeListerExpansion interface{}
@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+)
+
+func TestWorkspaceTypeClusterListerListPaged(t *testing.T) {
+	indexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{kcpcache.ClusterIndexName: kcpcache.ClusterIndexFunc})
+	for _, wt := range []struct {
+		cluster, name string
+		labels        map[string]string
+	}{
+		{"root", "universal", nil},
+		{"root", "organization", map[string]string{"team": "true"}},
+		{"root", "team", map[string]string{"team": "true"}},
+		{"root:org", "team", map[string]string{"team": "true"}},
+		{"root:org", "universal", nil},
+	} {
+		require.NoError(t, indexer.Add(&tenancyv1alpha1.WorkspaceType{ObjectMeta: metav1.ObjectMeta{
+			Name:        wt.name,
+			Labels:      wt.labels,
+			Annotations: map[string]string{logicalcluster.AnnotationKey: wt.cluster},
+		}}))
+	}
+	lister := NewWorkspaceTypeClusterLister(indexer)
+
+	listAll := func(selector labels.Selector, limit int64) (pages [][]string) {
+		var token string
+		for {
+			types, next, err := lister.ListPaged(selector, token, limit)
+			require.NoError(t, err)
+			var page []string
+			for _, wt := range types {
+				page = append(page, logicalcluster.From(wt).String()+"|"+wt.Name)
+			}
+			pages = append(pages, page)
+			if next == "" {
+				return pages
+			}
+			require.Len(t, types, int(limit))
+			token = next
+		}
+	}
+
+	t.Log("Without limit, everything is listed in one page")
+	require.Equal(t, [][]string{{"root:org|team", "root:org|universal", "root|organization", "root|team", "root|universal"}}, listAll(labels.Everything(), 0))
+
+	t.Log("With limit, pages are ordered by cluster-aware key")
+	require.Equal(t, [][]string{{"root:org|team", "root:org|universal"}, {"root|organization", "root|team"}, {"root|universal"}}, listAll(labels.Everything(), 2))
+
+	t.Log("No empty trailing page is returned")
+	require.Equal(t, [][]string{{"root:org|team", "root:org|universal", "root|organization"}, {"root|team", "root|universal"}}, listAll(labels.Everything(), 3))
+
+	t.Log("The selector is applied before paging")
+	require.Equal(t, [][]string{{"root:org|team", "root|organization"}, {"root|team"}}, listAll(labels.SelectorFromSet(labels.Set{"team": "true"}), 2))
+	require.Equal(t, [][]string{{"root:org|team", "root|organization", "root|team"}}, listAll(labels.SelectorFromSet(labels.Set{"team": "true"}), 3))
+
+	t.Log("The existing List is unchanged")
+	types, err := lister.List(labels.Everything())
+	require.NoError(t, err)
+	require.Len(t, types, 5)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientutils
+
+import (
+	"context"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+)
+
+// APIBindingLister lists APIBindings, e.g. the typed APIBinding client of a virtual workspace.
+type APIBindingLister interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*apisv1alpha1.APIBindingList, error)
+}
+
+// ListAPIExportConsumers returns the sorted logical clusters with a bound APIBinding to an APIExport.
+// The given client must list the APIBindings of all logical clusters in the APIExport virtual workspace
+// of the APIExport. The virtual workspace only serves the APIBindings of its APIExport, such that a
+// wildcard list of them yields the consumers of the APIExport.
+func ListAPIExportConsumers(ctx context.Context, vwClient APIBindingLister) ([]logicalcluster.Name, error) {
+	bindings, err := vwClient.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	consumers := sets.NewString()
+	for i := range bindings.Items {
+		binding := &bindings.Items[i]
+		if binding.Status.Phase != apisv1alpha1.APIBindingPhaseBound {
+			continue
+		}
+		consumers.Insert(logicalcluster.From(binding).String())
+	}
+
+	names := make([]logicalcluster.Name, 0, consumers.Len())
+	for _, name := range consumers.List() {
+		names = append(names, logicalcluster.Name(name))
+	}
+	return names, nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientutils_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/clientutils"
+)
+
+// fakeAPIBindingLister lists the APIBindings it holds, like the APIExport virtual workspace
+// lists those of its APIExport.
+type fakeAPIBindingLister []apisv1alpha1.APIBinding
+
+func (l fakeAPIBindingLister) List(_ context.Context, _ metav1.ListOptions) (*apisv1alpha1.APIBindingList, error) {
+	return &apisv1alpha1.APIBindingList{Items: l}, nil
+}
+
+func TestListAPIExportConsumers(t *testing.T) {
+	binding := func(cluster, name string, phase apisv1alpha1.APIBindingPhaseType) apisv1alpha1.APIBinding {
+		return apisv1alpha1.APIBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{logicalcluster.AnnotationKey: cluster},
+			},
+			Status: apisv1alpha1.APIBindingStatus{Phase: phase},
+		}
+	}
+
+	consumers, err := clientutils.ListAPIExportConsumers(context.Background(), fakeAPIBindingLister{
+		binding("consumer-b", "cowboys", apisv1alpha1.APIBindingPhaseBound),
+		binding("consumer-a", "cowboys", apisv1alpha1.APIBindingPhaseBound),
+		binding("consumer-a", "more-cowboys", apisv1alpha1.APIBindingPhaseBound),
+		binding("consumer-c", "cowboys", apisv1alpha1.APIBindingPhaseBinding),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []logicalcluster.Name{"consumer-a", "consumer-b"}, consumers, "expected bound consumers only, deduplicated and sorted")
+}
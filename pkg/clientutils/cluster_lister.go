@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientutils
+
+import (
+	"sort"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ScopedLister is implemented by every generated lister scoped to one workspace.
+type ScopedLister[T any] interface {
+	List(selector labels.Selector) ([]T, error)
+	Get(name string) (T, error)
+}
+
+// ClusterLister wraps a lister scoped to one workspace into a cluster-aware lister for that
+// workspace, i.e. it is the inverse of the Cluster method of the generated cluster listers. This
+// is useful to pass a scoped lister to helpers taking a cluster lister.
+//
+// With L being a generated lister interface, e.g. WorkspaceTypeLister, a *ClusterLister
+// implements the corresponding cluster lister interface, e.g. WorkspaceTypeClusterLister.
+// L must not have expansion methods, as other workspaces are served by an empty lister.
+type ClusterLister[T any, L ScopedLister[T]] struct {
+	clusterName logicalcluster.Name
+	resource    schema.GroupResource
+	lister      L
+}
+
+// NewClusterLister returns a cluster-aware lister serving the objects of the given scoped lister
+// in the given workspace, and no objects in any other workspace. It implements the generated
+// New<Type>ClusterListerFor constructors of the listers.
+func NewClusterLister[T any, L ScopedLister[T]](clusterName logicalcluster.Name, resource schema.GroupResource, lister L) *ClusterLister[T, L] {
+	return &ClusterLister[T, L]{
+		clusterName: clusterName,
+		resource:    resource,
+		lister:      lister,
+	}
+}
+
+// List lists all objects of the wrapped workspace.
+func (l *ClusterLister[T, L]) List(selector labels.Selector) ([]T, error) {
+	return l.lister.List(selector)
+}
+
+// ListPaged lists up to limit objects of the wrapped workspace, ordered by their cluster-aware key,
+// starting after the given continue token, like the ListPaged expansion of the generated cluster listers.
+func (l *ClusterLister[T, L]) ListPaged(selector labels.Selector, continueToken string, limit int64) ([]T, string, error) {
+	objs, err := l.lister.List(selector)
+	if err != nil {
+		return nil, "", err
+	}
+
+	keys := make(map[string]T, len(objs))
+	sortedKeys := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		metadata, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, "", err
+		}
+		key := kcpcache.ToClusterAwareKey(l.clusterName.String(), metadata.GetNamespace(), metadata.GetName())
+		if key <= continueToken {
+			continue
+		}
+		keys[key] = obj
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	if limit <= 0 || int64(len(sortedKeys)) <= limit {
+		ret := make([]T, 0, len(sortedKeys))
+		for _, key := range sortedKeys {
+			ret = append(ret, keys[key])
+		}
+		return ret, "", nil
+	}
+
+	ret := make([]T, 0, limit)
+	for _, key := range sortedKeys[:limit] {
+		ret = append(ret, keys[key])
+	}
+	return ret, sortedKeys[limit-1], nil
+}
+
+// Cluster returns the wrapped lister for its workspace, and an empty lister for any other workspace.
+func (l *ClusterLister[T, L]) Cluster(clusterName logicalcluster.Name) L {
+	if clusterName == l.clusterName {
+		return l.lister
+	}
+	return any(&emptyLister[T]{resource: l.resource}).(L)
+}
+
+// emptyLister is a scoped lister without any objects.
+type emptyLister[T any] struct {
+	resource schema.GroupResource
+}
+
+func (l *emptyLister[T]) List(selector labels.Selector) ([]T, error) {
+	return nil, nil
+}
+
+func (l *emptyLister[T]) Get(name string) (T, error) {
+	var zero T
+	return zero, errors.NewNotFound(l.resource, name)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientutils_test
+
+import (
+	"testing"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	tenancyv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/tenancy/v1alpha1"
+)
+
+func TestClusterLister(t *testing.T) {
+	indexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{kcpcache.ClusterIndexName: kcpcache.ClusterIndexFunc})
+	for _, wt := range []*tenancyv1alpha1.WorkspaceType{
+		{ObjectMeta: metav1.ObjectMeta{Name: "universal", Annotations: map[string]string{logicalcluster.AnnotationKey: "root"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "organization", Annotations: map[string]string{logicalcluster.AnnotationKey: "root"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team", Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org"}}},
+	} {
+		require.NoError(t, indexer.Add(wt))
+	}
+
+	scoped := tenancyv1alpha1listers.NewWorkspaceTypeClusterLister(indexer).Cluster("root")
+
+	clusterLister := tenancyv1alpha1listers.NewWorkspaceTypeClusterListerFor("root", scoped)
+
+	t.Log("Listing across workspaces only returns the objects of the wrapped workspace")
+	types, err := clusterLister.List(labels.Everything())
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"universal", "organization"}, names(types))
+
+	t.Log("Paging returns the objects of the wrapped workspace ordered by cluster-aware key")
+	types, next, err := clusterLister.ListPaged(labels.Everything(), "", 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"organization"}, names(types))
+	types, next, err = clusterLister.ListPaged(labels.Everything(), next, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"universal"}, names(types))
+	require.Empty(t, next)
+
+	t.Log("Round-tripping through Cluster returns the wrapped lister")
+	require.Equal(t, scoped, clusterLister.Cluster("root"))
+	wt, err := clusterLister.Cluster("root").Get("universal")
+	require.NoError(t, err)
+	require.Equal(t, "universal", wt.Name)
+
+	t.Log("Other workspaces are empty")
+	types, err = clusterLister.Cluster("root:org").List(labels.Everything())
+	require.NoError(t, err)
+	require.Empty(t, types)
+	_, err = clusterLister.Cluster("root:org").Get("team")
+	require.True(t, errors.IsNotFound(err), "expected NotFound, got %v", err)
+}
+
+func names(types []*tenancyv1alpha1.WorkspaceType) []string {
+	var ret []string
+	for _, wt := range types {
+		ret = append(ret, wt.Name)
+	}
+	return ret
+}
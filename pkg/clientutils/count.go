@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientutils
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ListClient is implemented by every generated typed client, cluster-aware or not.
+type ListClient[L any] interface {
+	List(ctx context.Context, opts metav1.ListOptions) (L, error)
+}
+
+// CountChunkSize is the page size used by Count.
+const CountChunkSize = 500
+
+// Count returns the number of objects of the given client matching opts. It lists in chunks of
+// CountChunkSize and stops as soon as the server reports the number of remaining items, such that
+// at most one chunk is held in memory. Any limit or continue token in opts is ignored. The
+// resourceVersion in opts only applies to the first chunk, the following chunks are served from
+// the snapshot of their continue token. It implements the generated Count methods of the typed
+// clients, and counts across workspaces with a cluster client.
+func Count[L runtime.Object](ctx context.Context, client ListClient[L], opts metav1.ListOptions) (int64, error) {
+	opts.Limit = CountChunkSize
+	opts.Continue = ""
+
+	var count int64
+	for {
+		list, err := client.List(ctx, opts)
+		if err != nil {
+			return 0, err
+		}
+		listMeta, err := meta.ListAccessor(list)
+		if err != nil {
+			return 0, fmt.Errorf("failed to access list metadata of %T: %w", list, err)
+		}
+
+		count += int64(meta.LenList(list))
+		if remaining := listMeta.GetRemainingItemCount(); remaining != nil {
+			return count + *remaining, nil
+		}
+		if listMeta.GetContinue() == "" {
+			return count, nil
+		}
+		opts.Continue = listMeta.GetContinue()
+		// a continue token must not be combined with a resourceVersion
+		opts.ResourceVersion = ""
+		opts.ResourceVersionMatch = ""
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientutils_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/pointer"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	kcpfakeclient "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster/fake"
+	"github.com/kcp-dev/kcp/pkg/clientutils"
+)
+
+func TestCount(t *testing.T) {
+	tests := map[string]struct {
+		pages     []metav1.ListMeta
+		itemCount int
+		want      int64
+		wantPages int
+	}{
+		"single page": {
+			pages:     []metav1.ListMeta{{}},
+			itemCount: 3,
+			want:      3,
+			wantPages: 1,
+		},
+		"remaining item count": {
+			pages:     []metav1.ListMeta{{Continue: "next", RemainingItemCount: pointer.Int64(1000)}},
+			itemCount: clientutils.CountChunkSize,
+			want:      clientutils.CountChunkSize + 1000,
+			wantPages: 1,
+		},
+		"continue without remaining item count": {
+			pages:     []metav1.ListMeta{{Continue: "1"}, {Continue: "2"}, {}},
+			itemCount: 2,
+			want:      6,
+			wantPages: 3,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var queries []url.Values
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				queries = append(queries, r.URL.Query())
+				page := tc.pages[len(queries)-1]
+				page.ResourceVersion = "42"
+
+				list := &apisv1alpha1.APIExportList{
+					TypeMeta: metav1.TypeMeta{APIVersion: apisv1alpha1.SchemeGroupVersion.String(), Kind: "APIExportList"},
+					ListMeta: page,
+				}
+				for i := 0; i < tc.itemCount; i++ {
+					list.Items = append(list.Items, apisv1alpha1.APIExport{ObjectMeta: metav1.ObjectMeta{Name: "export-" + strconv.Itoa(i)}})
+				}
+				w.Header().Set("Content-Type", "application/json")
+				if err := json.NewEncoder(w).Encode(list); err != nil {
+					t.Errorf("failed to encode list: %v", err)
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			clusterClient, err := kcpclientset.NewForConfig(&rest.Config{Host: server.URL})
+			require.NoError(t, err)
+
+			count, err := clusterClient.Cluster(logicalcluster.NewPath("root:org")).ApisV1alpha1().APIExports().Count(context.Background(), metav1.ListOptions{LabelSelector: "app=foo", Limit: 1, Continue: "stale", ResourceVersion: "0", ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan})
+			require.NoError(t, err)
+			require.Equal(t, tc.want, count)
+
+			require.Len(t, queries, tc.wantPages)
+			for i, query := range queries {
+				require.Equal(t, "app=foo", query.Get("labelSelector"))
+				require.Equal(t, strconv.Itoa(clientutils.CountChunkSize), query.Get("limit"))
+				if i == 0 {
+					require.Empty(t, query.Get("continue"))
+					require.Equal(t, "0", query.Get("resourceVersion"))
+					require.Equal(t, string(metav1.ResourceVersionMatchNotOlderThan), query.Get("resourceVersionMatch"))
+				} else {
+					require.Equal(t, tc.pages[i-1].Continue, query.Get("continue"))
+					require.Empty(t, query.Get("resourceVersion"))
+					require.Empty(t, query.Get("resourceVersionMatch"))
+				}
+			}
+		})
+	}
+}
+
+func TestCountFake(t *testing.T) {
+	newExport := func(cluster, name string, labels map[string]string) runtime.Object {
+		return &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      labels,
+				Annotations: map[string]string{logicalcluster.AnnotationKey: cluster},
+			},
+		}
+	}
+	fakeClient := kcpfakeclient.NewSimpleClientset(
+		newExport("root:org", "foo", map[string]string{"app": "foo"}),
+		newExport("root:org", "bar", map[string]string{"app": "bar"}),
+		newExport("root:org", "unlabeled", nil),
+		newExport("root:other", "foo", map[string]string{"app": "foo"}),
+	)
+
+	count, err := fakeClient.Cluster(logicalcluster.NewPath("root:org")).ApisV1alpha1().APIExports().Count(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	count, err = fakeClient.Cluster(logicalcluster.NewPath("root:org")).ApisV1alpha1().APIExports().Count(context.Background(), metav1.ListOptions{LabelSelector: "app=foo"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	t.Log("Counting across workspaces")
+	count, err = clientutils.Count[*apisv1alpha1.APIExportList](context.Background(), fakeClient.ApisV1alpha1().APIExports(), metav1.ListOptions{LabelSelector: "app=foo"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientutils
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListFromOptions returns a copy of opts that lists from a state not older than the given
+// resourceVersion. This allows a controller resuming from a known resourceVersion to avoid a
+// quorum read of the whole collection. An empty resourceVersion results in a consistent read.
+// It implements the generated ListFrom methods of the typed clients.
+func ListFromOptions(opts metav1.ListOptions, resourceVersion string) metav1.ListOptions {
+	opts.ResourceVersion = resourceVersion
+	opts.ResourceVersionMatch = ""
+	if resourceVersion != "" {
+		opts.ResourceVersionMatch = metav1.ResourceVersionMatchNotOlderThan
+	}
+	return opts
+}
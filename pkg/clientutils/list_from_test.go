@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientutils_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	kcpfakeclient "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster/fake"
+	"github.com/kcp-dev/kcp/pkg/clientutils"
+)
+
+func TestListFromOptions(t *testing.T) {
+	opts := clientutils.ListFromOptions(metav1.ListOptions{LabelSelector: "app=foo"}, "42")
+	require.Equal(t, metav1.ListOptions{
+		LabelSelector:        "app=foo",
+		ResourceVersion:      "42",
+		ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan,
+	}, opts)
+
+	t.Log("Without a resourceVersion a consistent read is done")
+	opts = clientutils.ListFromOptions(metav1.ListOptions{ResourceVersion: "0", ResourceVersionMatch: metav1.ResourceVersionMatchExact}, "")
+	require.Equal(t, metav1.ListOptions{}, opts)
+}
+
+func TestListFrom(t *testing.T) {
+	queries := make(chan url.Values, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries <- r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(&apisv1alpha1.APIExportList{
+			TypeMeta: metav1.TypeMeta{APIVersion: apisv1alpha1.SchemeGroupVersion.String(), Kind: "APIExportList"},
+			ListMeta: metav1.ListMeta{ResourceVersion: "43"},
+		}); err != nil {
+			t.Errorf("failed to encode list: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	clusterClient, err := kcpclientset.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	list, err := clusterClient.Cluster(logicalcluster.NewPath("root:org")).ApisV1alpha1().APIExports().ListFrom(context.Background(), metav1.ListOptions{LabelSelector: "app=foo"}, "42")
+	require.NoError(t, err)
+	require.Equal(t, "43", list.ResourceVersion)
+
+	query := <-queries
+	require.Equal(t, "42", query.Get("resourceVersion"))
+	require.Equal(t, string(metav1.ResourceVersionMatchNotOlderThan), query.Get("resourceVersionMatch"))
+	require.Equal(t, "app=foo", query.Get("labelSelector"))
+}
+
+// The fake clientset does not record the resourceVersion of list actions, hence this only
+// asserts that the fake works with ListFrom.
+func TestListFromFake(t *testing.T) {
+	fakeClient := kcpfakeclient.NewSimpleClientset(&apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "export",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org"},
+		},
+	})
+
+	list, err := fakeClient.Cluster(logicalcluster.NewPath("root:org")).ApisV1alpha1().APIExports().ListFrom(context.Background(), metav1.ListOptions{}, "42")
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	require.Equal(t, "export", list.Items[0].Name)
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientutils
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// WatchObjectOptions returns a copy of opts that selects the object with the given name only.
+// Any field selector in opts is replaced. It implements the generated WatchObject methods of the
+// typed clients.
+func WatchObjectOptions(opts metav1.ListOptions, name string) metav1.ListOptions {
+	opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+	return opts
+}
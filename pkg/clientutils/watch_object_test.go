@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientutils_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kcptesting "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/testing"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	kcpfakeclient "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster/fake"
+	"github.com/kcp-dev/kcp/pkg/clientutils"
+)
+
+func TestWatchObjectOptions(t *testing.T) {
+	opts := clientutils.WatchObjectOptions(metav1.ListOptions{LabelSelector: "app=foo", FieldSelector: "metadata.namespace=bar"}, "export")
+	require.Equal(t, metav1.ListOptions{
+		LabelSelector: "app=foo",
+		FieldSelector: "metadata.name=export",
+	}, opts)
+}
+
+// The fake clientset does not apply field selectors to watches, hence this only asserts that the
+// selector is passed on and that events for the named object are delivered.
+func TestWatchObjectFake(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	clusterPath := logicalcluster.NewPath("root:org")
+	fakeClient := kcpfakeclient.NewSimpleClientset()
+	exports := fakeClient.Cluster(clusterPath).ApisV1alpha1().APIExports()
+
+	w, err := exports.WatchObject(ctx, "export", metav1.ListOptions{})
+	require.NoError(t, err)
+	defer w.Stop()
+
+	var watchAction kcptesting.WatchAction
+	for _, action := range fakeClient.Actions() {
+		if a, ok := action.(kcptesting.WatchAction); ok {
+			watchAction = a
+		}
+	}
+	require.NotNil(t, watchAction, "expected a watch action")
+	require.Equal(t, "metadata.name=export", watchAction.GetWatchRestrictions().Fields.String())
+
+	_, err = exports.Create(ctx, &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "export",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: clusterPath.String()},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	select {
+	case e := <-w.ResultChan():
+		require.Equal(t, watch.Added, e.Type)
+		require.Equal(t, "export", e.Object.(*apisv1alpha1.APIExport).Name)
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("timed out waiting for the event of the named object")
+	}
+}
@@ -107,3 +107,55 @@ func IndexAPIBindingByAPIExport(obj interface{}) ([]string, error) {
 
 	return []string{path.Join(apiBinding.Spec.Reference.Export.Name).String()}, nil
 }
+
+const APIBindingByAppliedClaimedIdentityHash = "byAppliedClaimedIdentityHash"
+
+// IndexAPIBindingByAppliedClaimedIdentityHash indexes the APIBindings by the identity hashes of their applied
+// permission claims, i.e. by the APIExports providing the claimed resources.
+func IndexAPIBindingByAppliedClaimedIdentityHash(obj interface{}) ([]string, error) {
+	apiBinding, ok := obj.(*apisv1alpha1.APIBinding)
+	if !ok {
+		return []string{}, fmt.Errorf("obj %T is not an APIBinding", obj)
+	}
+
+	ret := make([]string, 0, len(apiBinding.Status.AppliedPermissionClaims))
+	for _, c := range apiBinding.Status.AppliedPermissionClaims {
+		if c.IdentityHash == "" {
+			continue
+		}
+		ret = append(ret, c.IdentityHash)
+	}
+
+	return ret, nil
+}
+
+// APIBindingIndexers are the strongly-typed indexes of APIBindings.
+var APIBindingIndexers = struct {
+	// ByClusterAndAcceptedClaimedGroupResources indexes APIBindings by their logical cluster and the group
+	// resources of their accepted permission claims. Keys are built with ClusterAndGroupResourceValue.
+	ByClusterAndAcceptedClaimedGroupResources Index[*apisv1alpha1.APIBinding]
+	// ByBoundResourceUID indexes APIBindings by the UIDs of the schemas of their bound resources.
+	ByBoundResourceUID Index[*apisv1alpha1.APIBinding]
+	// ByBoundResources indexes APIBindings by their bound resources. Keys are built with APIBindingBoundResourceValue.
+	ByBoundResources Index[*apisv1alpha1.APIBinding]
+	// ByAPIExport indexes APIBindings by the path and name of their APIExport.
+	ByAPIExport Index[*apisv1alpha1.APIBinding]
+	// ByAppliedClaimedIdentityHash indexes APIBindings by the identity hashes of their applied permission claims.
+	ByAppliedClaimedIdentityHash Index[*apisv1alpha1.APIBinding]
+}{
+	ByClusterAndAcceptedClaimedGroupResources: NewIndex(APIBindingByClusterAndAcceptedClaimedGroupResources, func(apiBinding *apisv1alpha1.APIBinding) ([]string, error) {
+		return IndexAPIBindingByClusterAndAcceptedClaimedGroupResources(apiBinding)
+	}),
+	ByBoundResourceUID: NewIndex(APIBindingByBoundResourceUID, func(apiBinding *apisv1alpha1.APIBinding) ([]string, error) {
+		return IndexAPIBindingByBoundResourceUID(apiBinding)
+	}),
+	ByBoundResources: NewIndex(APIBindingByBoundResources, func(apiBinding *apisv1alpha1.APIBinding) ([]string, error) {
+		return IndexAPIBindingByBoundResources(apiBinding)
+	}),
+	ByAPIExport: NewIndex(APIBindingsByAPIExport, func(apiBinding *apisv1alpha1.APIBinding) ([]string, error) {
+		return IndexAPIBindingByAPIExport(apiBinding)
+	}),
+	ByAppliedClaimedIdentityHash: NewIndex(APIBindingByAppliedClaimedIdentityHash, func(apiBinding *apisv1alpha1.APIBinding) ([]string, error) {
+		return IndexAPIBindingByAppliedClaimedIdentityHash(apiBinding)
+	}),
+}
@@ -92,3 +92,43 @@ func TestIndexAPIBindingByAPIExport(t *testing.T) {
 		})
 	}
 }
+
+func TestIndexAPIBindingByAppliedClaimedIdentityHash(t *testing.T) {
+	tests := map[string]struct {
+		obj     interface{}
+		want    []string
+		wantErr bool
+	}{
+		"not an APIBinding": {
+			obj:     "not an APIBinding",
+			want:    []string{},
+			wantErr: true,
+		},
+		"has applied claims": {
+			obj: &apisv1alpha1.APIBinding{
+				Status: apisv1alpha1.APIBindingStatus{
+					AppliedPermissionClaims: []apisv1alpha1.AppliedPermissionClaim{
+						{PermissionClaim: apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}}},
+						{PermissionClaim: apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Group: "wildwest.dev", Resource: "cowboys"}, IdentityHash: "hash1"}},
+						{PermissionClaim: apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}, IdentityHash: "hash2"}},
+					},
+				},
+			},
+			want:    []string{"hash1", "hash2"},
+			wantErr: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := IndexAPIBindingByAppliedClaimedIdentityHash(tt.obj)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IndexAPIBindingByAppliedClaimedIdentityHash() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IndexAPIBindingByAppliedClaimedIdentityHash() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
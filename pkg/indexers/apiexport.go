@@ -17,6 +17,8 @@ limitations under the License.
 package indexers
 
 import (
+	"strings"
+
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
 	"github.com/kcp-dev/logicalcluster/v3"
 
@@ -33,6 +35,9 @@ const (
 	// APIExportByClaimedIdentities is the indexer name for retrieving APIExports that have a permission claim for a
 	// particular identity hash.
 	APIExportByClaimedIdentities = "APIExportByClaimedIdentities"
+	// APIExportByExportedGroupResources is the indexer name for retrieving APIExports by the group resources of
+	// their latest resource schemas.
+	APIExportByExportedGroupResources = "APIExportByExportedGroupResources"
 )
 
 // IndexAPIExportByIdentity is an index function that indexes an APIExport by its identity hash.
@@ -72,3 +77,57 @@ func IndexAPIExportByClaimedIdentities(obj interface{}) ([]string, error) {
 	}
 	return claimedIdentities.List(), nil
 }
+
+// IndexAPIExportByExportedGroupResources is an index function that indexes an APIExport by the group resources of
+// its latest resource schemas. Index values are of the form <resource>.<group>, or <resource> for the core group.
+func IndexAPIExportByExportedGroupResources(obj interface{}) ([]string, error) {
+	apiExport := obj.(*apisv1alpha1.APIExport)
+	exported := sets.NewString()
+	for _, schemaName := range apiExport.Spec.LatestResourceSchemas {
+		// schema names are of the form <prefix>.<resource>.<group>
+		parts := strings.SplitN(schemaName, ".", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		group := parts[2]
+		if group == "core" {
+			group = ""
+		}
+		exported.Insert(GroupResourceKey(apisv1alpha1.GroupResource{Group: group, Resource: parts[1]}))
+	}
+	return exported.List(), nil
+}
+
+// GroupResourceKey returns the index value of the given group resource for the
+// APIExportByExportedGroupResources index.
+func GroupResourceKey(gr apisv1alpha1.GroupResource) string {
+	if gr.Group == "" {
+		return gr.Resource
+	}
+	return gr.Resource + "." + gr.Group
+}
+
+// APIExportIndexers are the strongly-typed indexes of APIExports.
+var APIExportIndexers = struct {
+	// ByIdentityHash indexes APIExports by their identity hash.
+	ByIdentityHash Index[*apisv1alpha1.APIExport]
+	// BySecret indexes APIExports by the cache key of their identity secret.
+	BySecret Index[*apisv1alpha1.APIExport]
+	// ByClaimedIdentities indexes APIExports by the identity hashes of their permission claims.
+	ByClaimedIdentities Index[*apisv1alpha1.APIExport]
+	// ByExportedGroupResources indexes APIExports by the group resources of their latest resource schemas.
+	ByExportedGroupResources Index[*apisv1alpha1.APIExport]
+}{
+	ByIdentityHash: NewIndex(APIExportByIdentity, func(apiExport *apisv1alpha1.APIExport) ([]string, error) {
+		return IndexAPIExportByIdentity(apiExport)
+	}),
+	BySecret: NewIndex(APIExportBySecret, func(apiExport *apisv1alpha1.APIExport) ([]string, error) {
+		return IndexAPIExportBySecret(apiExport)
+	}),
+	ByClaimedIdentities: NewIndex(APIExportByClaimedIdentities, func(apiExport *apisv1alpha1.APIExport) ([]string, error) {
+		return IndexAPIExportByClaimedIdentities(apiExport)
+	}),
+	ByExportedGroupResources: NewIndex(APIExportByExportedGroupResources, func(apiExport *apisv1alpha1.APIExport) ([]string, error) {
+		return IndexAPIExportByExportedGroupResources(apiExport)
+	}),
+}
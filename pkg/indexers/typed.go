@@ -0,0 +1,65 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package indexers
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Index is a strongly-typed informer index of objects of type T. It ties the name of the index to its
+// key function, so that an index cannot be registered under one name and queried under another, or
+// with a key function for another type.
+type Index[T runtime.Object] struct {
+	name string
+	keys func(obj T) ([]string, error)
+}
+
+// NewIndex returns an index with the given name, computing the keys of an object with keys.
+func NewIndex[T runtime.Object](name string, keys func(obj T) ([]string, error)) Index[T] {
+	return Index[T]{name: name, keys: keys}
+}
+
+// Name returns the name of the index.
+func (i Index[T]) Name() string {
+	return i.name
+}
+
+// IndexFunc returns the key function of the index for use with a cache.Indexer. It fails for objects
+// that are not of type T.
+func (i Index[T]) IndexFunc() cache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		typed, ok := obj.(T)
+		if !ok {
+			return []string{}, fmt.Errorf("index %s: obj %T is not a %T", i.name, obj, typed)
+		}
+		return i.keys(typed)
+	}
+}
+
+// AddIfNotPresentOrDie adds the index to the given indexer, unless an index of the same name is
+// registered already. It panics if it encounters an error.
+func (i Index[T]) AddIfNotPresentOrDie(indexer cache.Indexer) {
+	AddIfNotPresentOrDie(indexer, cache.Indexers{i.name: i.IndexFunc()})
+}
+
+// ByKey returns all objects in the indexer with the given key in the index.
+func (i Index[T]) ByKey(indexer cache.Indexer, key string) ([]T, error) {
+	return ByIndex[T](indexer, i.name, key)
+}
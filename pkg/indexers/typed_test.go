@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package indexers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+)
+
+func TestTypedIndex(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	APIExportIndexers.ByIdentityHash.AddIfNotPresentOrDie(indexer)
+	require.Contains(t, indexer.GetIndexers(), APIExportByIdentity)
+
+	t.Log("Registering the same index again is a no-op")
+	APIExportIndexers.ByIdentityHash.AddIfNotPresentOrDie(indexer)
+
+	cowboys := &apisv1alpha1.APIExport{ObjectMeta: metav1.ObjectMeta{Name: "cowboys"}, Status: apisv1alpha1.APIExportStatus{IdentityHash: "hc"}}
+	sheriffs := &apisv1alpha1.APIExport{ObjectMeta: metav1.ObjectMeta{Name: "sheriffs"}, Status: apisv1alpha1.APIExportStatus{IdentityHash: "hs"}}
+	require.NoError(t, indexer.Add(cowboys))
+	require.NoError(t, indexer.Add(sheriffs))
+
+	exports, err := APIExportIndexers.ByIdentityHash.ByKey(indexer, "hs")
+	require.NoError(t, err)
+	require.Equal(t, []*apisv1alpha1.APIExport{sheriffs}, exports)
+
+	exports, err = APIExportIndexers.ByIdentityHash.ByKey(indexer, "unknown")
+	require.NoError(t, err)
+	require.Empty(t, exports)
+
+	t.Log("Querying an index that is not registered fails")
+	_, err = APIExportIndexers.ByClaimedIdentities.ByKey(indexer, "hs")
+	require.Error(t, err)
+
+	t.Log("Objects of another type are rejected by the key function")
+	_, err = APIExportIndexers.ByIdentityHash.IndexFunc()(&apisv1alpha1.APIBinding{})
+	require.EqualError(t, err, "index APIExportByIdentity: obj *v1alpha1.APIBinding is not a *v1alpha1.APIExport")
+}
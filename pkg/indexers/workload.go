@@ -21,11 +21,15 @@ import (
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	schedulingv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1"
 	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
 )
 
 const (
 	SyncTargetsBySyncTargetKey = "SyncTargetsBySyncTargetKey"
+	// PlacementsByScheduledSyncTargetKey is the name for the index that indexes Placements by the key of the
+	// SyncTarget they are scheduled to.
+	PlacementsByScheduledSyncTargetKey = "PlacementsByScheduledSyncTargetKey"
 )
 
 func IndexSyncTargetsBySyncTargetKey(obj interface{}) ([]string, error) {
@@ -36,3 +40,29 @@ func IndexSyncTargetsBySyncTargetKey(obj interface{}) ([]string, error) {
 
 	return []string{workloadv1alpha1.ToSyncTargetKey(logicalcluster.From(syncTarget), syncTarget.Name)}, nil
 }
+
+// IndexPlacementsByScheduledSyncTargetKey indexes Placements by the key of the SyncTarget they are scheduled to,
+// as found in the internal.workload.kcp.io/synctarget annotation. Unscheduled Placements are not indexed.
+func IndexPlacementsByScheduledSyncTargetKey(obj interface{}) ([]string, error) {
+	placement, ok := obj.(*schedulingv1alpha1.Placement)
+	if !ok {
+		return []string{}, fmt.Errorf("obj is supposed to be a schedulingv1alpha1.Placement, but is %T", obj)
+	}
+
+	syncTargetKey := placement.Annotations[workloadv1alpha1.InternalSyncTargetPlacementAnnotationKey]
+	if syncTargetKey == "" {
+		return []string{}, nil
+	}
+	return []string{syncTargetKey}, nil
+}
+
+// PlacementIndexers are the strongly-typed indexes of Placements.
+var PlacementIndexers = struct {
+	// ByScheduledSyncTargetKey indexes Placements by the key of the SyncTarget they are scheduled to, e.g. to
+	// list them before draining the SyncTarget.
+	ByScheduledSyncTargetKey Index[*schedulingv1alpha1.Placement]
+}{
+	ByScheduledSyncTargetKey: NewIndex(PlacementsByScheduledSyncTargetKey, func(placement *schedulingv1alpha1.Placement) ([]string, error) {
+		return IndexPlacementsByScheduledSyncTargetKey(placement)
+	}),
+}
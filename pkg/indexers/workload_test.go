@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package indexers
+
+import (
+	"sort"
+	"testing"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	schedulingv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1"
+	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
+)
+
+func TestPlacementsByScheduledSyncTargetKey(t *testing.T) {
+	indexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{kcpcache.ClusterIndexName: kcpcache.ClusterIndexFunc})
+	PlacementIndexers.ByScheduledSyncTargetKey.AddIfNotPresentOrDie(indexer)
+
+	east := workloadv1alpha1.ToSyncTargetKey("root:compute", "east")
+	west := workloadv1alpha1.ToSyncTargetKey("root:compute", "west")
+	for _, p := range []struct {
+		cluster, name, syncTargetKey string
+	}{
+		{"root:org:a", "default", east},
+		{"root:org:a", "other", west},
+		{"root:org:b", "default", east},
+		{"root:org:c", "unscheduled", ""},
+	} {
+		annotations := map[string]string{logicalcluster.AnnotationKey: p.cluster}
+		if p.syncTargetKey != "" {
+			annotations[workloadv1alpha1.InternalSyncTargetPlacementAnnotationKey] = p.syncTargetKey
+		}
+		require.NoError(t, indexer.Add(&schedulingv1alpha1.Placement{ObjectMeta: metav1.ObjectMeta{
+			Name:        p.name,
+			Annotations: annotations,
+		}}))
+	}
+
+	listNames := func(syncTargetKey string) []string {
+		placements, err := PlacementIndexers.ByScheduledSyncTargetKey.ByKey(indexer, syncTargetKey)
+		require.NoError(t, err)
+		names := []string{}
+		for _, placement := range placements {
+			names = append(names, logicalcluster.From(placement).String()+"|"+placement.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	require.Equal(t, []string{"root:org:a|default", "root:org:b|default"}, listNames(east))
+	require.Equal(t, []string{"root:org:a|other"}, listNames(west))
+	require.Equal(t, []string{}, listNames(workloadv1alpha1.ToSyncTargetKey("root:compute", "north")))
+}
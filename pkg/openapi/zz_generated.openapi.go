@@ -62,12 +62,15 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportList":                               schema_pkg_apis_apis_v1alpha1_APIExportList(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportSpec":                               schema_pkg_apis_apis_v1alpha1_APIExportSpec(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportStatus":                             schema_pkg_apis_apis_v1alpha1_APIExportStatus(ref),
+		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportVirtualWorkspaceReference":          schema_pkg_apis_apis_v1alpha1_APIExportVirtualWorkspaceReference(ref),
+		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportWebhook":                            schema_pkg_apis_apis_v1alpha1_APIExportWebhook(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceSchema":                           schema_pkg_apis_apis_v1alpha1_APIResourceSchema(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceSchemaList":                       schema_pkg_apis_apis_v1alpha1_APIResourceSchemaList(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceSchemaSpec":                       schema_pkg_apis_apis_v1alpha1_APIResourceSchemaSpec(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceVersion":                          schema_pkg_apis_apis_v1alpha1_APIResourceVersion(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIVersionConversion":                        schema_pkg_apis_apis_v1alpha1_APIVersionConversion(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.AcceptablePermissionClaim":                   schema_pkg_apis_apis_v1alpha1_AcceptablePermissionClaim(ref),
+		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.AppliedPermissionClaim":                      schema_pkg_apis_apis_v1alpha1_AppliedPermissionClaim(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BindingReference":                            schema_pkg_apis_apis_v1alpha1_BindingReference(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BoundAPIResource":                            schema_pkg_apis_apis_v1alpha1_BoundAPIResource(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BoundAPIResourceSchema":                      schema_pkg_apis_apis_v1alpha1_BoundAPIResourceSchema(ref),
@@ -79,6 +82,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.PermissionClaim":                             schema_pkg_apis_apis_v1alpha1_PermissionClaim(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ResourceSelector":                            schema_pkg_apis_apis_v1alpha1_ResourceSelector(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.VirtualWorkspace":                            schema_pkg_apis_apis_v1alpha1_VirtualWorkspace(ref),
+		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.WebhookClientConfig":                         schema_pkg_apis_apis_v1alpha1_WebhookClientConfig(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalCluster":                              schema_pkg_apis_core_v1alpha1_LogicalCluster(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalClusterList":                          schema_pkg_apis_core_v1alpha1_LogicalClusterList(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalClusterOwner":                         schema_pkg_apis_core_v1alpha1_LogicalClusterOwner(ref),
@@ -118,6 +122,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionList":                           schema_pkg_apis_topology_v1alpha1_PartitionList(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSet":                            schema_pkg_apis_topology_v1alpha1_PartitionSet(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSetList":                        schema_pkg_apis_topology_v1alpha1_PartitionSetList(ref),
+		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSetNormalization":               schema_pkg_apis_topology_v1alpha1_PartitionSetNormalization(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSetSpec":                        schema_pkg_apis_topology_v1alpha1_PartitionSetSpec(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSetStatus":                      schema_pkg_apis_topology_v1alpha1_PartitionSetStatus(ref),
 		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSpec":                           schema_pkg_apis_topology_v1alpha1_PartitionSpec(ref),
@@ -1202,6 +1207,13 @@ func schema_pkg_apis_apis_v1alpha1_APIBindingSpec(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"conflictResolution": {
+						SchemaProps: spec.SchemaProps{
+							Description: "conflictResolution defines how resources of the APIExport are handled that overlap with a CustomResourceDefinition in the workspace of the APIBinding. With \"Error\", the binding fails with a naming conflict. With \"Skip\", overlapping resources stay served by the CustomResourceDefinition, and only the other resources of the APIExport are bound.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"reference"},
 			},
@@ -1277,7 +1289,7 @@ func schema_pkg_apis_apis_v1alpha1_APIBindingStatus(ref common.ReferenceCallback
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.PermissionClaim"),
+										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.AppliedPermissionClaim"),
 									},
 								},
 							},
@@ -1301,7 +1313,7 @@ func schema_pkg_apis_apis_v1alpha1_APIBindingStatus(ref common.ReferenceCallback
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BoundAPIResource", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.PermissionClaim", "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
+			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.AppliedPermissionClaim", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BoundAPIResource", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.PermissionClaim", "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
 	}
 }
 
@@ -1793,7 +1805,7 @@ func schema_pkg_apis_apis_v1alpha1_APIExportSpec(ref common.ReferenceCallback) c
 					},
 					"identity": {
 						SchemaProps: spec.SchemaProps{
-							Description: "identity points to a secret that contains the API identity in the 'key' file. The API identity determines an unique etcd prefix for objects stored via this APIExport.\n\nDifferent APIExport in a workspace can share a common identity, or have different ones. The identity (the secret) can also be transferred to another workspace when the APIExport is moved.\n\nThe identity is a secret of the API provider. The APIBindings referencing this APIExport will store a derived, non-sensitive value of this identity.\n\nThe identity of an APIExport cannot be changed. A derived, non-sensitive value of the identity key is stored in the APIExport status and this value is immutable.\n\nThe identity is defaulted. A secret with the name of the APIExport is automatically created.",
+							Description: "identity points to a secret that contains the API identity in the 'key' file. The API identity determines an unique etcd prefix for objects stored via this APIExport.\n\nDifferent APIExport in a workspace can share a common identity, or have different ones. The identity (the secret) can also be transferred to another workspace when the APIExport is moved.\n\nThe identity is a secret of the API provider. The APIBindings referencing this APIExport will store a derived, non-sensitive value of this identity.\n\nA derived, non-sensitive value of the identity key is stored in the APIExport status. It only changes when the identity is rotated explicitly with the apis.kcp.io/rotate-identity annotation. Objects stored under the previous identity are not served anymore after a rotation.\n\nThe identity is defaulted. A secret with the name of the APIExport is automatically created.",
 							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.Identity"),
 						},
 					},
@@ -1814,7 +1826,7 @@ func schema_pkg_apis_apis_v1alpha1_APIExportSpec(ref common.ReferenceCallback) c
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "permissionClaims make resources available in APIExport's virtual workspace that are not part of the actual APIExport resources.\n\nPermissionClaims are optional and should be the least access necessary to complete the functions that the service provider needs. Access is asked for on a GroupResource + identity basis.\n\nPermissionClaims must be accepted by the user's explicit acknowledgement. Hence, when claims change, the respecting objects are not visible immediately.\n\nPermissionClaims overlapping with the APIExport resources are ignored.",
+							Description: "permissionClaims make resources available in APIExport's virtual workspace that are not part of the actual APIExport resources.\n\nPermissionClaims are optional and should be the least access necessary to complete the functions that the service provider needs. Access is asked for on a GroupResource + identity basis.\n\nPermissionClaims must be accepted by the user's explicit acknowledgement. Hence, when claims change, the respecting objects are not visible immediately.\n\nPermissionClaims overlapping with the APIExport resources are ignored, and reported in the PermissionClaimsValid condition.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -1826,11 +1838,53 @@ func schema_pkg_apis_apis_v1alpha1_APIExportSpec(ref common.ReferenceCallback) c
 							},
 						},
 					},
+					"permissionClaimTemplates": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "permissionClaimTemplates are names of built-in bundles of permission claims. On admission, the claims of every template are added to permissionClaims, unless permissionClaims already has a claim for the same group and resource. The supported templates are:\n\n- \"configuration\": configmaps and secrets. - \"events\": events. - \"rbac\": roles and rolebindings.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"webhooks": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "webhooks are admission webhooks of the service provider. They are called for create, update and delete requests of the resources of this APIExport in workspaces that bind to it, in addition to the webhook configurations in the workspace of the APIExport.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportWebhook"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.Identity", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.MaximalPermissionPolicy", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.PermissionClaim"},
+			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportWebhook", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.Identity", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.MaximalPermissionPolicy", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.PermissionClaim"},
 	}
 }
 
@@ -1876,11 +1930,109 @@ func schema_pkg_apis_apis_v1alpha1_APIExportStatus(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"virtualWorkspaceURLs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"shard",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "virtualWorkspaceURLs contains the APIExport virtual workspace URL of every shard serving the APIExport, e.g. to select the endpoint of a nearby shard.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportVirtualWorkspaceReference"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.VirtualWorkspace", "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
+			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportVirtualWorkspaceReference", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.VirtualWorkspace", "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIExportVirtualWorkspaceReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIExportVirtualWorkspaceReference is the APIExport virtual workspace URL of a shard.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"shard": {
+						SchemaProps: spec.SchemaProps{
+							Description: "shard is the name of the shard serving the virtual workspace.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "url is the APIExport virtual workspace URL of the shard.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"shard", "url"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIExportWebhook(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIExportWebhook is an admission webhook of the service provider for the resources of an APIExport.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name is the name of the webhook. It must be unique within the APIExport.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "type is either \"Validating\" or \"Mutating\".",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clientConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "clientConfig defines how to communicate with the webhook.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.WebhookClientConfig"),
+						},
+					},
+					"failurePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "failurePolicy defines how errors calling the webhook are handled. Allowed values are \"Fail\" and \"Ignore\". Defaults to \"Fail\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "type", "clientConfig"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.WebhookClientConfig"},
 	}
 }
 
@@ -2235,6 +2387,80 @@ func schema_pkg_apis_apis_v1alpha1_AcceptablePermissionClaim(ref common.Referenc
 							Format:      "",
 						},
 					},
+					"namespaces": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "namespaces is an allowlist of namespaces the service provider may create or update claimed objects in through the APIExport virtual workspace. If empty, claimed objects can be created in any namespace. It has no effect on cluster-scoped resources.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "namespaceSelector restricts the claim to objects in namespaces whose labels match the selector. Claimed objects in other namespaces are invisible through the APIExport virtual workspace. It has no effect on cluster-scoped resources.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"verbs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "verbs is an allowlist of verbs the service provider may use on claimed objects through the APIExport virtual workspace, e.g. to create and read claimed objects without being able to delete them. Every verb is checked on its own, i.e. \"deletecollection\" is not implied by \"delete\". \"*\" allows all verbs. If empty, all verbs are allowed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"readOnly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "readOnly projects claimed objects read-only through the APIExport virtual workspace: all requests other than get, list and watch are rejected, independently of verbs and of any RBAC granted to the service provider. It cannot be combined with verbs allowing mutating requests.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"auditAnnotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "auditAnnotations enables stamping objects of the claimed resource that the service provider creates or updates through the APIExport virtual workspace with the apis.kcp.io/audit-apiexport and apis.kcp.io/audit-user annotations.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"identityAnnotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "identityAnnotation enables stamping objects of the claimed resource that the service provider creates or updates through the APIExport virtual workspace with the apis.kcp.io/apiexport-identity annotation, holding the identity hash of the APIExport. This allows the service provider to attribute objects to the APIExport across identity rotations.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"maxObjects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "maxObjects limits the number of objects of the claimed resource in a consumer workspace that are visible through the claim, beyond which the service provider cannot create further objects through the APIExport virtual workspace. This protects consumers from service providers exhausting their quota. Objects created otherwise count as well, but are never rejected. If unset, the number of objects is not limited.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"state": {
 						SchemaProps: spec.SchemaProps{
 							Default: "",
@@ -2242,12 +2468,143 @@ func schema_pkg_apis_apis_v1alpha1_AcceptablePermissionClaim(ref common.Referenc
 							Format:  "",
 						},
 					},
+					"expiresAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "expiresAt is the time until which an accepted claim is accepted. After that time, the claim is reverted to rejected and the access granted by it is revoked. It must only be set on accepted claims.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 				},
 				Required: []string{"state"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ResourceSelector"},
+			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ResourceSelector", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_AppliedPermissionClaim(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AppliedPermissionClaim is a PermissionClaim that has been applied to the objects in the workspace of the APIBinding.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"all": {
+						SchemaProps: spec.SchemaProps{
+							Description: "all claims all resources for the given group/resource. This is mutually exclusive with resourceSelector.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"resourceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resourceSelector is a list of claimed resource selectors.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ResourceSelector"),
+									},
+								},
+							},
+						},
+					},
+					"identityHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "This is the identity for a given APIExport that the APIResourceSchema belongs to. The hash can be found on APIExport and APIResourceSchema's status. It will be empty for core types. Note that one must look this up for a particular KCP instance.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespaces": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "namespaces is an allowlist of namespaces the service provider may create or update claimed objects in through the APIExport virtual workspace. If empty, claimed objects can be created in any namespace. It has no effect on cluster-scoped resources.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "namespaceSelector restricts the claim to objects in namespaces whose labels match the selector. Claimed objects in other namespaces are invisible through the APIExport virtual workspace. It has no effect on cluster-scoped resources.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"verbs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "verbs is an allowlist of verbs the service provider may use on claimed objects through the APIExport virtual workspace, e.g. to create and read claimed objects without being able to delete them. Every verb is checked on its own, i.e. \"deletecollection\" is not implied by \"delete\". \"*\" allows all verbs. If empty, all verbs are allowed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"readOnly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "readOnly projects claimed objects read-only through the APIExport virtual workspace: all requests other than get, list and watch are rejected, independently of verbs and of any RBAC granted to the service provider. It cannot be combined with verbs allowing mutating requests.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"auditAnnotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "auditAnnotations enables stamping objects of the claimed resource that the service provider creates or updates through the APIExport virtual workspace with the apis.kcp.io/audit-apiexport and apis.kcp.io/audit-user annotations.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"identityAnnotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "identityAnnotation enables stamping objects of the claimed resource that the service provider creates or updates through the APIExport virtual workspace with the apis.kcp.io/apiexport-identity annotation, holding the identity hash of the APIExport. This allows the service provider to attribute objects to the APIExport across identity rotations.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"maxObjects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "maxObjects limits the number of objects of the claimed resource in a consumer workspace that are visible through the claim, beyond which the service provider cannot create further objects through the APIExport virtual workspace. This protects consumers from service providers exhausting their quota. Objects created otherwise count as well, but are never rejected. If unset, the number of objects is not limited.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"authorized": {
+						SchemaProps: spec.SchemaProps{
+							Description: "authorized indicates whether the maximal permission policies of the APIExports providing the claimed resource currently authorize access to it, i.e. whether such an APIExport exists and the ClusterRoles bound by its policies grant the claimed verbs on the claimed resource. Claims of resources without an identity hash, like core Kubernetes resources, are not subject to a maximal permission policy and are always authorized.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ResourceSelector", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
@@ -2515,11 +2872,85 @@ func schema_pkg_apis_apis_v1alpha1_PermissionClaim(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"namespaces": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "namespaces is an allowlist of namespaces the service provider may create or update claimed objects in through the APIExport virtual workspace. If empty, claimed objects can be created in any namespace. It has no effect on cluster-scoped resources.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "namespaceSelector restricts the claim to objects in namespaces whose labels match the selector. Claimed objects in other namespaces are invisible through the APIExport virtual workspace. It has no effect on cluster-scoped resources.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"verbs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "verbs is an allowlist of verbs the service provider may use on claimed objects through the APIExport virtual workspace, e.g. to create and read claimed objects without being able to delete them. Every verb is checked on its own, i.e. \"deletecollection\" is not implied by \"delete\". \"*\" allows all verbs. If empty, all verbs are allowed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"readOnly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "readOnly projects claimed objects read-only through the APIExport virtual workspace: all requests other than get, list and watch are rejected, independently of verbs and of any RBAC granted to the service provider. It cannot be combined with verbs allowing mutating requests.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"auditAnnotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "auditAnnotations enables stamping objects of the claimed resource that the service provider creates or updates through the APIExport virtual workspace with the apis.kcp.io/audit-apiexport and apis.kcp.io/audit-user annotations.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"identityAnnotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "identityAnnotation enables stamping objects of the claimed resource that the service provider creates or updates through the APIExport virtual workspace with the apis.kcp.io/apiexport-identity annotation, holding the identity hash of the APIExport. This allows the service provider to attribute objects to the APIExport across identity rotations.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"maxObjects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "maxObjects limits the number of objects of the claimed resource in a consumer workspace that are visible through the claim, beyond which the service provider cannot create further objects through the APIExport virtual workspace. This protects consumers from service providers exhausting their quota. Objects created otherwise count as well, but are never rejected. If unset, the number of objects is not limited.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ResourceSelector"},
+			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ResourceSelector", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
@@ -2570,6 +3001,35 @@ func schema_pkg_apis_apis_v1alpha1_VirtualWorkspace(ref common.ReferenceCallback
 	}
 }
 
+func schema_pkg_apis_apis_v1alpha1_WebhookClientConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WebhookClientConfig contains the information to make a TLS connection with a webhook.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "url gives the location of the webhook, in standard URL form (`https://host:port/path`). It must use the https scheme, and must not contain a user, a query or a fragment. It is called by kcp with its network access, not by the service provider. Hence, unless kcp is started with --apiexport-webhooks-allow-private-hosts, the host must not be the local host, a cluster-internal service name, or a loopback, link-local, private or unspecified IP address.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"caBundle": {
+						SchemaProps: spec.SchemaProps{
+							Description: "caBundle is a PEM encoded CA bundle which is used to validate the webhook's server certificate. If unspecified, system trust roots are used.",
+							Type:        []string{"string"},
+							Format:      "byte",
+						},
+					},
+				},
+				Required: []string{"url"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_core_v1alpha1_LogicalCluster(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4305,6 +4765,49 @@ func schema_pkg_apis_topology_v1alpha1_PartitionSetList(ref common.ReferenceCall
 	}
 }
 
+func schema_pkg_apis_topology_v1alpha1_PartitionSetNormalization(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PartitionSetNormalization specifies how the label values of dimensions are normalized.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"dimensions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "dimensions are the dimensions whose label values are normalized.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"caseFold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "caseFold (optional) lower-cases the label values.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"trim": {
+						SchemaProps: spec.SchemaProps{
+							Description: "trim (optional) removes leading and trailing \"-\", \"_\" and \".\" characters from the label values. Shards with a label value that is empty after trimming are not part of any partition.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"dimensions"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_topology_v1alpha1_PartitionSetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4327,17 +4830,37 @@ func schema_pkg_apis_topology_v1alpha1_PartitionSetSpec(ref common.ReferenceCall
 							},
 						},
 					},
+					"topology": {
+						SchemaProps: spec.SchemaProps{
+							Description: "topology (optional) is a shortcut for the dimensions of a well-known topology. \"Zone\" groups shards by region and availability zone, i.e. it is equivalent to the dimensions \"region\" and \"az\". Dimensions are added to the ones of the topology.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"shardSelector": {
 						SchemaProps: spec.SchemaProps{
 							Description: "shardSelector (optional) specifies filtering for shard targets.",
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
 						},
 					},
+					"normalization": {
+						SchemaProps: spec.SchemaProps{
+							Description: "normalization (optional) normalizes the label values of dimensions before shards are grouped into partitions, e.g. such that shards labelled \"region: Europe\" and \"region: europe\" end up in the same partition. The partitions select all the original label values of their shards.",
+							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSetNormalization"),
+						},
+					},
+					"excludeUnreachableShards": {
+						SchemaProps: spec.SchemaProps{
+							Description: "excludeUnreachableShards (optional) leaves shards out of the partitions whose ShardReachable condition is False, i.e. which the root shard could not probe successfully. Shards are added back when they are reachable again.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+			"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSetNormalization", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
@@ -4590,6 +5113,13 @@ func schema_pkg_apis_workload_v1alpha1_SyncTargetSpec(ref common.ReferenceCallba
 							},
 						},
 					},
+					"weight": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Weight is the relative share of placements scheduled to this SyncTarget among the SyncTargets valid for a placement. A SyncTarget with weight 3 gets three times as many placements as one with weight 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 			},
 		},
@@ -23,6 +23,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/kcp-dev/logicalcluster/v3"
 
 	"k8s.io/apiextensions-apiserver/pkg/apihelpers"
@@ -38,31 +39,33 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
+	"github.com/kcp-dev/kcp/pkg/apis/apis"
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/apis/core"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
 	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	kcpscheme "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
 	apisv1alpha1client "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/apis/v1alpha1"
 	apisv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	"github.com/kcp-dev/kcp/pkg/reconciler/events"
 )
 
 const (
 	ControllerName = "kcp-apibinding"
 )
 
-var (
-	SystemBoundCRDsClusterName = logicalcluster.Name("system:bound-crds")
-)
-
 // NewController returns a new controller for APIBindings.
 func NewController(
 	crdClusterClient kcpapiextensionsclientset.ClusterInterface,
 	kcpClusterClient kcpclientset.ClusterInterface,
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
 	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
 	apiExportInformer apisv1alpha1informers.APIExportClusterInformer,
 	apiResourceSchemaInformer apisv1alpha1informers.APIResourceSchemaClusterInformer,
@@ -73,11 +76,16 @@ func NewController(
 	crdInformer kcpapiextensionsv1informers.CustomResourceDefinitionClusterInformer,
 ) (*controller, error) {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	eventBroadcaster := record.NewBroadcaster()
 
 	c := &controller{
-		queue:            queue,
-		crdClusterClient: crdClusterClient,
-		kcpClusterClient: kcpClusterClient,
+		queue:             queue,
+		crdClusterClient:  crdClusterClient,
+		kcpClusterClient:  kcpClusterClient,
+		kubeClusterClient: kubeClusterClient,
+
+		eventBroadcaster: eventBroadcaster,
+		recorder:         events.NewRecorder(eventBroadcaster, kcpscheme.Scheme, ControllerName),
 
 		listAPIBindings: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error) {
 			list, err := apiBindingInformer.Lister().List(labels.Everything())
@@ -133,18 +141,7 @@ func NewController(
 		},
 
 		getAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
-			// Try local informer first
-			export, err := indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), apiExportInformer.Informer().GetIndexer(), path, name)
-			if err == nil {
-				// Quick happy path - found it locally
-				return export, nil
-			}
-			if !apierrors.IsNotFound(err) {
-				// Unrecoverable error
-				return nil, err
-			}
-			// Didn't find it locally - try remote
-			return indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), globalAPIExportInformer.Informer().GetIndexer(), path, name)
+			return apiExportByPath(apiExportInformer.Informer().GetIndexer(), globalAPIExportInformer.Informer().GetIndexer(), path, name)
 		},
 		getAPIExportsBySchema: func(schema *apisv1alpha1.APIResourceSchema) ([]*apisv1alpha1.APIExport, error) {
 			key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(schema)
@@ -188,6 +185,14 @@ func NewController(
 		},
 		deletedCRDTracker: newLockedStringSet(),
 		commit:            committer.NewCommitter[*APIBinding, Patcher, *APIBindingSpec, *APIBindingStatus](kcpClusterClient.ApisV1alpha1().APIBindings()),
+		enqueueAfter: func(binding *apisv1alpha1.APIBinding, duration time.Duration) {
+			key, err := kcpcache.MetaClusterNamespaceKeyFunc(binding)
+			if err != nil {
+				utilruntime.HandleError(err)
+				return
+			}
+			queue.AddAfter(key, duration)
+		},
 	}
 
 	logger := logging.WithReconciler(klog.Background(), ControllerName)
@@ -210,17 +215,25 @@ func NewController(
 	// APIBinding handlers
 	apiBindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) { c.enqueueAPIBinding(objOrTombstone[*apisv1alpha1.APIBinding](obj), logger, "") },
-		UpdateFunc: func(_, obj interface{}) {
+		UpdateFunc: func(oldObj, obj interface{}) {
 			c.enqueueAPIBinding(objOrTombstone[*apisv1alpha1.APIBinding](obj), logger, "")
+			c.enqueueConflictingAPIBindings(objOrTombstone[*apisv1alpha1.APIBinding](oldObj), objOrTombstone[*apisv1alpha1.APIBinding](obj), logger)
+			recordPermissionClaimEvents(c.recorder, objOrTombstone[*apisv1alpha1.APIBinding](oldObj), objOrTombstone[*apisv1alpha1.APIBinding](obj))
+			recordAPIExportMovedEvent(c.recorder, objOrTombstone[*apisv1alpha1.APIBinding](oldObj), objOrTombstone[*apisv1alpha1.APIBinding](obj))
+			recordAPIExportIdentityRotatedEvent(c.recorder, objOrTombstone[*apisv1alpha1.APIBinding](oldObj), objOrTombstone[*apisv1alpha1.APIBinding](obj))
+		},
+		DeleteFunc: func(obj interface{}) {
+			apiBinding := objOrTombstone[*apisv1alpha1.APIBinding](obj)
+			c.enqueueAPIBinding(apiBinding, logger, "")
+			c.enqueueConflictingAPIBindings(apiBinding, nil, logger)
 		},
-		DeleteFunc: func(obj interface{}) { c.enqueueAPIBinding(objOrTombstone[*apisv1alpha1.APIBinding](obj), logger, "") },
 	})
 
 	// CRD handlers
 	crdInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: func(obj interface{}) bool {
 			crd := obj.(*apiextensionsv1.CustomResourceDefinition)
-			return logicalcluster.From(crd) == SystemBoundCRDsClusterName
+			return logicalcluster.From(crd) == apis.SystemBoundCRDsClusterName
 		},
 		Handler: cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
@@ -322,8 +335,12 @@ type CommitFunc = func(context.Context, *Resource, *Resource) error
 type controller struct {
 	queue workqueue.RateLimitingInterface
 
-	crdClusterClient kcpapiextensionsclientset.ClusterInterface
-	kcpClusterClient kcpclientset.ClusterInterface
+	crdClusterClient  kcpapiextensionsclientset.ClusterInterface
+	kcpClusterClient  kcpclientset.ClusterInterface
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface
+
+	eventBroadcaster record.EventBroadcaster
+	recorder         record.EventRecorder
 
 	listAPIBindings            func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error)
 	listAPIBindingsByAPIExport func(apiExport *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error)
@@ -342,6 +359,8 @@ type controller struct {
 
 	deletedCRDTracker *lockedStringSet
 	commit            CommitFunc
+
+	enqueueAfter func(*apisv1alpha1.APIBinding, time.Duration)
 }
 
 // enqueueAPIBinding enqueues an APIBinding .
@@ -356,6 +375,27 @@ func (c *controller) enqueueAPIBinding(apiBinding *apisv1alpha1.APIBinding, logg
 	c.queue.Add(key)
 }
 
+// enqueueConflictingAPIBindings enqueues the other APIBindings in the logical cluster of an APIBinding that is or was
+// conflicting with them, such that they report the conflict too, or stop reporting it.
+func (c *controller) enqueueConflictingAPIBindings(oldBinding, newBinding *apisv1alpha1.APIBinding, logger logr.Logger) {
+	if !conditions.IsFalse(oldBinding, apisv1alpha1.NoConflictingBindings) && (newBinding == nil || !conditions.IsFalse(newBinding, apisv1alpha1.NoConflictingBindings)) {
+		return
+	}
+
+	bindings, err := c.listAPIBindings(logicalcluster.From(oldBinding))
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, binding := range bindings {
+		if binding.Name == oldBinding.Name {
+			continue
+		}
+		c.enqueueAPIBinding(binding, logging.WithObject(logger, oldBinding), " because of conflicting APIBinding")
+	}
+}
+
 // enqueueAPIExport enqueues maps an APIExport to APIBindings for enqueuing.
 func (c *controller) enqueueAPIExport(export *apisv1alpha1.APIExport, logger logr.Logger, logSuffix string) {
 	bindings, err := c.listAPIBindingsByAPIExport(export)
@@ -433,6 +473,9 @@ func (c *controller) Start(ctx context.Context, numThreads int) {
 	logger.Info("Starting controller")
 	defer logger.Info("Shutting down controller")
 
+	c.eventBroadcaster.StartRecordingToSink(events.NewSink(c.kubeClusterClient))
+	defer c.eventBroadcaster.Shutdown()
+
 	for i := 0; i < numThreads; i++ {
 		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
 	}
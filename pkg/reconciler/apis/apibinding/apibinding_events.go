@@ -0,0 +1,144 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+)
+
+const (
+	// PermissionClaimAcceptedReason is the reason of the event recorded when a permission claim is accepted.
+	PermissionClaimAcceptedReason = "PermissionClaimAccepted"
+	// PermissionClaimRejectedReason is the reason of the event recorded when a permission claim is rejected.
+	PermissionClaimRejectedReason = "PermissionClaimRejected"
+	// PermissionClaimPendingReason is the reason of the event recorded when a permission claim of the
+	// APIExport is new, or neither accepted nor rejected anymore.
+	PermissionClaimPendingReason = "PermissionClaimPending"
+	// APIExportMovedReason is the reason of the event recorded when the APIExport referenced by path
+	// is found in a different logical cluster than before, e.g. because the workspace of the provider
+	// was moved to another shard.
+	APIExportMovedReason = "APIExportMoved"
+	// APIExportIdentityRotatedReason is the reason of the event recorded when the identity of the bound
+	// resources changed because the APIExport rotated its identity. Objects stored under the previous
+	// identity are not served anymore.
+	APIExportIdentityRotatedReason = "APIExportIdentityRotated"
+
+	claimPending = "Pending"
+	claimUnknown = "Unknown"
+)
+
+// claimKey identifies a permission claim in events.
+type claimKey struct {
+	apisv1alpha1.GroupResource
+	identityHash string
+}
+
+func (k claimKey) String() string {
+	gr := k.Resource
+	if k.Group != "" {
+		gr = k.Resource + "." + k.Group
+	}
+	if k.identityHash == "" {
+		return gr
+	}
+	return fmt.Sprintf("%s (identityHash %s)", gr, k.identityHash)
+}
+
+// permissionClaimStates returns the state of the permission claims of the given APIBinding. Claims of
+// the APIExport the binding has neither accepted nor rejected are pending.
+func permissionClaimStates(binding *apisv1alpha1.APIBinding) map[claimKey]string {
+	states := map[claimKey]string{}
+	if binding == nil {
+		return states
+	}
+	for _, claim := range binding.Status.ExportPermissionClaims {
+		states[claimKey{claim.GroupResource, claim.IdentityHash}] = claimPending
+	}
+	for _, claim := range binding.Spec.PermissionClaims {
+		states[claimKey{claim.GroupResource, claim.IdentityHash}] = string(claim.State)
+	}
+	return states
+}
+
+// recordPermissionClaimEvents records an event on the APIBinding for every permission claim whose state
+// changed between Accepted, Rejected and pending, or that is new. Claims that are gone are not reported.
+func recordPermissionClaimEvents(recorder record.EventRecorder, old, binding *apisv1alpha1.APIBinding) {
+	oldStates := permissionClaimStates(old)
+	newStates := permissionClaimStates(binding)
+
+	keys := make([]claimKey, 0, len(newStates))
+	for key, state := range newStates {
+		if oldStates[key] != state {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	for _, key := range keys {
+		from, to := oldStates[key], newStates[key]
+		if from == "" {
+			from = claimUnknown
+		}
+		switch to {
+		case string(apisv1alpha1.ClaimAccepted):
+			recorder.Eventf(binding, corev1.EventTypeNormal, PermissionClaimAcceptedReason, "Permission claim for %s changed from %s to %s", key, from, to)
+		case string(apisv1alpha1.ClaimRejected):
+			recorder.Eventf(binding, corev1.EventTypeWarning, PermissionClaimRejectedReason, "Permission claim for %s changed from %s to %s", key, from, to)
+		default:
+			recorder.Eventf(binding, corev1.EventTypeNormal, PermissionClaimPendingReason, "Permission claim for %s changed from %s to %s", key, from, to)
+		}
+	}
+}
+
+// recordAPIExportMovedEvent records an event on the APIBinding when the logical cluster of its APIExport changed.
+func recordAPIExportMovedEvent(recorder record.EventRecorder, old, binding *apisv1alpha1.APIBinding) {
+	if old == nil || binding.Spec.Reference.Export == nil || old.Status.APIExportClusterName == "" || binding.Status.APIExportClusterName == "" {
+		return
+	}
+	if old.Status.APIExportClusterName == binding.Status.APIExportClusterName {
+		return
+	}
+	recorder.Eventf(binding, corev1.EventTypeNormal, APIExportMovedReason, "APIExport %s moved from logical cluster %s to %s", binding.Spec.Reference.Export.Name, old.Status.APIExportClusterName, binding.Status.APIExportClusterName)
+}
+
+// recordAPIExportIdentityRotatedEvent records a warning event on the APIBinding when the identity hash of
+// its bound resources changed.
+func recordAPIExportIdentityRotatedEvent(recorder record.EventRecorder, old, binding *apisv1alpha1.APIBinding) {
+	if old == nil || binding.Spec.Reference.Export == nil {
+		return
+	}
+	oldHashes := map[apisv1alpha1.GroupResource]string{}
+	for _, r := range old.Status.BoundResources {
+		oldHashes[apisv1alpha1.GroupResource{Group: r.Group, Resource: r.Resource}] = r.Schema.IdentityHash
+	}
+	for _, r := range binding.Status.BoundResources {
+		oldHash := oldHashes[apisv1alpha1.GroupResource{Group: r.Group, Resource: r.Resource}]
+		if oldHash == "" || oldHash == r.Schema.IdentityHash {
+			continue
+		}
+		recorder.Eventf(binding, corev1.EventTypeWarning, APIExportIdentityRotatedReason,
+			"APIExport %s rotated its identity from %s to %s, objects created with the previous identity are not served anymore",
+			binding.Spec.Reference.Export.Name, oldHash, r.Schema.IdentityHash)
+		return
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/tools/record"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+)
+
+func TestRecordPermissionClaimEvents(t *testing.T) {
+	configMaps := apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true}
+	sheriffs := apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}, IdentityHash: "abc", All: true}
+
+	binding := func(exported []apisv1alpha1.PermissionClaim, accepted ...apisv1alpha1.AcceptablePermissionClaim) *apisv1alpha1.APIBinding {
+		return &apisv1alpha1.APIBinding{
+			Spec:   apisv1alpha1.APIBindingSpec{PermissionClaims: accepted},
+			Status: apisv1alpha1.APIBindingStatus{ExportPermissionClaims: exported},
+		}
+	}
+	withState := func(claim apisv1alpha1.PermissionClaim, state apisv1alpha1.AcceptablePermissionClaimState) apisv1alpha1.AcceptablePermissionClaim {
+		return apisv1alpha1.AcceptablePermissionClaim{PermissionClaim: claim, State: state}
+	}
+	exported := []apisv1alpha1.PermissionClaim{configMaps, sheriffs}
+
+	tests := []struct {
+		name       string
+		old, obj   *apisv1alpha1.APIBinding
+		wantEvents []string
+	}{
+		{
+			name: "no change",
+			old:  binding(exported, withState(configMaps, apisv1alpha1.ClaimAccepted)),
+			obj:  binding(exported, withState(configMaps, apisv1alpha1.ClaimAccepted)),
+		},
+		{
+			name: "new claims of the export are pending",
+			old:  binding(nil),
+			obj:  binding(exported),
+			wantEvents: []string{
+				"Normal PermissionClaimPending Permission claim for configmaps changed from Unknown to Pending",
+				"Normal PermissionClaimPending Permission claim for sheriffs.wild.wild.west (identityHash abc) changed from Unknown to Pending",
+			},
+		},
+		{
+			name: "pending claims are accepted and rejected",
+			old:  binding(exported),
+			obj:  binding(exported, withState(configMaps, apisv1alpha1.ClaimAccepted), withState(sheriffs, apisv1alpha1.ClaimRejected)),
+			wantEvents: []string{
+				"Normal PermissionClaimAccepted Permission claim for configmaps changed from Pending to Accepted",
+				"Warning PermissionClaimRejected Permission claim for sheriffs.wild.wild.west (identityHash abc) changed from Pending to Rejected",
+			},
+		},
+		{
+			name: "accepted claim is rejected",
+			old:  binding(exported, withState(sheriffs, apisv1alpha1.ClaimAccepted)),
+			obj:  binding(exported, withState(sheriffs, apisv1alpha1.ClaimRejected)),
+			wantEvents: []string{
+				"Warning PermissionClaimRejected Permission claim for sheriffs.wild.wild.west (identityHash abc) changed from Accepted to Rejected",
+			},
+		},
+		{
+			name: "accepted claim is removed from the binding",
+			old:  binding(exported, withState(configMaps, apisv1alpha1.ClaimAccepted)),
+			obj:  binding(exported),
+			wantEvents: []string{
+				"Normal PermissionClaimPending Permission claim for configmaps changed from Accepted to Pending",
+			},
+		},
+		{
+			name: "claim removed from the export is not reported",
+			old:  binding(exported),
+			obj:  binding([]apisv1alpha1.PermissionClaim{configMaps}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			recordPermissionClaimEvents(recorder, tt.old, tt.obj)
+			close(recorder.Events)
+
+			var got []string
+			for event := range recorder.Events {
+				got = append(got, event)
+			}
+			require.Equal(t, tt.wantEvents, got)
+		})
+	}
+}
+
+func TestRecordAPIExportMovedEvent(t *testing.T) {
+	binding := func(clusterName string) *apisv1alpha1.APIBinding {
+		return &apisv1alpha1.APIBinding{
+			Spec: apisv1alpha1.APIBindingSpec{
+				Reference: apisv1alpha1.BindingReference{
+					Export: &apisv1alpha1.ExportBindingReference{Path: "root:org:provider", Name: "today-cowboys"},
+				},
+			},
+			Status: apisv1alpha1.APIBindingStatus{APIExportClusterName: clusterName},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		old, obj   *apisv1alpha1.APIBinding
+		wantEvents []string
+	}{
+		{
+			name: "first resolution",
+			old:  binding(""),
+			obj:  binding("provider-a"),
+		},
+		{
+			name: "no change",
+			old:  binding("provider-a"),
+			obj:  binding("provider-a"),
+		},
+		{
+			name: "moved",
+			old:  binding("provider-a"),
+			obj:  binding("provider-b"),
+			wantEvents: []string{
+				"Normal APIExportMoved APIExport today-cowboys moved from logical cluster provider-a to provider-b",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			recordAPIExportMovedEvent(recorder, tt.old, tt.obj)
+			close(recorder.Events)
+
+			var got []string
+			for event := range recorder.Events {
+				got = append(got, event)
+			}
+			require.Equal(t, tt.wantEvents, got)
+		})
+	}
+}
+
+func TestRecordAPIExportIdentityRotatedEvent(t *testing.T) {
+	binding := func(identityHashes ...string) *apisv1alpha1.APIBinding {
+		b := &apisv1alpha1.APIBinding{
+			Spec: apisv1alpha1.APIBindingSpec{
+				Reference: apisv1alpha1.BindingReference{
+					Export: &apisv1alpha1.ExportBindingReference{Path: "root:org:provider", Name: "today-cowboys"},
+				},
+			},
+		}
+		for i, hash := range identityHashes {
+			b.Status.BoundResources = append(b.Status.BoundResources, apisv1alpha1.BoundAPIResource{
+				Group:    "wildwest.dev",
+				Resource: []string{"cowboys", "sheriffs"}[i],
+				Schema:   apisv1alpha1.BoundAPIResourceSchema{IdentityHash: hash},
+			})
+		}
+		return b
+	}
+
+	tests := []struct {
+		name       string
+		old, obj   *apisv1alpha1.APIBinding
+		wantEvents []string
+	}{
+		{
+			name: "first binding",
+			old:  binding(),
+			obj:  binding("a", "a"),
+		},
+		{
+			name: "no change",
+			old:  binding("a", "a"),
+			obj:  binding("a", "a"),
+		},
+		{
+			name: "rotated",
+			old:  binding("a", "a"),
+			obj:  binding("b", "b"),
+			wantEvents: []string{
+				"Warning APIExportIdentityRotated APIExport today-cowboys rotated its identity from a to b, objects created with the previous identity are not served anymore",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			recordAPIExportIdentityRotatedEvent(recorder, tt.old, tt.obj)
+			close(recorder.Events)
+
+			var got []string
+			for event := range recorder.Events {
+				got = append(got, event)
+			}
+			require.Equal(t, tt.wantEvents, got)
+		})
+	}
+}
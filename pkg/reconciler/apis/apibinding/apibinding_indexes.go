@@ -21,8 +21,12 @@ import (
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/client"
+	"github.com/kcp-dev/kcp/pkg/indexers"
 )
 
 const indexAPIExportsByAPIResourceSchema = "apiExportsByAPIResourceSchema"
@@ -41,3 +45,60 @@ func indexAPIExportsByAPIResourceSchemasFunc(obj interface{}) ([]string, error)
 
 	return ret, nil
 }
+
+// apiExportByPath returns the APIExport with the given name in the workspace with the given path, looking
+// at the local shard first, and at the cache server otherwise. The path is resolved on every call, such that
+// a binding follows its APIExport when the workspace of the provider is served by another shard.
+//
+// While a workspace moves, the APIExport can briefly be found in both the old and the new logical cluster.
+// APIExports that are being deleted are skipped then.
+func apiExportByPath(local, global cache.Indexer, path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
+	key := path.Join(name).String()
+
+	localExports, err := indexers.ByIndex[*apisv1alpha1.APIExport](local, indexers.ByLogicalClusterPathAndName, key)
+	if err != nil {
+		return nil, err
+	}
+	if live := withoutDeleted(localExports); len(live) == 1 {
+		// quick happy path - found it locally
+		return live[0], nil
+	}
+
+	globalExports, err := indexers.ByIndex[*apisv1alpha1.APIExport](global, indexers.ByLogicalClusterPathAndName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// the APIExports of the local shard are replicated to the cache server too. Prefer the local copies.
+	candidates := localExports
+	seen := map[logicalcluster.Name]bool{}
+	for _, export := range localExports {
+		seen[logicalcluster.From(export)] = true
+	}
+	for _, export := range globalExports {
+		if !seen[logicalcluster.From(export)] {
+			candidates = append(candidates, export)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiexports"), key)
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	if live := withoutDeleted(candidates); len(live) == 1 {
+		return live[0], nil
+	}
+	return nil, fmt.Errorf("multiple %s found for %s", apisv1alpha1.Resource("apiexports"), key)
+}
+
+func withoutDeleted(exports []*apisv1alpha1.APIExport) []*apisv1alpha1.APIExport {
+	live := make([]*apisv1alpha1.APIExport, 0, len(exports))
+	for _, export := range exports {
+		if export.DeletionTimestamp.IsZero() {
+			live = append(live, export)
+		}
+	}
+	return live
+}
@@ -19,13 +19,20 @@ package apibinding
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
 	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
 
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/core"
 	"github.com/kcp-dev/kcp/pkg/client"
+	"github.com/kcp-dev/kcp/pkg/indexers"
 )
 
 func TestIndexAPIExportByAPIResourceSchemas(t *testing.T) {
@@ -72,3 +79,82 @@ func TestIndexAPIExportByAPIResourceSchemas(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIExportByPath(t *testing.T) {
+	export := func(clusterName string, deleting bool) *apisv1alpha1.APIExport {
+		e := &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "today-cowboys",
+				Annotations: map[string]string{
+					logicalcluster.AnnotationKey:         clusterName,
+					core.LogicalClusterPathAnnotationKey: "root:org:provider",
+				},
+			},
+		}
+		if deleting {
+			now := metav1.NewTime(time.Now())
+			e.DeletionTimestamp = &now
+		}
+		return e
+	}
+	newIndexer := func(exports ...*apisv1alpha1.APIExport) cache.Indexer {
+		indexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{
+			indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+		})
+		for _, e := range exports {
+			require.NoError(t, indexer.Add(e))
+		}
+		return indexer
+	}
+
+	tests := map[string]struct {
+		local, global   []*apisv1alpha1.APIExport
+		wantClusterName string
+		wantNotFound    bool
+		wantErr         bool
+	}{
+		"not found": {
+			wantNotFound: true,
+		},
+		"local": {
+			local:           []*apisv1alpha1.APIExport{export("provider-a", false)},
+			global:          []*apisv1alpha1.APIExport{export("provider-a", false)},
+			wantClusterName: "provider-a",
+		},
+		"only in the cache server": {
+			global:          []*apisv1alpha1.APIExport{export("provider-b", false)},
+			wantClusterName: "provider-b",
+		},
+		"moved away from the local shard": {
+			local:           []*apisv1alpha1.APIExport{export("provider-a", true)},
+			global:          []*apisv1alpha1.APIExport{export("provider-a", true), export("provider-b", false)},
+			wantClusterName: "provider-b",
+		},
+		"moved between other shards": {
+			global:          []*apisv1alpha1.APIExport{export("provider-a", true), export("provider-b", false)},
+			wantClusterName: "provider-b",
+		},
+		"only deleting": {
+			global:          []*apisv1alpha1.APIExport{export("provider-a", true)},
+			wantClusterName: "provider-a",
+		},
+		"ambiguous": {
+			global:  []*apisv1alpha1.APIExport{export("provider-a", false), export("provider-b", false)},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := apiExportByPath(newIndexer(tt.local...), newIndexer(tt.global...), logicalcluster.NewPath("root:org:provider"), "today-cowboys")
+			switch {
+			case tt.wantNotFound:
+				require.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+			case tt.wantErr:
+				require.Error(t, err)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tt.wantClusterName, logicalcluster.From(got).String())
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const (
+	reconcileOutcomeReady         = "ready"
+	reconcileOutcomeConflict      = "conflict"
+	reconcileOutcomeSchemaMissing = "schema-missing"
+	reconcileOutcomeCRDOverlap    = "crd-overlap"
+)
+
+func init() {
+	legacyregistry.MustRegister(reconcileOutcomes)
+}
+
+var (
+	reconcileOutcomes = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "apibinding_reconcile_outcomes_total",
+			Help:           "Number of APIBinding reconciliations by outcome (ready, conflict, schema-missing, crd-overlap).",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"outcome"},
+	)
+)
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
@@ -34,7 +35,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
+	"github.com/kcp-dev/kcp/pkg/apis/apis"
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/core"
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
 	"github.com/kcp-dev/kcp/pkg/logging"
@@ -53,6 +56,8 @@ type reconciler interface {
 
 func (c *controller) reconcile(ctx context.Context, apiBinding *apisv1alpha1.APIBinding) (bool, error) {
 	reconcilers := []reconciler{
+		&claimExpiryReconciler{controller: c},
+		&conflictReconciler{controller: c},
 		&phaseReconciler{
 			newReconciler:     &newReconciler{controller: c},
 			bindingReconciler: &bindingReconciler{controller: c},
@@ -97,6 +102,104 @@ func (r *summaryReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 	return reconcileStatusContinue, nil
 }
 
+// claimExpiryReconciler rejects accepted permission claims whose expiresAt has passed, which revokes the
+// access granted by them, and requeues the APIBinding for the next expiry. As this changes the spec,
+// it stops the reconciliation such that the status is updated in the next iteration.
+type claimExpiryReconciler struct {
+	*controller
+}
+
+func (r *claimExpiryReconciler) reconcile(ctx context.Context, apiBinding *apisv1alpha1.APIBinding) (reconcileStatus, error) {
+	logger := klog.FromContext(ctx)
+	now := time.Now()
+
+	var expired bool
+	var nextExpiry *metav1.Time
+	for i := range apiBinding.Spec.PermissionClaims {
+		claim := &apiBinding.Spec.PermissionClaims[i]
+		if claim.State != apisv1alpha1.ClaimAccepted || claim.ExpiresAt == nil {
+			continue
+		}
+		if !claim.ExpiresAt.Time.After(now) {
+			logger.V(2).Info("permission claim expired", "group", claim.Group, "resource", claim.Resource, "identityHash", claim.IdentityHash, "expiresAt", claim.ExpiresAt)
+			claim.State = apisv1alpha1.ClaimRejected
+			claim.ExpiresAt = nil
+			expired = true
+			continue
+		}
+		if nextExpiry == nil || claim.ExpiresAt.Before(nextExpiry) {
+			nextExpiry = claim.ExpiresAt
+		}
+	}
+
+	if nextExpiry != nil {
+		r.enqueueAfter(apiBinding, nextExpiry.Sub(now))
+	}
+	if expired {
+		return reconcileStatusStopAndRequeue, nil
+	}
+	return reconcileStatusContinue, nil
+}
+
+// conflictReconciler reports other APIBindings in the same workspace binding APIs whose names conflict with the
+// APIs of the APIBinding, on both APIBindings. Errors getting the APIExport or its schemas are left to the
+// bindingReconciler to report.
+type conflictReconciler struct {
+	*controller
+}
+
+func (r *conflictReconciler) reconcile(ctx context.Context, apiBinding *apisv1alpha1.APIBinding) (reconcileStatus, error) {
+	if apiBinding.Status.Phase == "" || apiBinding.Spec.Reference.Export == nil {
+		return reconcileStatusContinue, nil
+	}
+	apiExportPath := logicalcluster.NewPath(apiBinding.Spec.Reference.Export.Path)
+	if apiExportPath.Empty() {
+		apiExportPath = logicalcluster.From(apiBinding).Path()
+	}
+	apiExport, err := r.getAPIExport(apiExportPath, apiBinding.Spec.Reference.Export.Name)
+	if err != nil {
+		return reconcileStatusContinue, nil
+	}
+
+	schemas := make([]*apisv1alpha1.APIResourceSchema, 0, len(apiExport.Spec.LatestResourceSchemas))
+	for _, schemaName := range apiExport.Spec.LatestResourceSchemas {
+		schema, err := r.getAPIResourceSchema(logicalcluster.From(apiExport), schemaName)
+		if err != nil {
+			return reconcileStatusContinue, nil
+		}
+		schemas = append(schemas, schema)
+	}
+
+	checker := &conflictChecker{
+		listAPIBindings:      r.listAPIBindings,
+		getAPIExport:         r.getAPIExport,
+		getAPIResourceSchema: r.getAPIResourceSchema,
+	}
+	conflicts, err := checker.conflictingBindings(apiBinding, schemas)
+	if err != nil {
+		return reconcileStatusContinue, fmt.Errorf("error checking for conflicting APIBindings of APIBinding %s|%s: %w", logicalcluster.From(apiBinding), apiBinding.Name, err)
+	}
+	if len(conflicts) == 0 {
+		conditions.MarkTrue(apiBinding, apisv1alpha1.NoConflictingBindings)
+		return reconcileStatusContinue, nil
+	}
+
+	descriptions := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		descriptions = append(descriptions, conflict.String())
+	}
+	conditions.MarkFalse(
+		apiBinding,
+		apisv1alpha1.NoConflictingBindings,
+		apisv1alpha1.NamingConflictsReason,
+		conditionsv1alpha1.ConditionSeverityError,
+		"Naming conflicts with %s",
+		strings.Join(descriptions, "; "),
+	)
+
+	return reconcileStatusContinue, nil
+}
+
 type phaseReconciler struct {
 	newReconciler     reconciler
 	bindingReconciler reconciler
@@ -210,6 +313,8 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 	apiBinding.Status.APIExportClusterName = clusterName.String()
 
 	var needToWaitForRequeueWhenEstablished []string
+	var deprecatedVersionWarnings []string
+	var skippedResources []string
 
 	// Process all APIResourceSchemas
 	for _, schemaName := range apiExport.Spec.LatestResourceSchemas {
@@ -229,6 +334,7 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 			)
 
 			if apierrors.IsNotFound(err) {
+				reconcileOutcomes.WithLabelValues(reconcileOutcomeSchemaMissing).Inc()
 				return reconcileStatusContinue, nil
 			}
 
@@ -247,6 +353,18 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 		}
 
 		if err := checker.checkForConflicts(schema, apiBinding); err != nil {
+			var overlapErr *crdOverlapError
+			if errors.As(err, &overlapErr) && apiBinding.Spec.ConflictResolution == apisv1alpha1.ConflictResolutionSkip {
+				logger.V(4).Info("skipping resource overlapping with CRD in the binding cluster", "crd", overlapErr.crdName)
+				skippedResources = append(skippedResources, fmt.Sprintf("%s (CustomResourceDefinition %q)", schema.Spec.Names.Plural+"."+schema.Spec.Group, overlapErr.crdName))
+				continue
+			}
+			if overlapErr != nil {
+				reconcileOutcomes.WithLabelValues(reconcileOutcomeCRDOverlap).Inc()
+			} else {
+				reconcileOutcomes.WithLabelValues(reconcileOutcomeConflict).Inc()
+			}
+
 			conditions.MarkFalse(
 				apiBinding,
 				apisv1alpha1.BindingUpToDate,
@@ -294,7 +412,7 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 		}
 
 		// Try to get the bound CRD
-		existingCRD, err := r.getCRD(SystemBoundCRDsClusterName, boundCRDName(schema))
+		existingCRD, err := r.getCRD(apis.SystemBoundCRDsClusterName, boundCRDName(schema))
 		if err != nil && !apierrors.IsNotFound(err) {
 			conditions.MarkFalse(
 				apiBinding,
@@ -306,7 +424,7 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 
 			return reconcileStatusContinue, fmt.Errorf(
 				"error getting CRD %s|%s for APIBinding %s|%s, APIExport %s|%s, APIResourceSchema %s|%s: %w",
-				SystemBoundCRDsClusterName, boundCRDName(schema),
+				apis.SystemBoundCRDsClusterName, boundCRDName(schema),
 				bindingClusterName, apiBinding.Name,
 				apiExportPath, apiExport.Name,
 				apiExportPath, schemaName,
@@ -354,7 +472,7 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 
 			// Create bound CRD
 			logger.V(2).Info("creating CRD")
-			if _, err := r.createCRD(ctx, SystemBoundCRDsClusterName.Path(), crd); err != nil {
+			if _, err := r.createCRD(ctx, apis.SystemBoundCRDsClusterName.Path(), crd); err != nil {
 				schemaClusterName := logicalcluster.From(schema)
 				if apierrors.IsInvalid(err) {
 					status := apierrors.APIStatus(nil)
@@ -449,10 +567,43 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 		if !found {
 			apiBinding.Status.BoundResources = append(apiBinding.Status.BoundResources, newBoundResource)
 		}
+
+		if warning := deprecatedStorageVersionsWarning(schema, sortedStorageVersions); warning != "" {
+			deprecatedVersionWarnings = append(deprecatedVersionWarnings, warning)
+		}
 	}
 
 	conditions.MarkTrue(apiBinding, apisv1alpha1.APIExportValid)
 
+	switch {
+	case apiBinding.Spec.ConflictResolution != apisv1alpha1.ConflictResolutionSkip:
+		conditions.Delete(apiBinding, apisv1alpha1.NoResourcesSkipped)
+	case len(skippedResources) > 0:
+		conditions.MarkFalse(
+			apiBinding,
+			apisv1alpha1.NoResourcesSkipped,
+			apisv1alpha1.ResourcesSkippedReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"Skipped resources overlapping with CustomResourceDefinitions in the workspace: %s",
+			strings.Join(skippedResources, ", "),
+		)
+	default:
+		conditions.MarkTrue(apiBinding, apisv1alpha1.NoResourcesSkipped)
+	}
+
+	if len(deprecatedVersionWarnings) > 0 {
+		conditions.MarkFalse(
+			apiBinding,
+			apisv1alpha1.BoundVersionsNotDeprecated,
+			apisv1alpha1.DeprecatedVersionsBoundReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"%s",
+			strings.Join(deprecatedVersionWarnings, "; "),
+		)
+	} else {
+		conditions.MarkTrue(apiBinding, apisv1alpha1.BoundVersionsNotDeprecated)
+	}
+
 	if len(needToWaitForRequeueWhenEstablished) > 0 {
 		sort.Strings(needToWaitForRequeueWhenEstablished)
 
@@ -478,6 +629,7 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 		conditions.MarkTrue(apiBinding, apisv1alpha1.InitialBindingCompleted)
 		conditions.MarkTrue(apiBinding, apisv1alpha1.BindingUpToDate)
 		apiBinding.Status.Phase = apisv1alpha1.APIBindingPhaseBound
+		reconcileOutcomes.WithLabelValues(reconcileOutcomeReady).Inc()
 	}
 
 	return reconcileStatusContinue, nil
@@ -492,7 +644,7 @@ func generateCRD(schema *apisv1alpha1.APIResourceSchema) (*apiextensionsv1.Custo
 		ObjectMeta: metav1.ObjectMeta{
 			Name: boundCRDName(schema),
 			Annotations: map[string]string{
-				logicalcluster.AnnotationKey:            SystemBoundCRDsClusterName.String(),
+				logicalcluster.AnnotationKey:            apis.SystemBoundCRDsClusterName.String(),
 				apisv1alpha1.AnnotationBoundCRDKey:      "",
 				apisv1alpha1.AnnotationSchemaClusterKey: logicalcluster.From(schema).String(),
 				apisv1alpha1.AnnotationSchemaNameKey:    schema.Name,
@@ -514,6 +666,11 @@ func generateCRD(schema *apisv1alpha1.APIResourceSchema) (*apiextensionsv1.Custo
 		crd.Annotations[apiextensionsv1.KubeAPIApprovedAnnotation] = value
 	}
 
+	// Propagate the deletion order annotation, such that logical cluster deletion can find it on the bound CRD.
+	if value, found := schema.Annotations[core.DeletionOrderAnnotationKey]; found {
+		crd.Annotations[core.DeletionOrderAnnotationKey] = value
+	}
+
 	for _, version := range schema.Spec.Versions {
 		crdVersion := apiextensionsv1.CustomResourceDefinitionVersion{
 			Name:                     version.Name,
@@ -536,3 +693,39 @@ func generateCRD(schema *apisv1alpha1.APIResourceSchema) (*apiextensionsv1.Custo
 
 	return crd, nil
 }
+
+// deprecatedStorageVersionsWarning returns a message guiding the migration away from the deprecated versions of
+// schema that objects of the bound resource are stored in, or an empty string if none of them is deprecated.
+func deprecatedStorageVersionsWarning(schema *apisv1alpha1.APIResourceSchema, storageVersions []string) string {
+	stored := sets.NewString(storageVersions...)
+
+	var deprecated []string
+	var storageVersion *apisv1alpha1.APIResourceVersion
+	for i := range schema.Spec.Versions {
+		version := &schema.Spec.Versions[i]
+		if version.Storage {
+			storageVersion = version
+		}
+		if !version.Deprecated || !stored.Has(version.Name) {
+			continue
+		}
+		if version.DeprecationWarning != nil && *version.DeprecationWarning != "" {
+			deprecated = append(deprecated, fmt.Sprintf("%s (%s)", version.Name, *version.DeprecationWarning))
+		} else {
+			deprecated = append(deprecated, version.Name)
+		}
+	}
+	if len(deprecated) == 0 {
+		return ""
+	}
+
+	gr := schema.Spec.Names.Plural
+	if schema.Spec.Group != "" {
+		gr += "." + schema.Spec.Group
+	}
+	msg := fmt.Sprintf("%s is stored in deprecated version(s) %s", gr, strings.Join(deprecated, ", "))
+	if storageVersion != nil && !storageVersion.Deprecated {
+		msg += fmt.Sprintf(", migrate objects to %s", storageVersion.Name)
+	}
+	return msg
+}
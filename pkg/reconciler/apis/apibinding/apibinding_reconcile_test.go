@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/stretchr/testify/require"
@@ -31,12 +32,17 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/component-base/metrics/testutil"
 	"k8s.io/utils/pointer"
 
+	"github.com/kcp-dev/kcp/pkg/apis/apis"
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/core"
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	"github.com/kcp-dev/kcp/pkg/indexers"
 )
 
 // requireConditionMatches looks for a condition matching c in g. Only fields that are set in c are compared (Type is
@@ -83,6 +89,8 @@ var (
 
 	invalidSchema = binding.DeepCopy().WithExportReference(logicalcluster.NewPath("org:some-workspace"), "invalid-schema")
 
+	deprecatedVersion = binding.DeepCopy().WithExportReference(logicalcluster.NewPath("org:some-workspace"), "deprecated-version")
+
 	bound = unbound.DeepCopy().
 		WithPhase(apisv1alpha1.APIBindingPhaseBound).
 		WithBoundResources(
@@ -137,6 +145,45 @@ var (
 		},
 	}
 
+	deprecatedWidgetsAPIResourceSchema = &apisv1alpha1.APIResourceSchema{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "org-some-workspace",
+			},
+			Name: "deprecated.widgets.kcp.io",
+			UID:  "deprecatedwidgetsuid",
+		},
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Group: "kcp.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   "widgets",
+				Singular: "widget",
+				Kind:     "Widget",
+				ListKind: "WidgetList",
+			},
+			Scope: "Namespace",
+			Versions: []apisv1alpha1.APIResourceVersion{
+				{
+					Name:               "v1",
+					Served:             true,
+					Deprecated:         true,
+					DeprecationWarning: pointer.StringPtr("kcp.io/v1 Widget is deprecated"),
+					Schema: runtime.RawExtension{
+						Raw: []byte(`{"description":"foo","type":"object"}`),
+					},
+				},
+				{
+					Name:    "v2",
+					Served:  true,
+					Storage: true,
+					Schema: runtime.RawExtension{
+						Raw: []byte(`{"description":"foo","type":"object"}`),
+					},
+				},
+			},
+		},
+	}
+
 	someOtherWidgetsAPIResourceSchema = &apisv1alpha1.APIResourceSchema{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "another.widgets.kcp.io",
@@ -183,6 +230,7 @@ func TestReconcileBinding(t *testing.T) {
 		getAPIExportError                       error
 		getAPIResourceSchemaError               error
 		existingAPIBindings                     []*apisv1alpha1.APIBinding
+		bindingClusterCRDs                      []*apiextensionsv1.CustomResourceDefinition
 		crdExists                               bool
 		getCRDError                             error
 		wantCreateCRD                           bool
@@ -206,6 +254,10 @@ func TestReconcileBinding(t *testing.T) {
 		wantPhaseBound                          bool
 		wantBoundResources                      []apisv1alpha1.BoundAPIResource
 		wantNamingConflict                      bool
+		wantCRDOverlap                          bool
+		wantResourcesSkipped                    bool
+		wantDeprecatedVersionsBound             bool
+		wantOutcome                             string
 		crdEstablished                          bool
 		crdStorageVersions                      []string
 	}{
@@ -229,6 +281,7 @@ func TestReconcileBinding(t *testing.T) {
 			getAPIResourceSchemaError:  apierrors.NewNotFound(schema.GroupResource{}, "foo"),
 			wantAPIExportInternalError: true,
 			wantError:                  false,
+			wantOutcome:                reconcileOutcomeSchemaMissing,
 		},
 		"APIResourceSchema get error - random error": {
 			apiBinding:                 binding.Build(),
@@ -291,10 +344,53 @@ func TestReconcileBinding(t *testing.T) {
 				conflicting.Build(),
 			},
 			wantNamingConflict: true,
+			wantOutcome:        reconcileOutcomeConflict,
 			wantError:          true,
 			wantRequeue:        true,
 			wantNoReady:        true,
 		},
+		"create CRD - overlaps with CRD in binding cluster": {
+			apiBinding: binding.Build(),
+			bindingClusterCRDs: []*apiextensionsv1.CustomResourceDefinition{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "widgets.kcp.io",
+					},
+					Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+						Group: "kcp.io",
+						Names: apiextensionsv1.CustomResourceDefinitionNames{
+							Plural: "widgets",
+						},
+					},
+				},
+			},
+			wantCRDOverlap: true,
+			wantOutcome:    reconcileOutcomeCRDOverlap,
+			wantError:      true,
+			wantRequeue:    true,
+			wantNoReady:    true,
+		},
+		"create CRD - overlaps with CRD in binding cluster - skipped": {
+			apiBinding: binding.DeepCopy().WithConflictResolution(apisv1alpha1.ConflictResolutionSkip).Build(),
+			bindingClusterCRDs: []*apiextensionsv1.CustomResourceDefinition{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "widgets.kcp.io",
+					},
+					Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+						Group: "kcp.io",
+						Names: apiextensionsv1.CustomResourceDefinitionNames{
+							Plural: "widgets",
+						},
+					},
+				},
+			},
+			wantResourcesSkipped:       true,
+			wantAPIExportValid:         true,
+			wantReady:                  true,
+			wantInitialBindingComplete: true,
+			wantPhaseBound:             true,
+		},
 		"bind existing CRD - other bindings - conflicts": {
 			apiBinding: binding.Build(),
 			crdExists:  true,
@@ -341,6 +437,7 @@ func TestReconcileBinding(t *testing.T) {
 			},
 			wantPhaseBound:             true,
 			wantInitialBindingComplete: true,
+			wantOutcome:                reconcileOutcomeReady,
 		},
 		"Ensure merging storage versions works": {
 			apiBinding:         rebinding.Build(),
@@ -366,6 +463,31 @@ func TestReconcileBinding(t *testing.T) {
 			wantPhaseBound:             true,
 			wantInitialBindingComplete: true,
 		},
+		"Binding to a deprecated version warns": {
+			apiBinding:         deprecatedVersion.Build(),
+			getCRDError:        nil,
+			crdExists:          true,
+			crdEstablished:     true,
+			crdStorageVersions: []string{"v1", "v2"},
+			wantAPIExportValid: true,
+			wantReady:          true,
+			wantBoundAPIExport: true,
+			wantBoundResources: []apisv1alpha1.BoundAPIResource{
+				{
+					Group:    "kcp.io",
+					Resource: "widgets",
+					Schema: apisv1alpha1.BoundAPIResourceSchema{
+						Name:         "deprecated.widgets.kcp.io",
+						UID:          "deprecatedwidgetsuid",
+						IdentityHash: "hash4",
+					},
+					StorageVersions: []string{"v1", "v2"},
+				},
+			},
+			wantPhaseBound:              true,
+			wantInitialBindingComplete:  true,
+			wantDeprecatedVersionsBound: true,
+		},
 	}
 
 	for testName, tc := range tests {
@@ -409,6 +531,18 @@ func TestReconcileBinding(t *testing.T) {
 					},
 					Status: apisv1alpha1.APIExportStatus{IdentityHash: "hash3"},
 				},
+				"deprecated-version": {
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							logicalcluster.AnnotationKey: "org-some-workspace",
+						},
+						Name: "deprecated-version",
+					},
+					Spec: apisv1alpha1.APIExportSpec{
+						LatestResourceSchemas: []string{"deprecated.widgets.kcp.io"},
+					},
+					Status: apisv1alpha1.APIExportStatus{IdentityHash: "hash4"},
+				},
 				"no-identity-hash": {
 					ObjectMeta: metav1.ObjectMeta{
 						Annotations: map[string]string{
@@ -440,8 +574,9 @@ func TestReconcileBinding(t *testing.T) {
 						},
 					},
 				},
-				"today.widgets.kcp.io":   todayWidgetsAPIResourceSchema,
-				"another.widgets.kcp.io": someOtherWidgetsAPIResourceSchema,
+				"today.widgets.kcp.io":      todayWidgetsAPIResourceSchema,
+				"another.widgets.kcp.io":    someOtherWidgetsAPIResourceSchema,
+				"deprecated.widgets.kcp.io": deprecatedWidgetsAPIResourceSchema,
 			}
 
 			c := &controller{
@@ -466,8 +601,11 @@ func TestReconcileBinding(t *testing.T) {
 
 					return schema, nil
 				},
+				getAPIConversion: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIConversion, error) {
+					return &apisv1alpha1.APIConversion{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+				},
 				getCRD: func(clusterName logicalcluster.Name, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
-					require.Equal(t, SystemBoundCRDsClusterName, clusterName)
+					require.Equal(t, apis.SystemBoundCRDsClusterName, clusterName)
 
 					if tc.getCRDError != nil {
 						return nil, tc.getCRDError
@@ -504,7 +642,7 @@ func TestReconcileBinding(t *testing.T) {
 					return crd, nil
 				},
 				listCRDs: func(clusterName logicalcluster.Name) ([]*apiextensionsv1.CustomResourceDefinition, error) {
-					return nil, nil
+					return tc.bindingClusterCRDs, nil
 				},
 				createCRD: func(ctx context.Context, clusterName logicalcluster.Path, crd *apiextensionsv1.CustomResourceDefinition) (*apiextensionsv1.CustomResourceDefinition, error) {
 					createCRDCalled = true
@@ -513,6 +651,13 @@ func TestReconcileBinding(t *testing.T) {
 				deletedCRDTracker: &lockedStringSet{},
 			}
 
+			var outcomesBefore float64
+			if tc.wantOutcome != "" {
+				var err error
+				outcomesBefore, err = testutil.GetCounterMetricValue(reconcileOutcomes.WithLabelValues(tc.wantOutcome))
+				require.NoError(t, err)
+			}
+
 			requeue, err := c.reconcile(context.Background(), tc.apiBinding)
 
 			if tc.wantError {
@@ -610,6 +755,46 @@ func TestReconcileBinding(t *testing.T) {
 				})
 			}
 
+			if tc.wantCRDOverlap {
+				requireConditionMatches(t, tc.apiBinding, &conditionsv1alpha1.Condition{
+					Type:     apisv1alpha1.InitialBindingCompleted,
+					Status:   corev1.ConditionFalse,
+					Severity: conditionsv1alpha1.ConditionSeverityError,
+					Reason:   apisv1alpha1.NamingConflictsReason,
+					Message:  "because it overlaps with \"widgets.kcp.io\" CustomResourceDefinition",
+				})
+			}
+
+			if tc.wantResourcesSkipped {
+				requireConditionMatches(t, tc.apiBinding, &conditionsv1alpha1.Condition{
+					Type:     apisv1alpha1.NoResourcesSkipped,
+					Status:   corev1.ConditionFalse,
+					Severity: conditionsv1alpha1.ConditionSeverityWarning,
+					Reason:   apisv1alpha1.ResourcesSkippedReason,
+					Message:  "Skipped resources overlapping with CustomResourceDefinitions in the workspace: widgets.kcp.io (CustomResourceDefinition \"widgets.kcp.io\")",
+				})
+			} else {
+				require.False(t, conditions.Has(tc.apiBinding, apisv1alpha1.NoResourcesSkipped), "unexpected NoResourcesSkipped condition")
+			}
+
+			if tc.wantDeprecatedVersionsBound {
+				requireConditionMatches(t, tc.apiBinding, &conditionsv1alpha1.Condition{
+					Type:     apisv1alpha1.BoundVersionsNotDeprecated,
+					Status:   corev1.ConditionFalse,
+					Severity: conditionsv1alpha1.ConditionSeverityWarning,
+					Reason:   apisv1alpha1.DeprecatedVersionsBoundReason,
+					Message:  "widgets.kcp.io is stored in deprecated version(s) v1 (kcp.io/v1 Widget is deprecated), migrate objects to v2",
+				})
+			} else if tc.wantPhaseBound {
+				requireConditionMatches(t, tc.apiBinding, conditions.TrueCondition(apisv1alpha1.BoundVersionsNotDeprecated))
+			}
+
+			if tc.wantOutcome != "" {
+				outcomesAfter, err := testutil.GetCounterMetricValue(reconcileOutcomes.WithLabelValues(tc.wantOutcome))
+				require.NoError(t, err)
+				require.Equal(t, outcomesBefore+1, outcomesAfter, "expected %q outcome to be counted", tc.wantOutcome)
+			}
+
 			if tc.wantInitialBindingCompleteInternalError {
 				requireConditionMatches(t, tc.apiBinding, &conditionsv1alpha1.Condition{
 					Type:     apisv1alpha1.InitialBindingCompleted,
@@ -631,6 +816,92 @@ func TestReconcileBinding(t *testing.T) {
 	}
 }
 
+func TestReconcileClaimExpiry(t *testing.T) {
+	claim := func(resource string, state apisv1alpha1.AcceptablePermissionClaimState, expiresAt *metav1.Time) apisv1alpha1.AcceptablePermissionClaim {
+		return apisv1alpha1.AcceptablePermissionClaim{
+			PermissionClaim: apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: resource}, All: true},
+			State:           state,
+			ExpiresAt:       expiresAt,
+		}
+	}
+	past := metav1.NewTime(time.Now().Add(-time.Minute))
+	soon := metav1.NewTime(time.Now().Add(time.Hour))
+	later := metav1.NewTime(time.Now().Add(2 * time.Hour))
+
+	tests := map[string]struct {
+		claims           []apisv1alpha1.AcceptablePermissionClaim
+		wantClaims       []apisv1alpha1.AcceptablePermissionClaim
+		wantRequeue      bool
+		wantEnqueueAfter time.Duration
+	}{
+		"claims without expiry are kept": {
+			claims:     []apisv1alpha1.AcceptablePermissionClaim{claim("configmaps", apisv1alpha1.ClaimAccepted, nil)},
+			wantClaims: []apisv1alpha1.AcceptablePermissionClaim{claim("configmaps", apisv1alpha1.ClaimAccepted, nil)},
+		},
+		"expired claims are rejected": {
+			claims: []apisv1alpha1.AcceptablePermissionClaim{
+				claim("configmaps", apisv1alpha1.ClaimAccepted, &past),
+				claim("secrets", apisv1alpha1.ClaimAccepted, nil),
+			},
+			wantClaims: []apisv1alpha1.AcceptablePermissionClaim{
+				claim("configmaps", apisv1alpha1.ClaimRejected, nil),
+				claim("secrets", apisv1alpha1.ClaimAccepted, nil),
+			},
+			wantRequeue: true,
+		},
+		"binding is requeued at the next expiry": {
+			claims: []apisv1alpha1.AcceptablePermissionClaim{
+				claim("configmaps", apisv1alpha1.ClaimAccepted, &later),
+				claim("secrets", apisv1alpha1.ClaimAccepted, &soon),
+				claim("events", apisv1alpha1.ClaimAccepted, &past),
+			},
+			wantClaims: []apisv1alpha1.AcceptablePermissionClaim{
+				claim("configmaps", apisv1alpha1.ClaimAccepted, &later),
+				claim("secrets", apisv1alpha1.ClaimAccepted, &soon),
+				claim("events", apisv1alpha1.ClaimRejected, nil),
+			},
+			wantRequeue:      true,
+			wantEnqueueAfter: time.Hour,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiBinding := newBindingBuilder().
+				WithClusterName("org-some-workspace").
+				WithName("my-binding").
+				WithExportReference(logicalcluster.NewPath("org:some-workspace"), "some-export").
+				WithPhase(apisv1alpha1.APIBindingPhaseBound).
+				Build()
+			apiBinding.Spec.PermissionClaims = tc.claims
+
+			var enqueuedAfter time.Duration
+			c := &controller{
+				enqueueAfter: func(binding *apisv1alpha1.APIBinding, duration time.Duration) {
+					enqueuedAfter = duration
+				},
+			}
+
+			status, err := (&claimExpiryReconciler{controller: c}).reconcile(context.Background(), apiBinding)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantRequeue, status == reconcileStatusStopAndRequeue, "mismatched requeue")
+			require.Equal(t, tc.wantClaims, apiBinding.Spec.PermissionClaims)
+			if tc.wantEnqueueAfter == 0 {
+				require.Zero(t, enqueuedAfter, "unexpected enqueue")
+			} else {
+				require.InDelta(t, tc.wantEnqueueAfter, enqueuedAfter, float64(time.Minute))
+			}
+
+			t.Log("Access is only granted by the claims that are still accepted")
+			indexed, err := indexers.IndexAPIBindingByClusterAndAcceptedClaimedGroupResources(apiBinding)
+			require.NoError(t, err)
+			for _, claim := range tc.wantClaims {
+				key := indexers.ClusterAndGroupResourceValue(logicalcluster.From(apiBinding), schema.GroupResource{Group: claim.Group, Resource: claim.Resource})
+				require.Equal(t, claim.State == apisv1alpha1.ClaimAccepted, sets.NewString(indexed...).Has(key), "unexpected access for %s", claim.Resource)
+			}
+		})
+	}
+}
+
 func TestCRDFromAPIResourceSchema(t *testing.T) {
 	tests := map[string]struct {
 		schema  *apisv1alpha1.APIResourceSchema
@@ -641,7 +912,8 @@ func TestCRDFromAPIResourceSchema(t *testing.T) {
 			schema: &apisv1alpha1.APIResourceSchema{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						logicalcluster.AnnotationKey: "my-cluster",
+						logicalcluster.AnnotationKey:    "my-cluster",
+						core.DeletionOrderAnnotationKey: "1",
 					},
 					Name: "my-name",
 					UID:  types.UID("my-uuid"),
@@ -730,10 +1002,11 @@ func TestCRDFromAPIResourceSchema(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "my-uuid",
 					Annotations: map[string]string{
-						logicalcluster.AnnotationKey:            SystemBoundCRDsClusterName.String(),
+						logicalcluster.AnnotationKey:            apis.SystemBoundCRDsClusterName.String(),
 						apisv1alpha1.AnnotationBoundCRDKey:      "",
 						apisv1alpha1.AnnotationSchemaClusterKey: "my-cluster",
 						apisv1alpha1.AnnotationSchemaNameKey:    "my-name",
+						core.DeletionOrderAnnotationKey:         "1",
 					},
 				},
 				Spec: apiextensionsv1.CustomResourceDefinitionSpec{
@@ -894,6 +1167,11 @@ func (b *bindingBuilder) WithExportReference(path logicalcluster.Path, exportNam
 	return b
 }
 
+func (b *bindingBuilder) WithConflictResolution(resolution apisv1alpha1.ConflictResolutionType) *bindingBuilder {
+	b.Spec.ConflictResolution = resolution
+	return b
+}
+
 func (b *bindingBuilder) WithPhase(phase apisv1alpha1.APIBindingPhaseType) *bindingBuilder {
 	b.Status.Phase = phase
 	return b
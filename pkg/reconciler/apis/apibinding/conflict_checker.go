@@ -23,8 +23,10 @@ import (
 	"github.com/kcp-dev/logicalcluster/v3"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	"github.com/kcp-dev/kcp/pkg/apis/apis"
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
 )
 
@@ -90,7 +92,7 @@ func (ncc *conflictChecker) getBoundCRDs(apiBindingToExclude *apisv1alpha1.APIBi
 				continue
 			}
 
-			crd, err := ncc.getCRD(SystemBoundCRDsClusterName, string(schema.UID))
+			crd, err := ncc.getCRD(apis.SystemBoundCRDsClusterName, string(schema.UID))
 			if err != nil {
 				return err
 			}
@@ -112,20 +114,81 @@ func (ncc *conflictChecker) checkForConflicts(schema *apisv1alpha1.APIResourceSc
 	for _, boundCRD := range ncc.boundCRDs {
 		if foundConflict, details := namesConflict(boundCRD, schema); foundConflict {
 			conflict := ncc.crdToBinding[boundCRD.Name]
-			path := logicalcluster.NewPath(conflict.Spec.Reference.Export.Path)
-			var boundTo string
-			if path.Empty() {
-				boundTo = fmt.Sprintf("local APIExport %q", conflict.Spec.Reference.Export.Name)
-			} else {
-				boundTo = fmt.Sprintf("APIExport %s", path.Join(conflict.Spec.Reference.Export.Name))
-			}
-			return fmt.Errorf("naming conflict with APIBinding %q bound to %s, %s", conflict.Name, boundTo, details)
+			return fmt.Errorf("naming conflict with APIBinding %q bound to %s, %s", conflict.Name, exportDescription(conflict), details)
 		}
 	}
 
 	return ncc.gvrConflict(schema, apiBinding)
 }
 
+// bindingConflict is another APIBinding in the same logical cluster binding APIs whose names conflict with
+// those of an APIBinding.
+type bindingConflict struct {
+	binding *apisv1alpha1.APIBinding
+	details string
+}
+
+func (c bindingConflict) String() string {
+	return fmt.Sprintf("APIBinding %q for %s: %s", c.binding.Name, exportDescription(c.binding), c.details)
+}
+
+// conflictingBindings returns the other APIBindings in the logical cluster of the given APIBinding whose APIExports
+// have schemas with names conflicting with the given schemas. In contrast to checkForConflicts, this is independent of
+// which of the APIBindings got bound first, such that a conflict is reported on both APIBindings.
+func (ncc *conflictChecker) conflictingBindings(apiBinding *apisv1alpha1.APIBinding, schemas []*apisv1alpha1.APIResourceSchema) ([]bindingConflict, error) {
+	apiBindings, err := ncc.listAPIBindings(logicalcluster.From(apiBinding))
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []bindingConflict
+	for _, other := range apiBindings {
+		if other.Name == apiBinding.Name || other.Spec.Reference.Export == nil {
+			continue
+		}
+
+		path := logicalcluster.NewPath(other.Spec.Reference.Export.Path)
+		if path.Empty() {
+			path = logicalcluster.From(other).Path()
+		}
+		apiExport, err := ncc.getAPIExport(path, other.Spec.Reference.Export.Name)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+	schemas:
+		for _, schemaName := range apiExport.Spec.LatestResourceSchemas {
+			otherSchema, err := ncc.getAPIResourceSchema(logicalcluster.From(apiExport), schemaName)
+			if apierrors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			for _, schema := range schemas {
+				if foundConflict, details := schemaNamesConflict(otherSchema, schema); foundConflict {
+					conflicts = append(conflicts, bindingConflict{binding: other, details: details})
+					break schemas
+				}
+			}
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].binding.Name < conflicts[j].binding.Name })
+	return conflicts, nil
+}
+
+// exportDescription describes the APIExport the given APIBinding references.
+func exportDescription(apiBinding *apisv1alpha1.APIBinding) string {
+	path := logicalcluster.NewPath(apiBinding.Spec.Reference.Export.Path)
+	if path.Empty() {
+		return fmt.Sprintf("local APIExport %q", apiBinding.Spec.Reference.Export.Name)
+	}
+	return fmt.Sprintf("APIExport %s", path.Join(apiBinding.Spec.Reference.Export.Name))
+}
+
 func (ncc *conflictChecker) gvrConflict(schema *apisv1alpha1.APIResourceSchema, apiBinding *apisv1alpha1.APIBinding) error {
 	bindingClusterName := logicalcluster.From(apiBinding)
 	bindingClusterCRDs, err := ncc.listCRDs(bindingClusterName)
@@ -134,13 +197,38 @@ func (ncc *conflictChecker) gvrConflict(schema *apisv1alpha1.APIResourceSchema,
 	}
 	for _, bindingClusterCRD := range bindingClusterCRDs {
 		if bindingClusterCRD.Spec.Group == schema.Spec.Group && bindingClusterCRD.Spec.Names.Plural == schema.Spec.Names.Plural {
-			return fmt.Errorf("cannot create CustomResourceDefinition with %q group and %q resource because it overlaps with %q CustomResourceDefinition in %q logical cluster",
-				schema.Spec.Group, schema.Spec.Names.Plural, bindingClusterCRD.Name, bindingClusterName)
+			return &crdOverlapError{
+				group:       schema.Spec.Group,
+				resource:    schema.Spec.Names.Plural,
+				crdName:     bindingClusterCRD.Name,
+				clusterName: bindingClusterName,
+			}
 		}
 	}
 	return nil
 }
 
+// crdOverlapError is returned when a schema overlaps with a CustomResourceDefinition in the binding's logical cluster.
+type crdOverlapError struct {
+	group, resource string
+	crdName         string
+	clusterName     logicalcluster.Name
+}
+
+func (e *crdOverlapError) Error() string {
+	return fmt.Sprintf("cannot create CustomResourceDefinition with %q group and %q resource because it overlaps with %q CustomResourceDefinition in %q logical cluster",
+		e.group, e.resource, e.crdName, e.clusterName)
+}
+
+// schemaNamesConflict checks the names of the incoming schema against the names the existing schema would be
+// accepted with.
+func schemaNamesConflict(existing, incoming *apisv1alpha1.APIResourceSchema) (bool, string) {
+	return namesConflict(&apiextensionsv1.CustomResourceDefinition{
+		Spec:   apiextensionsv1.CustomResourceDefinitionSpec{Group: existing.Spec.Group},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{AcceptedNames: existing.Spec.Names},
+	}, incoming)
+}
+
 func namesConflict(existing *apiextensionsv1.CustomResourceDefinition, incoming *apisv1alpha1.APIResourceSchema) (bool, string) {
 	if existing.Spec.Group != incoming.Spec.Group {
 		return false, ""
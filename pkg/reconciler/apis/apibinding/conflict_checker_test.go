@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -286,6 +287,81 @@ func TestGVRConflict(t *testing.T) {
 	}
 }
 
+func TestConflictingBindings(t *testing.T) {
+	newBinding := func(name, export string) *apisv1alpha1.APIBinding {
+		return new(bindingBuilder).
+			WithClusterName("root:org:ws").
+			WithName(name).
+			WithExportReference(logicalcluster.NewPath("root:org:exportWS"), export).
+			Build()
+	}
+	apiBindings := []*apisv1alpha1.APIBinding{
+		newBinding("bound", "export0"),
+		newBinding("unbound", "export1"),
+		newBinding("other", "export2"),
+		newBinding("missing-export", "doesnotexist"),
+	}
+	apiExports := map[string]*apisv1alpha1.APIExport{
+		"export0": {Spec: apisv1alpha1.APIExportSpec{LatestResourceSchemas: []string{"export0-schema"}}},
+		"export1": {Spec: apisv1alpha1.APIExportSpec{LatestResourceSchemas: []string{"export1-other", "export1-schema"}}},
+		"export2": {Spec: apisv1alpha1.APIExportSpec{LatestResourceSchemas: []string{"export2-schema"}}},
+	}
+	newSchema := func(group, plural, singular, kind string) *apisv1alpha1.APIResourceSchema {
+		crd := createCRD("", plural+"."+group, group, plural)
+		crd.Spec.Names.Singular = singular
+		crd.Spec.Names.Kind = kind
+		crd.Spec.Names.ListKind = kind + "List"
+		return schemaFor(t, crd)
+	}
+	apiResourceSchemas := map[string]*apisv1alpha1.APIResourceSchema{
+		"export0-schema": newSchema("kcp.io", "widgets", "widget", "Widget"),
+		"export1-other":  newSchema("kcp.io", "gadgets", "gadget", "Gadget"),
+		"export1-schema": newSchema("kcp.io", "widgets", "widget", "Widget"),
+		"export2-schema": newSchema("example.com", "widgets", "widget", "Widget"),
+	}
+
+	c := &conflictChecker{
+		listAPIBindings: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error) {
+			require.Equal(t, logicalcluster.Name("root:org:ws"), clusterName)
+			return apiBindings, nil
+		},
+		getAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
+			require.Equal(t, "root:org:exportWS", path.String())
+			if export, ok := apiExports[name]; ok {
+				return export, nil
+			}
+			return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiexports"), name)
+		},
+		getAPIResourceSchema: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+			return apiResourceSchemas[name], nil
+		},
+	}
+
+	names := func(conflicts []bindingConflict) []string {
+		var ret []string
+		for _, conflict := range conflicts {
+			ret = append(ret, conflict.binding.Name)
+		}
+		return ret
+	}
+
+	t.Log("The conflict is reported for the bound APIBinding")
+	conflicts, err := c.conflictingBindings(apiBindings[0], []*apisv1alpha1.APIResourceSchema{apiResourceSchemas["export0-schema"]})
+	require.NoError(t, err)
+	require.Equal(t, []string{"unbound"}, names(conflicts))
+	require.Equal(t, `APIBinding "unbound" for APIExport root:org:exportWS:export1: spec.names.plural=widgets is forbidden`, conflicts[0].String())
+
+	t.Log("The conflict is reported for the unbound APIBinding")
+	conflicts, err = c.conflictingBindings(apiBindings[1], []*apisv1alpha1.APIResourceSchema{apiResourceSchemas["export1-other"], apiResourceSchemas["export1-schema"]})
+	require.NoError(t, err)
+	require.Equal(t, []string{"bound"}, names(conflicts))
+
+	t.Log("No conflict for a different group")
+	conflicts, err = c.conflictingBindings(apiBindings[2], []*apisv1alpha1.APIResourceSchema{apiResourceSchemas["export2-schema"]})
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+}
+
 func createCRD(clusterName, name, group, resource string) *apiextensionsv1.CustomResourceDefinition {
 	return &apiextensionsv1.CustomResourceDefinition{
 		ObjectMeta: metav1.ObjectMeta{
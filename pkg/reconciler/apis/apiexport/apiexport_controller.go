@@ -19,19 +19,23 @@ package apiexport
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
 	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
+	kcprbacinformers "github.com/kcp-dev/client-go/informers/rbac/v1"
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/kcp-dev/logicalcluster/v3"
 
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
@@ -58,10 +62,16 @@ const (
 func NewController(
 	kcpClusterClient kcpclientset.ClusterInterface,
 	apiExportInformer apisv1alpha1informers.APIExportClusterInformer,
+	globalAPIExportInformer apisv1alpha1informers.APIExportClusterInformer,
 	globalShardInformer corev1alpha1informers.ShardClusterInformer,
+	apiResourceSchemaInformer apisv1alpha1informers.APIResourceSchemaClusterInformer,
 	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
 	namespaceInformer kcpcorev1informers.NamespaceClusterInformer,
 	secretInformer kcpcorev1informers.SecretClusterInformer,
+	clusterRoleBindingInformer kcprbacinformers.ClusterRoleBindingClusterInformer,
+	clusterRoleInformer kcprbacinformers.ClusterRoleClusterInformer,
+	roleBindingInformer kcprbacinformers.RoleBindingClusterInformer,
+	roleInformer kcprbacinformers.RoleClusterInformer,
 ) (*controller, error) {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 
@@ -82,9 +92,24 @@ func NewController(
 
 			return indexers.ByIndex[*apisv1alpha1.APIExport](apiExportInformer.Informer().GetIndexer(), indexers.APIExportBySecret, secretKey)
 		},
+		listAPIExportsInCluster: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExport, error) {
+			return apiExportInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
 		getAPIExport: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
 			return apiExportInformer.Lister().Cluster(clusterName).Get(name)
 		},
+		getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+			return indexers.APIExportIndexers.ByIdentityHash.ByKey(globalAPIExportInformer.Informer().GetIndexer(), identityHash)
+		},
+		listAPIExportsClaimingIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+			return indexers.ByIndex[*apisv1alpha1.APIExport](apiExportInformer.Informer().GetIndexer(), indexers.APIExportByClaimedIdentities, identityHash)
+		},
+		getAPIExportsByExportedGroupResource: func(gr apisv1alpha1.GroupResource) ([]*apisv1alpha1.APIExport, error) {
+			return indexers.APIExportIndexers.ByExportedGroupResources.ByKey(globalAPIExportInformer.Informer().GetIndexer(), indexers.GroupResourceKey(gr))
+		},
+		getAPIResourceSchema: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+			return apiResourceSchemaInformer.Lister().Cluster(clusterName).Get(name)
+		},
 
 		getNamespace: func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error) {
 			return namespaceInformer.Lister().Cluster(clusterName).Get(name)
@@ -118,17 +143,34 @@ func NewController(
 			return globalShardInformer.Lister().List(labels.Everything())
 		},
 
+		listClusterRoleBindings: func(clusterName logicalcluster.Name) ([]*rbacv1.ClusterRoleBinding, error) {
+			return clusterRoleBindingInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+		getClusterRole: func(clusterName logicalcluster.Name, name string) (*rbacv1.ClusterRole, error) {
+			return clusterRoleInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		listRoleBindings: func(clusterName logicalcluster.Name) ([]*rbacv1.RoleBinding, error) {
+			return roleBindingInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+		getRole: func(clusterName logicalcluster.Name, namespace, name string) (*rbacv1.Role, error) {
+			return roleInformer.Lister().Cluster(clusterName).Roles(namespace).Get(name)
+		},
+
 		commit: committer.NewCommitter[*APIExport, Patcher, *APIExportSpec, *APIExportStatus](kcpClusterClient.ApisV1alpha1().APIExports()),
 	}
 
 	indexers.AddIfNotPresentOrDie(
 		apiExportInformer.Informer().GetIndexer(),
 		cache.Indexers{
-			indexers.APIExportByIdentity: indexers.IndexAPIExportByIdentity,
-			indexers.APIExportBySecret:   indexers.IndexAPIExportBySecret,
+			indexers.APIExportByIdentity:          indexers.IndexAPIExportByIdentity,
+			indexers.APIExportBySecret:            indexers.IndexAPIExportBySecret,
+			indexers.APIExportByClaimedIdentities: indexers.IndexAPIExportByClaimedIdentities,
 		},
 	)
 
+	indexers.APIExportIndexers.ByIdentityHash.AddIfNotPresentOrDie(globalAPIExportInformer.Informer().GetIndexer())
+	indexers.APIExportIndexers.ByExportedGroupResources.AddIfNotPresentOrDie(globalAPIExportInformer.Informer().GetIndexer())
+
 	apiExportInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			c.enqueueAPIExport(obj.(*apisv1alpha1.APIExport))
@@ -141,6 +183,33 @@ func NewController(
 		},
 	})
 
+	// claims of other APIExports might close a permission claim cycle, or reference a rotated identity.
+	// Other APIExports exporting a claimed resource might make a claim without identity hash ambiguous.
+	globalAPIExportInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueClaimingAPIExports(obj.(*apisv1alpha1.APIExport))
+			c.enqueueAPIExportsClaimingWithoutIdentity(obj.(*apisv1alpha1.APIExport))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldExport, newExport := oldObj.(*apisv1alpha1.APIExport), newObj.(*apisv1alpha1.APIExport)
+			if oldExport.Status.IdentityHash != newExport.Status.IdentityHash {
+				c.enqueueClaimingAPIExports(oldExport)
+			}
+			c.enqueueClaimingAPIExports(newExport)
+			if oldExport.Status.IdentityHash != newExport.Status.IdentityHash || !reflect.DeepEqual(oldExport.Spec.LatestResourceSchemas, newExport.Spec.LatestResourceSchemas) {
+				c.enqueueAPIExportsClaimingWithoutIdentity(oldExport)
+				c.enqueueAPIExportsClaimingWithoutIdentity(newExport)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			c.enqueueClaimingAPIExports(obj.(*apisv1alpha1.APIExport))
+			c.enqueueAPIExportsClaimingWithoutIdentity(obj.(*apisv1alpha1.APIExport))
+		},
+	})
+
 	secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			c.enqueueSecret(obj.(*corev1.Secret))
@@ -153,6 +222,26 @@ func NewController(
 		},
 	})
 
+	// the maximal permission policy is backed by RBAC in the logical cluster of the APIExport.
+	rbacHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueAPIExportsWithMaximalPermissionPolicy(obj)
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			c.enqueueAPIExportsWithMaximalPermissionPolicy(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			c.enqueueAPIExportsWithMaximalPermissionPolicy(obj)
+		},
+	}
+	clusterRoleBindingInformer.Informer().AddEventHandler(rbacHandler)
+	clusterRoleInformer.Informer().AddEventHandler(rbacHandler)
+	roleBindingInformer.Informer().AddEventHandler(rbacHandler)
+	roleInformer.Informer().AddEventHandler(rbacHandler)
+
 	globalShardInformer.Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
@@ -186,8 +275,16 @@ type controller struct {
 
 	listAPIExports          func() ([]*apisv1alpha1.APIExport, error)
 	listAPIExportsForSecret func(secret *corev1.Secret) ([]*apisv1alpha1.APIExport, error)
+	listAPIExportsInCluster func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExport, error)
 	getAPIExport            func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error)
 
+	getAPIExportsByIdentity        func(identityHash string) ([]*apisv1alpha1.APIExport, error)
+	listAPIExportsClaimingIdentity func(identityHash string) ([]*apisv1alpha1.APIExport, error)
+
+	getAPIExportsByExportedGroupResource func(gr apisv1alpha1.GroupResource) ([]*apisv1alpha1.APIExport, error)
+
+	getAPIResourceSchema func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error)
+
 	getNamespace    func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error)
 	createNamespace func(ctx context.Context, clusterName logicalcluster.Path, ns *corev1.Namespace) error
 
@@ -198,6 +295,11 @@ type controller struct {
 
 	listShards func() ([]*corev1alpha1.Shard, error)
 
+	listClusterRoleBindings func(clusterName logicalcluster.Name) ([]*rbacv1.ClusterRoleBinding, error)
+	getClusterRole          func(clusterName logicalcluster.Name, name string) (*rbacv1.ClusterRole, error)
+	listRoleBindings        func(clusterName logicalcluster.Name) ([]*rbacv1.RoleBinding, error)
+	getRole                 func(clusterName logicalcluster.Name, namespace, name string) (*rbacv1.Role, error)
+
 	commit CommitFunc
 }
 
@@ -234,6 +336,103 @@ func (c *controller) enqueueAllAPIExports(shard *corev1alpha1.Shard) {
 	}
 }
 
+// enqueueClaimingAPIExports enqueues the local APIExports with a permission claim
+// for the identity of the given APIExport.
+func (c *controller) enqueueClaimingAPIExports(apiExport *apisv1alpha1.APIExport) {
+	if apiExport.Status.IdentityHash == "" {
+		return
+	}
+
+	apiExports, err := c.listAPIExportsClaimingIdentity(apiExport.Status.IdentityHash)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithObject(logging.WithReconciler(klog.Background(), ControllerName), apiExport)
+	for _, claimingAPIExport := range apiExports {
+		key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(claimingAPIExport)
+		if err != nil {
+			runtime.HandleError(err)
+			return
+		}
+		logging.WithQueueKey(logger, key).V(2).Info("queueing APIExport because a claimed APIExport changed")
+		c.queue.Add(key)
+	}
+}
+
+// enqueueAPIExportsClaimingWithoutIdentity enqueues the local APIExports with a permission claim
+// without identity hash for a resource exported by the given APIExport.
+func (c *controller) enqueueAPIExportsClaimingWithoutIdentity(apiExport *apisv1alpha1.APIExport) {
+	exported, err := indexers.IndexAPIExportByExportedGroupResources(apiExport)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	if len(exported) == 0 {
+		return
+	}
+	exportedSet := sets.NewString(exported...)
+
+	apiExports, err := c.listAPIExportsClaimingIdentity("")
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithObject(logging.WithReconciler(klog.Background(), ControllerName), apiExport)
+	for _, claimingAPIExport := range apiExports {
+		claims := false
+		for _, claim := range claimingAPIExport.Spec.PermissionClaims {
+			if claim.IdentityHash == "" && exportedSet.Has(indexers.GroupResourceKey(claim.GroupResource)) {
+				claims = true
+				break
+			}
+		}
+		if !claims {
+			continue
+		}
+
+		key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(claimingAPIExport)
+		if err != nil {
+			runtime.HandleError(err)
+			return
+		}
+		logging.WithQueueKey(logger, key).V(2).Info("queueing APIExport because an APIExport exporting a resource claimed without identity changed")
+		c.queue.Add(key)
+	}
+}
+
+// enqueueAPIExportsWithMaximalPermissionPolicy enqueues the APIExports with a maximal permission policy
+// in the logical cluster of the given RBAC object.
+func (c *controller) enqueueAPIExportsWithMaximalPermissionPolicy(obj interface{}) {
+	metaObj, ok := obj.(metav1.Object)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("unexpected object type %T", obj))
+		return
+	}
+
+	apiExports, err := c.listAPIExportsInCluster(logicalcluster.From(metaObj))
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+	for _, apiExport := range apiExports {
+		if apiExport.Spec.MaximalPermissionPolicy == nil {
+			continue
+		}
+		key, err := kcpcache.MetaClusterNamespaceKeyFunc(apiExport)
+		if err != nil {
+			runtime.HandleError(err)
+			continue
+		}
+		logging.WithQueueKey(logger, key).V(4).Info("queueing APIExport because RBAC of its maximal permission policy changed", "rbac", metaObj.GetName())
+		c.queue.Add(key)
+	}
+}
+
 func (c *controller) enqueueSecret(secret *corev1.Secret) {
 	apiExports, err := c.listAPIExportsForSecret(secret)
 	if err != nil {
@@ -27,13 +27,18 @@ import (
 	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
 	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	"github.com/kcp-dev/kcp/pkg/indexers"
 )
 
 func TestReconcile(t *testing.T) {
@@ -155,6 +160,9 @@ func TestReconcile(t *testing.T) {
 					return nil
 				},
 				secretNamespace: "default-ns",
+				getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+					return nil, nil
+				},
 				getSecret: func(ctx context.Context, clusterName logicalcluster.Name, ns, name string) (*corev1.Secret, error) {
 					if tc.secretExists {
 						secret := &corev1.Secret{
@@ -190,7 +198,8 @@ func TestReconcile(t *testing.T) {
 								Name: "shard1",
 							},
 							Spec: corev1alpha1.ShardSpec{
-								ExternalURL: "https://server-1.kcp.io/",
+								ExternalURL:         "https://server-1.kcp.io/",
+								VirtualWorkspaceURL: "https://server-1.kcp.io/",
 							},
 						},
 						{
@@ -201,7 +210,8 @@ func TestReconcile(t *testing.T) {
 								Name: "shard2",
 							},
 							Spec: corev1alpha1.ShardSpec{
-								ExternalURL: "https://server-2.kcp.io/",
+								ExternalURL:         "https://server-2.kcp.io/",
+								VirtualWorkspaceURL: "https://server-2.kcp.io/",
 							},
 						},
 					}, nil
@@ -301,6 +311,15 @@ func TestReconcile(t *testing.T) {
 
 			if tc.wantVirtualWorkspaceURLsReady {
 				requireConditionMatches(t, apiExport, conditions.TrueCondition(apisv1alpha1.APIExportVirtualWorkspaceURLsReady))
+				require.Equal(t, []apisv1alpha1.APIExportVirtualWorkspaceReference{
+					{Shard: "shard1", URL: "https://server-1.kcp.io/services/apiexport/root:org:ws/my-export"},
+					{Shard: "shard2", URL: "https://server-2.kcp.io/services/apiexport/root:org:ws/my-export"},
+				}, apiExport.Status.VirtualWorkspaceURLs)
+				//nolint:staticcheck // SA1019 VirtualWorkspaces is deprecated but not removed yet
+				require.Equal(t, []apisv1alpha1.VirtualWorkspace{
+					{URL: "https://server-1.kcp.io/services/apiexport/root:org:ws/my-export"},
+					{URL: "https://server-2.kcp.io/services/apiexport/root:org:ws/my-export"},
+				}, apiExport.Status.VirtualWorkspaces)
 			}
 		})
 	}
@@ -331,3 +350,547 @@ func requireConditionMatches(t *testing.T, g conditions.Getter, c *conditionsv1a
 		require.Contains(t, actual.Message, c.Message)
 	}
 }
+
+func TestFindPermissionClaimCycle(t *testing.T) {
+	newExport := func(cluster, name, identityHash string, claimedIdentityHashes ...string) *apisv1alpha1.APIExport {
+		export := &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					logicalcluster.AnnotationKey: cluster,
+				},
+				Name: name,
+			},
+			Status: apisv1alpha1.APIExportStatus{
+				IdentityHash: identityHash,
+			},
+		}
+		for i, h := range claimedIdentityHashes {
+			export.Spec.PermissionClaims = append(export.Spec.PermissionClaims, apisv1alpha1.PermissionClaim{
+				GroupResource: apisv1alpha1.GroupResource{Group: "example.com", Resource: fmt.Sprintf("things%d", i)},
+				IdentityHash:  h,
+				All:           true,
+			})
+		}
+		return export
+	}
+
+	tests := map[string]struct {
+		export    *apisv1alpha1.APIExport
+		others    []*apisv1alpha1.APIExport
+		wantCycle []string
+	}{
+		"no claims": {
+			export: newExport("root:a", "a", "ha"),
+		},
+		"claims of built-in types": {
+			export: newExport("root:a", "a", "ha", ""),
+		},
+		"self-claims are no cross-export cycle": {
+			export: newExport("root:a", "a", "ha", "ha"),
+		},
+		"two-level chain without cycle": {
+			export: newExport("root:cowboys", "cowboys", "hc", "hs"),
+			others: []*apisv1alpha1.APIExport{
+				newExport("root:sheriffs", "sheriffs", "hs", "hd"),
+				newExport("root:deputies", "deputies", "hd"),
+			},
+		},
+		"claimed identity without export": {
+			export: newExport("root:cowboys", "cowboys", "hc", "unknown"),
+		},
+		"direct cycle": {
+			export: newExport("root:cowboys", "cowboys", "hc", "hs"),
+			others: []*apisv1alpha1.APIExport{
+				newExport("root:sheriffs", "sheriffs", "hs", "hc"),
+			},
+			wantCycle: []string{"root:cowboys:cowboys", "root:sheriffs:sheriffs", "root:cowboys:cowboys"},
+		},
+		"two-level cycle": {
+			export: newExport("root:cowboys", "cowboys", "hc", "hs"),
+			others: []*apisv1alpha1.APIExport{
+				newExport("root:sheriffs", "sheriffs", "hs", "hd"),
+				newExport("root:deputies", "deputies", "hd", "hc"),
+			},
+			wantCycle: []string{"root:cowboys:cowboys", "root:sheriffs:sheriffs", "root:deputies:deputies", "root:cowboys:cowboys"},
+		},
+		"cycle not involving the export": {
+			export: newExport("root:cowboys", "cowboys", "hc", "hs"),
+			others: []*apisv1alpha1.APIExport{
+				newExport("root:sheriffs", "sheriffs", "hs", "hd"),
+				newExport("root:deputies", "deputies", "hd", "hs"),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			getAPIExportsByIdentity := func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+				var ret []*apisv1alpha1.APIExport
+				for _, other := range append(tc.others, tc.export) {
+					if other.Status.IdentityHash == identityHash {
+						ret = append(ret, other)
+					}
+				}
+				return ret, nil
+			}
+
+			cycle, err := findPermissionClaimCycle(tc.export, getAPIExportsByIdentity)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantCycle, cycle)
+		})
+	}
+}
+
+func TestReconcilePermissionClaimCycle(t *testing.T) {
+	cowboys := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root:cowboys",
+			},
+			Name: "cowboys",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}, IdentityHash: "hs", All: true},
+			},
+		},
+		Status: apisv1alpha1.APIExportStatus{IdentityHash: "hc"},
+	}
+	sheriffs := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root:sheriffs",
+			},
+			Name: "sheriffs",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{GroupResource: apisv1alpha1.GroupResource{Group: "wildwest.dev", Resource: "cowboys"}, IdentityHash: "hc", All: true},
+			},
+		},
+		Status: apisv1alpha1.APIExportStatus{IdentityHash: "hs"},
+	}
+
+	c := &controller{
+		getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+			if identityHash == "hs" {
+				return []*apisv1alpha1.APIExport{sheriffs}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	require.NoError(t, c.updatePermissionClaimsValid(cowboys))
+	require.True(t, conditions.IsFalse(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+	require.Equal(t, apisv1alpha1.PermissionClaimCycleReason, conditions.GetReason(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+	require.Equal(t, "Permission claims form a cycle across APIExports: root:cowboys:cowboys -> root:sheriffs:sheriffs -> root:cowboys:cowboys",
+		conditions.GetMessage(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+
+	t.Log("Remove the claim closing the cycle")
+	sheriffs.Spec.PermissionClaims = nil
+	require.NoError(t, c.updatePermissionClaimsValid(cowboys))
+	require.True(t, conditions.IsTrue(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+}
+
+func TestReconcilePermissionClaimSelfReference(t *testing.T) {
+	cowboys := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root:cowboys",
+			},
+			Name: "cowboys",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			// the group and resource are taken from the schema, not from its name.
+			LatestResourceSchemas: []string{"cowboys-schema", "missing-schema"},
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{GroupResource: apisv1alpha1.GroupResource{Group: "wildwest.dev", Resource: "cowboys"}, IdentityHash: "hc", All: true},
+			},
+		},
+		Status: apisv1alpha1.APIExportStatus{IdentityHash: "hc"},
+	}
+
+	c := &controller{
+		getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+			if identityHash == "other" {
+				return []*apisv1alpha1.APIExport{{ObjectMeta: metav1.ObjectMeta{Name: "other"}}}, nil
+			}
+			return nil, nil
+		},
+		getAPIResourceSchema: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+			if clusterName == "root:cowboys" && name == "cowboys-schema" {
+				return &apisv1alpha1.APIResourceSchema{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+					Spec: apisv1alpha1.APIResourceSchemaSpec{
+						Group: "wildwest.dev",
+						Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "cowboys"},
+					},
+				}, nil
+			}
+			return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiresourceschemas"), name)
+		},
+	}
+
+	require.NoError(t, c.updatePermissionClaimsValid(cowboys))
+	require.True(t, conditions.IsFalse(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+	require.Equal(t, apisv1alpha1.PermissionClaimSelfReferenceReason, conditions.GetReason(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+	require.Equal(t, "Permission claims for resources exported by the APIExport itself are not allowed: cowboys.wildwest.dev:hc",
+		conditions.GetMessage(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+
+	t.Log("A claim with a different identity is no self-claim")
+	cowboys.Spec.PermissionClaims[0].IdentityHash = "other"
+	require.NoError(t, c.updatePermissionClaimsValid(cowboys))
+	require.True(t, conditions.IsTrue(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+
+	t.Log("A claim of another resource with the own identity is no self-claim")
+	cowboys.Spec.PermissionClaims[0] = apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Group: "wildwest.dev", Resource: "sheriffs"}, IdentityHash: "hc", All: true}
+	require.NoError(t, c.updatePermissionClaimsValid(cowboys))
+	require.True(t, conditions.IsTrue(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+
+	t.Log("Failing to get a schema is returned")
+	c.getAPIResourceSchema = func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+		return nil, errors.New("lister broken")
+	}
+	require.EqualError(t, c.updatePermissionClaimsValid(cowboys), "lister broken")
+}
+
+func TestReconcilePermissionClaimIdentityNotFound(t *testing.T) {
+	cowboys := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root:cowboys",
+			},
+			Name: "cowboys",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}, IdentityHash: "hs", All: true},
+				{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true},
+			},
+		},
+		Status: apisv1alpha1.APIExportStatus{IdentityHash: "hc"},
+	}
+	sheriffs := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root:sheriffs",
+			},
+			Name: "sheriffs",
+		},
+		Status: apisv1alpha1.APIExportStatus{IdentityHash: "hs"},
+	}
+
+	c := &controller{
+		getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+			if identityHash == sheriffs.Status.IdentityHash {
+				return []*apisv1alpha1.APIExport{sheriffs}, nil
+			}
+			return nil, nil
+		},
+		getAPIExportsByExportedGroupResource: func(gr apisv1alpha1.GroupResource) ([]*apisv1alpha1.APIExport, error) {
+			return nil, nil
+		},
+	}
+
+	require.NoError(t, c.updatePermissionClaimsValid(cowboys))
+	require.True(t, conditions.IsTrue(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+
+	t.Log("Rotate the identity of the claimed APIExport")
+	sheriffs.Status.IdentityHash = "hs2"
+	require.NoError(t, c.updatePermissionClaimsValid(cowboys))
+	require.True(t, conditions.IsFalse(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+	require.Equal(t, apisv1alpha1.PermissionClaimIdentityNotFoundReason, conditions.GetReason(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+	require.Equal(t, "Permission claims reference identities no APIExport has: sheriffs.wild.wild.west:hs. The claimed APIExport might have rotated its identity, update the identityHash of the claims",
+		conditions.GetMessage(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+
+	t.Log("Update the claim to the new identity")
+	cowboys.Spec.PermissionClaims[0].IdentityHash = "hs2"
+	require.NoError(t, c.updatePermissionClaimsValid(cowboys))
+	require.True(t, conditions.IsTrue(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+}
+
+func TestReconcilePermissionClaimIdentityAmbiguous(t *testing.T) {
+	cowboys := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root:cowboys",
+			},
+			Name: "cowboys",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{GroupResource: apisv1alpha1.GroupResource{Group: "apis.kcp.io", Resource: "apibindings"}, All: true},
+				{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true},
+			},
+		},
+		Status: apisv1alpha1.APIExportStatus{IdentityHash: "hc"},
+	}
+	newExport := func(name, identityHash string, latestResourceSchemas ...string) *apisv1alpha1.APIExport {
+		return &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					logicalcluster.AnnotationKey: "root:" + name,
+				},
+				Name: name,
+			},
+			Spec:   apisv1alpha1.APIExportSpec{LatestResourceSchemas: latestResourceSchemas},
+			Status: apisv1alpha1.APIExportStatus{IdentityHash: identityHash},
+		}
+	}
+	exports := []*apisv1alpha1.APIExport{
+		newExport("bindings", "hb1", "today.apibindings.apis.kcp.io"),
+	}
+
+	c := &controller{
+		getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+			return nil, nil
+		},
+		getAPIExportsByExportedGroupResource: func(gr apisv1alpha1.GroupResource) ([]*apisv1alpha1.APIExport, error) {
+			var ret []*apisv1alpha1.APIExport
+			for _, export := range exports {
+				keys, err := indexers.IndexAPIExportByExportedGroupResources(export)
+				require.NoError(t, err)
+				if sets.NewString(keys...).Has(indexers.GroupResourceKey(gr)) {
+					ret = append(ret, export)
+				}
+			}
+			return ret, nil
+		},
+	}
+
+	t.Log("A single identity exporting a claimed resource is not ambiguous")
+	require.NoError(t, c.updatePermissionClaimsValid(cowboys))
+	require.True(t, conditions.IsTrue(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+
+	t.Log("A second identity exporting the claimed resources makes the claims ambiguous")
+	exports = append(exports,
+		newExport("other-bindings", "hb2", "tomorrow.apibindings.apis.kcp.io"),
+		newExport("configmaps", "hm1", "today.configmaps.core"),
+		newExport("more-configmaps", "hm2", "today.configmaps.core"),
+		newExport("not-ready", "", "today.configmaps.core"),
+	)
+	require.NoError(t, c.updatePermissionClaimsValid(cowboys))
+	require.True(t, conditions.IsFalse(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+	require.Equal(t, apisv1alpha1.PermissionClaimIdentityAmbiguousReason, conditions.GetReason(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+	require.Equal(t, "Permission claims without identityHash are ambiguous: apibindings.apis.kcp.io is exported with identities hb1, hb2; configmaps is exported with identities hm1, hm2. Set the identityHash of the claims to one of the identities",
+		conditions.GetMessage(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+
+	t.Log("Claims with identity hash are not ambiguous")
+	cowboys.Spec.PermissionClaims[0].IdentityHash = "hb2"
+	cowboys.Spec.PermissionClaims[1].IdentityHash = "hm1"
+	c.getAPIExportsByIdentity = func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+		for _, export := range exports {
+			if export.Status.IdentityHash == identityHash {
+				return []*apisv1alpha1.APIExport{export}, nil
+			}
+		}
+		return nil, nil
+	}
+	require.NoError(t, c.updatePermissionClaimsValid(cowboys))
+	require.True(t, conditions.IsTrue(cowboys, apisv1alpha1.APIExportPermissionClaimsValid))
+}
+
+func TestReconcileMaximalPermissionPolicySatisfiable(t *testing.T) {
+	cowboys := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root:cowboys",
+			},
+			Name: "cowboys",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			MaximalPermissionPolicy: &apisv1alpha1.MaximalPermissionPolicy{Local: &apisv1alpha1.LocalAPIExportPolicy{}},
+		},
+	}
+
+	bindings := []*rbacv1.ClusterRoleBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "unrelated"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "user-1"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cowboys-maximal"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cowboys-maximal"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: apisv1alpha1.MaximalPermissionPolicyRBACUserGroupPrefix + "system:authenticated"}},
+		},
+	}
+	roleBindings := []*rbacv1.RoleBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cowboys-creator"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "cowboys-creator"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: apisv1alpha1.MaximalPermissionPolicyRBACUserGroupPrefix + "user-1"}},
+		},
+	}
+	clusterRoles := map[string]bool{"unrelated": true, "cowboys-maximal": true}
+	roles := map[string]bool{"default/cowboys-creator": true}
+
+	c := &controller{
+		listClusterRoleBindings: func(clusterName logicalcluster.Name) ([]*rbacv1.ClusterRoleBinding, error) {
+			require.Equal(t, logicalcluster.Name("root:cowboys"), clusterName)
+			return bindings, nil
+		},
+		getClusterRole: func(clusterName logicalcluster.Name, name string) (*rbacv1.ClusterRole, error) {
+			require.Equal(t, logicalcluster.Name("root:cowboys"), clusterName)
+			if !clusterRoles[name] {
+				return nil, apierrors.NewNotFound(schema.GroupResource{Group: rbacv1.GroupName, Resource: "clusterroles"}, name)
+			}
+			return &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+		},
+		listRoleBindings: func(clusterName logicalcluster.Name) ([]*rbacv1.RoleBinding, error) {
+			require.Equal(t, logicalcluster.Name("root:cowboys"), clusterName)
+			return roleBindings, nil
+		},
+		getRole: func(clusterName logicalcluster.Name, namespace, name string) (*rbacv1.Role, error) {
+			require.Equal(t, logicalcluster.Name("root:cowboys"), clusterName)
+			if !roles[namespace+"/"+name] {
+				return nil, apierrors.NewNotFound(schema.GroupResource{Group: rbacv1.GroupName, Resource: "roles"}, name)
+			}
+			return &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}, nil
+		},
+	}
+
+	require.NoError(t, c.updateMaximalPermissionPolicySatisfiable(cowboys))
+	requireConditionMatches(t, cowboys, conditions.TrueCondition(apisv1alpha1.APIExportMaximalPermissionPolicySatisfiable))
+
+	t.Log("Removing the roles referenced by the maximal permission policy bindings")
+	delete(clusterRoles, "cowboys-maximal")
+	delete(roles, "default/cowboys-creator")
+	require.NoError(t, c.updateMaximalPermissionPolicySatisfiable(cowboys))
+	requireConditionMatches(t, cowboys, conditions.FalseCondition(
+		apisv1alpha1.APIExportMaximalPermissionPolicySatisfiable,
+		apisv1alpha1.MaximalPermissionPolicyRBACMissingReason,
+		conditionsv1alpha1.ConditionSeverityWarning,
+		"Roles referenced by the maximal permission policy do not exist: ClusterRole cowboys-maximal, Role default/cowboys-creator",
+	))
+
+	t.Log("Removing the maximal permission policy bindings")
+	bindings = bindings[:1]
+	roleBindings = nil
+	require.NoError(t, c.updateMaximalPermissionPolicySatisfiable(cowboys))
+	requireConditionMatches(t, cowboys, conditions.FalseCondition(
+		apisv1alpha1.APIExportMaximalPermissionPolicySatisfiable,
+		apisv1alpha1.MaximalPermissionPolicyRBACMissingReason,
+		conditionsv1alpha1.ConditionSeverityWarning,
+		`No ClusterRoleBinding or RoleBinding grants permissions to users or groups prefixed with "apis.kcp.io:binding:", all requests to bound resources are denied`,
+	))
+
+	t.Log("Without a maximal permission policy the condition is removed")
+	cowboys.Spec.MaximalPermissionPolicy = nil
+	require.NoError(t, c.updateMaximalPermissionPolicySatisfiable(cowboys))
+	require.Nil(t, conditions.Get(cowboys, apisv1alpha1.APIExportMaximalPermissionPolicySatisfiable))
+}
+
+func TestReconcileIdentityHashStableAcrossSchemaUpdates(t *testing.T) {
+	c := &controller{
+		getSecret: func(ctx context.Context, clusterName logicalcluster.Name, ns, name string) (*corev1.Secret, error) {
+			return &corev1.Secret{Data: map[string][]byte{apisv1alpha1.SecretKeyAPIExportIdentity: []byte("abc")}}, nil
+		},
+		getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+			return nil, nil
+		},
+		listShards: func() ([]*corev1alpha1.Shard, error) {
+			return nil, nil
+		},
+	}
+
+	apiExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root:org:ws",
+			},
+			Name: "cowboys",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			LatestResourceSchemas: []string{"today.cowboys.wildwest.dev"},
+			Identity: &apisv1alpha1.Identity{
+				SecretRef: &corev1.SecretReference{Namespace: "somens", Name: "somename"},
+			},
+		},
+	}
+
+	t.Log("Updating a schema to a new version")
+	requireIdentityHashStable(t, c, apiExport, func(export *apisv1alpha1.APIExport) {
+		export.Spec.LatestResourceSchemas = []string{"tomorrow.cowboys.wildwest.dev"}
+	})
+
+	t.Log("Adding a schema")
+	requireIdentityHashStable(t, c, apiExport, func(export *apisv1alpha1.APIExport) {
+		export.Spec.LatestResourceSchemas = append(export.Spec.LatestResourceSchemas, "today.sheriffs.wildwest.dev")
+	})
+
+	t.Log("Removing all schemas")
+	requireIdentityHashStable(t, c, apiExport, func(export *apisv1alpha1.APIExport) {
+		export.Spec.LatestResourceSchemas = nil
+	})
+}
+
+// requireIdentityHashStable reconciles the APIExport before and after applying update, and requires
+// that the identity hash is set and does not change.
+func requireIdentityHashStable(t *testing.T, c *controller, apiExport *apisv1alpha1.APIExport, update func(export *apisv1alpha1.APIExport)) {
+	t.Helper()
+
+	require.NoError(t, c.reconcile(context.Background(), apiExport))
+	hash := apiExport.Status.IdentityHash
+	require.NotEmpty(t, hash, "expected the identity hash to be set")
+
+	update(apiExport)
+
+	require.NoError(t, c.reconcile(context.Background(), apiExport))
+	require.Equal(t, hash, apiExport.Status.IdentityHash, "expected the identity hash to be stable")
+	requireConditionMatches(t, apiExport, conditions.TrueCondition(apisv1alpha1.APIExportIdentityValid))
+}
+
+func TestReconcileIdentityRotation(t *testing.T) {
+	secret := &corev1.Secret{Data: map[string][]byte{apisv1alpha1.SecretKeyAPIExportIdentity: []byte("abc")}}
+	c := &controller{
+		getSecret: func(ctx context.Context, clusterName logicalcluster.Name, ns, name string) (*corev1.Secret, error) {
+			return secret, nil
+		},
+		getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+			return nil, nil
+		},
+		listShards: func() ([]*corev1alpha1.Shard, error) {
+			return nil, nil
+		},
+	}
+
+	apiExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root:org:ws",
+			},
+			Name: "cowboys",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			Identity: &apisv1alpha1.Identity{
+				SecretRef: &corev1.SecretReference{Namespace: "somens", Name: "somename"},
+			},
+		},
+	}
+
+	require.NoError(t, c.reconcile(context.Background(), apiExport))
+	oldHash := apiExport.Status.IdentityHash
+	require.NotEmpty(t, oldHash)
+
+	t.Log("Changing the identity secret without the rotation annotation")
+	secret = &corev1.Secret{Data: map[string][]byte{apisv1alpha1.SecretKeyAPIExportIdentity: []byte("def")}}
+	newHash, err := IdentityHash(secret)
+	require.NoError(t, err)
+	require.NoError(t, c.reconcile(context.Background(), apiExport))
+	require.Equal(t, oldHash, apiExport.Status.IdentityHash)
+	require.True(t, conditions.IsFalse(apiExport, apisv1alpha1.APIExportIdentityValid))
+	require.Contains(t, conditions.GetMessage(apiExport, apisv1alpha1.APIExportIdentityValid), apisv1alpha1.AnnotationAPIExportRotateIdentityKey+"="+newHash)
+
+	t.Log("Annotating with a hash not matching the secret")
+	apiExport.Annotations[apisv1alpha1.AnnotationAPIExportRotateIdentityKey] = oldHash
+	require.NoError(t, c.reconcile(context.Background(), apiExport))
+	require.Equal(t, oldHash, apiExport.Status.IdentityHash)
+	require.True(t, conditions.IsFalse(apiExport, apisv1alpha1.APIExportIdentityValid))
+
+	t.Log("Annotating with the hash of the new secret")
+	apiExport.Annotations[apisv1alpha1.AnnotationAPIExportRotateIdentityKey] = newHash
+	require.NoError(t, c.reconcile(context.Background(), apiExport))
+	require.Equal(t, newHash, apiExport.Status.IdentityHash)
+	requireConditionMatches(t, apiExport, conditions.TrueCondition(apisv1alpha1.APIExportIdentityValid))
+}
@@ -21,10 +21,13 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"sort"
+	"strings"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -95,6 +98,14 @@ func (c *controller) reconcile(ctx context.Context, apiExport *apisv1alpha1.APIE
 		)
 	}
 
+	if err := c.updatePermissionClaimsValid(apiExport); err != nil {
+		return err
+	}
+
+	if err := c.updateMaximalPermissionPolicySatisfiable(apiExport); err != nil {
+		return err
+	}
+
 	// TODO(sttts): reactivate this with multi-shard support eventually
 	/*
 		// check if any APIBindings are bound to this APIExport. If so, add a virtualworkspaceURL
@@ -169,7 +180,13 @@ func (c *controller) updateOrVerifyIdentitySecretHash(ctx context.Context, clust
 	}
 
 	if apiExport.Status.IdentityHash != hash {
-		return fmt.Errorf("hash mismatch: identity secret hash %q must match status.identityHash %q", hash, apiExport.Status.IdentityHash)
+		if apiExport.Annotations[apisv1alpha1.AnnotationAPIExportRotateIdentityKey] != hash {
+			return fmt.Errorf("hash mismatch: identity secret hash %q must match status.identityHash %q. To rotate the identity, annotate the APIExport with %s=%s",
+				hash, apiExport.Status.IdentityHash, apisv1alpha1.AnnotationAPIExportRotateIdentityKey, hash)
+		}
+
+		klog.FromContext(ctx).Info("rotating APIExport identity", "previousIdentityHash", apiExport.Status.IdentityHash, "identityHash", hash)
+		apiExport.Status.IdentityHash = hash
 	}
 
 	conditions.MarkTrue(apiExport, apisv1alpha1.APIExportIdentityValid)
@@ -177,6 +194,306 @@ func (c *controller) updateOrVerifyIdentitySecretHash(ctx context.Context, clust
 	return nil
 }
 
+// updatePermissionClaimsValid checks that the permission claims of the APIExport do not claim
+// the resources exported by the APIExport itself, and that they do not form a cycle across
+// APIExports, i.e. that no chain of claims leads back to the identity of the APIExport itself.
+// Claims must reference existing identities, and claims without identity hash must not be
+// ambiguous.
+func (c *controller) updatePermissionClaimsValid(apiExport *apisv1alpha1.APIExport) error {
+	if apiExport.Status.IdentityHash == "" {
+		// self-claims and cycles are detected via the identity. Wait for it to be set.
+		return nil
+	}
+
+	claims, err := findSelfClaims(apiExport, c.getAPIResourceSchema)
+	if err != nil {
+		return err
+	}
+	if len(claims) > 0 {
+		conditions.MarkFalse(
+			apiExport,
+			apisv1alpha1.APIExportPermissionClaimsValid,
+			apisv1alpha1.PermissionClaimSelfReferenceReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"Permission claims for resources exported by the APIExport itself are not allowed: %s",
+			strings.Join(claims, ", "),
+		)
+		return nil
+	}
+
+	cycle, err := findPermissionClaimCycle(apiExport, c.getAPIExportsByIdentity)
+	if err != nil {
+		return err
+	}
+	if len(cycle) > 0 {
+		conditions.MarkFalse(
+			apiExport,
+			apisv1alpha1.APIExportPermissionClaimsValid,
+			apisv1alpha1.PermissionClaimCycleReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"Permission claims form a cycle across APIExports: %s",
+			strings.Join(cycle, " -> "),
+		)
+		return nil
+	}
+
+	unknown, err := findClaimsOfUnknownIdentities(apiExport, c.getAPIExportsByIdentity)
+	if err != nil {
+		return err
+	}
+	if len(unknown) > 0 {
+		conditions.MarkFalse(
+			apiExport,
+			apisv1alpha1.APIExportPermissionClaimsValid,
+			apisv1alpha1.PermissionClaimIdentityNotFoundReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"Permission claims reference identities no APIExport has: %s. The claimed APIExport might have rotated its identity, update the identityHash of the claims",
+			strings.Join(unknown, ", "),
+		)
+		return nil
+	}
+
+	ambiguous, err := findAmbiguousClaims(apiExport, c.getAPIExportsByExportedGroupResource)
+	if err != nil {
+		return err
+	}
+	if len(ambiguous) > 0 {
+		conditions.MarkFalse(
+			apiExport,
+			apisv1alpha1.APIExportPermissionClaimsValid,
+			apisv1alpha1.PermissionClaimIdentityAmbiguousReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"Permission claims without identityHash are ambiguous: %s. Set the identityHash of the claims to one of the identities",
+			strings.Join(ambiguous, "; "),
+		)
+		return nil
+	}
+
+	conditions.MarkTrue(apiExport, apisv1alpha1.APIExportPermissionClaimsValid)
+
+	return nil
+}
+
+// updateMaximalPermissionPolicySatisfiable checks that the RBAC backing the local maximal permission
+// policy of the APIExport exists, i.e. that some ClusterRoleBinding or RoleBinding in the logical
+// cluster of the APIExport binds users or groups with the maximal permission policy prefix, and that
+// the roles referenced by those bindings exist.
+func (c *controller) updateMaximalPermissionPolicySatisfiable(apiExport *apisv1alpha1.APIExport) error {
+	if apiExport.Spec.MaximalPermissionPolicy == nil || apiExport.Spec.MaximalPermissionPolicy.Local == nil {
+		conditions.Delete(apiExport, apisv1alpha1.APIExportMaximalPermissionPolicySatisfiable)
+		return nil
+	}
+
+	clusterName := logicalcluster.From(apiExport)
+	found := false
+	missing := sets.NewString()
+
+	clusterRoleBindings, err := c.listClusterRoleBindings(clusterName)
+	if err != nil {
+		return fmt.Errorf("error listing ClusterRoleBindings in %s: %w", clusterName, err)
+	}
+	for _, binding := range clusterRoleBindings {
+		if !hasMaximalPermissionPolicySubject(binding.Subjects) {
+			continue
+		}
+		found = true
+
+		if _, err := c.getClusterRole(clusterName, binding.RoleRef.Name); errors.IsNotFound(err) {
+			missing.Insert("ClusterRole " + binding.RoleRef.Name)
+		} else if err != nil {
+			return fmt.Errorf("error getting ClusterRole %s|%s: %w", clusterName, binding.RoleRef.Name, err)
+		}
+	}
+
+	roleBindings, err := c.listRoleBindings(clusterName)
+	if err != nil {
+		return fmt.Errorf("error listing RoleBindings in %s: %w", clusterName, err)
+	}
+	for _, binding := range roleBindings {
+		if !hasMaximalPermissionPolicySubject(binding.Subjects) {
+			continue
+		}
+		found = true
+
+		switch binding.RoleRef.Kind {
+		case "ClusterRole":
+			if _, err := c.getClusterRole(clusterName, binding.RoleRef.Name); errors.IsNotFound(err) {
+				missing.Insert("ClusterRole " + binding.RoleRef.Name)
+			} else if err != nil {
+				return fmt.Errorf("error getting ClusterRole %s|%s: %w", clusterName, binding.RoleRef.Name, err)
+			}
+		case "Role":
+			if _, err := c.getRole(clusterName, binding.Namespace, binding.RoleRef.Name); errors.IsNotFound(err) {
+				missing.Insert("Role " + binding.Namespace + "/" + binding.RoleRef.Name)
+			} else if err != nil {
+				return fmt.Errorf("error getting Role %s|%s/%s: %w", clusterName, binding.Namespace, binding.RoleRef.Name, err)
+			}
+		}
+	}
+
+	if !found {
+		conditions.MarkFalse(
+			apiExport,
+			apisv1alpha1.APIExportMaximalPermissionPolicySatisfiable,
+			apisv1alpha1.MaximalPermissionPolicyRBACMissingReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"No ClusterRoleBinding or RoleBinding grants permissions to users or groups prefixed with %q, all requests to bound resources are denied",
+			apisv1alpha1.MaximalPermissionPolicyRBACUserGroupPrefix,
+		)
+		return nil
+	}
+	if missing.Len() > 0 {
+		conditions.MarkFalse(
+			apiExport,
+			apisv1alpha1.APIExportMaximalPermissionPolicySatisfiable,
+			apisv1alpha1.MaximalPermissionPolicyRBACMissingReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"Roles referenced by the maximal permission policy do not exist: %s",
+			strings.Join(missing.List(), ", "),
+		)
+		return nil
+	}
+
+	conditions.MarkTrue(apiExport, apisv1alpha1.APIExportMaximalPermissionPolicySatisfiable)
+
+	return nil
+}
+
+// hasMaximalPermissionPolicySubject returns whether the given subjects contain a user or group
+// with the maximal permission policy prefix.
+func hasMaximalPermissionPolicySubject(subjects []rbacv1.Subject) bool {
+	for _, subject := range subjects {
+		if (subject.Kind == rbacv1.UserKind || subject.Kind == rbacv1.GroupKind) &&
+			strings.HasPrefix(subject.Name, apisv1alpha1.MaximalPermissionPolicyRBACUserGroupPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// findSelfClaims returns the permission claims of the given APIExport for resources exported by
+// the APIExport itself, i.e. claims with the identity of the APIExport and the group resource of
+// one of its latest resource schemas. Schemas that do not exist (yet) are skipped.
+func findSelfClaims(apiExport *apisv1alpha1.APIExport, getAPIResourceSchema func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error)) ([]string, error) {
+	var ownIdentityClaims []apisv1alpha1.PermissionClaim
+	for _, claim := range apiExport.Spec.PermissionClaims {
+		if claim.IdentityHash == apiExport.Status.IdentityHash {
+			ownIdentityClaims = append(ownIdentityClaims, claim)
+		}
+	}
+	if len(ownIdentityClaims) == 0 {
+		return nil, nil
+	}
+
+	clusterName := logicalcluster.From(apiExport)
+	exported := map[apisv1alpha1.GroupResource]bool{}
+	for _, schemaName := range apiExport.Spec.LatestResourceSchemas {
+		apiResourceSchema, err := getAPIResourceSchema(clusterName, schemaName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		exported[apisv1alpha1.GroupResource{Group: apiResourceSchema.Spec.Group, Resource: apiResourceSchema.Spec.Names.Plural}] = true
+	}
+
+	var claims []string
+	for _, claim := range ownIdentityClaims {
+		if exported[claim.GroupResource] {
+			claims = append(claims, claim.String())
+		}
+	}
+	return claims, nil
+}
+
+// findPermissionClaimCycle follows the permission claims of the given APIExport to the APIExports
+// providing the claimed resources, and their claims in turn. If a chain of claims leads back to the
+// identity of the given APIExport, the APIExports of that chain are returned. Claims of an APIExport
+// for its own identity are not followed.
+func findPermissionClaimCycle(apiExport *apisv1alpha1.APIExport, getAPIExportsByIdentity func(identityHash string) ([]*apisv1alpha1.APIExport, error)) ([]string, error) {
+	start := apiExport.Status.IdentityHash
+	visited := sets.NewString(start)
+
+	var visit func(export *apisv1alpha1.APIExport, chain []string) ([]string, error)
+	visit = func(export *apisv1alpha1.APIExport, chain []string) ([]string, error) {
+		chain = append(chain[:len(chain):len(chain)], logicalcluster.From(export).Path().Join(export.Name).String())
+
+		for _, claim := range export.Spec.PermissionClaims {
+			if claim.IdentityHash == "" || claim.IdentityHash == export.Status.IdentityHash {
+				continue
+			}
+			if claim.IdentityHash == start {
+				return append(chain, chain[0]), nil
+			}
+			if visited.Has(claim.IdentityHash) {
+				continue
+			}
+			visited.Insert(claim.IdentityHash)
+
+			claimedExports, err := getAPIExportsByIdentity(claim.IdentityHash)
+			if err != nil {
+				return nil, err
+			}
+			for _, claimedExport := range claimedExports {
+				if cycle, err := visit(claimedExport, chain); err != nil || len(cycle) > 0 {
+					return cycle, err
+				}
+			}
+		}
+
+		return nil, nil
+	}
+
+	return visit(apiExport, nil)
+}
+
+// findClaimsOfUnknownIdentities returns the permission claims of the APIExport for an identity hash
+// that no APIExport has, e.g. because the claimed APIExport rotated its identity.
+func findClaimsOfUnknownIdentities(apiExport *apisv1alpha1.APIExport, getAPIExportsByIdentity func(identityHash string) ([]*apisv1alpha1.APIExport, error)) ([]string, error) {
+	var unknown []string
+	for _, claim := range apiExport.Spec.PermissionClaims {
+		if claim.IdentityHash == "" || claim.IdentityHash == apiExport.Status.IdentityHash {
+			continue
+		}
+		claimedExports, err := getAPIExportsByIdentity(claim.IdentityHash)
+		if err != nil {
+			return nil, err
+		}
+		if len(claimedExports) == 0 {
+			unknown = append(unknown, claim.String())
+		}
+	}
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
+// findAmbiguousClaims returns the permission claims of the APIExport without identity hash for a
+// resource that APIExports export with more than one identity, together with those identities.
+func findAmbiguousClaims(apiExport *apisv1alpha1.APIExport, getAPIExportsByExportedGroupResource func(gr apisv1alpha1.GroupResource) ([]*apisv1alpha1.APIExport, error)) ([]string, error) {
+	var ambiguous []string
+	for _, claim := range apiExport.Spec.PermissionClaims {
+		if claim.IdentityHash != "" {
+			continue
+		}
+		exports, err := getAPIExportsByExportedGroupResource(claim.GroupResource)
+		if err != nil {
+			return nil, err
+		}
+		identities := sets.NewString()
+		for _, export := range exports {
+			if export.Status.IdentityHash != "" {
+				identities.Insert(export.Status.IdentityHash)
+			}
+		}
+		if identities.Len() > 1 {
+			ambiguous = append(ambiguous, fmt.Sprintf("%s is exported with identities %s", claim.String(), strings.Join(identities.List(), ", ")))
+		}
+	}
+	sort.Strings(ambiguous)
+	return ambiguous, nil
+}
+
 func (c *controller) updateVirtualWorkspaceURLs(ctx context.Context, apiExport *apisv1alpha1.APIExport) error {
 	logger := klog.FromContext(ctx)
 	shards, err := c.listShards()
@@ -185,6 +502,7 @@ func (c *controller) updateVirtualWorkspaceURLs(ctx context.Context, apiExport *
 	}
 
 	desiredURLs := sets.NewString()
+	var shardURLs []apisv1alpha1.APIExportVirtualWorkspaceReference
 	for _, shard := range shards {
 		logger = logging.WithObject(logger, shard)
 		if shard.Spec.VirtualWorkspaceURL == "" {
@@ -211,7 +529,15 @@ func (c *controller) updateVirtualWorkspaceURLs(ctx context.Context, apiExport *
 		)
 
 		desiredURLs.Insert(u.String())
+		shardURLs = append(shardURLs, apisv1alpha1.APIExportVirtualWorkspaceReference{
+			Shard: shard.Name,
+			URL:   u.String(),
+		})
 	}
+	sort.Slice(shardURLs, func(i, j int) bool {
+		return shardURLs[i].Shard < shardURLs[j].Shard
+	})
+	apiExport.Status.VirtualWorkspaceURLs = shardURLs
 
 	//nolint:staticcheck // SA1019 VirtualWorkspaces is deprecated but not removed yet
 	apiExport.Status.VirtualWorkspaces = nil
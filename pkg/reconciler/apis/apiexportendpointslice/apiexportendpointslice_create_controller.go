@@ -0,0 +1,215 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportendpointslice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	apisinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/logging"
+)
+
+const (
+	CreateControllerName = "kcp-apiexportendpointslice-create"
+)
+
+// NewCreateController returns a new controller creating an APIExportEndpointSlice for the
+// APIExports of this shard that opt in via the apis.kcp.io/create-endpointslice annotation.
+// The endpoints of the created slices are then maintained like those of any other slice.
+func NewCreateController(
+	apiExportClusterInformer apisinformers.APIExportClusterInformer,
+	apiExportEndpointSliceClusterInformer apisinformers.APIExportEndpointSliceClusterInformer,
+	kcpClusterClient kcpclientset.ClusterInterface,
+) (*createController, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), CreateControllerName)
+
+	c := &createController{
+		queue: queue,
+		getAPIExport: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+			return apiExportClusterInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		getAPIExportEndpointSlice: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExportEndpointSlice, error) {
+			return apiExportEndpointSliceClusterInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		createAPIExportEndpointSlice: func(ctx context.Context, clusterName logicalcluster.Path, slice *apisv1alpha1.APIExportEndpointSlice) error {
+			_, err := kcpClusterClient.Cluster(clusterName).ApisV1alpha1().APIExportEndpointSlices().Create(ctx, slice, metav1.CreateOptions{})
+			return err
+		},
+	}
+
+	apiExportClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueAPIExport(obj)
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			c.enqueueAPIExport(newObj)
+		},
+	})
+
+	// recreate the APIExportEndpointSlice of an APIExport when it is deleted.
+	apiExportEndpointSliceClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			c.enqueueAPIExport(obj)
+		},
+	})
+
+	return c, nil
+}
+
+// createController creates the APIExportEndpointSlices of opted-in APIExports.
+type createController struct {
+	queue workqueue.RateLimitingInterface
+
+	getAPIExport                 func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error)
+	getAPIExportEndpointSlice    func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExportEndpointSlice, error)
+	createAPIExportEndpointSlice func(ctx context.Context, clusterName logicalcluster.Path, slice *apisv1alpha1.APIExportEndpointSlice) error
+}
+
+// enqueueAPIExport enqueues the APIExport of the given APIExport or APIExportEndpointSlice. Both
+// share the key, as the slice of an APIExport is named like the APIExport.
+func (c *createController) enqueueAPIExport(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), CreateControllerName), key)
+	logger.V(4).Info("queueing APIExport")
+	c.queue.Add(key)
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *createController) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), CreateControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *createController) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *createController) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	if err := c.process(ctx, key); err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", CreateControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *createController) process(ctx context.Context, key string) error {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	apiExport, err := c.getAPIExport(clusterName, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+
+	logger := logging.WithObject(klog.FromContext(ctx), apiExport)
+	ctx = klog.NewContext(ctx, logger)
+
+	return c.reconcile(ctx, apiExport)
+}
+
+// reconcile creates an APIExportEndpointSlice with the name of the APIExport in its logical
+// cluster, if the APIExport opts in via the annotation. Existing slices of that name are left alone.
+func (c *createController) reconcile(ctx context.Context, apiExport *apisv1alpha1.APIExport) error {
+	if apiExport.Annotations[apisv1alpha1.AnnotationAPIExportEndpointSliceKey] != "true" {
+		return nil
+	}
+
+	clusterName := logicalcluster.From(apiExport)
+	_, err := c.getAPIExportEndpointSlice(clusterName, apiExport.Name)
+	if err == nil {
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return fmt.Errorf("error getting APIExportEndpointSlice %s|%s: %w", clusterName, apiExport.Name, err)
+	}
+
+	slice := &apisv1alpha1.APIExportEndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: apiExport.Name,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(apiExport, apisv1alpha1.SchemeGroupVersion.WithKind("APIExport")),
+			},
+		},
+		Spec: apisv1alpha1.APIExportEndpointSliceSpec{
+			// an empty path refers to the APIExport in the logical cluster of the slice.
+			APIExport: apisv1alpha1.ExportBindingReference{
+				Name: apiExport.Name,
+			},
+		},
+	}
+
+	logger := logging.WithObject(klog.FromContext(ctx), slice)
+	logger.V(2).Info("creating APIExportEndpointSlice")
+	if err := c.createAPIExportEndpointSlice(ctx, clusterName.Path(), slice); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("error creating APIExportEndpointSlice %s|%s: %w", clusterName, apiExport.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportendpointslice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+)
+
+func TestCreateReconcile(t *testing.T) {
+	optIn := map[string]string{apisv1alpha1.AnnotationAPIExportEndpointSliceKey: "true"}
+
+	tests := map[string]struct {
+		annotations map[string]string
+		sliceExists bool
+		createError error
+		wantCreated bool
+		wantErr     bool
+	}{
+		"slice is not created without opt-in": {},
+		"slice is not created with an opt-in other than true": {
+			annotations: map[string]string{apisv1alpha1.AnnotationAPIExportEndpointSliceKey: "false"},
+		},
+		"slice is created when missing": {
+			annotations: optIn,
+			wantCreated: true,
+		},
+		"existing slice is left alone": {
+			annotations: optIn,
+			sliceExists: true,
+		},
+		"concurrently created slice is no error": {
+			annotations: optIn,
+			createError: apierrors.NewAlreadyExists(apisv1alpha1.Resource("apiexportendpointslices"), "cowboys"),
+			wantCreated: true,
+		},
+		"create error is returned": {
+			annotations: optIn,
+			createError: errors.New("boom"),
+			wantCreated: true,
+			wantErr:     true,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var created *apisv1alpha1.APIExportEndpointSlice
+			c := &createController{
+				getAPIExportEndpointSlice: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExportEndpointSlice, error) {
+					if tc.sliceExists {
+						return &apisv1alpha1.APIExportEndpointSlice{}, nil
+					}
+					return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiexportendpointslices"), name)
+				},
+				createAPIExportEndpointSlice: func(ctx context.Context, clusterName logicalcluster.Path, slice *apisv1alpha1.APIExportEndpointSlice) error {
+					require.Equal(t, logicalcluster.NewPath("root:org:ws"), clusterName)
+					created = slice
+					return tc.createError
+				},
+			}
+
+			annotations := map[string]string{logicalcluster.AnnotationKey: "root:org:ws"}
+			for k, v := range tc.annotations {
+				annotations[k] = v
+			}
+			apiExport := &apisv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: annotations,
+					Name:        "cowboys",
+					UID:         "uid",
+				},
+			}
+
+			err := c.reconcile(context.Background(), apiExport)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if !tc.wantCreated {
+				require.Nil(t, created, "expected no APIExportEndpointSlice to be created")
+				return
+			}
+			require.NotNil(t, created, "expected an APIExportEndpointSlice to be created")
+			require.Equal(t, "cowboys", created.Name)
+			require.Equal(t, apisv1alpha1.ExportBindingReference{Name: "cowboys"}, created.Spec.APIExport)
+			require.Len(t, created.OwnerReferences, 1)
+			require.Equal(t, "APIExport", created.OwnerReferences[0].Kind)
+			require.Equal(t, apiExport.UID, created.OwnerReferences[0].UID)
+		})
+	}
+}
@@ -24,9 +24,16 @@ import (
 	"github.com/go-logr/logr"
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
 	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
+	kcprbacinformers "github.com/kcp-dev/client-go/informers/rbac/v1"
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -35,6 +42,7 @@ import (
 	"k8s.io/klog/v2"
 
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
 	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
 	apisv1alpha1client "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/apis/v1alpha1"
 	apisv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
@@ -43,6 +51,7 @@ import (
 	"github.com/kcp-dev/kcp/pkg/informer"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	rbacwrapper "github.com/kcp-dev/kcp/pkg/virtual/framework/wrappers/rbac"
 )
 
 const (
@@ -57,6 +66,9 @@ func NewController(
 	dynamicDiscoverySharedInformerFactory *informer.DiscoveringDynamicSharedInformerFactory,
 	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
 	apiExportInformer, globalAPIExportInformer apisv1alpha1informers.APIExportClusterInformer,
+	namespaceInformer kcpcorev1informers.NamespaceClusterInformer,
+	clusterRoleBindingInformer, globalClusterRoleBindingInformer kcprbacinformers.ClusterRoleBindingClusterInformer,
+	clusterRoleInformer, globalClusterRoleInformer kcprbacinformers.ClusterRoleClusterInformer,
 ) (*controller, error) {
 	logger := logging.WithReconciler(klog.Background(), ControllerName)
 
@@ -81,12 +93,44 @@ func NewController(
 			return obj, nil
 		},
 
+		getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+			return indexers.APIExportIndexers.ByIdentityHash.ByKey(globalAPIExportInformer.Informer().GetIndexer(), identityHash)
+		},
+
+		listAPIExports: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExport, error) {
+			return globalAPIExportInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+
+		listAPIBindingsByClaimedIdentity: func(identityHash string) ([]*apisv1alpha1.APIBinding, error) {
+			return indexers.APIBindingIndexers.ByAppliedClaimedIdentityHash.ByKey(apiBindingInformer.Informer().GetIndexer(), identityHash)
+		},
+
+		listNamespaces: func(clusterName logicalcluster.Name) ([]*corev1.Namespace, error) {
+			return namespaceInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+
+		// the ClusterRoles and ClusterRoleBindings of maximal permission policies are replicated to the cache server.
+		listClusterRoleBindings: func(clusterName logicalcluster.Name) ([]*rbacv1.ClusterRoleBinding, error) {
+			return rbacwrapper.NewMergedClusterRoleBindingLister(
+				clusterRoleBindingInformer.Lister().Cluster(clusterName),
+				globalClusterRoleBindingInformer.Lister().Cluster(clusterName),
+			).List(labels.Everything())
+		},
+		getClusterRole: func(clusterName logicalcluster.Name, name string) (*rbacv1.ClusterRole, error) {
+			return rbacwrapper.NewMergedClusterRoleLister(
+				clusterRoleInformer.Lister().Cluster(clusterName),
+				globalClusterRoleInformer.Lister().Cluster(clusterName),
+			).Get(name)
+		},
+
 		commit: committer.NewCommitter[*APIBinding, Patcher, *APIBindingSpec, *APIBindingStatus](kcpClusterClient.ApisV1alpha1().APIBindings()),
 	}
 
 	indexers.AddIfNotPresentOrDie(apiExportInformer.Informer().GetIndexer(), cache.Indexers{
 		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
 	})
+	indexers.APIExportIndexers.ByIdentityHash.AddIfNotPresentOrDie(globalAPIExportInformer.Informer().GetIndexer())
+	indexers.APIBindingIndexers.ByAppliedClaimedIdentityHash.AddIfNotPresentOrDie(apiBindingInformer.Informer().GetIndexer())
 
 	apiBindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) { c.enqueueAPIBinding(obj, logger) },
@@ -96,6 +140,51 @@ func NewController(
 		DeleteFunc: func(obj interface{}) { c.enqueueAPIBinding(obj, logger) },
 	})
 
+	// the maximal permission policies of the APIExports providing claimed resources decide whether claims are authorized.
+	globalAPIExportInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { c.enqueueAPIBindingsForAPIExport(obj, logger) },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldExport, newExport := oldObj.(*apisv1alpha1.APIExport), newObj.(*apisv1alpha1.APIExport)
+			if !equality.Semantic.DeepEqual(oldExport.Spec.MaximalPermissionPolicy, newExport.Spec.MaximalPermissionPolicy) ||
+				!equality.Semantic.DeepEqual(
+					conditions.Get(oldExport, apisv1alpha1.APIExportMaximalPermissionPolicySatisfiable),
+					conditions.Get(newExport, apisv1alpha1.APIExportMaximalPermissionPolicySatisfiable),
+				) {
+				c.enqueueAPIBindingsForAPIExport(newObj, logger)
+			}
+			// bindings with claims for the previous identity are invalid after an identity rotation.
+			if oldExport.Status.IdentityHash != newExport.Status.IdentityHash {
+				c.enqueueAPIBindingsForAPIExport(oldObj, logger)
+			}
+		},
+		DeleteFunc: func(obj interface{}) { c.enqueueAPIBindingsForAPIExport(obj, logger) },
+	})
+
+	// the RBAC of the maximal permission policies decides whether claims are authorized.
+	for _, informer := range []cache.SharedIndexInformer{
+		clusterRoleBindingInformer.Informer(),
+		globalClusterRoleBindingInformer.Informer(),
+		clusterRoleInformer.Informer(),
+		globalClusterRoleInformer.Informer(),
+	} {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueAPIBindingsForRBAC(obj, logger) },
+			UpdateFunc: func(_, newObj interface{}) { c.enqueueAPIBindingsForRBAC(newObj, logger) },
+			DeleteFunc: func(obj interface{}) { c.enqueueAPIBindingsForRBAC(obj, logger) },
+		})
+	}
+
+	// namespace labels decide whether namespace selectors of permission claims can be satisfied.
+	namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { c.enqueueAPIBindingsForNamespace(obj, logger) },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if !equality.Semantic.DeepEqual(oldObj.(*corev1.Namespace).Labels, newObj.(*corev1.Namespace).Labels) {
+				c.enqueueAPIBindingsForNamespace(newObj, logger)
+			}
+		},
+		DeleteFunc: func(obj interface{}) { c.enqueueAPIBindingsForNamespace(obj, logger) },
+	})
+
 	return c, nil
 }
 
@@ -117,8 +206,16 @@ type controller struct {
 	dynamicClusterClient kcpdynamic.ClusterInterface
 	ddsif                *informer.DiscoveringDynamicSharedInformerFactory
 
-	apiBindingsLister apisv1alpha1listers.APIBindingClusterLister
-	getAPIExport      func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error)
+	apiBindingsLister       apisv1alpha1listers.APIBindingClusterLister
+	getAPIExport            func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error)
+	getAPIExportsByIdentity func(identityHash string) ([]*apisv1alpha1.APIExport, error)
+	listAPIExports          func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExport, error)
+	listNamespaces          func(clusterName logicalcluster.Name) ([]*corev1.Namespace, error)
+
+	listAPIBindingsByClaimedIdentity func(identityHash string) ([]*apisv1alpha1.APIBinding, error)
+
+	listClusterRoleBindings func(clusterName logicalcluster.Name) ([]*rbacv1.ClusterRoleBinding, error)
+	getClusterRole          func(clusterName logicalcluster.Name, name string) (*rbacv1.ClusterRole, error)
 
 	commit CommitFunc
 }
@@ -135,6 +232,83 @@ func (c *controller) enqueueAPIBinding(obj interface{}, logger logr.Logger) {
 	c.queue.Add(key)
 }
 
+// enqueueAPIBindingsForNamespace enqueues the APIBindings in the logical cluster of the given namespace
+// that accepted a permission claim with a namespace selector.
+func (c *controller) enqueueAPIBindingsForNamespace(obj interface{}, logger logr.Logger) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("unexpected object type %T", obj))
+		return
+	}
+
+	bindings, err := c.apiBindingsLister.Cluster(logicalcluster.From(ns)).List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, binding := range bindings {
+		for _, claim := range binding.Spec.PermissionClaims {
+			if claim.State == apisv1alpha1.ClaimAccepted && claim.NamespaceSelector != nil {
+				c.enqueueAPIBinding(binding, logging.WithObject(logger, ns))
+				break
+			}
+		}
+	}
+}
+
+// enqueueAPIBindingsForAPIExport enqueues the APIBindings that applied a permission claim for a resource
+// provided by the given APIExport.
+func (c *controller) enqueueAPIBindingsForAPIExport(obj interface{}, logger logr.Logger) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	export, ok := obj.(*apisv1alpha1.APIExport)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("unexpected object type %T", obj))
+		return
+	}
+	if export.Status.IdentityHash == "" {
+		return
+	}
+
+	bindings, err := c.listAPIBindingsByClaimedIdentity(export.Status.IdentityHash)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, binding := range bindings {
+		c.enqueueAPIBinding(binding, logging.WithObject(logger, export))
+	}
+}
+
+// enqueueAPIBindingsForRBAC enqueues the APIBindings that applied a permission claim for a resource
+// provided by an APIExport with a local maximal permission policy in the logical cluster of the given
+// ClusterRole or ClusterRoleBinding.
+func (c *controller) enqueueAPIBindingsForRBAC(obj interface{}, logger logr.Logger) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	metaObj, ok := obj.(metav1.Object)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("unexpected object type %T", obj))
+		return
+	}
+
+	exports, err := c.listAPIExports(logicalcluster.From(metaObj))
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, export := range exports {
+		if export.Spec.MaximalPermissionPolicy != nil && export.Spec.MaximalPermissionPolicy.Local != nil {
+			c.enqueueAPIBindingsForAPIExport(export, logger)
+		}
+	}
+}
+
 // Start starts the controller, which stops when ctx.Done() is closed.
 func (c *controller) Start(ctx context.Context, numThreads int) {
 	defer runtime.HandleCrash()
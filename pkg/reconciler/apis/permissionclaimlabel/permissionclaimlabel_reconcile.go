@@ -24,6 +24,7 @@ import (
 	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -32,8 +33,10 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	aggregateerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/apiserver/pkg/endpoints/handlers"
 	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/plugin/pkg/auth/authorizer/rbac"
 
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
@@ -84,7 +87,7 @@ func (c *controller) reconcile(ctx context.Context, apiBinding *apisv1alpha1.API
 
 	appliedClaims := sets.NewString()
 	for _, claim := range apiBinding.Status.AppliedPermissionClaims {
-		appliedClaims.Insert(setKeyForClaim(claim))
+		appliedClaims.Insert(setKeyForClaim(claim.PermissionClaim))
 	}
 
 	expectedClaims := exportedClaims.Intersection(acceptedClaims)
@@ -180,6 +183,10 @@ func (c *controller) reconcile(ctx context.Context, apiBinding *apisv1alpha1.API
 		}
 	}
 
+	if err := c.updateNamespaceSelectorsSatisfied(apiBinding, apiExport, expectedClaims); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	unexpectedOrInvalidErrors := make([]error, 0, unexpectedClaims.Len())
 	for _, s := range unexpectedClaims.List() {
 		claim := claimFromSetKey(s)
@@ -202,16 +209,37 @@ func (c *controller) reconcile(ctx context.Context, apiBinding *apisv1alpha1.API
 			len(errsToDisplay.Errors()),
 			errsToDisplay,
 		)
+	} else if unknown, err := c.findClaimsOfUnknownIdentities(expectedClaims); err != nil {
+		allErrs = append(allErrs, err)
+	} else if len(unknown) > 0 {
+		conditions.MarkFalse(
+			apiBinding,
+			apisv1alpha1.PermissionClaimsValid,
+			apisv1alpha1.PermissionClaimIdentityNotFoundReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"Accepted permission claims reference identities no APIExport has: %s. The claimed APIExport might have rotated its identity, accept the claims again once APIExport %s|%s updated them",
+			strings.Join(unknown, ", "),
+			logicalcluster.From(apiExport),
+			apiExport.Name,
+		)
 	} else {
 		conditions.MarkTrue(apiBinding, apisv1alpha1.PermissionClaimsValid)
 	}
 
 	fullyApplied := expectedClaims.Difference(applyErrors)
-	apiBinding.Status.AppliedPermissionClaims = []apisv1alpha1.PermissionClaim{}
+	apiBinding.Status.AppliedPermissionClaims = []apisv1alpha1.AppliedPermissionClaim{}
 	for _, s := range fullyApplied.List() {
 		// fullyApplied = (exportedClaims ∩ acceptedClaims) ⊖ applyErrors,
 		// hence s must be in acceptedClaims (and exportedClaims).
-		apiBinding.Status.AppliedPermissionClaims = append(apiBinding.Status.AppliedPermissionClaims, acceptedClaimsMap[s])
+		claim := acceptedClaimsMap[s]
+		authorized, err := c.isClaimAuthorized(claim)
+		if err != nil {
+			allErrs = append(allErrs, fmt.Errorf("error evaluating maximal permission policies for group=%q, resource=%q: %w", claim.Group, claim.Resource, err))
+		}
+		apiBinding.Status.AppliedPermissionClaims = append(apiBinding.Status.AppliedPermissionClaims, apisv1alpha1.AppliedPermissionClaim{
+			PermissionClaim: claim,
+			Authorized:      authorized,
+		})
 	}
 
 	if len(allErrs) > 0 {
@@ -244,6 +272,174 @@ func (c *controller) reconcile(ctx context.Context, apiBinding *apisv1alpha1.API
 	return nil
 }
 
+// isClaimAuthorized returns whether the maximal permission policies of the APIExports providing the
+// claimed resource authorize access to it. Like the maximal permission policy authorizer of the APIExport
+// virtual workspace, all of these APIExports have to grant access, and a claim whose identity hash no
+// APIExport provides yet is not authorized.
+func (c *controller) isClaimAuthorized(claim apisv1alpha1.PermissionClaim) (bool, error) {
+	if claim.IdentityHash == "" {
+		// core Kubernetes and system resources, for which no maximal permission policy can exist.
+		return true, nil
+	}
+
+	apiExports, err := c.getAPIExportsByIdentity(claim.IdentityHash)
+	if err != nil {
+		return false, err
+	}
+	if len(apiExports) == 0 {
+		return false, nil
+	}
+
+	for _, apiExport := range apiExports {
+		if apiExport.Spec.MaximalPermissionPolicy == nil || apiExport.Spec.MaximalPermissionPolicy.Local == nil {
+			continue
+		}
+		allowed, err := c.maximalPermissionPolicyAllows(apiExport, claim)
+		if err != nil {
+			return false, err
+		}
+		if !allowed {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// maximalPermissionPolicyAllows returns whether the local maximal permission policy of the given APIExport
+// allows all verbs of the claim on the claimed resource. A claim covers the resource in all namespaces,
+// hence only ClusterRoleBindings to users or groups with the maximal permission policy prefix are
+// considered. A claim without verbs claims all verbs.
+func (c *controller) maximalPermissionPolicyAllows(apiExport *apisv1alpha1.APIExport, claim apisv1alpha1.PermissionClaim) (bool, error) {
+	clusterName := logicalcluster.From(apiExport)
+
+	clusterRoleBindings, err := c.listClusterRoleBindings(clusterName)
+	if err != nil {
+		return false, fmt.Errorf("error listing ClusterRoleBindings in %s: %w", clusterName, err)
+	}
+	var rules []rbacv1.PolicyRule
+	for _, binding := range clusterRoleBindings {
+		if binding.RoleRef.Kind != "ClusterRole" || !hasMaximalPermissionPolicySubject(binding.Subjects) {
+			continue
+		}
+		clusterRole, err := c.getClusterRole(clusterName, binding.RoleRef.Name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, fmt.Errorf("error getting ClusterRole %s|%s: %w", clusterName, binding.RoleRef.Name, err)
+		}
+		rules = append(rules, clusterRole.Rules...)
+	}
+
+	verbs := claim.Verbs
+	if len(verbs) == 0 {
+		verbs = []string{rbacv1.VerbAll}
+	}
+	for _, verb := range verbs {
+		attr := authorizer.AttributesRecord{
+			Verb:            verb,
+			APIGroup:        claim.Group,
+			Resource:        claim.Resource,
+			ResourceRequest: true,
+		}
+		if !rbac.RulesAllow(attr, rules...) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// hasMaximalPermissionPolicySubject returns whether the given subjects contain a user or group
+// with the maximal permission policy prefix.
+func hasMaximalPermissionPolicySubject(subjects []rbacv1.Subject) bool {
+	for _, subject := range subjects {
+		if (subject.Kind == rbacv1.UserKind || subject.Kind == rbacv1.GroupKind) &&
+			strings.HasPrefix(subject.Name, apisv1alpha1.MaximalPermissionPolicyRBACUserGroupPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// findClaimsOfUnknownIdentities returns the given claims, as set keys, whose identity hash no APIExport has,
+// e.g. because the claimed APIExport rotated its identity.
+func (c *controller) findClaimsOfUnknownIdentities(claims sets.String) ([]string, error) {
+	var unknown []string
+	for _, s := range claims.List() {
+		claim := claimFromSetKey(s)
+		if claim.IdentityHash == "" {
+			continue
+		}
+		apiExports, err := c.getAPIExportsByIdentity(claim.IdentityHash)
+		if err != nil {
+			return nil, err
+		}
+		if len(apiExports) == 0 {
+			unknown = append(unknown, claim.String())
+		}
+	}
+	return unknown, nil
+}
+
+// updateNamespaceSelectorsSatisfied checks that for every accepted claim of the APIExport with a
+// namespace selector, some namespace in the logical cluster of the APIBinding matches the selector.
+// Otherwise, no claimed object is visible to the APIExport owner through the claim.
+func (c *controller) updateNamespaceSelectorsSatisfied(apiBinding *apisv1alpha1.APIBinding, apiExport *apisv1alpha1.APIExport, acceptedClaims sets.String) error {
+	var selectorClaims []apisv1alpha1.PermissionClaim
+	for _, claim := range apiExport.Spec.PermissionClaims {
+		if claim.NamespaceSelector != nil && acceptedClaims.Has(setKeyForClaim(claim)) {
+			selectorClaims = append(selectorClaims, claim)
+		}
+	}
+	if len(selectorClaims) == 0 {
+		conditions.Delete(apiBinding, apisv1alpha1.PermissionClaimNamespaceSelectorsSatisfied)
+		return nil
+	}
+
+	clusterName := logicalcluster.From(apiBinding)
+	namespaces, err := c.listNamespaces(clusterName)
+	if err != nil {
+		return fmt.Errorf("error listing namespaces in %s: %w", clusterName, err)
+	}
+
+	var unsatisfied []string
+	for _, claim := range selectorClaims {
+		selector, err := metav1.LabelSelectorAsSelector(claim.NamespaceSelector)
+		if err != nil {
+			unsatisfied = append(unsatisfied, fmt.Sprintf("%s (invalid namespace selector: %v)", claim.String(), err))
+			continue
+		}
+
+		found := false
+		for _, ns := range namespaces {
+			if selector.Matches(labels.Set(ns.Labels)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unsatisfied = append(unsatisfied, fmt.Sprintf("%s (namespace selector %q)", claim.String(), selector.String()))
+		}
+	}
+
+	if len(unsatisfied) > 0 {
+		conditions.MarkFalse(
+			apiBinding,
+			apisv1alpha1.PermissionClaimNamespaceSelectorsSatisfied,
+			apisv1alpha1.NoMatchingNamespacesReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"No namespace matches the namespace selector of accepted permission claims: %s",
+			strings.Join(unsatisfied, ", "),
+		)
+		return nil
+	}
+
+	conditions.MarkTrue(apiBinding, apisv1alpha1.PermissionClaimNamespaceSelectorsSatisfied)
+
+	return nil
+}
+
 func setKeyForClaim(claim apisv1alpha1.PermissionClaim) string {
 	return fmt.Sprintf("%s/%s/%s", claim.Resource, claim.Group, claim.IdentityHash)
 }
@@ -20,9 +20,18 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/stretchr/testify/require"
 
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
 )
 
 func TestClaimSetKeys(t *testing.T) {
@@ -72,3 +81,227 @@ func TestClaimSetKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateNamespaceSelectorsSatisfied(t *testing.T) {
+	configmaps := apisv1alpha1.PermissionClaim{
+		GroupResource:     apisv1alpha1.GroupResource{Resource: "configmaps"},
+		All:               true,
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+	}
+	secrets := apisv1alpha1.PermissionClaim{
+		GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"},
+		All:           true,
+	}
+	apiExport := &apisv1alpha1.APIExport{
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{configmaps, secrets},
+		},
+	}
+	apiBinding := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "root:consumer"},
+			Name:        "binding",
+		},
+	}
+
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Labels: map[string]string{"tenant": "a"}}},
+	}
+	c := &controller{
+		listNamespaces: func(clusterName logicalcluster.Name) ([]*corev1.Namespace, error) {
+			require.Equal(t, logicalcluster.Name("root:consumer"), clusterName)
+			return namespaces, nil
+		},
+	}
+
+	t.Log("Without accepted claims with namespace selector there is no condition")
+	require.NoError(t, c.updateNamespaceSelectorsSatisfied(apiBinding, apiExport, sets.NewString(setKeyForClaim(secrets))))
+	require.Nil(t, conditions.Get(apiBinding, apisv1alpha1.PermissionClaimNamespaceSelectorsSatisfied))
+
+	accepted := sets.NewString(setKeyForClaim(configmaps), setKeyForClaim(secrets))
+
+	t.Log("A labeled namespace satisfies the namespace selector")
+	require.NoError(t, c.updateNamespaceSelectorsSatisfied(apiBinding, apiExport, accepted))
+	require.True(t, conditions.IsTrue(apiBinding, apisv1alpha1.PermissionClaimNamespaceSelectorsSatisfied))
+
+	t.Log("Without a labeled namespace the namespace selector cannot be satisfied")
+	namespaces = namespaces[:1]
+	require.NoError(t, c.updateNamespaceSelectorsSatisfied(apiBinding, apiExport, accepted))
+	require.True(t, conditions.IsFalse(apiBinding, apisv1alpha1.PermissionClaimNamespaceSelectorsSatisfied))
+	require.Equal(t, apisv1alpha1.NoMatchingNamespacesReason, conditions.GetReason(apiBinding, apisv1alpha1.PermissionClaimNamespaceSelectorsSatisfied))
+	require.Equal(t, conditionsv1alpha1.ConditionSeverityWarning, *conditions.GetSeverity(apiBinding, apisv1alpha1.PermissionClaimNamespaceSelectorsSatisfied))
+	require.Equal(t, `No namespace matches the namespace selector of accepted permission claims: configmaps (namespace selector "tenant=a")`,
+		conditions.GetMessage(apiBinding, apisv1alpha1.PermissionClaimNamespaceSelectorsSatisfied))
+}
+
+func TestIsClaimAuthorized(t *testing.T) {
+	withPolicy := func(clusterName string) *apisv1alpha1.APIExport {
+		return &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{logicalcluster.AnnotationKey: clusterName},
+				Name:        "wild.wild.west",
+			},
+			Spec: apisv1alpha1.APIExportSpec{
+				MaximalPermissionPolicy: &apisv1alpha1.MaximalPermissionPolicy{Local: &apisv1alpha1.LocalAPIExportPolicy{}},
+			},
+		}
+	}
+	binding := func(clusterName, roleName, subject string) *rbacv1.ClusterRoleBinding {
+		return &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{logicalcluster.AnnotationKey: clusterName},
+				Name:        roleName,
+			},
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: subject}},
+			RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: roleName},
+		}
+	}
+	role := func(clusterName, name string, verbs ...string) *rbacv1.ClusterRole {
+		return &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{logicalcluster.AnnotationKey: clusterName},
+				Name:        name,
+			},
+			Rules: []rbacv1.PolicyRule{{APIGroups: []string{"wild.wild.west"}, Resources: []string{"sheriffs"}, Verbs: verbs}},
+		}
+	}
+	prefixed := apisv1alpha1.MaximalPermissionPolicyRBACUserGroupPrefix + "user"
+
+	tests := map[string]struct {
+		identityHash        string
+		verbs               []string
+		apiExports          []*apisv1alpha1.APIExport
+		clusterRoleBindings []*rbacv1.ClusterRoleBinding
+		clusterRoles        []*rbacv1.ClusterRole
+		want                bool
+	}{
+		"claim without identity hash": {
+			want: true,
+		},
+		"no APIExport providing the identity": {
+			identityHash: "hash",
+			want:         false,
+		},
+		"APIExport without maximal permission policy": {
+			identityHash: "hash",
+			apiExports:   []*apisv1alpha1.APIExport{{}},
+			want:         true,
+		},
+		"maximal permission policy without RBAC": {
+			identityHash: "hash",
+			apiExports:   []*apisv1alpha1.APIExport{withPolicy("root:provider")},
+			want:         false,
+		},
+		"maximal permission policy granting the claimed verbs": {
+			identityHash:        "hash",
+			verbs:               []string{"get", "list"},
+			apiExports:          []*apisv1alpha1.APIExport{withPolicy("root:provider")},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{binding("root:provider", "sheriffs", prefixed)},
+			clusterRoles:        []*rbacv1.ClusterRole{role("root:provider", "sheriffs", "get", "list", "watch")},
+			want:                true,
+		},
+		"maximal permission policy not granting all claimed verbs": {
+			identityHash:        "hash",
+			verbs:               []string{"get", "delete"},
+			apiExports:          []*apisv1alpha1.APIExport{withPolicy("root:provider")},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{binding("root:provider", "sheriffs", prefixed)},
+			clusterRoles:        []*rbacv1.ClusterRole{role("root:provider", "sheriffs", "get", "list", "watch")},
+			want:                false,
+		},
+		"claim without verbs requires all verbs": {
+			identityHash:        "hash",
+			apiExports:          []*apisv1alpha1.APIExport{withPolicy("root:provider")},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{binding("root:provider", "sheriffs", prefixed)},
+			clusterRoles:        []*rbacv1.ClusterRole{role("root:provider", "sheriffs", "get", "list", "watch")},
+			want:                false,
+		},
+		"maximal permission policy granting all verbs": {
+			identityHash:        "hash",
+			apiExports:          []*apisv1alpha1.APIExport{withPolicy("root:provider")},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{binding("root:provider", "sheriffs", prefixed)},
+			clusterRoles:        []*rbacv1.ClusterRole{role("root:provider", "sheriffs", "*")},
+			want:                true,
+		},
+		"ClusterRoleBinding without prefixed subject": {
+			identityHash:        "hash",
+			verbs:               []string{"get"},
+			apiExports:          []*apisv1alpha1.APIExport{withPolicy("root:provider")},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{binding("root:provider", "sheriffs", "user")},
+			clusterRoles:        []*rbacv1.ClusterRole{role("root:provider", "sheriffs", "get")},
+			want:                false,
+		},
+		"missing ClusterRole": {
+			identityHash:        "hash",
+			verbs:               []string{"get"},
+			apiExports:          []*apisv1alpha1.APIExport{withPolicy("root:provider")},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{binding("root:provider", "sheriffs", prefixed)},
+			want:                false,
+		},
+		"one of several maximal permission policies not granting the claimed verbs": {
+			identityHash:        "hash",
+			verbs:               []string{"get"},
+			apiExports:          []*apisv1alpha1.APIExport{withPolicy("root:provider"), withPolicy("root:other")},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{binding("root:provider", "sheriffs", prefixed)},
+			clusterRoles:        []*rbacv1.ClusterRole{role("root:provider", "sheriffs", "get")},
+			want:                false,
+		},
+	}
+
+	for testName, tc := range tests {
+		t.Run(testName, func(t *testing.T) {
+			c := &controller{
+				getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+					require.Equal(t, tc.identityHash, identityHash)
+					return tc.apiExports, nil
+				},
+				listClusterRoleBindings: func(clusterName logicalcluster.Name) ([]*rbacv1.ClusterRoleBinding, error) {
+					var ret []*rbacv1.ClusterRoleBinding
+					for _, b := range tc.clusterRoleBindings {
+						if logicalcluster.From(b) == clusterName {
+							ret = append(ret, b)
+						}
+					}
+					return ret, nil
+				},
+				getClusterRole: func(clusterName logicalcluster.Name, name string) (*rbacv1.ClusterRole, error) {
+					for _, r := range tc.clusterRoles {
+						if logicalcluster.From(r) == clusterName && r.Name == name {
+							return r, nil
+						}
+					}
+					return nil, apierrors.NewNotFound(rbacv1.Resource("clusterroles"), name)
+				},
+			}
+
+			claim := apisv1alpha1.PermissionClaim{
+				GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"},
+				Verbs:         tc.verbs,
+				IdentityHash:  tc.identityHash,
+			}
+			authorized, err := c.isClaimAuthorized(claim)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, authorized)
+		})
+	}
+}
+
+func TestFindClaimsOfUnknownIdentities(t *testing.T) {
+	c := &controller{
+		getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+			if identityHash == "known" {
+				return []*apisv1alpha1.APIExport{{}}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	claims := sets.NewString(
+		setKeyForClaim(apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}}),
+		setKeyForClaim(apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Group: "wildwest.dev", Resource: "cowboys"}, IdentityHash: "known"}),
+		setKeyForClaim(apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}, IdentityHash: "rotated"}),
+	)
+	unknown, err := c.findClaimsOfUnknownIdentities(claims)
+	require.NoError(t, err)
+	require.Equal(t, []string{"sheriffs.wild.wild.west:rotated"}, unknown)
+}
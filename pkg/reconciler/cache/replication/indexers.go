@@ -23,13 +23,38 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
 	// ByShardAndLogicalClusterAndNamespaceAndName is the name for the index that indexes by an object's shard and logical cluster, namespace and name.
 	ByShardAndLogicalClusterAndNamespaceAndName = "kcp-byShardAndLogicalClusterAndNamespaceAndName"
+
+	// ByShard is the name for the index that indexes by an object's shard.
+	ByShard = "kcp-byShard"
 )
 
+// IndexByShard is an index function that indexes by an object's shard, i.e. the shard
+// the object has been replicated from. Objects without shard annotation are not indexed.
+func IndexByShard(obj interface{}) ([]string, error) {
+	a, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	// TODO: rename to genericapirequest.ShardNameAnnotationKey
+	shardName := a.GetAnnotations()[genericapirequest.AnnotationKey]
+	if shardName == "" {
+		return nil, nil
+	}
+	return []string{shardName}, nil
+}
+
+// ListByShard returns the objects of the given cache indexer that have been replicated from
+// the given shard. The indexer must have the ByShard index.
+func ListByShard(indexer cache.Indexer, shard string) ([]interface{}, error) {
+	return indexer.ByIndex(ByShard, shard)
+}
+
 // IndexByShardAndLogicalClusterAndNamespace is an index function that indexes by an object's shard and logical cluster, namespace and name.
 func IndexByShardAndLogicalClusterAndNamespace(obj interface{}) ([]string, error) {
 	a, err := meta.Accessor(obj)
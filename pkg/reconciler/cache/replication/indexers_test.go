@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"sort"
+	"testing"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestListByShard(t *testing.T) {
+	newObject := func(cluster, name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"kind":       "Elephant",
+				"metadata": map[string]interface{}{
+					"name": name,
+					"annotations": map[string]interface{}{
+						"kcp.io/cluster": cluster,
+					},
+				},
+			},
+		}
+	}
+
+	indexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{
+		ByShardAndLogicalClusterAndNamespaceAndName: IndexByShardAndLogicalClusterAndNamespace,
+		ByShard: IndexByShard,
+	})
+	require.NoError(t, indexer.Add(WithShardName(newObject("root:one", "dumbo"), "amber")))
+	require.NoError(t, indexer.Add(WithShardName(newObject("root:two", "jumbo"), "amber")))
+	require.NoError(t, indexer.Add(WithShardName(newObject("root:three", "babar"), "beta")))
+	require.NoError(t, indexer.Add(newObject("root:four", "without-shard")))
+
+	names := func(objs []interface{}) []string {
+		var ret []string
+		for _, obj := range objs {
+			ret = append(ret, obj.(*unstructured.Unstructured).GetName())
+		}
+		sort.Strings(ret)
+		return ret
+	}
+
+	objs, err := ListByShard(indexer, "amber")
+	require.NoError(t, err)
+	require.Equal(t, []string{"dumbo", "jumbo"}, names(objs))
+
+	objs, err = ListByShard(indexer, "beta")
+	require.NoError(t, err)
+	require.Equal(t, []string{"babar"}, names(objs))
+
+	objs, err = ListByShard(indexer, "unknown")
+	require.NoError(t, err)
+	require.Empty(t, objs)
+}
@@ -20,19 +20,24 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
 	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
 	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
@@ -44,14 +49,20 @@ import (
 	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
 	cacheclient "github.com/kcp-dev/kcp/pkg/cache/client"
 	"github.com/kcp-dev/kcp/pkg/cache/client/shard"
+	cacheserverbootstrap "github.com/kcp-dev/kcp/pkg/cache/server/bootstrap"
+	kcpscheme "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
 	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
 	"github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/events"
 )
 
 const (
 	// ControllerName hold this controller name.
 	ControllerName = "kcp-replication-controller"
+
+	// cacheGenerationCheckInterval is the interval in which the generation of the cache server is checked.
+	cacheGenerationCheckInterval = 30 * time.Second
 )
 
 // NewController returns a new replication controller.
@@ -59,18 +70,44 @@ const (
 // The replicated object will be placed under the same cluster as the original object.
 // In addition to that, all replicated objects will be placed under the shard taken from the shardName argument.
 // For example: shards/{shardName}/clusters/{clusterName}/apis/apis.kcp.io/v1alpha1/apiexports.
+//
+// Objects whose JSON serialization exceeds maxObjectSize bytes are not replicated, and a warning
+// event is emitted on them. Zero means unlimited.
 func NewController(
 	shardName string,
+	maxObjectSize int64,
 	dynamicCacheClient kcpdynamic.ClusterInterface,
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
 	localKcpInformers kcpinformers.SharedInformerFactory,
 	globalKcpInformers kcpinformers.SharedInformerFactory,
 	localKubeInformers kcpkubernetesinformers.SharedInformerFactory,
 	globalKubeInformers kcpkubernetesinformers.SharedInformerFactory,
 ) (*controller, error) {
+	eventBroadcaster := record.NewBroadcaster()
+
 	c := &controller{
 		shardName:          shardName,
+		maxObjectSize:      maxObjectSize,
 		queue:              workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
 		dynamicCacheClient: dynamicCacheClient,
+		kubeClusterClient:  kubeClusterClient,
+		observed:           map[string]time.Time{},
+
+		eventBroadcaster: eventBroadcaster,
+		recorder:         events.NewRecorder(eventBroadcaster, kcpscheme.Scheme, ControllerName),
+
+		getCacheGeneration: func(ctx context.Context) (string, error) {
+			// the cache server bootstraps its CRDs on start. With a non-persistent backend
+			// they are recreated with a new UID, which tells us that the cache lost its data.
+			ctx = cacheclient.WithShardInContext(ctx, cacheserverbootstrap.SystemCacheServerShard)
+			crd, err := dynamicCacheClient.Cluster(cacheserverbootstrap.SystemCRDLogicalCluster.Path()).
+				Resource(apiextensionsv1.SchemeGroupVersion.WithResource("customresourcedefinitions")).
+				Get(ctx, "apiexports.apis.kcp.io", metav1.GetOptions{})
+			if err != nil {
+				return "", err
+			}
+			return string(crd.GetUID()), nil
+		},
 
 		gvrs: map[schema.GroupVersionResource]replicatedGVR{
 			apisv1alpha1.SchemeGroupVersion.WithResource("apiexports"): {
@@ -146,38 +183,41 @@ func NewController(
 	}
 
 	for gvr, info := range c.gvrs {
-		indexers.AddIfNotPresentOrDie(
-			info.global.GetIndexer(),
-			cache.Indexers{
-				ByShardAndLogicalClusterAndNamespaceAndName: IndexByShardAndLogicalClusterAndNamespace,
-			},
-		)
-
-		// shadow gvr to get the right value in the closure
-		gvr := gvr
-
-		info.local.AddEventHandler(cache.FilteringResourceEventHandler{
-			FilterFunc: IsNoSystemClusterName,
-			Handler: cache.ResourceEventHandlerFuncs{
-				AddFunc:    func(obj interface{}) { c.enqueueObject(obj, gvr) },
-				UpdateFunc: func(_, obj interface{}) { c.enqueueObject(obj, gvr) },
-				DeleteFunc: func(obj interface{}) { c.enqueueObject(obj, gvr) },
-			},
-		})
-
-		info.global.AddEventHandler(cache.FilteringResourceEventHandler{
-			FilterFunc: IsNoSystemClusterName, // not really needed, but cannot harm
-			Handler: cache.ResourceEventHandlerFuncs{
-				AddFunc:    func(obj interface{}) { c.enqueueCacheObject(obj, gvr) },
-				UpdateFunc: func(_, obj interface{}) { c.enqueueCacheObject(obj, gvr) },
-				DeleteFunc: func(obj interface{}) { c.enqueueCacheObject(obj, gvr) },
-			},
-		})
+		c.addEventHandlers(gvr, info)
 	}
 
 	return c, nil
 }
 
+// addEventHandlers makes sure changes of the local and the cached objects of the given resource are replicated.
+func (c *controller) addEventHandlers(gvr schema.GroupVersionResource, info replicatedGVR) {
+	indexers.AddIfNotPresentOrDie(
+		info.global.GetIndexer(),
+		cache.Indexers{
+			ByShardAndLogicalClusterAndNamespaceAndName: IndexByShardAndLogicalClusterAndNamespace,
+			ByShard: IndexByShard,
+		},
+	)
+
+	info.local.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: IsNoSystemClusterName,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueObject(obj, gvr) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueueObject(obj, gvr) },
+			DeleteFunc: func(obj interface{}) { c.enqueueObject(obj, gvr) },
+		},
+	})
+
+	info.global.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: IsNoSystemClusterName, // not really needed, but cannot harm
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueCacheObject(obj, gvr) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueueCacheObject(obj, gvr) },
+			DeleteFunc: func(obj interface{}) { c.enqueueCacheObject(obj, gvr) },
+		},
+	})
+}
+
 func (c *controller) enqueueObject(obj interface{}, gvr schema.GroupVersionResource) {
 	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
 	if err != nil {
@@ -185,9 +225,33 @@ func (c *controller) enqueueObject(obj interface{}, gvr schema.GroupVersionResou
 		return
 	}
 	gvrKey := fmt.Sprintf("%s.%s.%s::%s", gvr.Version, gvr.Resource, gvr.Group, key)
+	c.observe(gvrKey, time.Now())
 	c.queue.Add(gvrKey)
 }
 
+// observe records the time a change of the local object under the given key was observed,
+// unless an earlier change is still waiting to be replicated.
+func (c *controller) observe(gvrKey string, t time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.observed == nil {
+		c.observed = map[string]time.Time{}
+	}
+	if existing, found := c.observed[gvrKey]; !found || t.Before(existing) {
+		c.observed[gvrKey] = t
+	}
+}
+
+// popObserved returns and forgets the time the oldest unreplicated change of the local
+// object under the given key was observed. It returns the zero time if none is recorded.
+func (c *controller) popObserved(gvrKey string) time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	t := c.observed[gvrKey]
+	delete(c.observed, gvrKey)
+	return t
+}
+
 func (c *controller) enqueueCacheObject(obj interface{}, gvr schema.GroupVersionResource) {
 	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
 	if err != nil {
@@ -208,12 +272,53 @@ func (c *controller) Start(ctx context.Context, workers int) {
 	logger.Info("Starting controller")
 	defer logger.Info("Shutting down controller")
 
+	c.eventBroadcaster.StartRecordingToSink(events.NewSink(c.kubeClusterClient))
+	defer c.eventBroadcaster.Shutdown()
+
 	for i := 0; i < workers; i++ {
 		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
 	}
+	go wait.UntilWithContext(ctx, c.checkCacheGeneration, cacheGenerationCheckInterval)
+
 	<-ctx.Done()
 }
 
+// checkCacheGeneration detects a restart of the cache server that lost its data,
+// and re-enqueues all local objects to replicate them again.
+func (c *controller) checkCacheGeneration(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+
+	generation, err := c.getCacheGeneration(ctx)
+	if err != nil {
+		logger.V(2).Info("failed to get the cache server generation", "err", err)
+		return
+	}
+
+	c.lock.Lock()
+	previous := c.cacheGeneration
+	c.cacheGeneration = generation
+	c.lock.Unlock()
+
+	if previous == "" || previous == generation {
+		return
+	}
+
+	logger.Info("cache server generation changed, replicating all objects again", "previous", previous, "generation", generation)
+	c.enqueueAll()
+}
+
+// enqueueAll enqueues all local objects of all replicated resources.
+func (c *controller) enqueueAll() {
+	for gvr, info := range c.gvrs {
+		for _, obj := range info.local.GetStore().List() {
+			if !IsNoSystemClusterName(obj) {
+				continue
+			}
+			c.enqueueObject(obj, gvr)
+		}
+	}
+}
+
 func (c *controller) startWorker(ctx context.Context) {
 	for c.processNextWorkItem(ctx) {
 	}
@@ -259,10 +364,22 @@ func IsNoSystemClusterName(obj interface{}) bool {
 }
 
 type controller struct {
-	shardName string
-	queue     workqueue.RateLimitingInterface
+	shardName     string
+	maxObjectSize int64
+	queue         workqueue.RateLimitingInterface
 
 	dynamicCacheClient kcpdynamic.ClusterInterface
+	kubeClusterClient  kcpkubernetesclientset.ClusterInterface
+
+	eventBroadcaster   record.EventBroadcaster
+	recorder           record.EventRecorder
+	getCacheGeneration func(ctx context.Context) (string, error)
+
+	lock            sync.Mutex
+	cacheGeneration string
+	// observed holds the time the oldest unreplicated change of a local object was
+	// observed, by queue key. It is used to compute the replication lag.
+	observed map[string]time.Time
 
 	gvrs map[schema.GroupVersionResource]replicatedGVR
 }
@@ -0,0 +1,170 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	kcpinformers "github.com/kcp-dev/apimachinery/v2/third_party/informers"
+	kcpfakedynamic "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/dynamic/fake"
+	kcptesting "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/testing"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/component-base/metrics/testutil"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+)
+
+func TestCheckCacheGenerationReplicatesAfterCacheReset(t *testing.T) {
+	gvr := apisv1alpha1.SchemeGroupVersion.WithResource("apiexports")
+
+	local := cache.NewSharedIndexInformer(&cache.ListWatch{}, &apisv1alpha1.APIExport{}, 0, cache.Indexers{})
+	for _, export := range []*apisv1alpha1.APIExport{
+		newAPIExport("root:org", "foo"),
+		newAPIExport("root:org", "bar"),
+		newAPIExport("system:admin", "internal"),
+	} {
+		require.NoError(t, local.GetStore().Add(export))
+	}
+
+	generation := "1"
+	c := &controller{
+		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		getCacheGeneration: func(ctx context.Context) (string, error) {
+			return generation, nil
+		},
+		gvrs: map[schema.GroupVersionResource]replicatedGVR{
+			gvr: {kind: "APIExport", local: local},
+		},
+	}
+	defer c.queue.ShutDown()
+
+	ctx := context.Background()
+
+	t.Log("The first observed generation is only recorded")
+	c.checkCacheGeneration(ctx)
+	require.Equal(t, 0, c.queue.Len())
+
+	t.Log("An unchanged generation does not trigger replication")
+	c.checkCacheGeneration(ctx)
+	require.Equal(t, 0, c.queue.Len())
+
+	t.Log("Simulate a cache server restart that lost its data")
+	generation = "2"
+	c.checkCacheGeneration(ctx)
+	require.Equal(t, []string{
+		"v1alpha1.apiexports.apis.kcp.io::root:org|bar",
+		"v1alpha1.apiexports.apis.kcp.io::root:org|foo",
+	}, drainQueue(c.queue))
+
+	t.Log("The new generation is recorded")
+	c.checkCacheGeneration(ctx)
+	require.Equal(t, 0, c.queue.Len())
+}
+
+func TestReconcileRecordsReplicationMetrics(t *testing.T) {
+	gvr := apisv1alpha1.SchemeGroupVersion.WithResource("apiexports")
+
+	local := kcpinformers.NewSharedIndexInformer(&cache.ListWatch{}, &apisv1alpha1.APIExport{}, 0, cache.Indexers{})
+	global := kcpinformers.NewSharedIndexInformer(&cache.ListWatch{}, &apisv1alpha1.APIExport{}, 0, cache.Indexers{
+		ByShardAndLogicalClusterAndNamespaceAndName: IndexByShardAndLogicalClusterAndNamespace,
+	})
+	export := newAPIExport("root:org", "foo")
+	require.NoError(t, local.GetStore().Add(export))
+
+	failCreate := true
+	cacheClient := kcpfakedynamic.NewSimpleDynamicClient(runtime.NewScheme())
+	cacheClient.PrependReactor("create", "apiexports", func(action kcptesting.Action) (handled bool, ret runtime.Object, err error) {
+		if failCreate {
+			return true, nil, errors.New("cache server unavailable")
+		}
+		return false, nil, nil
+	})
+
+	c := &controller{
+		shardName:          "amber",
+		queue:              workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		dynamicCacheClient: cacheClient,
+		gvrs: map[schema.GroupVersionResource]replicatedGVR{
+			gvr: {kind: "APIExport", local: local, global: global},
+		},
+	}
+	defer c.queue.ShutDown()
+
+	ctx := context.Background()
+	errorsBefore, err := testutil.GetCounterMetricValue(replicationErrors.WithLabelValues("apiexports.v1alpha1.apis.kcp.io", "amber"))
+	require.NoError(t, err)
+	lagBefore, err := testutil.GetHistogramMetricCount(replicationLag.WithLabelValues("apiexports.v1alpha1.apis.kcp.io"))
+	require.NoError(t, err)
+
+	t.Log("A failed replication is counted as an error and does not record a lag")
+	c.enqueueObject(export, gvr)
+	key := "v1alpha1.apiexports.apis.kcp.io::root:org|foo"
+	require.Equal(t, []string{key}, drainQueue(c.queue))
+	require.Error(t, c.reconcile(ctx, key))
+	errorsAfter, err := testutil.GetCounterMetricValue(replicationErrors.WithLabelValues("apiexports.v1alpha1.apis.kcp.io", "amber"))
+	require.NoError(t, err)
+	require.Equal(t, errorsBefore+1, errorsAfter)
+	lagAfter, err := testutil.GetHistogramMetricCount(replicationLag.WithLabelValues("apiexports.v1alpha1.apis.kcp.io"))
+	require.NoError(t, err)
+	require.Equal(t, lagBefore, lagAfter)
+
+	t.Log("A successful retry records the lag since the change was first observed")
+	failCreate = false
+	require.NoError(t, c.reconcile(ctx, key))
+	lagAfter, err = testutil.GetHistogramMetricCount(replicationLag.WithLabelValues("apiexports.v1alpha1.apis.kcp.io"))
+	require.NoError(t, err)
+	require.Equal(t, lagBefore+1, lagAfter)
+	require.Empty(t, c.observed)
+
+	t.Log("A reconciliation without a newly observed change does not record a lag")
+	require.NoError(t, c.reconcile(ctx, key))
+	lagAfter, err = testutil.GetHistogramMetricCount(replicationLag.WithLabelValues("apiexports.v1alpha1.apis.kcp.io"))
+	require.NoError(t, err)
+	require.Equal(t, lagBefore+1, lagAfter)
+}
+
+func newAPIExport(cluster logicalcluster.Name, name string) *apisv1alpha1.APIExport {
+	return &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: cluster.String(),
+			},
+		},
+	}
+}
+
+func drainQueue(queue workqueue.RateLimitingInterface) []string {
+	var keys []string
+	for queue.Len() > 0 {
+		key, _ := queue.Get()
+		keys = append(keys, key.(string))
+		queue.Done(key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+func init() {
+	legacyregistry.MustRegister(replicationLag)
+	legacyregistry.MustRegister(replicationErrors)
+}
+
+var (
+	replicationLag = compbasemetrics.NewHistogramVec(
+		&compbasemetrics.HistogramOpts{
+			Name:           "kcp_cache_replication_lag_seconds",
+			Help:           "Time from observing a change of a local object until its cached copy is written to the cache server, by resource.",
+			Buckets:        compbasemetrics.ExponentialBuckets(0.001, 2, 15),
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"gvr"},
+	)
+
+	replicationErrors = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "kcp_cache_replication_errors_total",
+			Help:           "Number of failed attempts to replicate an object to the cache server, by resource and shard.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"gvr", "shard"},
+	)
+)
+
+// gvrLabel returns the gvr label value of the given resource, e.g. apiexports.v1alpha1.apis.kcp.io.
+func gvrLabel(gvr schema.GroupVersionResource) string {
+	if gvr.Group == "" {
+		return gvr.Resource + "." + gvr.Version
+	}
+	return gvr.Resource + "." + gvr.Version + "." + gvr.Group
+}
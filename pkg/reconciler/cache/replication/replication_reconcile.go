@@ -18,21 +18,29 @@ package replication
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 )
 
+// ObjectTooLargeReason is the reason of the warning event emitted on a local object that is not
+// replicated to the cache server because it exceeds the maximum replicated object size.
+const ObjectTooLargeReason = "ObjectTooLargeForCache"
+
 func (c *controller) reconcile(ctx context.Context, gvrKey string) error {
 	// split apart the gvr from the key
 	keyParts := strings.Split(gvrKey, "::")
@@ -45,8 +53,17 @@ func (c *controller) reconcile(ctx context.Context, gvrKey string) error {
 
 	info := c.gvrs[gvr]
 
+	observed := c.popObserved(gvrKey)
+	written := func() {
+		if !observed.IsZero() {
+			replicationLag.WithLabelValues(gvrLabel(gvr)).Observe(time.Since(observed).Seconds())
+		}
+	}
+
 	r := &reconciler{
-		shardName: c.shardName,
+		shardName:     c.shardName,
+		maxObjectSize: c.maxObjectSize,
+		recorder:      c.recorder,
 		getLocalCopy: func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error) {
 			key := kcpcache.ToClusterAwareKey(cluster.String(), namespace, name)
 			obj, exists, err := info.local.GetIndexer().GetByKey(key)
@@ -98,20 +115,44 @@ func (c *controller) reconcile(ctx context.Context, gvrKey string) error {
 			return u, nil
 		},
 		createObject: func(ctx context.Context, cluster logicalcluster.Name, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-			return c.dynamicCacheClient.Cluster(cluster.Path()).Resource(gvr).Namespace(obj.GetNamespace()).Create(ctx, obj, metav1.CreateOptions{})
+			created, err := c.dynamicCacheClient.Cluster(cluster.Path()).Resource(gvr).Namespace(obj.GetNamespace()).Create(ctx, obj, metav1.CreateOptions{})
+			if err == nil {
+				written()
+			}
+			return created, err
 		},
 		updateObject: func(ctx context.Context, cluster logicalcluster.Name, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-			return c.dynamicCacheClient.Cluster(cluster.Path()).Resource(gvr).Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{})
+			updated, err := c.dynamicCacheClient.Cluster(cluster.Path()).Resource(gvr).Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{})
+			if err == nil {
+				written()
+			}
+			return updated, err
 		},
 		deleteObject: func(ctx context.Context, cluster logicalcluster.Name, ns, name string) error {
-			return c.dynamicCacheClient.Cluster(cluster.Path()).Resource(gvr).Namespace(ns).Delete(ctx, name, metav1.DeleteOptions{})
+			err := c.dynamicCacheClient.Cluster(cluster.Path()).Resource(gvr).Namespace(ns).Delete(ctx, name, metav1.DeleteOptions{})
+			if err == nil {
+				written()
+			}
+			return err
 		},
 	}
-	return r.reconcile(ctx, key)
+	if err := r.reconcile(ctx, key); err != nil {
+		replicationErrors.WithLabelValues(gvrLabel(gvr), c.shardName).Inc()
+		// the change is still unreplicated, keep measuring from its observation on retry.
+		if !observed.IsZero() {
+			c.observe(gvrKey, observed)
+		}
+		return err
+	}
+	return nil
 }
 
 type reconciler struct {
 	shardName string
+	// maxObjectSize is the maximum size in bytes of the JSON serialization of an object to be
+	// replicated. Zero means unlimited.
+	maxObjectSize int64
+	recorder      record.EventRecorder
 
 	getLocalCopy  func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error)
 	getGlobalCopy func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error)
@@ -126,6 +167,11 @@ type reconciler struct {
 //  1. creation of the object in the cache server when the cached object is not found by getGlobalCopy
 //  2. deletion of the object from the cache server when the original/local object was removed OR was not found by getLocalCopy
 //  3. modification of the cached object to match the original one when meta.annotations, meta.labels, spec or status are different
+//
+// Objects exceeding maxObjectSize are not replicated. A warning event is emitted on them instead, and
+// a previously replicated copy is removed from the cache server so that it does not get stale.
+//
+// Status is replicated like spec. An empty status is treated like no status, and is not stored in the cache server.
 func (r *reconciler) reconcile(ctx context.Context, key string) error {
 	logger := klog.FromContext(ctx).WithValues("reconcilerKey", key)
 
@@ -141,6 +187,9 @@ func (r *reconciler) reconcile(ctx context.Context, key string) error {
 		return nil
 	}
 	localExists := !apierrors.IsNotFound(err)
+	if localExists {
+		dropEmptyStatus(localCopy)
+	}
 
 	globalCopy, err := r.getGlobalCopy(clusterName, ns, name)
 	if err != nil && !apierrors.IsNotFound(err) {
@@ -148,14 +197,32 @@ func (r *reconciler) reconcile(ctx context.Context, key string) error {
 		return nil
 	}
 	globalExists := !apierrors.IsNotFound(err)
+	if globalExists {
+		// typed informers render a missing status as an empty one.
+		dropEmptyStatus(globalCopy)
+	}
 
-	// local is gone or being deleted. Delete in cache.
-	if !localExists || !localCopy.GetDeletionTimestamp().IsZero() {
+	tooLarge := false
+	if localExists && localCopy.GetDeletionTimestamp().IsZero() && r.maxObjectSize > 0 {
+		size, err := objectSize(localCopy)
+		if err != nil {
+			return err
+		}
+		if size > r.maxObjectSize {
+			tooLarge = true
+			logger.V(2).Info("Object exceeds the maximum replicated object size, skipping it", "size", size, "maxSize", r.maxObjectSize)
+			r.recorder.Eventf(localCopy, corev1.EventTypeWarning, ObjectTooLargeReason,
+				"Object of %d bytes exceeds the maximum size of %d bytes for replication to the cache server", size, r.maxObjectSize)
+		}
+	}
+
+	// local is gone, being deleted or too large. Delete in cache.
+	if !localExists || !localCopy.GetDeletionTimestamp().IsZero() || tooLarge {
 		if !globalExists {
 			return nil
 		}
 
-		// Object doesn't exist anymore, delete it from the global cache.
+		// Object doesn't exist anymore or must not be replicated, delete it from the global cache.
 		logger.V(2).WithValues("cluster", clusterName, "namespace", ns, "name", name).Info("Deleting object from global cache")
 		if err := r.deleteObject(ctx, clusterName, ns, name); err != nil && !apierrors.IsNotFound(err) {
 			return err
@@ -201,3 +268,12 @@ func (r *reconciler) reconcile(ctx context.Context, key string) error {
 	_, err = r.updateObject(ctx, clusterName, globalCopy) // no need for patch because there is only this actor
 	return err
 }
+
+// objectSize returns the size in bytes of the JSON serialization of the given object.
+func objectSize(obj *unstructured.Unstructured) (int64, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(data)), nil
+}
@@ -31,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/tools/record"
 )
 
 func TestReconcile(t *testing.T) {
@@ -136,6 +137,56 @@ func TestReconcile(t *testing.T) {
 			key:            "root|zoo/dumbo",
 			expectedUpdate: WithChange(WithResourceVersion(elephant.DeepCopy(), "7"), []string{"status", "weight"}, "42.5"),
 		},
+		{
+			name: "case 1: creation of the object in the cache server drops an empty status",
+			getLocalCopy: func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error) {
+				return WithChange(elephant.DeepCopy(), []string{"status"}, map[string]interface{}{}), nil
+			},
+			getGlobalCopy:  getCopyNotFoundFunc,
+			key:            "root|zoo/dumbo",
+			expectedCreate: WithShardName(WithoutResourceVersion(WithoutStatus(elephant.DeepCopy())), "root"),
+		},
+		{
+			name: "case 3: no update, empty status of both objects is ignored",
+			getLocalCopy: func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error) {
+				return WithChange(elephant.DeepCopy(), []string{"status"}, map[string]interface{}{}), nil
+			},
+			getGlobalCopy: func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error) {
+				return WithChange(WithResourceVersion(elephant.DeepCopy(), "7"), []string{"status"}, map[string]interface{}{}), nil
+			},
+			key: "root|zoo/dumbo",
+		},
+		{
+			name: "case 3: no update, empty status of the cached object is ignored",
+			getLocalCopy: func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error) {
+				return WithoutStatus(elephant.DeepCopy()), nil
+			},
+			getGlobalCopy: func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error) {
+				return WithChange(WithResourceVersion(elephant.DeepCopy(), "7"), []string{"status"}, map[string]interface{}{}), nil
+			},
+			key: "root|zoo/dumbo",
+		},
+		{
+			name: "case 3: update, status set on a cached object with empty status",
+			getLocalCopy: func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error) {
+				return WithChange(WithoutStatus(elephant.DeepCopy()), []string{"status", "weight"}, "42.5"), nil
+			},
+			getGlobalCopy: func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error) {
+				return WithChange(WithResourceVersion(elephant.DeepCopy(), "7"), []string{"status"}, map[string]interface{}{}), nil
+			},
+			key:            "root|zoo/dumbo",
+			expectedUpdate: WithChange(WithResourceVersion(WithoutStatus(elephant.DeepCopy()), "7"), []string{"status", "weight"}, "42.5"),
+		},
+		{
+			name: "case 3: no update, neither object has a status",
+			getLocalCopy: func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error) {
+				return WithChange(elephant.DeepCopy(), []string{"status"}, nil), nil
+			},
+			getGlobalCopy: func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error) {
+				return WithResourceVersion(WithoutStatus(elephant.DeepCopy()), "7"), nil
+			},
+			key: "root|zoo/dumbo",
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(tt *testing.T) {
@@ -197,6 +248,76 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestReconcileSkipsOversizedObjects(t *testing.T) {
+	elephant := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Elephant",
+			"metadata": map[string]interface{}{
+				"name":      "dumbo",
+				"namespace": "zoo",
+			},
+			"spec": map[string]interface{}{
+				"trunk": strings.Repeat("x", 1024),
+			},
+		},
+	}
+
+	for _, globalExists := range []bool{false, true} {
+		recorder := record.NewFakeRecorder(10)
+		var created, updated bool
+		var deletedName string
+
+		r := &reconciler{
+			shardName:     "root",
+			maxObjectSize: 512,
+			recorder:      recorder,
+			getLocalCopy: func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error) {
+				return elephant.DeepCopy(), nil
+			},
+			getGlobalCopy: func(cluster logicalcluster.Name, namespace, name string) (*unstructured.Unstructured, error) {
+				if !globalExists {
+					return nil, errors.NewNotFound(schema.GroupResource{Group: "example.com", Resource: "elephants"}, name)
+				}
+				return WithShardName(elephant.DeepCopy(), "root"), nil
+			},
+			createObject: func(ctx context.Context, cluster logicalcluster.Name, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+				created = true
+				return obj, nil
+			},
+			updateObject: func(ctx context.Context, cluster logicalcluster.Name, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+				updated = true
+				return obj, nil
+			},
+			deleteObject: func(ctx context.Context, cluster logicalcluster.Name, ns, name string) error {
+				deletedName = name
+				return nil
+			},
+		}
+
+		if err := r.reconcile(context.Background(), "root|zoo/dumbo"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if created || updated {
+			t.Fatalf("expected the oversized object not to be replicated, created=%v, updated=%v", created, updated)
+		}
+		if globalExists && deletedName != "dumbo" {
+			t.Fatalf("expected the stale cached object to be deleted")
+		} else if !globalExists && deletedName != "" {
+			t.Fatalf("expected nothing to be deleted, got %q", deletedName)
+		}
+
+		select {
+		case event := <-recorder.Events:
+			if !strings.HasPrefix(event, "Warning "+ObjectTooLargeReason+" ") {
+				t.Fatalf("expected a %s warning event, got %q", ObjectTooLargeReason, event)
+			}
+		default:
+			t.Fatalf("expected a %s warning event", ObjectTooLargeReason)
+		}
+	}
+}
+
 func WithResourceVersion(u *unstructured.Unstructured, rv string) *unstructured.Unstructured {
 	u.SetResourceVersion(rv)
 
@@ -207,6 +328,11 @@ func WithoutResourceVersion(u *unstructured.Unstructured) *unstructured.Unstruct
 	return u
 }
 
+func WithoutStatus(u *unstructured.Unstructured) *unstructured.Unstructured {
+	unstructured.RemoveNestedField(u.Object, "status")
+	return u
+}
+
 func WithDeletionTimestamp(u *unstructured.Unstructured, t time.Time) *unstructured.Unstructured {
 	ts := metav1.NewTime(t)
 	u.SetDeletionTimestamp(&ts)
@@ -138,6 +138,19 @@ func ensureRemaining(cacheObject *unstructured.Unstructured, localObject *unstru
 	return true, nil
 }
 
+// dropEmptyStatus removes a nil or empty status from the given object. Status is replicated like
+// any other field, but typed objects converted to unstructured always carry a status, while the
+// apiserver drops it for objects that have none.
+func dropEmptyStatus(u *unstructured.Unstructured) {
+	status, found := u.Object["status"]
+	if !found {
+		return
+	}
+	if m, ok := status.(map[string]interface{}); status == nil || (ok && len(m) == 0) {
+		delete(u.Object, "status")
+	}
+}
+
 func toUnstructured(obj interface{}) (*unstructured.Unstructured, error) {
 	unstructured := &unstructured.Unstructured{Object: map[string]interface{}{}}
 	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
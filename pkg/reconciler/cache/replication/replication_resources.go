@@ -0,0 +1,234 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpdynamicinformer "github.com/kcp-dev/client-go/dynamic/dynamicinformer"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+
+	cacheclient "github.com/kcp-dev/kcp/pkg/cache/client"
+	cacheserverbootstrap "github.com/kcp-dev/kcp/pkg/cache/server/bootstrap"
+)
+
+// discoveryTimeout is how long AddResources waits for additional resources to show up in discovery,
+// e.g. because the APIs serving them are still being bootstrapped.
+const discoveryTimeout = time.Minute
+
+// ParseGroupResources parses resources given as <resource>.<group>, as accepted by the
+// --cache-replicated-resources flag. Resources of the core API group cannot be replicated.
+func ParseGroupResources(values []string) ([]schema.GroupResource, error) {
+	grs := make([]schema.GroupResource, 0, len(values))
+	for _, value := range values {
+		gr := schema.ParseGroupResource(value)
+		if gr.Resource == "" || gr.Group == "" {
+			return nil, fmt.Errorf("invalid resource %q, expected <resource>.<group> of a non-core API group", value)
+		}
+		grs = append(grs, gr)
+	}
+	return grs, nil
+}
+
+// AddResources adds the given resources to the replicated ones, unless they are replicated already.
+// The resources are resolved through the given discovery client, which fails if some of them are
+// unknown. The CustomResourceDefinitions serving them are created in the cache server, and informers
+// for the local shard and the cache server are started and synced. It must be called before Start.
+func (c *controller) AddResources(ctx context.Context, discoveryClient discovery.DiscoveryInterface, localDynamicClient kcpdynamic.ClusterInterface, grs []schema.GroupResource) error {
+	logger := klog.FromContext(ctx)
+
+	var additional []schema.GroupResource
+	for _, gr := range grs {
+		if c.replicates(gr) {
+			logger.V(2).Info("resource is replicated already", "resource", gr)
+			continue
+		}
+		additional = append(additional, gr)
+	}
+	if len(additional) == 0 {
+		return nil
+	}
+
+	var resources []metav1.APIResource
+	var discoveryErr error
+	if err := wait.PollImmediateWithContext(ctx, time.Second, discoveryTimeout, func(ctx context.Context) (bool, error) {
+		lists, err := discoveryClient.ServerPreferredResources()
+		if err != nil && len(lists) == 0 {
+			discoveryErr = err
+			return false, nil
+		}
+		resources, discoveryErr = resolveResources(lists, additional)
+		return discoveryErr == nil, nil
+	}); err != nil {
+		if discoveryErr != nil {
+			return discoveryErr
+		}
+		return err
+	}
+
+	localInformers := kcpdynamicinformer.NewDynamicSharedInformerFactory(localDynamicClient, 0)
+	globalInformers := kcpdynamicinformer.NewDynamicSharedInformerFactory(c.dynamicCacheClient, 0)
+	for _, resource := range resources {
+		gvr := schema.GroupVersionResource{Group: resource.Group, Version: resource.Version, Resource: resource.Name}
+		if err := c.createCacheCRD(ctx, resource); err != nil {
+			return fmt.Errorf("failed to create a CustomResourceDefinition for %s in the cache server: %w", gvr.GroupResource(), err)
+		}
+
+		info := replicatedGVR{
+			kind:   resource.Kind,
+			local:  localInformers.ForResource(gvr).Informer(),
+			global: globalInformers.ForResource(gvr).Informer(),
+		}
+		c.gvrs[gvr] = info
+		c.addEventHandlers(gvr, info)
+		logger.Info("replicating additional resource", "gvr", gvr)
+	}
+
+	localInformers.Start(ctx.Done())
+	globalInformers.Start(ctx.Done())
+	for _, synced := range []map[schema.GroupVersionResource]bool{localInformers.WaitForCacheSync(ctx.Done()), globalInformers.WaitForCacheSync(ctx.Done())} {
+		for gvr, ok := range synced {
+			if !ok {
+				return fmt.Errorf("failed to sync informer for %s", gvr)
+			}
+		}
+	}
+
+	return nil
+}
+
+// replicates returns whether the given resource is replicated, in any version.
+func (c *controller) replicates(gr schema.GroupResource) bool {
+	for gvr := range c.gvrs {
+		if gvr.GroupResource() == gr {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveResources finds the given resources in the given discovery information. The returned
+// resources have their group and version set. It fails with an error naming all unknown resources.
+func resolveResources(lists []*metav1.APIResourceList, grs []schema.GroupResource) ([]metav1.APIResource, error) {
+	discovered := map[schema.GroupResource]metav1.APIResource{}
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, resource := range list.APIResources {
+			if strings.Contains(resource.Name, "/") {
+				continue // subresource
+			}
+			resource.Group, resource.Version = gv.Group, gv.Version
+			discovered[schema.GroupResource{Group: gv.Group, Resource: resource.Name}] = resource
+		}
+	}
+
+	var resources []metav1.APIResource
+	var unknown []string
+	for _, gr := range grs {
+		resource, found := discovered[gr]
+		if !found {
+			unknown = append(unknown, gr.String())
+			continue
+		}
+		resources = append(resources, resource)
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown resources to replicate to the cache server: %s", strings.Join(unknown, ", "))
+	}
+
+	return resources, nil
+}
+
+// createCacheCRD creates a CustomResourceDefinition serving the given resource in the cache server,
+// unless it exists already.
+func (c *controller) createCacheCRD(ctx context.Context, resource metav1.APIResource) error {
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cacheCRDFor(resource))
+	if err != nil {
+		return err
+	}
+	u := &unstructured.Unstructured{Object: raw}
+	u.SetAPIVersion(apiextensionsv1.SchemeGroupVersion.String())
+	u.SetKind("CustomResourceDefinition")
+
+	ctx = cacheclient.WithShardInContext(ctx, cacheserverbootstrap.SystemCacheServerShard)
+	_, err = c.dynamicCacheClient.Cluster(cacheserverbootstrap.SystemCRDLogicalCluster.Path()).
+		Resource(apiextensionsv1.SchemeGroupVersion.WithResource("customresourcedefinitions")).
+		Create(ctx, u, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// cacheCRDFor returns a CustomResourceDefinition serving the given resource, without validation
+// and without subresources, like the ones bootstrapped by the cache server.
+func cacheCRDFor(resource metav1.APIResource) *apiextensionsv1.CustomResourceDefinition {
+	scope := apiextensionsv1.ClusterScoped
+	if resource.Namespaced {
+		scope = apiextensionsv1.NamespaceScoped
+	}
+	singular := resource.SingularName
+	if singular == "" {
+		singular = strings.ToLower(resource.Kind)
+	}
+
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: resource.Name + "." + resource.Group},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: resource.Group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   resource.Name,
+				Singular: singular,
+				Kind:     resource.Kind,
+				ListKind: resource.Kind + "List",
+			},
+			Scope: scope,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:    resource.Version,
+					Served:  true,
+					Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type:                   "object",
+							XPreserveUnknownFields: pointer.BoolPtr(true),
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseGroupResources(t *testing.T) {
+	grs, err := ParseGroupResources([]string{"partitions.topology.kcp.io", "workspaces.tenancy.kcp.io"})
+	require.NoError(t, err)
+	require.Equal(t, []schema.GroupResource{
+		{Group: "topology.kcp.io", Resource: "partitions"},
+		{Group: "tenancy.kcp.io", Resource: "workspaces"},
+	}, grs)
+
+	_, err = ParseGroupResources([]string{"namespaces"})
+	require.EqualError(t, err, `invalid resource "namespaces", expected <resource>.<group> of a non-core API group`)
+
+	_, err = ParseGroupResources([]string{".topology.kcp.io"})
+	require.Error(t, err)
+}
+
+func TestResolveResources(t *testing.T) {
+	lists := []*metav1.APIResourceList{
+		{
+			GroupVersion: "topology.kcp.io/v1alpha1",
+			APIResources: []metav1.APIResource{
+				{Name: "partitions", SingularName: "partition", Kind: "Partition"},
+				{Name: "partitions/status", Kind: "Partition"},
+			},
+		},
+		{
+			GroupVersion: "tenancy.kcp.io/v1alpha1",
+			APIResources: []metav1.APIResource{
+				{Name: "workspaces", SingularName: "workspace", Kind: "Workspace"},
+			},
+		},
+	}
+
+	resources, err := resolveResources(lists, []schema.GroupResource{{Group: "topology.kcp.io", Resource: "partitions"}})
+	require.NoError(t, err)
+	require.Equal(t, []metav1.APIResource{
+		{Name: "partitions", SingularName: "partition", Kind: "Partition", Group: "topology.kcp.io", Version: "v1alpha1"},
+	}, resources)
+
+	_, err = resolveResources(lists, []schema.GroupResource{
+		{Group: "topology.kcp.io", Resource: "status"},
+		{Group: "tenancy.kcp.io", Resource: "workspaces"},
+		{Group: "example.com", Resource: "unknowns"},
+	})
+	require.EqualError(t, err, "unknown resources to replicate to the cache server: status.topology.kcp.io, unknowns.example.com")
+}
+
+func TestCacheCRDFor(t *testing.T) {
+	crd := cacheCRDFor(metav1.APIResource{Name: "partitions", Kind: "Partition", Group: "topology.kcp.io", Version: "v1alpha1"})
+	require.Equal(t, "partitions.topology.kcp.io", crd.Name)
+	require.Equal(t, apiextensionsv1.ClusterScoped, crd.Spec.Scope)
+	require.Equal(t, apiextensionsv1.CustomResourceDefinitionNames{Plural: "partitions", Singular: "partition", Kind: "Partition", ListKind: "PartitionList"}, crd.Spec.Names)
+	require.Len(t, crd.Spec.Versions, 1)
+	require.Equal(t, "v1alpha1", crd.Spec.Versions[0].Name)
+	require.True(t, *crd.Spec.Versions[0].Schema.OpenAPIV3Schema.XPreserveUnknownFields)
+
+	crd = cacheCRDFor(metav1.APIResource{Name: "widgets", Kind: "Widget", Group: "example.com", Version: "v1", Namespaced: true})
+	require.Equal(t, apiextensionsv1.NamespaceScoped, crd.Spec.Scope)
+}
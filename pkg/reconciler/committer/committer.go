@@ -17,6 +17,7 @@ limitations under the License.
 package committer
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -30,6 +31,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
 )
 
 // Resource is a generic wrapper around resources so we can generate patches.
@@ -108,6 +112,15 @@ func generatePatchAndSubResources[Sp any, St any](old, obj *Resource[Sp, St]) ([
 
 	specOrObjectMetaChanged := specChanged || objectMetaChanged
 
+	if statusChanged && !specOrObjectMetaChanged {
+		// conditions which only differ in their order are no change.
+		equal, err := equalStatusIgnoringConditionOrder(old.Status, obj.Status)
+		if err != nil {
+			return nil, nil, err
+		}
+		statusChanged = !equal
+	}
+
 	// Simultaneous updates of spec and status are never allowed.
 	if specOrObjectMetaChanged && statusChanged {
 		panic(fmt.Sprintf("programmer error: spec and status changed in same reconcile iteration. diff=%s", cmp.Diff(old, obj)))
@@ -142,6 +155,9 @@ func generatePatchAndSubResources[Sp any, St any](old, obj *Resource[Sp, St]) ([
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to Marshal old data for %s|%s: %w", clusterName, name, err)
 	}
+	if oldData, err = sortConditions(oldData); err != nil {
+		return nil, nil, fmt.Errorf("failed to sort old conditions for %s|%s: %w", clusterName, name, err)
+	}
 
 	newForPatch := forPatch(obj)
 	// to ensure they appear in the patch as preconditions
@@ -152,6 +168,9 @@ func generatePatchAndSubResources[Sp any, St any](old, obj *Resource[Sp, St]) ([
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to Marshal new data for %s|%s: %w", clusterName, name, err)
 	}
+	if newData, err = sortConditions(newData); err != nil {
+		return nil, nil, fmt.Errorf("failed to sort new conditions for %s|%s: %w", clusterName, name, err)
+	}
 
 	patchBytes, err := jsonpatch.CreateMergePatch(oldData, newData)
 	if err != nil {
@@ -165,3 +184,75 @@ func generatePatchAndSubResources[Sp any, St any](old, obj *Resource[Sp, St]) ([
 
 	return patchBytes, subresources, nil
 }
+
+// equalStatusIgnoringConditionOrder returns true if both statuses are equal once their
+// conditions are sorted in the canonical order.
+func equalStatusIgnoringConditionOrder[St any](old, obj St) (bool, error) {
+	oldData, err := json.Marshal(struct {
+		Status St `json:"status"`
+	}{old})
+	if err != nil {
+		return false, err
+	}
+	newData, err := json.Marshal(struct {
+		Status St `json:"status"`
+	}{obj})
+	if err != nil {
+		return false, err
+	}
+	if oldData, err = sortConditions(oldData); err != nil {
+		return false, err
+	}
+	if newData, err = sortConditions(newData); err != nil {
+		return false, err
+	}
+	return bytes.Equal(oldData, newData), nil
+}
+
+// sortConditions sorts status.conditions of the given marshalled resource in the canonical
+// order of conditions.Sort, such that equal sets of conditions produce no patch, and conditions
+// are always stored in the same order.
+func sortConditions(data []byte) ([]byte, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	var status struct {
+		Conditions []json.RawMessage `json:"conditions"`
+	}
+	if raw, found := obj["status"]; !found || json.Unmarshal(raw, &status) != nil || len(status.Conditions) < 2 {
+		return data, nil
+	}
+
+	// sort by type only, keeping the raw conditions with all of their fields.
+	conds := make(conditionsv1alpha1.Conditions, len(status.Conditions))
+	byType := make(map[conditionsv1alpha1.ConditionType]json.RawMessage, len(status.Conditions))
+	for i, raw := range status.Conditions {
+		if err := json.Unmarshal(raw, &conds[i]); err != nil {
+			return nil, err
+		}
+		byType[conds[i].Type] = raw
+	}
+	if len(byType) != len(conds) {
+		// duplicate types have no canonical order.
+		return data, nil
+	}
+	conditions.Sort(conds)
+	sorted := make([]json.RawMessage, 0, len(conds))
+	for _, c := range conds {
+		sorted = append(sorted, byType[c.Type])
+	}
+
+	var rawStatus map[string]json.RawMessage
+	if err := json.Unmarshal(obj["status"], &rawStatus); err != nil {
+		return nil, err
+	}
+	var err error
+	if rawStatus["conditions"], err = json.Marshal(sorted); err != nil {
+		return nil, err
+	}
+	if obj["status"], err = json.Marshal(rawStatus); err != nil {
+		return nil, err
+	}
+	return json.Marshal(obj)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package committer
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+func TestGeneratePatchIgnoresConditionOrder(t *testing.T) {
+	ready := conditionsv1alpha1.Condition{Type: conditionsv1alpha1.ReadyCondition, Status: corev1.ConditionTrue}
+	initialized := conditionsv1alpha1.Condition{Type: "Initialized", Status: corev1.ConditionTrue}
+	scheduled := conditionsv1alpha1.Condition{Type: "Scheduled", Status: corev1.ConditionTrue}
+	unscheduled := conditionsv1alpha1.Condition{Type: "Scheduled", Status: corev1.ConditionFalse, Reason: "Unschedulable"}
+
+	logicalCluster := func(conditions ...conditionsv1alpha1.Condition) *Resource[*corev1alpha1.LogicalClusterSpec, *corev1alpha1.LogicalClusterStatus] {
+		return &Resource[*corev1alpha1.LogicalClusterSpec, *corev1alpha1.LogicalClusterStatus]{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            corev1alpha1.LogicalClusterName,
+				UID:             "uid",
+				ResourceVersion: "42",
+				Annotations:     map[string]string{logicalcluster.AnnotationKey: "root:org"},
+			},
+			Spec: &corev1alpha1.LogicalClusterSpec{},
+			Status: &corev1alpha1.LogicalClusterStatus{
+				Phase:      corev1alpha1.LogicalClusterPhaseReady,
+				Conditions: conditions,
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		old, obj  *Resource[*corev1alpha1.LogicalClusterSpec, *corev1alpha1.LogicalClusterStatus]
+		wantPatch string
+	}{
+		{
+			name: "reordered but equal conditions",
+			old:  logicalCluster(scheduled, initialized, ready),
+			obj:  logicalCluster(ready, initialized, scheduled),
+		},
+		{
+			name: "equal conditions in the same order",
+			old:  logicalCluster(ready, initialized, scheduled),
+			obj:  logicalCluster(ready, initialized, scheduled),
+		},
+		{
+			name:      "changed condition is stored in canonical order",
+			old:       logicalCluster(scheduled, initialized, ready),
+			obj:       logicalCluster(unscheduled, initialized, ready),
+			wantPatch: `{"metadata":{"resourceVersion":"42","uid":"uid"},"status":{"conditions":[{"lastTransitionTime":null,"status":"True","type":"Ready"},{"lastTransitionTime":null,"status":"True","type":"Initialized"},{"lastTransitionTime":null,"reason":"Unschedulable","status":"False","type":"Scheduled"}]}}`,
+		},
+		{
+			name:      "added condition",
+			old:       logicalCluster(ready),
+			obj:       logicalCluster(initialized, ready),
+			wantPatch: `{"metadata":{"resourceVersion":"42","uid":"uid"},"status":{"conditions":[{"lastTransitionTime":null,"status":"True","type":"Ready"},{"lastTransitionTime":null,"status":"True","type":"Initialized"}]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, subresources, err := generatePatchAndSubResources(tt.old, tt.obj)
+			require.NoError(t, err)
+			if tt.wantPatch == "" {
+				require.Empty(t, patch, "expected no patch, got %s", patch)
+				require.Empty(t, subresources)
+				return
+			}
+			require.JSONEq(t, tt.wantPatch, string(patch))
+			require.Equal(t, []string{"status"}, subresources)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalcluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcilePhase(t *testing.T) {
+	for _, testCase := range []struct {
+		name            string
+		spec, status    []corev1alpha1.LogicalClusterInitializer
+		expectedPhase   corev1alpha1.LogicalClusterPhaseType
+		expectedStatus  corev1.ConditionStatus
+		expectedMessage string
+	}{
+		{
+			name:            "stays initializing while all initializers are pending",
+			spec:            []corev1alpha1.LogicalClusterInitializer{"root:a", "root:b"},
+			status:          []corev1alpha1.LogicalClusterInitializer{"root:a", "root:b"},
+			expectedPhase:   corev1alpha1.LogicalClusterPhaseInitializing,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedMessage: "Initializers still exist: [root:a root:b]",
+		},
+		{
+			name:            "stays initializing while some initializers are pending",
+			spec:            []corev1alpha1.LogicalClusterInitializer{"root:a", "root:b"},
+			status:          []corev1alpha1.LogicalClusterInitializer{"root:b"},
+			expectedPhase:   corev1alpha1.LogicalClusterPhaseInitializing,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedMessage: "Initializers still exist: [root:b], completed: [root:a]",
+		},
+		{
+			name:           "becomes ready when all initializers completed",
+			spec:           []corev1alpha1.LogicalClusterInitializer{"root:a", "root:b"},
+			expectedPhase:  corev1alpha1.LogicalClusterPhaseReady,
+			expectedStatus: corev1.ConditionTrue,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			logicalCluster := &corev1alpha1.LogicalCluster{
+				Spec: corev1alpha1.LogicalClusterSpec{Initializers: testCase.spec},
+				Status: corev1alpha1.LogicalClusterStatus{
+					Phase:        corev1alpha1.LogicalClusterPhaseInitializing,
+					Initializers: testCase.status,
+				},
+			}
+
+			r := &phaseReconciler{}
+			status, err := r.reconcile(context.Background(), logicalCluster)
+			require.NoError(t, err)
+			require.Equal(t, reconcileStatusContinue, status)
+			require.Equal(t, testCase.expectedPhase, logicalCluster.Status.Phase)
+
+			cond := conditions.Get(logicalCluster, tenancyv1alpha1.WorkspaceInitialized)
+			require.NotNil(t, cond)
+			require.Equal(t, testCase.expectedStatus, cond.Status)
+			require.Equal(t, testCase.expectedMessage, cond.Message)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletion
+
+import (
+	"sync"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DiscoveryCache caches the discovered resources per logical cluster, such that repeated deletion
+// attempts of a logical cluster do not do a full discovery each time. The entry of a logical cluster
+// must be invalidated when the set of resources served in it changes, e.g. on CRD or APIBinding changes.
+type DiscoveryCache struct {
+	discoverResourcesFn func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error)
+
+	lock      sync.RWMutex
+	resources map[logicalcluster.Path][]*metav1.APIResourceList
+	// generation is bumped on every invalidation, such that a discovery racing with an
+	// invalidation does not store stale resources.
+	generation uint64
+}
+
+// NewDiscoveryCache returns a DiscoveryCache that delegates to discoverResourcesFn on a cache miss.
+func NewDiscoveryCache(discoverResourcesFn func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error)) *DiscoveryCache {
+	return &DiscoveryCache{
+		discoverResourcesFn: discoverResourcesFn,
+		resources:           map[logicalcluster.Path][]*metav1.APIResourceList{},
+	}
+}
+
+// DiscoverResources returns the cached resources of the given logical cluster, or discovers them.
+// Results of a failed discovery, including partial results, are not cached.
+func (c *DiscoveryCache) DiscoverResources(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error) {
+	c.lock.RLock()
+	resources, ok := c.resources[clusterName]
+	generation := c.generation
+	c.lock.RUnlock()
+	if ok {
+		return resources, nil
+	}
+
+	resources, err := c.discoverResourcesFn(clusterName)
+	if err != nil {
+		return resources, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.generation == generation {
+		c.resources[clusterName] = resources
+	}
+	return resources, nil
+}
+
+// Invalidate drops the cached resources of the given logical cluster.
+func (c *DiscoveryCache) Invalidate(clusterName logicalcluster.Path) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.generation++
+	delete(c.resources, clusterName)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletion
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiscoveryCache(t *testing.T) {
+	calls := map[logicalcluster.Path]int{}
+	resources := map[logicalcluster.Path][]string{
+		logicalcluster.NewPath("root:one"): {"configmaps"},
+		logicalcluster.NewPath("root:two"): {"secrets"},
+	}
+	var discoveryErr error
+	c := NewDiscoveryCache(func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error) {
+		calls[clusterName]++
+		list := &metav1.APIResourceList{GroupVersion: "v1"}
+		for _, resource := range resources[clusterName] {
+			list.APIResources = append(list.APIResources, metav1.APIResource{Name: resource})
+		}
+		return []*metav1.APIResourceList{list}, discoveryErr
+	})
+
+	names := func(clusterName logicalcluster.Path) []string {
+		lists, err := c.DiscoverResources(clusterName)
+		require.NoError(t, err)
+		var ret []string
+		for _, list := range lists {
+			for _, resource := range list.APIResources {
+				ret = append(ret, resource.Name)
+			}
+		}
+		return ret
+	}
+
+	one, two := logicalcluster.NewPath("root:one"), logicalcluster.NewPath("root:two")
+
+	t.Log("Repeated calls are served from the cache")
+	require.Equal(t, []string{"configmaps"}, names(one))
+	require.Equal(t, []string{"configmaps"}, names(one))
+	require.Equal(t, []string{"secrets"}, names(two))
+	require.Equal(t, map[logicalcluster.Path]int{one: 1, two: 1}, calls)
+
+	t.Log("A schema change invalidates only the affected logical cluster")
+	resources[one] = append(resources[one], "widgets")
+	c.Invalidate(one)
+	require.Equal(t, []string{"configmaps", "widgets"}, names(one))
+	require.Equal(t, []string{"secrets"}, names(two))
+	require.Equal(t, map[logicalcluster.Path]int{one: 2, two: 1}, calls)
+
+	t.Log("Failed discoveries are not cached")
+	c.Invalidate(one)
+	discoveryErr = errors.New("partial discovery failure")
+	_, err := c.DiscoverResources(one)
+	require.Error(t, err)
+	discoveryErr = nil
+	require.Equal(t, []string{"configmaps", "widgets"}, names(one))
+	require.Equal(t, []string{"configmaps", "widgets"}, names(one))
+	require.Equal(t, map[logicalcluster.Path]int{one: 4, two: 1}, calls)
+}
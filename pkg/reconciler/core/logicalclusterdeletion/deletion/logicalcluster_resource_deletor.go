@@ -36,6 +36,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	kcpmetadata "github.com/kcp-dev/client-go/metadata"
@@ -71,15 +72,24 @@ const (
 // - update deleteCollection to delete resources from all namespaces.
 type WorkspaceResourcesDeleterInterface interface {
 	Delete(ctx context.Context, cluster *corev1alpha1.LogicalCluster) error
+	// DryRun returns the content Delete would remove from the logical cluster, without deleting anything.
+	DryRun(ctx context.Context, cluster *corev1alpha1.LogicalCluster) (*DeletionPlan, error)
 }
 
 // NewWorkspacedResourcesDeleter returns a new NamespacedResourcesDeleter.
+//
+// resourceAnnotationsFn returns the annotations of the CustomResourceDefinition or APIResourceSchema
+// defining a resource in a logical cluster, or nil for other resources. The deletion.kcp.io/order
+// annotation orders the deletion of resources, including namespaced ones, which are otherwise
+// removed by the deletion of their namespaces.
 func NewWorkspacedResourcesDeleter(
 	metadataClusterClient kcpmetadata.ClusterInterface,
-	discoverResourcesFn func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error)) WorkspaceResourcesDeleterInterface {
+	discoverResourcesFn func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error),
+	resourceAnnotationsFn func(clusterName logicalcluster.Name, gr schema.GroupResource) map[string]string) WorkspaceResourcesDeleterInterface {
 	d := &logicalClusterResourcesDeleter{
 		metadataClusterClient: metadataClusterClient,
 		discoverResourcesFn:   discoverResourcesFn,
+		resourceAnnotationsFn: resourceAnnotationsFn,
 	}
 	return d
 }
@@ -91,7 +101,8 @@ type logicalClusterResourcesDeleter struct {
 	// Dynamic client to list and delete all resources in the logical cluster.
 	metadataClusterClient kcpmetadata.ClusterInterface
 
-	discoverResourcesFn func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error)
+	discoverResourcesFn   func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error)
+	resourceAnnotationsFn func(clusterName logicalcluster.Name, gr schema.GroupResource) map[string]string
 }
 
 // Delete deletes all resources in the given logical cluster.
@@ -354,24 +365,7 @@ func (d *logicalClusterResourcesDeleter) deleteAllContent(ctx context.Context, w
 		deletionContentSuccessReason = "DiscoveryFailed"
 	}
 
-	deletableResources := discovery.FilteredBy(and{
-		discovery.SupportsAllVerbs{Verbs: []string{"delete"}},
-
-		// LogicalCluster is the trigger for the whole deletion. Don't block on it.
-		isNotGroupResource{group: core.GroupName, resource: "logicalclusters"},
-
-		// Keep the logical cluster accessible for users in case they have to debug.
-		isNotGroupResource{group: rbac.GroupName, resource: "clusterroles"},
-		isNotGroupResource{group: rbac.GroupName, resource: "clusterrolebindings"},
-
-		// Don't try to delete projected resources - these are virtual projections and we shouldn't try to delete them.
-		// The projections will disappear when the real underlying data are deleted.
-		isNotVirtualResource{},
-		// no need to delete namespace scoped resource since it will be handled by namespace deletion anyway. This
-		// can avoid redundant list/delete requests.
-		isNotNamespaceScoped{},
-	}, resources)
-	groupVersionResources, err := groupVersionResources(deletableResources)
+	groupVersionResources, err := groupVersionResources(d.deletableResources(logicalcluster.From(ws), resources))
 	if err != nil {
 		// discovery errors are not fatal.  We often have some set of resources we can operate against even if we don't have a complete list
 		errs = append(errs, err)
@@ -383,25 +377,32 @@ func (d *logicalClusterResourcesDeleter) deleteAllContent(ctx context.Context, w
 		finalizersToNumRemaining: map[string]int{},
 	}
 	deleteContentErrs := []error{}
-	for gvr, verbs := range groupVersionResources {
-		gvrDeletionMetadata, err := d.deleteAllContentForGroupVersionResource(ctx, logicalcluster.From(ws), gvr, verbs, clusterDeletedAt)
-		if err != nil {
-			// If there is an error, hold on to it but proceed with all the remaining
-			// groupVersionResources.
-			deleteContentErrs = append(deleteContentErrs, err)
-		}
-		if gvrDeletionMetadata.finalizerEstimateSeconds > estimate {
-			estimate = gvrDeletionMetadata.finalizerEstimateSeconds
-		}
-		if gvrDeletionMetadata.numRemaining > 0 {
-			numRemainingTotals.gvrToNumRemaining[gvr] = gvrDeletionMetadata.numRemaining
-			for finalizer, numRemaining := range gvrDeletionMetadata.finalizersToNumRemaining {
-				if numRemaining == 0 {
-					continue
+	// delete the resources group by group, and only advance to the next group when the prior one is empty.
+	for _, group := range d.deletionOrder(ctx, logicalcluster.From(ws), groupVersionResources) {
+		for _, gvr := range group {
+			gvrDeletionMetadata, err := d.deleteAllContentForGroupVersionResource(ctx, logicalcluster.From(ws), gvr, groupVersionResources[gvr], clusterDeletedAt)
+			if err != nil {
+				// If there is an error, hold on to it but proceed with all the remaining
+				// groupVersionResources of the group.
+				deleteContentErrs = append(deleteContentErrs, err)
+			}
+			if gvrDeletionMetadata.finalizerEstimateSeconds > estimate {
+				estimate = gvrDeletionMetadata.finalizerEstimateSeconds
+			}
+			if gvrDeletionMetadata.numRemaining > 0 {
+				numRemainingTotals.gvrToNumRemaining[gvr] = gvrDeletionMetadata.numRemaining
+				for finalizer, numRemaining := range gvrDeletionMetadata.finalizersToNumRemaining {
+					if numRemaining == 0 {
+						continue
+					}
+					numRemainingTotals.finalizersToNumRemaining[finalizer] += numRemaining
 				}
-				numRemainingTotals.finalizersToNumRemaining[finalizer] += numRemaining
 			}
 		}
+		if len(deleteContentErrs) > 0 || len(numRemainingTotals.gvrToNumRemaining) > 0 {
+			logger.V(5).Info("waiting for resources to be deleted before deleting the next group")
+			break
+		}
 	}
 
 	if len(deleteContentErrs) > 0 {
@@ -463,6 +464,171 @@ func (d *logicalClusterResourcesDeleter) deleteAllContent(ctx context.Context, w
 	return estimate, "", nil
 }
 
+// deletableResources filters the discovered resources down to those removed by the deletion of a logical cluster.
+func (d *logicalClusterResourcesDeleter) deletableResources(clusterName logicalcluster.Name, resources []*metav1.APIResourceList) []*metav1.APIResourceList {
+	return discovery.FilteredBy(and{
+		discovery.SupportsAllVerbs{Verbs: []string{"delete"}},
+
+		// LogicalCluster is the trigger for the whole deletion. Don't block on it.
+		isNotGroupResource{group: core.GroupName, resource: "logicalclusters"},
+
+		// Keep the logical cluster accessible for users in case they have to debug.
+		isNotGroupResource{group: rbac.GroupName, resource: "clusterroles"},
+		isNotGroupResource{group: rbac.GroupName, resource: "clusterrolebindings"},
+
+		// Don't try to delete projected resources - these are virtual projections and we shouldn't try to delete them.
+		// The projections will disappear when the real underlying data are deleted.
+		isNotVirtualResource{},
+		// no need to delete namespace scoped resource since it will be handled by namespace deletion anyway. This
+		// can avoid redundant list/delete requests. Only namespaced resources with a deletion order are deleted
+		// in that order, before namespaces without one.
+		isNotNamespaceScopedOrOrdered{isOrdered: func(gr schema.GroupResource) bool {
+			_, ordered, err := d.resourceDeletionOrder(clusterName, gr)
+			return ordered && err == nil
+		}},
+	}, resources)
+}
+
+// DeletionPlan is the content that would be removed by the deletion of a logical cluster.
+type DeletionPlan struct {
+	// Groups are the objects that would be deleted, grouped in the order of deletion. The objects
+	// of a group are only deleted when those of the prior groups are gone.
+	Groups [][]PlannedDeletion
+	// Estimate is the estimate in seconds for the planned objects to be removed.
+	Estimate int64
+}
+
+// PlannedDeletion is an object that would be deleted.
+type PlannedDeletion struct {
+	Resource   schema.GroupVersionResource
+	Namespace  string
+	Name       string
+	Finalizers []string
+}
+
+// DryRun lists the content that Delete would remove from the given logical cluster, in the order of
+// deletion. It only lists objects and never deletes anything.
+func (d *logicalClusterResourcesDeleter) DryRun(ctx context.Context, logicalCluster *corev1alpha1.LogicalCluster) (*DeletionPlan, error) {
+	logger := klog.FromContext(ctx).WithValues("operation", "dryRun")
+	logger.V(5).Info("running operation")
+
+	clusterName := logicalcluster.From(logicalCluster)
+	var clusterDeletedAt metav1.Time
+	if logicalCluster.DeletionTimestamp != nil {
+		clusterDeletedAt = *logicalCluster.DeletionTimestamp
+	}
+
+	resources, err := d.discoverResourcesFn(clusterName.Path())
+	if err != nil {
+		return nil, err
+	}
+	groupVersionResources, err := groupVersionResources(d.deletableResources(clusterName, resources))
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &DeletionPlan{}
+	for _, group := range d.deletionOrder(ctx, clusterName, groupVersionResources) {
+		var planned []PlannedDeletion
+		for _, gvr := range group {
+			list, listSupported, err := d.listCollection(ctx, clusterName, gvr, groupVersionResources[gvr])
+			if err != nil {
+				return nil, err
+			}
+			if !listSupported || len(list.Items) == 0 {
+				continue
+			}
+
+			estimate, err := d.estimateGracefulTermination(ctx, gvr, clusterName, clusterDeletedAt)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range list.Items {
+				planned = append(planned, PlannedDeletion{
+					Resource:   gvr,
+					Namespace:  item.Namespace,
+					Name:       item.Name,
+					Finalizers: item.Finalizers,
+				})
+				if estimate == 0 && len(item.Finalizers) > 0 {
+					estimate = finalizerEstimateSeconds
+				}
+			}
+			if estimate > plan.Estimate {
+				plan.Estimate = estimate
+			}
+		}
+		if len(planned) > 0 {
+			plan.Groups = append(plan.Groups, planned)
+		}
+	}
+
+	return plan, nil
+}
+
+// deletionOrder groups the given resources by the deletion.kcp.io/order annotation of the
+// CustomResourceDefinition or APIResourceSchema defining them. The groups are ordered ascending,
+// followed by the group of resources without or with an invalid annotation. The resources within
+// a group are sorted for stable deletion.
+func (d *logicalClusterResourcesDeleter) deletionOrder(ctx context.Context, clusterName logicalcluster.Name, gvrs map[schema.GroupVersionResource]sets.String) [][]schema.GroupVersionResource {
+	logger := klog.FromContext(ctx)
+
+	ordered := map[int][]schema.GroupVersionResource{}
+	var unordered []schema.GroupVersionResource
+	for gvr := range gvrs {
+		order, found, err := d.resourceDeletionOrder(clusterName, gvr.GroupResource())
+		if err != nil {
+			logger.V(2).Info("ignoring invalid deletion order annotation", "gvr", gvr, "err", err)
+			unordered = append(unordered, gvr)
+			continue
+		}
+		if !found {
+			unordered = append(unordered, gvr)
+			continue
+		}
+		ordered[order] = append(ordered[order], gvr)
+	}
+
+	orders := make([]int, 0, len(ordered))
+	for order := range ordered {
+		orders = append(orders, order)
+	}
+	sort.Ints(orders)
+
+	groups := make([][]schema.GroupVersionResource, 0, len(orders)+1)
+	for _, order := range orders {
+		groups = append(groups, sortedGroupVersionResources(ordered[order]))
+	}
+	if len(unordered) > 0 {
+		groups = append(groups, sortedGroupVersionResources(unordered))
+	}
+	return groups
+}
+
+// resourceDeletionOrder returns the deletion.kcp.io/order annotation of the CustomResourceDefinition or
+// APIResourceSchema defining the given resource, and whether it is set.
+func (d *logicalClusterResourcesDeleter) resourceDeletionOrder(clusterName logicalcluster.Name, gr schema.GroupResource) (int, bool, error) {
+	if d.resourceAnnotationsFn == nil {
+		return 0, false, nil
+	}
+	value, found := d.resourceAnnotationsFn(clusterName, gr)[core.DeletionOrderAnnotationKey]
+	if !found {
+		return 0, false, nil
+	}
+	order, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, true, fmt.Errorf("invalid %s annotation %q: %w", core.DeletionOrderAnnotationKey, value, err)
+	}
+	return order, true, nil
+}
+
+func sortedGroupVersionResources(gvrs []schema.GroupVersionResource) []schema.GroupVersionResource {
+	sort.Slice(gvrs, func(i, j int) bool {
+		return gvrs[i].String() < gvrs[j].String()
+	})
+	return gvrs
+}
+
 // estimateGracefulTermination will estimate the graceful termination required for the specific entity in the logical cluster.
 func (d *logicalClusterResourcesDeleter) estimateGracefulTermination(ctx context.Context, gvr schema.GroupVersionResource, clusterName logicalcluster.Name, clusterDeletedAt metav1.Time) (int64, error) {
 	logger := klog.FromContext(ctx).WithValues("operation", "estimateGracefulTermination", "gvr", gvr)
@@ -481,7 +647,12 @@ func groupVersionResources(rls []*metav1.APIResourceList) (map[schema.GroupVersi
 			return nil, err
 		}
 		for i := range rl.APIResources {
-			gvrs[schema.GroupVersionResource{Group: gv.Group, Version: gv.Version, Resource: rl.APIResources[i].Name}] = sets.NewString(rl.APIResources[i].Verbs...)
+			verbs := sets.NewString(rl.APIResources[i].Verbs...)
+			if rl.APIResources[i].Namespaced {
+				// namespaced collections cannot be deleted across all namespaces. Delete their items one by one.
+				verbs.Delete(string(operationDeleteCollection))
+			}
+			gvrs[schema.GroupVersionResource{Group: gv.Group, Version: gv.Version, Resource: rl.APIResources[i].Name}] = verbs
 		}
 	}
 	return gvrs, nil
@@ -513,11 +684,20 @@ func (vr isNotVirtualResource) Match(groupVersion string, r *metav1.APIResource)
 	return !projection.Includes(gvr)
 }
 
-type isNotNamespaceScoped struct{}
+type isNotNamespaceScopedOrOrdered struct {
+	isOrdered func(gr schema.GroupResource) bool
+}
 
-// Match checks if the resource is a cluster scoped resource.
-func (n isNotNamespaceScoped) Match(groupVersion string, r *metav1.APIResource) bool {
-	return !r.Namespaced
+// Match checks if the resource is a cluster scoped resource, or a namespaced resource with a deletion order.
+func (n isNotNamespaceScopedOrOrdered) Match(groupVersion string, r *metav1.APIResource) bool {
+	if !r.Namespaced {
+		return true
+	}
+	gv, err := schema.ParseGroupVersion(groupVersion)
+	if err != nil {
+		return false
+	}
+	return n.isOrdered(schema.GroupResource{Group: gv.Group, Resource: r.Name})
 }
 
 type and []discovery.ResourcePredicate
@@ -22,14 +22,17 @@ import (
 	"testing"
 
 	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
 
 	kcpfakemetadata "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/metadata/fake"
 	kcptesting "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/testing"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 
+	"github.com/kcp-dev/kcp/pkg/apis/core"
 	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
@@ -123,7 +126,7 @@ func TestWorkspaceTerminating(t *testing.T) {
 				return resources, tt.gvrError
 			}
 			mockMetadataClient := kcpfakemetadata.NewSimpleMetadataClient(scheme, tt.existingObject...)
-			d := NewWorkspacedResourcesDeleter(mockMetadataClient, fn)
+			d := NewWorkspacedResourcesDeleter(mockMetadataClient, fn, nil)
 
 			err := d.Delete(context.TODO(), ws)
 			if !matchErrors(err, tt.expectErrorOnDelete) {
@@ -153,6 +156,208 @@ func TestWorkspaceTerminating(t *testing.T) {
 	}
 }
 
+func TestWorkspaceTerminatingOrdered(t *testing.T) {
+	now := metav1.Now()
+	ws := &corev1alpha1.LogicalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test",
+			DeletionTimestamp: &now,
+			Finalizers:        []string{LogicalClusterDeletionFinalizer},
+			Annotations:       map[string]string{logicalcluster.AnnotationKey: "root"},
+		},
+	}
+	resources := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "namespaces", Kind: "Namespace", Verbs: []string{"list", "delete", "deletecollection"}},
+			},
+		},
+		{
+			GroupVersion: "apiextensions.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "customresourcedefinitions", Kind: "CustomResourceDefinition", Verbs: []string{"list", "delete", "deletecollection"}},
+			},
+		},
+		{
+			GroupVersion: "wild.wild.west/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "sheriffs", Kind: "Sheriff", Verbs: []string{"list", "delete", "deletecollection"}},
+				{Name: "cowboys", Kind: "Cowboy", Verbs: []string{"list", "delete", "deletecollection"}},
+				{Name: "horses", Kind: "Horse", Verbs: []string{"list", "delete", "deletecollection"}},
+				{Name: "saloons", Kind: "Saloon", Namespaced: true, Verbs: []string{"list", "delete", "deletecollection"}},
+				{Name: "bottles", Kind: "Bottle", Namespaced: true, Verbs: []string{"list", "delete", "deletecollection"}},
+				{Name: "guns", Kind: "Gun", Namespaced: true, Verbs: []string{"list", "delete", "deletecollection"}},
+			},
+		},
+	}
+	annotations := map[schema.GroupResource]map[string]string{
+		{Group: "wild.wild.west", Resource: "sheriffs"}: {core.DeletionOrderAnnotationKey: "10"},
+		{Group: "wild.wild.west", Resource: "cowboys"}:  {core.DeletionOrderAnnotationKey: "-1"},
+		{Group: "wild.wild.west", Resource: "horses"}:   {core.DeletionOrderAnnotationKey: "invalid"},
+		{Group: "wild.wild.west", Resource: "saloons"}:  {core.DeletionOrderAnnotationKey: "0"},
+		{Group: "wild.wild.west", Resource: "guns"}:     {core.DeletionOrderAnnotationKey: "invalid"},
+	}
+
+	tests := []struct {
+		name                string
+		existingObject      []runtime.Object
+		wantActions         []metaAction
+		expectErrorOnDelete error
+	}{
+		{
+			name: "groups are deleted in ascending order, followed by unordered resources, and namespaced resources without order are left to namespace deletion",
+			wantActions: []metaAction{
+				{"cowboys", "delete-collection"},
+				{"cowboys", "list"},
+				{"saloons", "list"},
+				{"saloons", "list"},
+				{"sheriffs", "delete-collection"},
+				{"sheriffs", "list"},
+				{"namespaces", "delete-collection"},
+				{"namespaces", "list"},
+				{"customresourcedefinitions", "delete-collection"},
+				{"customresourcedefinitions", "list"},
+				{"horses", "delete-collection"},
+				{"horses", "list"},
+			},
+		},
+		{
+			name: "next group is not deleted before the prior is empty",
+			existingObject: []runtime.Object{
+				newPartialObject("wild.wild.west/v1", "Sheriff", "wyatt", ""),
+			},
+			wantActions: []metaAction{
+				{"cowboys", "delete-collection"},
+				{"cowboys", "list"},
+				{"saloons", "list"},
+				{"saloons", "list"},
+				{"sheriffs", "delete-collection"},
+				{"sheriffs", "list"},
+			},
+			expectErrorOnDelete: &ResourcesRemainingError{5, "Some resources are remaining: sheriffs.wild.wild.west has 1 resource instances"},
+		},
+		{
+			name: "ordered namespaced resources are deleted item by item before namespaces",
+			existingObject: []runtime.Object{
+				newPartialObject("v1", "Namespace", "tombstone", ""),
+				newPartialObject("wild.wild.west/v1", "Saloon", "oriental", "tombstone"),
+				newPartialObject("wild.wild.west/v1", "Bottle", "whiskey", "tombstone"),
+			},
+			wantActions: []metaAction{
+				{"cowboys", "delete-collection"},
+				{"cowboys", "list"},
+				{"saloons", "list"},
+				{"saloons", "delete"},
+				{"saloons", "list"},
+				{"sheriffs", "delete-collection"},
+				{"sheriffs", "list"},
+				{"namespaces", "delete-collection"},
+				{"namespaces", "list"},
+				{"customresourcedefinitions", "delete-collection"},
+				{"customresourcedefinitions", "list"},
+				{"horses", "delete-collection"},
+				{"horses", "list"},
+			},
+			// the fake client does not delete collections. The bottle is left to the deletion of the namespace.
+			expectErrorOnDelete: &ResourcesRemainingError{5, "Some resources are remaining: namespaces. has 1 resource instances"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discoverFn := func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error) {
+				return resources, nil
+			}
+			annotationsFn := func(clusterName logicalcluster.Name, gr schema.GroupResource) map[string]string {
+				require.Equal(t, logicalcluster.Name("root"), clusterName)
+				return annotations[gr]
+			}
+			mockMetadataClient := kcpfakemetadata.NewSimpleMetadataClient(scheme, tt.existingObject...)
+			d := NewWorkspacedResourcesDeleter(mockMetadataClient, discoverFn, annotationsFn)
+
+			err := d.Delete(context.TODO(), ws.DeepCopy())
+			if !matchErrors(err, tt.expectErrorOnDelete) {
+				t.Errorf("expected error %q when syncing namespace, got %q", tt.expectErrorOnDelete, err)
+			}
+
+			var got []metaAction
+			for _, action := range mockMetadataClient.Actions() {
+				got = append(got, metaAction{action.GetResource().Resource, action.GetVerb()})
+			}
+			require.Equal(t, tt.wantActions, got)
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	ws := &corev1alpha1.LogicalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "root"},
+		},
+	}
+	resources := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "namespaces", Kind: "Namespace", Verbs: []string{"list", "delete", "deletecollection"}},
+				{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: []string{"list", "delete", "deletecollection"}},
+			},
+		},
+		{
+			GroupVersion: "rbac.authorization.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "clusterroles", Kind: "ClusterRole", Verbs: []string{"list", "delete", "deletecollection"}},
+			},
+		},
+		{
+			GroupVersion: "wild.wild.west/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "sheriffs", Kind: "Sheriff", Verbs: []string{"list", "delete", "deletecollection"}},
+			},
+		},
+	}
+	annotationsFn := func(clusterName logicalcluster.Name, gr schema.GroupResource) map[string]string {
+		if gr.Resource == "sheriffs" {
+			return map[string]string{core.DeletionOrderAnnotationKey: "1"}
+		}
+		return nil
+	}
+	discoverFn := func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error) {
+		return resources, nil
+	}
+
+	sheriff := newPartialObject("wild.wild.west/v1", "Sheriff", "wyatt", "")
+	sheriff.Finalizers = []string{"wild.wild.west/badge"}
+	mockMetadataClient := kcpfakemetadata.NewSimpleMetadataClient(scheme,
+		newPartialObject("v1", "Namespace", "default", ""),
+		newPartialObject("v1", "Secret", "s1", "default"),
+		newPartialObject("rbac.authorization.k8s.io/v1", "ClusterRole", "admin", ""),
+		sheriff,
+	)
+	d := NewWorkspacedResourcesDeleter(mockMetadataClient, discoverFn, annotationsFn)
+
+	plan, err := d.DryRun(context.TODO(), ws)
+	require.NoError(t, err)
+	require.Equal(t, &DeletionPlan{
+		Groups: [][]PlannedDeletion{
+			{
+				{Resource: schema.GroupVersionResource{Group: "wild.wild.west", Version: "v1", Resource: "sheriffs"}, Name: "wyatt", Finalizers: []string{"wild.wild.west/badge"}},
+			},
+			{
+				{Resource: schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}, Name: "default"},
+			},
+		},
+		Estimate: 5,
+	}, plan)
+
+	for _, action := range mockMetadataClient.Actions() {
+		require.Equal(t, "list", action.GetVerb(), "dry-run must not change anything")
+	}
+	require.Empty(t, ws.Status.Conditions, "dry-run must not change conditions")
+}
+
 type metaAction struct {
 	resource string
 	verb     string
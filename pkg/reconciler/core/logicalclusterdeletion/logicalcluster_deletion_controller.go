@@ -20,31 +20,40 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"sync"
 	"time"
 
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
 	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	kcpmetadata "github.com/kcp-dev/client-go/metadata"
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	corev1 "k8s.io/api/core/v1"
+	kcpapiextensionsv1informers "k8s.io/apiextensions-apiserver/pkg/client/kcp/informers/externalversions/apiextensions/v1"
+	kcpapiextensionsv1listers "k8s.io/apiextensions-apiserver/pkg/client/kcp/listers/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
+	"github.com/kcp-dev/kcp/pkg/apis/apis"
 	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
 	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
 	corev1alpha1client "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/core/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
 	corev1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/core/v1alpha1"
+	apisv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/apis/v1alpha1"
 	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
@@ -60,27 +69,68 @@ var (
 	backgroudDeletion = metav1.DeleteOptions{PropagationPolicy: &background}
 )
 
+// FrontProxyTLSOverrides adjust the TLS client config of the front-proxy client, e.g. for
+// deployments where the front-proxy serves with a certificate of a different CA than the shards.
+type FrontProxyTLSOverrides struct {
+	// CAFile is the path to a CA bundle used to verify the front-proxy serving certificate.
+	CAFile string
+	// CAData holds a PEM-encoded CA bundle. It takes precedence over CAFile.
+	CAData []byte
+	// ServerName is sent to the front-proxy for SNI, and used to verify its serving certificate.
+	ServerName string
+}
+
+// apply applies the overrides to the given config.
+func (o FrontProxyTLSOverrides) apply(config *rest.Config) {
+	if len(o.CAData) > 0 || o.CAFile != "" {
+		config.TLSClientConfig.CAData = o.CAData
+		config.TLSClientConfig.CAFile = o.CAFile
+		config.TLSClientConfig.Insecure = false
+	}
+	if o.ServerName != "" {
+		config.TLSClientConfig.ServerName = o.ServerName
+	}
+}
+
 func NewController(
 	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
 	kcpClusterClient kcpclientset.ClusterInterface,
 	logicalClusterAdminConfig *rest.Config,
 	shardExternalURL func() string,
+	frontProxyTLSOverrides FrontProxyTLSOverrides,
 	metadataClusterClient kcpmetadata.ClusterInterface,
 	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+	configMapInformer kcpcorev1informers.ConfigMapClusterInformer,
+	crdInformer kcpapiextensionsv1informers.CustomResourceDefinitionClusterInformer,
+	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
 	discoverResourcesFn func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error),
+	maxRetryInterval time.Duration,
 ) *Controller {
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter(maxRetryInterval), ControllerName)
+
+	// discovery is repeated on every deletion attempt. Cache it per logical cluster until the
+	// served resources change.
+	discoveryCache := deletion.NewDiscoveryCache(discoverResourcesFn)
 
 	c := &Controller{
 		queue:                     queue,
+		maxRetryInterval:          maxRetryInterval,
 		kubeClusterClient:         kubeClusterClient,
 		kcpClusterClient:          kcpClusterClient,
 		logicalClusterAdminConfig: logicalClusterAdminConfig,
 		shardExternalURL:          shardExternalURL,
-		metadataClusterClient:     metadataClusterClient,
-		logicalClusterLister:      logicalClusterInformer.Lister(),
-		deleter:                   deletion.NewWorkspacedResourcesDeleter(metadataClusterClient, discoverResourcesFn),
-		commit:                    committer.NewCommitter[*LogicalCluster, Patcher, *LogicalClusterSpec, *LogicalClusterStatus](kcpClusterClient.CoreV1alpha1().LogicalClusters()),
+		frontProxyTLSOverrides:    frontProxyTLSOverrides,
+		newDynamicClient: func(config *rest.Config) (kcpdynamic.ClusterInterface, error) {
+			return kcpdynamic.NewForConfig(config)
+		},
+		metadataClusterClient: metadataClusterClient,
+		logicalClusterLister:  logicalClusterInformer.Lister(),
+		deleter: deletion.NewWorkspacedResourcesDeleter(
+			metadataClusterClient,
+			discoveryCache.DiscoverResources,
+			resourceAnnotations(crdInformer.Lister(), apiBindingInformer.Lister()),
+		),
+		commit: committer.NewCommitter[*LogicalCluster, Patcher, *LogicalClusterSpec, *LogicalClusterStatus](kcpClusterClient.CoreV1alpha1().LogicalClusters()),
 	}
 
 	logicalClusterInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
@@ -98,9 +148,96 @@ func NewController(
 		},
 	})
 
+	invalidateDiscovery := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		metaObj, ok := obj.(metav1.Object)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("unexpected object type %T", obj))
+			return
+		}
+		discoveryCache.Invalidate(logicalcluster.From(metaObj).Path())
+	}
+	invalidateDiscoveryHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    invalidateDiscovery,
+		UpdateFunc: func(_, obj interface{}) { invalidateDiscovery(obj) },
+		DeleteFunc: invalidateDiscovery,
+	}
+	// bound resources are served from CRDs in the system:bound-crds logical cluster, hence
+	// APIBindings invalidate the logical cluster they bind into.
+	crdInformer.Informer().AddEventHandler(invalidateDiscoveryHandler)
+	apiBindingInformer.Informer().AddEventHandler(invalidateDiscoveryHandler)
+	// drop the cache entry of deleted logical clusters.
+	logicalClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: invalidateDiscovery,
+	})
+
+	configMapInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: isWorkersConfigMap,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.updateWorkersFromConfigMap(obj) },
+			UpdateFunc: func(_, obj interface{}) { c.updateWorkersFromConfigMap(obj) },
+			DeleteFunc: func(obj interface{}) { c.SetWorkers(0) },
+		},
+	})
+
 	return c
 }
 
+// rateLimiter returns the default controller rate limiter, with the delays capped at maxRetryInterval.
+func rateLimiter(maxRetryInterval time.Duration) workqueue.RateLimiter {
+	return &cappedRateLimiter{
+		RateLimiter: workqueue.DefaultControllerRateLimiter(),
+		max:         maxRetryInterval,
+	}
+}
+
+type cappedRateLimiter struct {
+	workqueue.RateLimiter
+	max time.Duration
+}
+
+func (r *cappedRateLimiter) When(item interface{}) time.Duration {
+	if d := r.RateLimiter.When(item); r.max <= 0 || d < r.max {
+		return d
+	}
+	return r.max
+}
+
+// resourceAnnotations returns a function returning the annotations of the CRD serving a resource in a
+// logical cluster, either a CRD in the logical cluster itself, or the bound CRD of an APIBinding, which
+// carries the relevant annotations of the APIResourceSchema.
+func resourceAnnotations(
+	crdLister kcpapiextensionsv1listers.CustomResourceDefinitionClusterLister,
+	apiBindingLister apisv1alpha1listers.APIBindingClusterLister,
+) func(clusterName logicalcluster.Name, gr schema.GroupResource) map[string]string {
+	return func(clusterName logicalcluster.Name, gr schema.GroupResource) map[string]string {
+		if gr.Group != "" {
+			if crd, err := crdLister.Cluster(clusterName).Get(gr.String()); err == nil {
+				return crd.Annotations
+			}
+		}
+
+		bindings, err := apiBindingLister.Cluster(clusterName).List(labels.Everything())
+		if err != nil {
+			return nil
+		}
+		for _, binding := range bindings {
+			for _, br := range binding.Status.BoundResources {
+				if br.Group != gr.Group || br.Resource != gr.Resource {
+					continue
+				}
+				if crd, err := crdLister.Cluster(apis.SystemBoundCRDsClusterName).Get(br.Schema.UID); err == nil {
+					return crd.Annotations
+				}
+				return nil
+			}
+		}
+		return nil
+	}
+}
+
 type LogicalCluster = corev1alpha1.LogicalCluster
 type LogicalClusterSpec = corev1alpha1.LogicalClusterSpec
 type LogicalClusterStatus = corev1alpha1.LogicalClusterStatus
@@ -110,13 +247,21 @@ type CommitFunc = func(context.Context, *Resource, *Resource) error
 
 type Controller struct {
 	queue workqueue.RateLimitingInterface
+	// maxRetryInterval caps the time to wait before retrying the deletion of a logical cluster.
+	maxRetryInterval time.Duration
 
 	kubeClusterClient kcpkubernetesclientset.ClusterInterface
 	kcpClusterClient  kcpclientset.ClusterInterface
 
 	logicalClusterAdminConfig *rest.Config
 	shardExternalURL          func() string
-	dynamicFrontProxyClient   kcpdynamic.ClusterInterface
+	frontProxyTLSOverrides    FrontProxyTLSOverrides
+	newDynamicClient          func(config *rest.Config) (kcpdynamic.ClusterInterface, error)
+
+	// lock guards the front-proxy client, which is re-created when the shard external URL changes.
+	lock                    sync.Mutex
+	frontProxyHost          string
+	dynamicFrontProxyClient kcpdynamic.ClusterInterface
 
 	metadataClusterClient kcpmetadata.ClusterInterface
 
@@ -125,6 +270,13 @@ type Controller struct {
 	deleter deletion.WorkspaceResourcesDeleterInterface
 
 	commit CommitFunc
+
+	// workersLock guards the worker pool, which can be resized while the controller is running.
+	workersLock    sync.Mutex
+	workersCtx     context.Context
+	defaultWorkers int
+	workers        int
+	workerStops    []chan struct{}
 }
 
 func (c *Controller) enqueue(obj interface{}) {
@@ -147,26 +299,50 @@ func (c *Controller) Start(ctx context.Context, numThreads int) {
 	logger.Info("Starting controller")
 	defer logger.Info("Shutting down controller")
 
+	c.startWorkers(ctx, numThreads)
+	defer c.stopWorkers()
+
+	<-ctx.Done()
+}
+
+// frontProxyClient returns a dynamic client pointed at the current shard external URL. The URL
+// is resolved on every call, and the client is re-created when it has changed, e.g. after a
+// load balancer failover.
+func (c *Controller) frontProxyClient() (kcpdynamic.ClusterInterface, error) {
+	host := c.shardExternalURL()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.dynamicFrontProxyClient != nil && c.frontProxyHost == host {
+		return c.dynamicFrontProxyClient, nil
+	}
+
 	// a client needed to remove the finalizer from the logical cluster on a different shard
 	frontProxyConfig := rest.CopyConfig(c.logicalClusterAdminConfig)
 	frontProxyConfig = rest.AddUserAgent(frontProxyConfig, ControllerName)
-	frontProxyConfig.Host = c.shardExternalURL()
-	dynamicFrontProxyClient, err := kcpdynamic.NewForConfig(frontProxyConfig)
+	frontProxyConfig.Host = host
+	c.frontProxyTLSOverrides.apply(frontProxyConfig)
+	dynamicFrontProxyClient, err := c.newDynamicClient(frontProxyConfig)
 	if err != nil {
-		runtime.HandleError(err)
-		return
+		return nil, fmt.Errorf("failed to create front-proxy client for %q: %w", host, err)
 	}
+	c.frontProxyHost = host
 	c.dynamicFrontProxyClient = dynamicFrontProxyClient
 
-	for i := 0; i < numThreads; i++ {
-		go wait.Until(func() { c.startWorker(ctx) }, time.Second, ctx.Done())
-	}
-
-	<-ctx.Done()
+	return dynamicFrontProxyClient, nil
 }
 
-func (c *Controller) startWorker(ctx context.Context) {
-	for c.processNextWorkItem(ctx) {
+func (c *Controller) startWorker(ctx context.Context, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		if !c.processNextWorkItem(ctx) {
+			return
+		}
 	}
 }
 
@@ -197,8 +373,7 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 
 	var estimate *deletion.ResourcesRemainingError
 	if errors.As(err, &estimate) {
-		t := estimate.Estimate/2 + 1
-		duration := time.Duration(t) * time.Second
+		duration := c.retryAfter(estimate)
 		logger.V(2).Error(err, "content remaining in logical cluster after a wait, waiting more to continue", "duration", time.Since(startTime), "waiting", duration)
 
 		c.queue.AddAfter(key, duration)
@@ -211,6 +386,16 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	return true
 }
 
+// retryAfter returns the time to wait before retrying the deletion of a logical cluster with
+// content remaining, capped at maxRetryInterval.
+func (c *Controller) retryAfter(estimate *deletion.ResourcesRemainingError) time.Duration {
+	duration := time.Duration(estimate.Estimate/2+1) * time.Second
+	if c.maxRetryInterval > 0 && duration > c.maxRetryInterval {
+		return c.maxRetryInterval
+	}
+	return duration
+}
+
 func (c *Controller) process(ctx context.Context, key string) error {
 	logger := klog.FromContext(ctx)
 	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
@@ -256,12 +441,11 @@ func (c *Controller) process(ctx context.Context, key string) error {
 	return utilerrors.NewAggregate(errs)
 }
 
-// finalizeNamespace removes the specified finalizer and finalizes the logical cluster.
+// finalizeWorkspace removes the specified finalizer and finalizes the logical cluster.
 func (c *Controller) finalizeWorkspace(ctx context.Context, ws *corev1alpha1.LogicalCluster) error {
 	logger := klog.FromContext(ctx)
 	for i := range ws.Finalizers {
 		if ws.Finalizers[i] == deletion.LogicalClusterDeletionFinalizer {
-			ws.Finalizers = append(ws.Finalizers[:i], ws.Finalizers[i+1:]...)
 			clusterName := logicalcluster.From(ws)
 
 			// TODO(hasheddan): ClusterRole and ClusterRoleBinding cleanup
@@ -277,49 +461,26 @@ func (c *Controller) finalizeWorkspace(ctx context.Context, ws *corev1alpha1.Log
 			}
 
 			if ws.Spec.Owner != nil {
-				gvr := schema.GroupVersionResource{
-					Resource: ws.Spec.Owner.Resource,
-				}
-				comps := strings.SplitN(ws.Spec.Owner.APIVersion, "/", 2)
-				if len(comps) == 2 {
-					gvr.Group = comps[0]
-					gvr.Version = comps[1]
-				} else {
-					gvr.Version = comps[0]
+				gvr, err := ownerGVR(ws.Spec.Owner)
+				if err != nil {
+					// the owner can never be looked up. Don't block deletion forever.
+					logger.Error(err, "skipping finalization of invalid owner", "owner.apiVersion", ws.Spec.Owner.APIVersion, "owner.resource", ws.Spec.Owner.Resource, "owner.name", ws.Spec.Owner.Name, "owner.namespace", ws.Spec.Owner.Namespace, "owner.cluster", ws.Spec.Owner.Cluster)
+				} else if err := c.finalizeOwner(ctx, ws, gvr); err != nil {
+					return err
 				}
-				uid := ws.Spec.Owner.UID
-				logger = logger.WithValues("owner.gvr", gvr, "owner.uid", uid, "owner.name", ws.Spec.Owner.Name, "owner.namespace", ws.Spec.Owner.Namespace, "owner.cluster", ws.Spec.Owner.Cluster)
-
-				// remove finalizer from owner
-				logger.Info("checking owner for finalizer")
-				clusterPath := logicalcluster.NewPath(ws.Spec.Owner.Cluster)
-				obj, err := c.dynamicFrontProxyClient.Cluster(clusterPath).Resource(gvr).Namespace(ws.Spec.Owner.Namespace).Get(ctx, ws.Spec.Owner.Name, metav1.GetOptions{})
-				if err != nil && !apierrors.IsNotFound(err) {
-					return fmt.Errorf("could not get owner %s %s/%s in cluster %s: %w", gvr, ws.Spec.Owner.Namespace, ws.Spec.Owner.Name, ws.Spec.Owner.Cluster, err)
-				} else if err == nil && obj.GetUID() != uid {
-					logger.Info("owner has changed, skipping finalizer removal")
-					return fmt.Errorf("could not get owner %s %s/%s in cluster %s is of wrong UID: %w", gvr, ws.Spec.Owner.Namespace, ws.Spec.Owner.Name, ws.Spec.Owner.Cluster, err)
-				} else if err == nil {
-					finalizers := sets.NewString(obj.GetFinalizers()...)
-					if finalizers.Has(corev1alpha1.LogicalClusterFinalizer) {
-						logger.Info("removing finalizer from owner")
-						finalizers.Delete(corev1alpha1.LogicalClusterFinalizer)
-						obj.SetFinalizers(finalizers.List())
-						if obj, err = c.dynamicFrontProxyClient.Cluster(clusterPath).Resource(gvr).Namespace(ws.Spec.Owner.Namespace).Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
-							return fmt.Errorf("could not remove finalizer from owner %s %s/%s in cluster %s: %w", gvr, ws.Spec.Owner.Namespace, ws.Spec.Owner.Name, ws.Spec.Owner.Cluster, err)
-						}
-					}
-
-					// delete owner
-					if obj.GetDeletionTimestamp().IsZero() && ws.Spec.DirectlyDeletable {
-						logger.Info("deleting owner")
-						if err := c.dynamicFrontProxyClient.Cluster(clusterPath).Resource(gvr).Namespace(ws.Spec.Owner.Namespace).Delete(ctx, ws.Spec.Owner.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}}); err != nil && !apierrors.IsNotFound(err) {
-							return fmt.Errorf("could not delete owner %s %s/%s in cluster %s: %w", gvr, ws.Spec.Owner.Namespace, ws.Spec.Owner.Name, ws.Spec.Owner.Cluster, err)
-						}
-					}
+			}
+
+			// record a lost owner before the finalizer is gone, which might remove the LogicalCluster.
+			if conditions.IsTrue(ws, corev1alpha1.LogicalClusterOwnerLost) {
+				logger.V(2).Info("recording lost owner in LogicalCluster status")
+				updated, err := c.kcpClusterClient.CoreV1alpha1().LogicalClusters().Cluster(clusterName.Path()).UpdateStatus(ctx, ws, metav1.UpdateOptions{})
+				if err != nil {
+					return err
 				}
+				ws.ResourceVersion = updated.ResourceVersion
 			}
 
+			ws.Finalizers = append(ws.Finalizers[:i], ws.Finalizers[i+1:]...)
 			logger.V(2).Info("removing finalizer from LogicalCluster")
 			_, err := c.kcpClusterClient.CoreV1alpha1().LogicalClusters().Cluster(clusterName.Path()).Update(ctx, ws, metav1.UpdateOptions{})
 			return err
@@ -328,3 +489,79 @@ func (c *Controller) finalizeWorkspace(ctx context.Context, ws *corev1alpha1.Log
 
 	return nil
 }
+
+// finalizeOwner removes the logical cluster finalizer from the owner of the logical cluster,
+// and deletes the owner if the logical cluster is directly deletable. If the owner does not
+// exist anymore or was recreated with another UID, it is left alone and the LogicalClusterOwnerLost
+// condition is set on the logical cluster instead, so that its deletion is not blocked forever.
+func (c *Controller) finalizeOwner(ctx context.Context, ws *corev1alpha1.LogicalCluster, gvr schema.GroupVersionResource) error {
+	uid := ws.Spec.Owner.UID
+	logger := klog.FromContext(ctx).WithValues("owner.gvr", gvr, "owner.uid", uid, "owner.name", ws.Spec.Owner.Name, "owner.namespace", ws.Spec.Owner.Namespace, "owner.cluster", ws.Spec.Owner.Cluster)
+
+	dynamicFrontProxyClient, err := c.frontProxyClient()
+	if err != nil {
+		return err
+	}
+
+	// remove finalizer from owner
+	logger.Info("checking owner for finalizer")
+	clusterPath := logicalcluster.NewPath(ws.Spec.Owner.Cluster)
+	obj, err := dynamicFrontProxyClient.Cluster(clusterPath).Resource(gvr).Namespace(ws.Spec.Owner.Namespace).Get(ctx, ws.Spec.Owner.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		logger.Error(nil, "owner not found, skipping its finalization")
+		conditions.Set(ws, &conditionsv1alpha1.Condition{
+			Type:    corev1alpha1.LogicalClusterOwnerLost,
+			Status:  corev1.ConditionTrue,
+			Reason:  corev1alpha1.LogicalClusterOwnerNotFoundReason,
+			Message: fmt.Sprintf("Owner %s %s/%s in cluster %s not found", gvr, ws.Spec.Owner.Namespace, ws.Spec.Owner.Name, ws.Spec.Owner.Cluster),
+		})
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("could not get owner %s %s/%s in cluster %s: %w", gvr, ws.Spec.Owner.Namespace, ws.Spec.Owner.Name, ws.Spec.Owner.Cluster, err)
+	}
+	if obj.GetUID() != uid {
+		logger.Error(nil, "owner has been recreated with another UID, skipping its finalization", "owner.actualUID", obj.GetUID())
+		conditions.Set(ws, &conditionsv1alpha1.Condition{
+			Type:    corev1alpha1.LogicalClusterOwnerLost,
+			Status:  corev1.ConditionTrue,
+			Reason:  corev1alpha1.LogicalClusterOwnerUIDMismatchReason,
+			Message: fmt.Sprintf("Owner %s %s/%s in cluster %s has UID %s, expected %s", gvr, ws.Spec.Owner.Namespace, ws.Spec.Owner.Name, ws.Spec.Owner.Cluster, obj.GetUID(), uid),
+		})
+		return nil
+	}
+
+	finalizers := sets.NewString(obj.GetFinalizers()...)
+	if finalizers.Has(corev1alpha1.LogicalClusterFinalizer) {
+		logger.Info("removing finalizer from owner")
+		finalizers.Delete(corev1alpha1.LogicalClusterFinalizer)
+		obj.SetFinalizers(finalizers.List())
+		if obj, err = dynamicFrontProxyClient.Cluster(clusterPath).Resource(gvr).Namespace(ws.Spec.Owner.Namespace).Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("could not remove finalizer from owner %s %s/%s in cluster %s: %w", gvr, ws.Spec.Owner.Namespace, ws.Spec.Owner.Name, ws.Spec.Owner.Cluster, err)
+		}
+	}
+
+	// delete owner, guarded by its UID in case it is recreated concurrently
+	if obj.GetDeletionTimestamp().IsZero() && ws.Spec.DirectlyDeletable {
+		logger.Info("deleting owner")
+		if err := dynamicFrontProxyClient.Cluster(clusterPath).Resource(gvr).Namespace(ws.Spec.Owner.Namespace).Delete(ctx, ws.Spec.Owner.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("could not delete owner %s %s/%s in cluster %s: %w", gvr, ws.Spec.Owner.Namespace, ws.Spec.Owner.Name, ws.Spec.Owner.Cluster, err)
+		}
+	}
+
+	return nil
+}
+
+// ownerGVR returns the resource of the owner of a logical cluster.
+func ownerGVR(owner *corev1alpha1.LogicalClusterOwner) (schema.GroupVersionResource, error) {
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	if gv.Version == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid owner apiVersion %q: version must not be empty", owner.APIVersion)
+	}
+	if owner.Resource == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid owner resource: must not be empty")
+	}
+	return gv.WithResource(owner.Resource), nil
+}
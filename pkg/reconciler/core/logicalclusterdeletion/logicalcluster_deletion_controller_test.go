@@ -0,0 +1,305 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalclusterdeletion
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpfakekubeclient "github.com/kcp-dev/client-go/kubernetes/fake"
+	kcpfakedynamic "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/dynamic/fake"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kcpapiextensionsv1listers "k8s.io/apiextensions-apiserver/pkg/client/kcp/listers/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kcp-dev/kcp/pkg/apis/apis"
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	kcpfakeclient "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster/fake"
+	apisv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/reconciler/core/logicalclusterdeletion/deletion"
+)
+
+func TestFrontProxyClientFollowsShardExternalURL(t *testing.T) {
+	externalURL := "https://front-proxy-1.example.com:6443"
+	var hosts []string
+
+	c := &Controller{
+		logicalClusterAdminConfig: &rest.Config{},
+		shardExternalURL: func() string {
+			return externalURL
+		},
+		newDynamicClient: func(config *rest.Config) (kcpdynamic.ClusterInterface, error) {
+			hosts = append(hosts, config.Host)
+			return kcpdynamic.NewForConfig(config)
+		},
+	}
+
+	first, err := c.frontProxyClient()
+	require.NoError(t, err)
+	again, err := c.frontProxyClient()
+	require.NoError(t, err)
+	require.Same(t, first, again, "expected the client to be reused while the URL does not change")
+	require.Equal(t, []string{"https://front-proxy-1.example.com:6443"}, hosts)
+
+	t.Log("Change the external URL, e.g. after a load balancer failover")
+	externalURL = "https://front-proxy-2.example.com:6443"
+
+	second, err := c.frontProxyClient()
+	require.NoError(t, err)
+	require.NotSame(t, first, second, "expected a new client for the new URL")
+	require.Equal(t, []string{"https://front-proxy-1.example.com:6443", "https://front-proxy-2.example.com:6443"}, hosts)
+}
+
+func TestFinalizeWorkspaceWithMalformedOwner(t *testing.T) {
+	for _, apiVersion := range []string{"tenancy.kcp.io/v1alpha1/extra", ""} {
+		t.Run(apiVersion, func(t *testing.T) {
+			now := metav1.Now()
+			logicalCluster := &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              corev1alpha1.LogicalClusterName,
+					Annotations:       map[string]string{logicalcluster.AnnotationKey: "root:org:ws"},
+					DeletionTimestamp: &now,
+					Finalizers:        []string{deletion.LogicalClusterDeletionFinalizer},
+				},
+				Spec: corev1alpha1.LogicalClusterSpec{
+					Owner: &corev1alpha1.LogicalClusterOwner{
+						APIVersion: apiVersion,
+						Resource:   "workspaces",
+						Name:       "ws",
+						Cluster:    "root:org",
+						UID:        "uid",
+					},
+				},
+			}
+			kcpClient := kcpfakeclient.NewSimpleClientset(logicalCluster.DeepCopy())
+
+			c := &Controller{
+				kubeClusterClient:         kcpfakekubeclient.NewSimpleClientset(),
+				kcpClusterClient:          kcpClient,
+				logicalClusterAdminConfig: &rest.Config{},
+				shardExternalURL: func() string {
+					return "https://front-proxy.example.com:6443"
+				},
+				newDynamicClient: func(config *rest.Config) (kcpdynamic.ClusterInterface, error) {
+					return nil, errors.New("the owner must not be looked up")
+				},
+			}
+
+			require.NoError(t, c.finalizeWorkspace(context.Background(), logicalCluster))
+
+			updated, err := kcpClient.Cluster(logicalcluster.NewPath("root:org:ws")).CoreV1alpha1().LogicalClusters().Get(context.Background(), corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Empty(t, updated.Finalizers, "expected the deletion finalizer to be removed")
+		})
+	}
+}
+
+func TestFinalizeWorkspaceWithLostOwner(t *testing.T) {
+	workspacesGVR := schema.GroupVersionResource{Group: "tenancy.kcp.io", Version: "v1alpha1", Resource: "workspaces"}
+	recreatedOwner := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "tenancy.kcp.io/v1alpha1",
+		"kind":       "Workspace",
+		"metadata": map[string]interface{}{
+			"name":        "ws",
+			"uid":         "new-uid",
+			"annotations": map[string]interface{}{logicalcluster.AnnotationKey: "root:org"},
+			"finalizers":  []interface{}{corev1alpha1.LogicalClusterFinalizer},
+		},
+	}}
+
+	tests := []struct {
+		name           string
+		owners         []runtime.Object
+		expectedReason string
+	}{
+		{name: "owner recreated with a new UID", owners: []runtime.Object{recreatedOwner}, expectedReason: corev1alpha1.LogicalClusterOwnerUIDMismatchReason},
+		{name: "owner not found", expectedReason: corev1alpha1.LogicalClusterOwnerNotFoundReason},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := metav1.Now()
+			logicalCluster := &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              corev1alpha1.LogicalClusterName,
+					Annotations:       map[string]string{logicalcluster.AnnotationKey: "root:org:ws"},
+					DeletionTimestamp: &now,
+					Finalizers:        []string{deletion.LogicalClusterDeletionFinalizer},
+				},
+				Spec: corev1alpha1.LogicalClusterSpec{
+					DirectlyDeletable: true,
+					Owner: &corev1alpha1.LogicalClusterOwner{
+						APIVersion: "tenancy.kcp.io/v1alpha1",
+						Resource:   "workspaces",
+						Name:       "ws",
+						Cluster:    "root:org",
+						UID:        "old-uid",
+					},
+				},
+			}
+			kcpClient := kcpfakeclient.NewSimpleClientset(logicalCluster.DeepCopy())
+			frontProxyClient := kcpfakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), tt.owners...)
+
+			c := &Controller{
+				kubeClusterClient:         kcpfakekubeclient.NewSimpleClientset(),
+				kcpClusterClient:          kcpClient,
+				logicalClusterAdminConfig: &rest.Config{},
+				shardExternalURL: func() string {
+					return "https://front-proxy.example.com:6443"
+				},
+				newDynamicClient: func(config *rest.Config) (kcpdynamic.ClusterInterface, error) {
+					return frontProxyClient, nil
+				},
+			}
+
+			require.NoError(t, c.finalizeWorkspace(context.Background(), logicalCluster))
+
+			updated, err := kcpClient.Cluster(logicalcluster.NewPath("root:org:ws")).CoreV1alpha1().LogicalClusters().Get(context.Background(), corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Empty(t, updated.Finalizers, "expected the deletion finalizer to be removed")
+			require.True(t, conditions.IsTrue(updated, corev1alpha1.LogicalClusterOwnerLost), "expected the owner to be reported as lost")
+			require.Equal(t, tt.expectedReason, conditions.GetReason(updated, corev1alpha1.LogicalClusterOwnerLost))
+
+			for _, action := range frontProxyClient.Actions() {
+				require.True(t, action.Matches("get", "workspaces"), "expected the lost owner to be left alone, got %s %s", action.GetVerb(), action.GetResource().Resource)
+			}
+			if len(tt.owners) > 0 {
+				owner, err := frontProxyClient.Cluster(logicalcluster.NewPath("root:org")).Resource(workspacesGVR).Get(context.Background(), "ws", metav1.GetOptions{})
+				require.NoError(t, err, "expected the recreated owner not to be deleted")
+				require.Equal(t, []string{corev1alpha1.LogicalClusterFinalizer}, owner.GetFinalizers())
+			}
+		})
+	}
+}
+
+func TestFrontProxyClientTLSOverrides(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMapList","items":[]}`))
+	}))
+	t.Cleanup(server.Close)
+	frontProxyCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	newController := func(overrides FrontProxyTLSOverrides) (*Controller, *rest.Config) {
+		var frontProxyConfig rest.Config
+		return &Controller{
+			// the admin config of the shards does not trust the front-proxy
+			logicalClusterAdminConfig: &rest.Config{TLSClientConfig: rest.TLSClientConfig{ServerName: "shard.example.com"}},
+			shardExternalURL: func() string {
+				return server.URL
+			},
+			frontProxyTLSOverrides: overrides,
+			newDynamicClient: func(config *rest.Config) (kcpdynamic.ClusterInterface, error) {
+				frontProxyConfig = *config
+				return kcpdynamic.NewForConfig(config)
+			},
+		}, &frontProxyConfig
+	}
+	list := func(c *Controller) error {
+		client, err := c.frontProxyClient()
+		require.NoError(t, err)
+		_, err = client.Cluster(logicalcluster.NewPath("root")).Resource(corev1.SchemeGroupVersion.WithResource("configmaps")).List(context.Background(), metav1.ListOptions{})
+		return err
+	}
+
+	t.Log("Without overrides the front-proxy certificate is not trusted")
+	c, _ := newController(FrontProxyTLSOverrides{})
+	require.Error(t, list(c))
+
+	t.Log("With overrides the front-proxy CA and server name are used")
+	c, frontProxyConfig := newController(FrontProxyTLSOverrides{CAData: frontProxyCA, ServerName: "example.com"})
+	require.NoError(t, list(c))
+	tlsConfig, err := rest.TLSConfigFor(frontProxyConfig)
+	require.NoError(t, err)
+	require.Equal(t, "example.com", tlsConfig.ServerName)
+	require.NotNil(t, tlsConfig.RootCAs)
+	require.Equal(t, "shard.example.com", c.logicalClusterAdminConfig.ServerName, "expected the admin config not to be modified")
+}
+
+func TestResourceAnnotations(t *testing.T) {
+	crdIndexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{kcpcache.ClusterIndexName: kcpcache.ClusterIndexFunc})
+	apiBindingIndexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{kcpcache.ClusterIndexName: kcpcache.ClusterIndexFunc})
+
+	require.NoError(t, crdIndexer.Add(&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{
+		Name:        "cowboys.wild.wild.west",
+		Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org", core.DeletionOrderAnnotationKey: "1"},
+	}}))
+	require.NoError(t, crdIndexer.Add(&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{
+		Name:        "schema-uid",
+		Annotations: map[string]string{logicalcluster.AnnotationKey: apis.SystemBoundCRDsClusterName.String(), core.DeletionOrderAnnotationKey: "2"},
+	}}))
+	require.NoError(t, apiBindingIndexer.Add(&apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sheriffs",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org"},
+		},
+		Status: apisv1alpha1.APIBindingStatus{
+			BoundResources: []apisv1alpha1.BoundAPIResource{
+				{Group: "wild.wild.west", Resource: "sheriffs", Schema: apisv1alpha1.BoundAPIResourceSchema{UID: "schema-uid"}},
+			},
+		},
+	}))
+
+	fn := resourceAnnotations(
+		kcpapiextensionsv1listers.NewCustomResourceDefinitionClusterLister(crdIndexer),
+		apisv1alpha1listers.NewAPIBindingClusterLister(apiBindingIndexer),
+	)
+
+	require.Equal(t, "1", fn("root:org", schema.GroupResource{Group: "wild.wild.west", Resource: "cowboys"})[core.DeletionOrderAnnotationKey], "expected annotations of the CRD")
+	require.Equal(t, "2", fn("root:org", schema.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"})[core.DeletionOrderAnnotationKey], "expected annotations of the bound CRD")
+	require.Nil(t, fn("root:other", schema.GroupResource{Group: "wild.wild.west", Resource: "cowboys"}), "expected no annotations in another logical cluster")
+	require.Nil(t, fn("root:org", schema.GroupResource{Resource: "configmaps"}), "expected no annotations for built-in resources")
+}
+
+func TestRetryAfter(t *testing.T) {
+	c := &Controller{maxRetryInterval: time.Minute}
+
+	require.Equal(t, 6*time.Second, c.retryAfter(&deletion.ResourcesRemainingError{Estimate: 10}))
+	require.Equal(t, time.Minute, c.retryAfter(&deletion.ResourcesRemainingError{Estimate: 3600}), "expected the estimate to be capped")
+}
+
+func TestRateLimiterIsCapped(t *testing.T) {
+	limiter := rateLimiter(time.Second)
+
+	var last time.Duration
+	for i := 0; i < 20; i++ {
+		last = limiter.When("key")
+		require.LessOrEqual(t, last, time.Second)
+	}
+	require.Equal(t, time.Second, last, "expected the backoff to reach the cap")
+
+	limiter.Forget("key")
+	require.Less(t, limiter.When("key"), time.Second)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalclusterdeletion
+
+import (
+	"context"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/reconciler/core/logicalclusterdeletion/deletion"
+)
+
+// DryRunReport is what the deletion of a logical cluster would remove.
+type DryRunReport struct {
+	// Content is the content of the logical cluster that would be deleted, in the order of deletion.
+	Content *deletion.DeletionPlan
+
+	// ClusterRoles and ClusterRoleBindings are deleted after all other content is gone.
+	ClusterRoles        []string
+	ClusterRoleBindings []string
+
+	// Owner is the owner of the logical cluster the logical cluster finalizer would be removed from.
+	// It is nil if the logical cluster has no owner or the owner reference is invalid.
+	Owner *corev1alpha1.LogicalClusterOwner
+	// DeleteOwner is true if the owner would be deleted too, because the logical cluster is directly deletable.
+	DeleteOwner bool
+}
+
+// DryRun reports what the deletion of the given logical cluster would remove, without deleting
+// or updating anything. The logical cluster does not have to be marked for deletion.
+func (c *Controller) DryRun(ctx context.Context, clusterName logicalcluster.Name) (*DryRunReport, error) {
+	logger := klog.FromContext(ctx)
+
+	logicalCluster, err := c.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := c.deleter.DryRun(ctx, logicalCluster.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+	report := &DryRunReport{Content: content}
+
+	clusterRoles, err := c.kubeClusterClient.Cluster(clusterName.Path()).RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cr := range clusterRoles.Items {
+		report.ClusterRoles = append(report.ClusterRoles, cr.Name)
+	}
+	clusterRoleBindings, err := c.kubeClusterClient.Cluster(clusterName.Path()).RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, crb := range clusterRoleBindings.Items {
+		report.ClusterRoleBindings = append(report.ClusterRoleBindings, crb.Name)
+	}
+
+	if owner := logicalCluster.Spec.Owner; owner != nil {
+		if _, err := ownerGVR(owner); err != nil {
+			// finalizeWorkspace skips invalid owners, so does the dry-run.
+			logger.V(2).Info("skipping invalid owner in dry-run", "err", err)
+		} else {
+			report.Owner = owner.DeepCopy()
+			report.DeleteOwner = logicalCluster.Spec.DirectlyDeletable
+		}
+	}
+
+	return report, nil
+}
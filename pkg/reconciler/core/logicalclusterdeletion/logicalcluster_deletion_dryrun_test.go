@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalclusterdeletion
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpfakekubeclient "github.com/kcp-dev/client-go/kubernetes/fake"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/reconciler/core/logicalclusterdeletion/deletion"
+)
+
+// planDeleter returns a fixed plan on dry-run and fails on deletion.
+type planDeleter struct {
+	plan *deletion.DeletionPlan
+}
+
+func (d *planDeleter) Delete(ctx context.Context, _ *corev1alpha1.LogicalCluster) error {
+	return errors.New("dry-run must not delete")
+}
+
+func (d *planDeleter) DryRun(ctx context.Context, _ *corev1alpha1.LogicalCluster) (*deletion.DeletionPlan, error) {
+	return d.plan, nil
+}
+
+func TestDryRun(t *testing.T) {
+	owner := &corev1alpha1.LogicalClusterOwner{
+		APIVersion: "tenancy.kcp.io/v1alpha1",
+		Resource:   "workspaces",
+		Name:       "ws",
+		Cluster:    "root:org",
+		UID:        "uid",
+	}
+	plan := &deletion.DeletionPlan{
+		Groups: [][]deletion.PlannedDeletion{
+			{{Resource: schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}, Name: "default"}},
+		},
+		Estimate: 5,
+	}
+
+	tests := []struct {
+		name              string
+		owner             *corev1alpha1.LogicalClusterOwner
+		directlyDeletable bool
+		want              *DryRunReport
+	}{
+		{
+			name: "no owner",
+			want: &DryRunReport{
+				Content:             plan,
+				ClusterRoles:        []string{"admin"},
+				ClusterRoleBindings: []string{"admin-binding"},
+			},
+		},
+		{
+			name:  "owner finalizer is removed",
+			owner: owner,
+			want: &DryRunReport{
+				Content:             plan,
+				ClusterRoles:        []string{"admin"},
+				ClusterRoleBindings: []string{"admin-binding"},
+				Owner:               owner,
+			},
+		},
+		{
+			name:              "owner is deleted if directly deletable",
+			owner:             owner,
+			directlyDeletable: true,
+			want: &DryRunReport{
+				Content:             plan,
+				ClusterRoles:        []string{"admin"},
+				ClusterRoleBindings: []string{"admin-binding"},
+				Owner:               owner,
+				DeleteOwner:         true,
+			},
+		},
+		{
+			name:  "invalid owner is skipped",
+			owner: &corev1alpha1.LogicalClusterOwner{APIVersion: "tenancy.kcp.io/v1alpha1/extra", Resource: "workspaces", Name: "ws"},
+			want: &DryRunReport{
+				Content:             plan,
+				ClusterRoles:        []string{"admin"},
+				ClusterRoleBindings: []string{"admin-binding"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logicalCluster := &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        corev1alpha1.LogicalClusterName,
+					Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org:ws"},
+				},
+				Spec: corev1alpha1.LogicalClusterSpec{
+					Owner:             tt.owner,
+					DirectlyDeletable: tt.directlyDeletable,
+				},
+			}
+			indexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{})
+			require.NoError(t, indexer.Add(logicalCluster))
+
+			annotations := map[string]string{logicalcluster.AnnotationKey: "root:org:ws"}
+			kubeClient := kcpfakekubeclient.NewSimpleClientset(
+				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "admin", Annotations: annotations}},
+				&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "admin-binding", Annotations: annotations}},
+			)
+
+			c := &Controller{
+				kubeClusterClient:    kubeClient,
+				logicalClusterLister: corev1alpha1listers.NewLogicalClusterClusterLister(indexer),
+				deleter:              &planDeleter{plan: plan},
+			}
+
+			got, err := c.DryRun(context.Background(), "root:org:ws")
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+
+			for _, action := range kubeClient.Actions() {
+				require.Equal(t, "list", action.GetVerb(), "dry-run must not change anything")
+			}
+			require.Nil(t, logicalCluster.Status.Conditions, "dry-run must not change the logical cluster")
+		})
+	}
+}
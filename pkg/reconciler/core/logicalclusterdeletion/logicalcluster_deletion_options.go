@@ -0,0 +1,47 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalclusterdeletion
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func DefaultOptions() *Options {
+	return &Options{
+		MaxRetryInterval: 5 * time.Minute,
+	}
+}
+
+func BindOptions(o *Options, fs *pflag.FlagSet) *Options {
+	fs.DurationVar(&o.MaxRetryInterval, "logicalcluster-deletion-max-retry-interval", o.MaxRetryInterval, "Maximum amount of time to wait before retrying the deletion of a logical cluster. "+
+		"It caps both the wait derived from the estimated remaining deletion time of content in the logical cluster, and the exponential backoff after failed deletion attempts.")
+	return o
+}
+
+type Options struct {
+	MaxRetryInterval time.Duration
+}
+
+func (o *Options) Validate() error {
+	if o.MaxRetryInterval <= 0 {
+		return fmt.Errorf("--logicalcluster-deletion-max-retry-interval must be >0 (%s)", o.MaxRetryInterval)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalclusterdeletion
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	configshard "github.com/kcp-dev/kcp/config/shard"
+	"github.com/kcp-dev/kcp/pkg/logging"
+)
+
+const (
+	// WorkersConfigMapName is the name of the config map in the default namespace of the
+	// system:shard logical cluster that configures the number of deletion workers at runtime.
+	WorkersConfigMapName = "logicalcluster-deletion"
+	// WorkersConfigMapKey is the key in the config map holding the number of workers.
+	WorkersConfigMapKey = "workers"
+)
+
+// SetWorkers changes the number of workers processing logical cluster deletions. It can be
+// called at any time, also before the controller is started. A value smaller than one resets
+// the number of workers to the one passed to Start.
+//
+// Removed workers finish the item they are processing before they stop.
+func (c *Controller) SetWorkers(n int) {
+	c.workersLock.Lock()
+	defer c.workersLock.Unlock()
+
+	c.workers = n
+	c.resizeWorkersLocked()
+}
+
+func (c *Controller) startWorkers(ctx context.Context, defaultWorkers int) {
+	c.workersLock.Lock()
+	defer c.workersLock.Unlock()
+
+	c.workersCtx = ctx
+	c.defaultWorkers = defaultWorkers
+	c.resizeWorkersLocked()
+}
+
+func (c *Controller) stopWorkers() {
+	c.workersLock.Lock()
+	defer c.workersLock.Unlock()
+
+	for _, stop := range c.workerStops {
+		close(stop)
+	}
+	c.workerStops = nil
+	c.workersCtx = nil
+}
+
+func (c *Controller) resizeWorkersLocked() {
+	if c.workersCtx == nil {
+		// not started yet, Start picks up the number of workers
+		return
+	}
+
+	n := c.workers
+	if n < 1 {
+		n = c.defaultWorkers
+	}
+	if n != len(c.workerStops) {
+		klog.FromContext(c.workersCtx).Info("resizing workers", "from", len(c.workerStops), "to", n)
+	}
+
+	for len(c.workerStops) < n {
+		stop := make(chan struct{})
+		c.workerStops = append(c.workerStops, stop)
+		ctx := c.workersCtx
+		go wait.Until(func() { c.startWorker(ctx, stop) }, time.Second, stop)
+	}
+	for len(c.workerStops) > n {
+		last := len(c.workerStops) - 1
+		close(c.workerStops[last])
+		c.workerStops = c.workerStops[:last]
+	}
+}
+
+func isWorkersConfigMap(obj interface{}) bool {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return false
+	}
+	cluster, namespace, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return false
+	}
+	return logicalcluster.Name(cluster.String()) == configshard.SystemShardCluster && namespace == "default" && name == WorkersConfigMapName
+}
+
+func (c *Controller) updateWorkersFromConfigMap(obj interface{}) {
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+
+	value, found := cm.Data[WorkersConfigMapKey]
+	if !found {
+		c.SetWorkers(0)
+		return
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		runtime.HandleError(fmt.Errorf("invalid %q in config map %s|default/%s: %q must be a positive integer", WorkersConfigMapKey, configshard.SystemShardCluster, WorkersConfigMapName, value))
+		return
+	}
+
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+	logger.V(2).Info("updating number of workers from config map", "workers", n)
+	c.SetWorkers(n)
+}
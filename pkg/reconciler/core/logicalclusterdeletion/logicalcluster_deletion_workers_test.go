@@ -0,0 +1,158 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalclusterdeletion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	configshard "github.com/kcp-dev/kcp/config/shard"
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/reconciler/core/logicalclusterdeletion/deletion"
+)
+
+// blockingDeleter blocks every deletion until released, and records the maximum number of
+// deletions in flight.
+type blockingDeleter struct {
+	lock        sync.Mutex
+	inFlight    int
+	maxInFlight int
+	release     chan struct{}
+}
+
+func (d *blockingDeleter) Delete(ctx context.Context, _ *corev1alpha1.LogicalCluster) error {
+	d.lock.Lock()
+	d.inFlight++
+	if d.inFlight > d.maxInFlight {
+		d.maxInFlight = d.inFlight
+	}
+	d.lock.Unlock()
+
+	defer func() {
+		d.lock.Lock()
+		d.inFlight--
+		d.lock.Unlock()
+	}()
+
+	select {
+	case <-d.release:
+	case <-ctx.Done():
+	}
+	return errors.New("content remaining")
+}
+
+func (d *blockingDeleter) DryRun(ctx context.Context, _ *corev1alpha1.LogicalCluster) (*deletion.DeletionPlan, error) {
+	return &deletion.DeletionPlan{}, nil
+}
+
+func (d *blockingDeleter) max() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.maxInFlight
+}
+
+func TestSetWorkers(t *testing.T) {
+	now := metav1.Now()
+	indexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{})
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	for i := 0; i < 5; i++ {
+		logicalCluster := &corev1alpha1.LogicalCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              corev1alpha1.LogicalClusterName,
+				Annotations:       map[string]string{logicalcluster.AnnotationKey: fmt.Sprintf("root:ws-%d", i)},
+				DeletionTimestamp: &now,
+			},
+		}
+		require.NoError(t, indexer.Add(logicalCluster))
+		key, err := kcpcache.MetaClusterNamespaceKeyFunc(logicalCluster)
+		require.NoError(t, err)
+		queue.Add(key)
+	}
+
+	deleter := &blockingDeleter{release: make(chan struct{})}
+	c := &Controller{
+		queue:                queue,
+		logicalClusterLister: corev1alpha1listers.NewLogicalClusterClusterLister(indexer),
+		deleter:              deleter,
+		commit: func(ctx context.Context, old, obj *Resource) error {
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	t.Cleanup(func() { close(deleter.release) })
+
+	go c.Start(ctx, 1)
+
+	t.Log("With a single worker only one deletion is in flight")
+	require.Eventually(t, func() bool { return deleter.max() == 1 }, wait.ForeverTestTimeout, 10*time.Millisecond)
+	require.Never(t, func() bool { return deleter.max() > 1 }, 200*time.Millisecond, 10*time.Millisecond)
+
+	t.Log("Raise the number of workers at runtime")
+	c.SetWorkers(3)
+	require.Eventually(t, func() bool { return deleter.max() == 3 }, wait.ForeverTestTimeout, 10*time.Millisecond)
+	require.Never(t, func() bool { return deleter.max() > 3 }, 200*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestUpdateWorkersFromConfigMap(t *testing.T) {
+	c := &Controller{}
+
+	newConfigMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        WorkersConfigMapName,
+				Namespace:   "default",
+				Annotations: map[string]string{logicalcluster.AnnotationKey: configshard.SystemShardCluster.String()},
+			},
+			Data: data,
+		}
+	}
+
+	cm := newConfigMap(map[string]string{WorkersConfigMapKey: "7"})
+	require.True(t, isWorkersConfigMap(cm))
+	c.updateWorkersFromConfigMap(cm)
+	require.Equal(t, 7, c.workers)
+
+	t.Log("Invalid values are ignored")
+	c.updateWorkersFromConfigMap(newConfigMap(map[string]string{WorkersConfigMapKey: "many"}))
+	require.Equal(t, 7, c.workers)
+
+	t.Log("Without the key the default is restored")
+	c.updateWorkersFromConfigMap(newConfigMap(nil))
+	require.Equal(t, 0, c.workers)
+
+	t.Log("Config maps in other logical clusters are not considered")
+	cm = newConfigMap(map[string]string{WorkersConfigMapKey: "7"})
+	cm.Annotations[logicalcluster.AnnotationKey] = "root"
+	require.False(t, isWorkersConfigMap(cm))
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalclusterdeletion
+
+import (
+	"sort"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
+)
+
+// PendingDeletion is a LogicalCluster with a deletion timestamp and the progress of its content deletion.
+type PendingDeletion struct {
+	LogicalCluster *corev1alpha1.LogicalCluster
+
+	// ContentDeleted is the WorkspaceContentDeleted condition of the logical cluster. It is nil
+	// if the deletion controller has not reported any progress yet.
+	ContentDeleted *conditionsv1alpha1.Condition
+}
+
+// ListPendingDeletion returns all LogicalClusters in the lister that have a deletion timestamp,
+// oldest deletion first, together with their WorkspaceContentDeleted condition.
+func ListPendingDeletion(lister corev1alpha1listers.LogicalClusterClusterLister) ([]PendingDeletion, error) {
+	logicalClusters, err := lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []PendingDeletion
+	for _, lc := range logicalClusters {
+		if lc.DeletionTimestamp.IsZero() {
+			continue
+		}
+		pending = append(pending, PendingDeletion{
+			LogicalCluster: lc,
+			ContentDeleted: conditions.Get(lc, tenancyv1alpha1.WorkspaceContentDeleted),
+		})
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		ti, tj := pending[i].LogicalCluster.DeletionTimestamp, pending[j].LogicalCluster.DeletionTimestamp
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return logicalcluster.From(pending[i].LogicalCluster) < logicalcluster.From(pending[j].LogicalCluster)
+	})
+
+	return pending, nil
+}
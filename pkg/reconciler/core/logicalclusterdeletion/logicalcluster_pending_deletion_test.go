@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalclusterdeletion
+
+import (
+	"testing"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
+)
+
+func TestListPendingDeletion(t *testing.T) {
+	now := time.Now()
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+	later := metav1.NewTime(now)
+
+	newLogicalCluster := func(cluster string, deletionTimestamp *metav1.Time, conds ...conditionsv1alpha1.Condition) *corev1alpha1.LogicalCluster {
+		return &corev1alpha1.LogicalCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              corev1alpha1.LogicalClusterName,
+				Annotations:       map[string]string{logicalcluster.AnnotationKey: cluster},
+				DeletionTimestamp: deletionTimestamp,
+			},
+			Status: corev1alpha1.LogicalClusterStatus{
+				Phase:      corev1alpha1.LogicalClusterPhaseReady,
+				Conditions: conds,
+			},
+		}
+	}
+
+	remaining := conditionsv1alpha1.Condition{
+		Type:     tenancyv1alpha1.WorkspaceContentDeleted,
+		Status:   corev1.ConditionFalse,
+		Severity: conditionsv1alpha1.ConditionSeverityInfo,
+		Reason:   "SomeResourcesRemain",
+		Message:  "Some resources are remaining: configmaps. has 3 resource instances",
+	}
+	deleted := conditionsv1alpha1.Condition{
+		Type:   tenancyv1alpha1.WorkspaceContentDeleted,
+		Status: corev1.ConditionTrue,
+	}
+
+	indexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(newLogicalCluster("root:active", nil)))
+	require.NoError(t, indexer.Add(newLogicalCluster("root:not-started", &later)))
+	require.NoError(t, indexer.Add(newLogicalCluster("root:remaining", &earlier, remaining)))
+	require.NoError(t, indexer.Add(newLogicalCluster("root:content-deleted", &later, deleted)))
+
+	pending, err := ListPendingDeletion(corev1alpha1listers.NewLogicalClusterClusterLister(indexer))
+	require.NoError(t, err)
+
+	var clusters []logicalcluster.Name
+	for _, p := range pending {
+		clusters = append(clusters, logicalcluster.From(p.LogicalCluster))
+	}
+	require.Equal(t, []logicalcluster.Name{"root:remaining", "root:content-deleted", "root:not-started"}, clusters)
+
+	require.NotNil(t, pending[0].ContentDeleted)
+	require.Equal(t, corev1.ConditionFalse, pending[0].ContentDeleted.Status)
+	require.Equal(t, "SomeResourcesRemain", pending[0].ContentDeleted.Reason)
+
+	require.NotNil(t, pending[1].ContentDeleted)
+	require.Equal(t, corev1.ConditionTrue, pending[1].ContentDeleted.Status)
+
+	require.Nil(t, pending[2].ContentDeleted, "expected no progress for a cluster the controller has not processed yet")
+}
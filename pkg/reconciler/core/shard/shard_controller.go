@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	jsonpatch "github.com/evanphx/json-patch"
@@ -32,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
@@ -51,7 +53,13 @@ const (
 func NewController(
 	rootKcpClient kcpclientset.ClusterInterface,
 	shardInformer corev1alpha1informers.ShardClusterInformer,
+	shardClientConfig *rest.Config,
 ) (*Controller, error) {
+	probeClient, err := newProbeClient(shardClientConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 
 	c := &Controller{
@@ -59,6 +67,7 @@ func NewController(
 		kcpClient:    rootKcpClient,
 		shardIndexer: shardInformer.Informer().GetIndexer(),
 		shardLister:  shardInformer.Lister(),
+		probeClient:  probeClient,
 	}
 
 	shardInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -69,8 +78,7 @@ func NewController(
 	return c, nil
 }
 
-// Controller watches WorkspaceShards and Secrets in order to make sure every Shard
-// has its URL exposed when a valid kubeconfig is connected to it.
+// Controller watches Shards and probes them periodically to maintain their ShardReachable condition.
 type Controller struct {
 	queue workqueue.RateLimitingInterface
 
@@ -78,6 +86,8 @@ type Controller struct {
 
 	shardIndexer cache.Indexer
 	shardLister  corev1alpha1listers.ShardClusterLister
+
+	probeClient *http.Client
 }
 
 func (c *Controller) enqueue(obj interface{}) {
@@ -165,6 +175,8 @@ func (c *Controller) process(ctx context.Context, key string) error {
 	if err := c.reconcile(ctx, obj); err != nil {
 		return err
 	}
+	// probe again later, as long as the shard exists
+	defer c.queue.AddAfter(key, probeInterval)
 
 	// If the object being reconciled changed as a result, update it.
 	if !equality.Semantic.DeepEqual(previous.Status, obj.Status) {
@@ -197,7 +209,3 @@ func (c *Controller) process(ctx context.Context, key string) error {
 	logger.V(6).Info("processed Shard")
 	return nil
 }
-
-func (c *Controller) reconcile(ctx context.Context, workspaceShard *corev1alpha1.Shard) error {
-	return nil
-}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+)
+
+const (
+	// probeInterval is how often every shard is probed.
+	probeInterval = 30 * time.Second
+	// probeTimeout is how long a probe waits for the shard to answer.
+	probeTimeout = 5 * time.Second
+)
+
+// newProbeClient returns the client probing the shards. It verifies the serving certificates of the
+// shards like every other client of the root shard talking to shards, i.e. using the CA and TLS
+// settings of the given shard client config. No credentials are sent, i.e. /healthz is requested
+// anonymously.
+func newProbeClient(shardClientConfig *rest.Config) (*http.Client, error) {
+	config := rest.AnonymousClientConfig(shardClientConfig)
+	config.Timeout = probeTimeout
+	return rest.HTTPClientFor(config)
+}
+
+// probeHealthz requests the /healthz endpoint of the shard with the given base URL. It returns an
+// empty reason if the shard answered with 200 OK, and the reason and message for the ShardReachable
+// condition otherwise.
+func probeHealthz(ctx context.Context, client *http.Client, baseURL string) (reason, message string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+"/healthz", nil)
+	if err != nil {
+		return corev1alpha1.ShardNotReachableReason, fmt.Sprintf("invalid base URL %q: %v", baseURL, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return corev1alpha1.ShardNotReachableReason, err.Error()
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return corev1alpha1.ShardNotHealthyReason, fmt.Sprintf("/healthz returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return "", ""
+}
+
+// reconcile probes the shard and updates its ShardReachable condition.
+func (c *Controller) reconcile(ctx context.Context, shard *corev1alpha1.Shard) error {
+	reason, message := probeHealthz(ctx, c.probeClient, shard.Spec.BaseURL)
+	if reason != "" {
+		conditions.MarkFalse(shard, corev1alpha1.ShardReachable, reason, conditionsv1alpha1.ConditionSeverityError, message)
+		return nil
+	}
+	conditions.MarkTrue(shard, corev1alpha1.ShardReachable)
+	return nil
+}
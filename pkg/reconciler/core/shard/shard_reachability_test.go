@@ -0,0 +1,119 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestReconcile(t *testing.T) {
+	tests := map[string]struct {
+		baseURL      string
+		statusCode   int
+		body         string
+		err          error
+		wantStatus   corev1.ConditionStatus
+		wantReason   string
+		wantMessage  string
+		wantRequests int
+	}{
+		"healthy": {
+			baseURL:      "https://shard-1.example.com:6443",
+			statusCode:   http.StatusOK,
+			body:         "ok",
+			wantStatus:   corev1.ConditionTrue,
+			wantRequests: 1,
+		},
+		"healthy with trailing slash": {
+			baseURL:      "https://shard-1.example.com:6443/",
+			statusCode:   http.StatusOK,
+			body:         "ok",
+			wantStatus:   corev1.ConditionTrue,
+			wantRequests: 1,
+		},
+		"not healthy": {
+			baseURL:      "https://shard-1.example.com:6443",
+			statusCode:   http.StatusInternalServerError,
+			body:         "[-]etcd failed: reason withheld\nhealthz check failed\n",
+			wantStatus:   corev1.ConditionFalse,
+			wantReason:   corev1alpha1.ShardNotHealthyReason,
+			wantMessage:  "/healthz returned 500: [-]etcd failed: reason withheld\nhealthz check failed",
+			wantRequests: 1,
+		},
+		"not reachable": {
+			baseURL:      "https://shard-1.example.com:6443",
+			err:          errors.New("connection refused"),
+			wantStatus:   corev1.ConditionFalse,
+			wantReason:   corev1alpha1.ShardNotReachableReason,
+			wantMessage:  `Get "https://shard-1.example.com:6443/healthz": connection refused`,
+			wantRequests: 1,
+		},
+		"invalid base URL": {
+			baseURL:     "https://shard-1.example.com:6443\n",
+			wantStatus:  corev1.ConditionFalse,
+			wantReason:  corev1alpha1.ShardNotReachableReason,
+			wantMessage: "invalid base URL",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			c := &Controller{
+				probeClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					requests++
+					require.Equal(t, "https://shard-1.example.com:6443/healthz", req.URL.String())
+					require.Empty(t, req.Header.Get("Authorization"), "expected anonymous requests")
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &http.Response{
+						StatusCode: tc.statusCode,
+						Body:       io.NopCloser(strings.NewReader(tc.body)),
+					}, nil
+				})},
+			}
+
+			shard := &corev1alpha1.Shard{Spec: corev1alpha1.ShardSpec{BaseURL: tc.baseURL}}
+			require.NoError(t, c.reconcile(context.Background(), shard))
+			require.Equal(t, tc.wantRequests, requests)
+
+			condition := conditions.Get(shard, corev1alpha1.ShardReachable)
+			require.NotNil(t, condition)
+			require.Equal(t, tc.wantStatus, condition.Status)
+			require.Equal(t, tc.wantReason, condition.Reason)
+			require.True(t, strings.HasPrefix(condition.Message, tc.wantMessage), "unexpected message %q", condition.Message)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events provides an event recorder for controllers that reconcile objects across
+// logical clusters. Events are created in the logical cluster of the object they are about.
+package events
+
+import (
+	"context"
+	"fmt"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// NewRecorder returns an event recorder for the given component, passing events to the given broadcaster.
+// The logical cluster of the object an event is about is recorded with the event, such that a sink
+// returned by NewSink creates it in that logical cluster.
+func NewRecorder(broadcaster record.EventBroadcaster, scheme *runtime.Scheme, component string) record.EventRecorder {
+	return &recorder{
+		delegate: broadcaster.NewRecorder(scheme, corev1.EventSource{Component: component}),
+	}
+}
+
+type recorder struct {
+	delegate record.EventRecorder
+}
+
+func (r *recorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.AnnotatedEventf(object, nil, eventtype, reason, "%s", message)
+}
+
+func (r *recorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.AnnotatedEventf(object, nil, eventtype, reason, messageFmt, args...)
+}
+
+func (r *recorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	objMeta, err := meta.Accessor(object)
+	if err != nil {
+		// let the delegate fail on the reference
+		r.delegate.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+		return
+	}
+
+	withCluster := make(map[string]string, len(annotations)+1)
+	for k, v := range annotations {
+		withCluster[k] = v
+	}
+	withCluster[logicalcluster.AnnotationKey] = logicalcluster.From(objMeta).String()
+	r.delegate.AnnotatedEventf(object, withCluster, eventtype, reason, messageFmt, args...)
+}
+
+// NewSink returns an event sink creating events in the logical cluster recorded by a recorder returned by NewRecorder.
+func NewSink(kubeClusterClient kcpkubernetesclientset.ClusterInterface) record.EventSink {
+	return &sink{kubeClusterClient: kubeClusterClient}
+}
+
+type sink struct {
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface
+}
+
+func (s *sink) Create(event *corev1.Event) (*corev1.Event, error) {
+	events, event, err := s.eventsFor(event)
+	if err != nil {
+		return nil, err
+	}
+	return events.Create(context.TODO(), event, metav1.CreateOptions{})
+}
+
+func (s *sink) Update(event *corev1.Event) (*corev1.Event, error) {
+	events, event, err := s.eventsFor(event)
+	if err != nil {
+		return nil, err
+	}
+	return events.Update(context.TODO(), event, metav1.UpdateOptions{})
+}
+
+func (s *sink) Patch(event *corev1.Event, data []byte) (*corev1.Event, error) {
+	events, event, err := s.eventsFor(event)
+	if err != nil {
+		return nil, err
+	}
+	return events.Patch(context.TODO(), event.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+}
+
+// eventsFor returns the events client for the logical cluster of the given event, and a copy
+// of the event without the logical cluster annotation.
+func (s *sink) eventsFor(event *corev1.Event) (typedcorev1.EventInterface, *corev1.Event, error) {
+	clusterName := logicalcluster.From(event)
+	if clusterName.Empty() {
+		return nil, nil, fmt.Errorf("event %s/%s has no %s annotation", event.Namespace, event.Name, logicalcluster.AnnotationKey)
+	}
+
+	event = event.DeepCopy()
+	delete(event.Annotations, logicalcluster.AnnotationKey)
+	if len(event.Annotations) == 0 {
+		event.Annotations = nil
+	}
+
+	return s.kubeClusterClient.Cluster(clusterName.Path()).CoreV1().Events(event.Namespace), event, nil
+}
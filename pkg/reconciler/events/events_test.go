@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	kcpkubernetesfake "github.com/kcp-dev/client-go/kubernetes/fake"
+	kcptesting "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/testing"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	kcpscheme "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
+)
+
+func TestRecorderCreatesEventsInLogicalCluster(t *testing.T) {
+	kubeClusterClient := kcpkubernetesfake.NewSimpleClientset()
+
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(NewSink(kubeClusterClient))
+	defer broadcaster.Shutdown()
+	recorder := NewRecorder(broadcaster, kcpscheme.Scheme, "test")
+
+	binding := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cowboys",
+			UID:         "uid",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org:ws"},
+		},
+	}
+	recorder.AnnotatedEventf(binding, map[string]string{"foo": "bar"}, corev1.EventTypeNormal, "Bound", "bound to %s", "export")
+
+	var create kcptesting.CreateAction
+	require.Eventually(t, func() bool {
+		for _, action := range kubeClusterClient.Actions() {
+			if a, ok := action.(kcptesting.CreateAction); ok && a.GetResource().Resource == "events" {
+				create = a
+				return true
+			}
+		}
+		return false
+	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+
+	require.Equal(t, logicalcluster.NewPath("root:org:ws"), create.GetCluster())
+	require.Equal(t, metav1.NamespaceDefault, create.GetNamespace())
+	event := create.GetObject().(*corev1.Event)
+	require.Equal(t, "cowboys", event.InvolvedObject.Name)
+	require.Equal(t, "APIBinding", event.InvolvedObject.Kind)
+	require.Equal(t, "Bound", event.Reason)
+	require.Equal(t, "bound to export", event.Message)
+	require.Equal(t, map[string]string{"foo": "bar"}, event.Annotations, "logical cluster annotation should be dropped")
+}
+
+func TestSinkRequiresLogicalCluster(t *testing.T) {
+	_, err := NewSink(kcpkubernetesfake.NewSimpleClientset()).Create(&corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "event", Namespace: metav1.NamespaceDefault},
+	})
+	require.EqualError(t, err, "event default/event has no kcp.io/cluster annotation")
+}
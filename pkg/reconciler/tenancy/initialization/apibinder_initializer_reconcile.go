@@ -115,7 +115,7 @@ func (b *APIBinder) reconcile(ctx context.Context, logicalCluster *corev1alpha1.
 	}
 
 	requiredExportRefs := map[tenancyv1alpha1.APIExportReference]struct{}{}
-	someExportsMissing := false
+	var missingExports []string
 
 	for _, wt := range wts {
 		logger := logging.WithObject(logger, wt)
@@ -128,10 +128,8 @@ func (b *APIBinder) reconcile(ctx context.Context, logicalCluster *corev1alpha1.
 			}
 			apiExport, err := b.getAPIExport(logicalcluster.NewPath(exportRef.Path), exportRef.Export)
 			if err != nil {
-				if !someExportsMissing {
-					errors = append(errors, fmt.Errorf("unable to complete initialization: unable to find at least 1 APIExport"))
-				}
-				someExportsMissing = true
+				logger.V(2).Info("APIExport of a default APIBinding not found", "apiExport.path", exportRef.Path, "apiExport.name", exportRef.Export, "err", err)
+				missingExports = append(missingExports, exportRef.Path+"|"+exportRef.Export)
 				continue
 			}
 
@@ -149,30 +147,8 @@ func (b *APIBinder) reconcile(ctx context.Context, logicalCluster *corev1alpha1.
 				continue
 			}
 
-			apiBinding := &apisv1alpha1.APIBinding{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: apiBindingName,
-				},
-				Spec: apisv1alpha1.APIBindingSpec{
-					Reference: apisv1alpha1.BindingReference{
-						Export: &apisv1alpha1.ExportBindingReference{
-							Path: exportRef.Path,
-							Name: apiExport.Name,
-						},
-					},
-				},
-			}
-
-			for i := range apiExport.Spec.PermissionClaims {
-				exportClaim := apiExport.Spec.PermissionClaims[i]
-
-				acceptedClaim := apisv1alpha1.AcceptablePermissionClaim{
-					PermissionClaim: exportClaim,
-					State:           apisv1alpha1.ClaimAccepted,
-				}
-
-				apiBinding.Spec.PermissionClaims = append(apiBinding.Spec.PermissionClaims, acceptedClaim)
-			}
+			apiBinding := NewAPIBindingAcceptingAllClaims(exportRef.Path, apiExport.Name, apiExport)
+			apiBinding.Name = apiBindingName
 
 			logger = logging.WithObject(logger, apiBinding)
 
@@ -191,6 +167,29 @@ func (b *APIBinder) reconcile(ctx context.Context, logicalCluster *corev1alpha1.
 		}
 	}
 
+	if len(missingExports) > 0 {
+		sort.Strings(missingExports)
+
+		message := fmt.Sprintf("APIExport(s) referenced by the default APIBindings of the WorkspaceType(s) not found: %s", strings.Join(missingExports, ", "))
+		// Retry, as it's possible they'll show up (cache server slow to catch up, arrive via replication)
+		errs := []error{fmt.Errorf("unable to complete initialization: APIExport(s) not found: %s", strings.Join(missingExports, ", "))}
+		if len(errors) > 0 {
+			message = fmt.Sprintf("%s; encountered errors: %v", message, utilerrors.NewAggregate(errors))
+			errs = append(errs, errors...)
+		}
+
+		conditions.MarkFalse(
+			logicalCluster,
+			tenancyv1alpha1.WorkspaceAPIBindingsInitialized,
+			tenancyv1alpha1.WorkspaceInitializedAPIExportsNotFound,
+			conditionsv1alpha1.ConditionSeverityError,
+			"%s",
+			message,
+		)
+
+		return utilerrors.NewAggregate(errs)
+	}
+
 	if len(errors) > 0 {
 		logger.Error(utilerrors.NewAggregate(errors), "error initializing APIBindings")
 
@@ -203,12 +202,6 @@ func (b *APIBinder) reconcile(ctx context.Context, logicalCluster *corev1alpha1.
 			utilerrors.NewAggregate(errors),
 		)
 
-		if someExportsMissing {
-			// Retry if any APIExports are missing, as it's possible they'll show up (cache server slow to catch up,
-			// arrive via replication)
-			return utilerrors.NewAggregate(errors)
-		}
-
 		return nil
 	}
 
@@ -249,6 +242,37 @@ func (b *APIBinder) reconcile(ctx context.Context, logicalCluster *corev1alpha1.
 	return nil
 }
 
+// NewAPIBindingAcceptingAllClaims returns an APIBinding named like the APIExport, binding to it by path,
+// that accepts all permission claims of the given APIExport. The claims are copied as they are, including
+// their identity hashes, which are empty for claims of built-in resources like configmaps.
+func NewAPIBindingAcceptingAllClaims(exportPath, exportName string, export *apisv1alpha1.APIExport) *apisv1alpha1.APIBinding {
+	binding := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: exportName,
+		},
+		Spec: apisv1alpha1.APIBindingSpec{
+			Reference: apisv1alpha1.BindingReference{
+				Export: &apisv1alpha1.ExportBindingReference{
+					Path: exportPath,
+					Name: exportName,
+				},
+			},
+		},
+	}
+
+	if export == nil {
+		return binding
+	}
+	for _, claim := range export.Spec.PermissionClaims {
+		binding.Spec.PermissionClaims = append(binding.Spec.PermissionClaims, apisv1alpha1.AcceptablePermissionClaim{
+			PermissionClaim: *claim.DeepCopy(),
+			State:           apisv1alpha1.ClaimAccepted,
+		})
+	}
+
+	return binding
+}
+
 // maxExportNamePrefixLength is the maximum allowed length for the export name portion of the generated API binding
 // name. Subtrace 1 for the dash ("-") that separates the export name prefix from the hash suffix, and 5 for the
 // hash length.
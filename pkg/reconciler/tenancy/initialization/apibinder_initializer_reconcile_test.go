@@ -17,12 +17,22 @@ limitations under the License.
 package initialization
 
 import (
+	"context"
+	"errors"
 	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
 )
 
 func TestGenerateAPIBindingName(t *testing.T) {
@@ -64,6 +74,44 @@ func TestGenerateAPIBindingName(t *testing.T) {
 	}
 }
 
+func TestNewAPIBindingAcceptingAllClaims(t *testing.T) {
+	export := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "today-cowboys"},
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true},
+				{GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}, IdentityHash: "abc", Verbs: []string{"get"}, All: true},
+			},
+		},
+	}
+
+	got := NewAPIBindingAcceptingAllClaims("root:org:provider", "today-cowboys", export)
+	require.Equal(t, &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "today-cowboys"},
+		Spec: apisv1alpha1.APIBindingSpec{
+			Reference: apisv1alpha1.BindingReference{
+				Export: &apisv1alpha1.ExportBindingReference{Path: "root:org:provider", Name: "today-cowboys"},
+			},
+			PermissionClaims: []apisv1alpha1.AcceptablePermissionClaim{
+				{
+					PermissionClaim: apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true},
+					State:           apisv1alpha1.ClaimAccepted,
+				},
+				{
+					PermissionClaim: apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}, IdentityHash: "abc", Verbs: []string{"get"}, All: true},
+					State:           apisv1alpha1.ClaimAccepted,
+				},
+			},
+		},
+	}, got)
+
+	got.Spec.PermissionClaims[1].Verbs[0] = "delete"
+	require.Equal(t, "get", export.Spec.PermissionClaims[1].Verbs[0], "expected the claims of the APIExport not to be shared with the APIBinding")
+
+	got = NewAPIBindingAcceptingAllClaims("root:org:provider", "today-cowboys", nil)
+	require.Empty(t, got.Spec.PermissionClaims, "expected no claims without an APIExport")
+}
+
 func TestGenerateAPIBindingNameWithMultipleSimilarLongNames(t *testing.T) {
 	t.Parallel()
 
@@ -82,3 +130,82 @@ func TestGenerateAPIBindingNameWithMultipleSimilarLongNames(t *testing.T) {
 	require.Len(t, generated2, 253)
 	require.NotEqual(t, generated1, generated2, "expected different generated names")
 }
+
+type fakeTransitiveTypeResolver struct{}
+
+func (fakeTransitiveTypeResolver) Resolve(t *tenancyv1alpha1.WorkspaceType) ([]*tenancyv1alpha1.WorkspaceType, error) {
+	return []*tenancyv1alpha1.WorkspaceType{t}, nil
+}
+
+func TestReconcileMissingAPIExport(t *testing.T) {
+	wt := &tenancyv1alpha1.WorkspaceType{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org"},
+			Name:        "cowboys",
+		},
+		Spec: tenancyv1alpha1.WorkspaceTypeSpec{
+			DefaultAPIBindings: []tenancyv1alpha1.APIExportReference{
+				{Path: "root:org:provider", Export: "sheriffs"},
+				{Export: "cowboys"},
+			},
+		},
+	}
+	logicalCluster := &corev1alpha1.LogicalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey:                    "consumer",
+				tenancyv1alpha1.LogicalClusterTypeAnnotationKey: "root:org:cowboys",
+			},
+			Name: corev1alpha1.LogicalClusterName,
+		},
+		Status: corev1alpha1.LogicalClusterStatus{
+			Initializers: []corev1alpha1.LogicalClusterInitializer{tenancyv1alpha1.WorkspaceAPIBindingsInitializer},
+		},
+	}
+
+	var created []string
+	b := &APIBinder{
+		getWorkspaceType: func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+			return wt, nil
+		},
+		listAPIBindings: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error) {
+			return nil, nil
+		},
+		getAPIBinding: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIBinding, error) {
+			return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apibindings"), name)
+		},
+		createAPIBinding: func(ctx context.Context, clusterName logicalcluster.Path, binding *apisv1alpha1.APIBinding) (*apisv1alpha1.APIBinding, error) {
+			created = append(created, binding.Spec.Reference.Export.Path+"|"+binding.Spec.Reference.Export.Name)
+			return binding, nil
+		},
+		getAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
+			if path.String() == "root:org" && name == "cowboys" {
+				return &apisv1alpha1.APIExport{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+			}
+			return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiexports"), name)
+		},
+		transitiveTypeResolver: fakeTransitiveTypeResolver{},
+	}
+
+	err := b.reconcile(context.Background(), logicalCluster)
+	require.EqualError(t, err, "unable to complete initialization: APIExport(s) not found: root:org:provider|sheriffs")
+	require.Equal(t, []string{"root:org|cowboys"}, created, "expected the APIBindings of existing APIExports to be created")
+	require.True(t, conditions.IsFalse(logicalCluster, tenancyv1alpha1.WorkspaceAPIBindingsInitialized))
+	require.Equal(t, tenancyv1alpha1.WorkspaceInitializedAPIExportsNotFound, conditions.GetReason(logicalCluster, tenancyv1alpha1.WorkspaceAPIBindingsInitialized))
+	require.Equal(t, "APIExport(s) referenced by the default APIBindings of the WorkspaceType(s) not found: root:org:provider|sheriffs",
+		conditions.GetMessage(logicalCluster, tenancyv1alpha1.WorkspaceAPIBindingsInitialized))
+	require.Equal(t, []corev1alpha1.LogicalClusterInitializer{tenancyv1alpha1.WorkspaceAPIBindingsInitializer}, logicalCluster.Status.Initializers,
+		"expected the initializer to stay pending")
+
+	t.Log("Errors creating the APIBindings of existing APIExports are reported too")
+	b.createAPIBinding = func(ctx context.Context, clusterName logicalcluster.Path, binding *apisv1alpha1.APIBinding) (*apisv1alpha1.APIBinding, error) {
+		return nil, apierrors.NewForbidden(apisv1alpha1.Resource("apibindings"), binding.Name, errors.New("denied"))
+	}
+	err = b.reconcile(context.Background(), logicalCluster)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to complete initialization: APIExport(s) not found: root:org:provider|sheriffs")
+	require.Contains(t, err.Error(), "denied")
+	require.Equal(t, tenancyv1alpha1.WorkspaceInitializedAPIExportsNotFound, conditions.GetReason(logicalCluster, tenancyv1alpha1.WorkspaceAPIBindingsInitialized))
+	require.Contains(t, conditions.GetMessage(logicalCluster, tenancyv1alpha1.WorkspaceAPIBindingsInitialized), "not found: root:org:provider|sheriffs; encountered errors:")
+	require.Contains(t, conditions.GetMessage(logicalCluster, tenancyv1alpha1.WorkspaceAPIBindingsInitialized), "denied")
+}
@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	topologyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1"
 )
 
 func TestPartition(t *testing.T) {
@@ -116,21 +117,88 @@ func TestPartition(t *testing.T) {
 		},
 	}
 
-	matchLabelsMap := partition(shards, []string{}, nil)
+	matchLabelsMap := partition(shards, []string{}, nil, nil)
 	require.Equal(t, 0, len(matchLabelsMap), "No label selector expected when no dimension is provided, got: %v", matchLabelsMap)
 
-	matchLabelsMap = partition(shards, []string{"doesnotexist"}, nil)
+	matchLabelsMap = partition(shards, []string{"doesnotexist"}, nil, nil)
 	require.Equal(t, 0, len(matchLabelsMap), "No label selector expected when no shard with the dimension, got: %v", matchLabelsMap)
 
-	matchLabelsMap = partition(shards, []string{"region"}, nil)
+	matchLabelsMap = partition(shards, []string{"region"}, nil, nil)
 	require.Equal(t, 2, len(matchLabelsMap), "2 label selectors for region: Europe and Asia expected, got: %v", matchLabelsMap)
 
-	matchLabelsMap = partition(shards, []string{"region", "cloud"}, nil)
+	matchLabelsMap = partition(shards, []string{"region", "cloud"}, nil, nil)
 	require.Equal(t, 3, len(matchLabelsMap), "3 label selectors for: Asia/Azure, Europe/AWS and Europe/Azure expected, got: %v", matchLabelsMap)
 
-	matchLabelsMap = partition(shards, []string{"region", "cloud"}, map[string]string{"environment": "prod"})
+	matchLabelsMap = partition(shards, []string{"region", "cloud"}, map[string]string{"environment": "prod"}, nil)
 	require.Equal(t, 3, len(matchLabelsMap), "3 label selectors for: Asia/Azure, Europe/AWS, Europe/Azure expected, got: %v", matchLabelsMap)
 	for _, v := range matchLabelsMap {
-		require.Equal(t, "prod", v["environment"], "Expected that all partitions have a label selector for environment = prod")
+		require.Equal(t, "prod", v.values["environment"], "Expected that all partitions have a label selector for environment = prod")
 	}
+
+	t.Log("Mixed-case label values are distinct partitions without normalization")
+	mixedCase := append(shards,
+		&corev1alpha1.Shard{ObjectMeta: metav1.ObjectMeta{Name: "shard8", Labels: map[string]string{"region": "europe", "cloud": "Azure"}}},
+		&corev1alpha1.Shard{ObjectMeta: metav1.ObjectMeta{Name: "shard9", Labels: map[string]string{"region": "-EUROPE.", "cloud": "azure"}}},
+		&corev1alpha1.Shard{ObjectMeta: metav1.ObjectMeta{Name: "shard10", Labels: map[string]string{"region": "--", "cloud": "Azure"}}},
+	)
+	matchLabelsMap = partition(mixedCase, []string{"region"}, nil, nil)
+	require.Len(t, matchLabelsMap, 5, "expected partitions for Europe, europe, -EUROPE., -- and Asia, got: %v", matchLabelsMap)
+
+	t.Log("Case-folded and trimmed label values are grouped into one partition selecting the original values")
+	normalize := normalizer(topologyv1alpha1.PartitionSetSpec{
+		Normalization: &topologyv1alpha1.PartitionSetNormalization{Dimensions: []string{"region"}, CaseFold: true, Trim: true},
+	})
+	matchLabelsMap = partition(mixedCase, []string{"region"}, nil, normalize)
+	require.Len(t, matchLabelsMap, 2, "expected partitions for europe and asia, got: %v", matchLabelsMap)
+	europe := matchLabelsMap["+region=europe"]
+	require.NotNil(t, europe, "expected a partition for europe, got: %v", matchLabelsMap)
+	require.Equal(t, map[string]string{"region": "europe"}, europe.values)
+	require.Equal(t, &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "region", Operator: metav1.LabelSelectorOpIn, Values: []string{"-EUROPE.", "Europe", "europe"}},
+		},
+	}, europe.selector(nil))
+
+	t.Log("Only the specified dimensions are normalized")
+	matchLabelsMap = partition(mixedCase, []string{"region", "cloud"}, nil, normalize)
+	require.Len(t, matchLabelsMap, 4, "expected partitions for europe/Azure, europe/azure, europe/AWS and asia/Azure, got: %v", matchLabelsMap)
+	require.Equal(t, &metav1.LabelSelector{
+		MatchLabels: map[string]string{"cloud": "Azure"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "environment", Operator: metav1.LabelSelectorOpIn, Values: []string{"prod"}},
+			{Key: "region", Operator: metav1.LabelSelectorOpIn, Values: []string{"Europe", "europe"}},
+		},
+	}, matchLabelsMap["+cloud=Azure+region=europe"].selector([]metav1.LabelSelectorRequirement{
+		{Key: "environment", Operator: metav1.LabelSelectorOpIn, Values: []string{"prod"}},
+	}))
+
+	t.Log("Normalization is deterministic")
+	require.Equal(t, matchLabelsMap, partition(mixedCase, []string{"region", "cloud"}, nil, normalize))
+	for key, p := range matchLabelsMap {
+		require.Equal(t, selectorKey(p.selector(nil)), selectorKey(partition(mixedCase, []string{"region", "cloud"}, nil, normalize)[key].selector(nil)))
+	}
+}
+
+func TestExpandDimensions(t *testing.T) {
+	shards := []*corev1alpha1.Shard{
+		{ObjectMeta: metav1.ObjectMeta{Name: "eu-1", Labels: map[string]string{"region": "Europe", "az": "EU-1", "cloud": "AWS"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "eu-2", Labels: map[string]string{"region": "Europe", "az": "EU-2", "cloud": "Azure"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "eu-2-bis", Labels: map[string]string{"region": "Europe", "az": "EU-2", "cloud": "Azure"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "asia-1", Labels: map[string]string{"region": "Asia", "az": "cn-west-1", "cloud": "Azure"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "no-az", Labels: map[string]string{"region": "Asia"}}},
+	}
+
+	zone := topologyv1alpha1.PartitionSetSpec{Topology: topologyv1alpha1.PartitionSetTopologyZone}
+	require.Equal(t, []string{"region", "az"}, expandDimensions(zone))
+	require.Equal(t, partition(shards, []string{"region", "az"}, nil, nil), partition(shards, expandDimensions(zone), nil, nil))
+	require.Len(t, partition(shards, expandDimensions(zone), nil, nil), 3, "expected one partition per availability zone")
+
+	t.Log("Explicit dimensions are added to the ones of the topology")
+	zoneAndCloud := topologyv1alpha1.PartitionSetSpec{Topology: topologyv1alpha1.PartitionSetTopologyZone, Dimensions: []string{"cloud", "az"}}
+	require.Equal(t, []string{"region", "az", "cloud"}, expandDimensions(zoneAndCloud))
+	require.Equal(t, partition(shards, []string{"region", "az", "cloud"}, nil, nil), partition(shards, expandDimensions(zoneAndCloud), nil, nil))
+
+	t.Log("Without topology the explicit dimensions are used")
+	require.Equal(t, []string{"cloud"}, expandDimensions(topologyv1alpha1.PartitionSetSpec{Dimensions: []string{"cloud"}}))
+	require.Empty(t, expandDimensions(topologyv1alpha1.PartitionSetSpec{}))
 }
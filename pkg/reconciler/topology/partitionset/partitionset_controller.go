@@ -36,6 +36,7 @@ import (
 	"k8s.io/klog/v2"
 
 	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
 	topologyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1"
 	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
 	topologyv1alpha1client "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/topology/v1alpha1"
@@ -321,6 +322,7 @@ func (c *controller) process(ctx context.Context, key string) error {
 }
 
 // filterShardEvent returns true if the event passes the filter and needs to be processed false otherwise.
+// Changes of the labels and of the reachability of a shard are processed.
 func filterShardEvent(oldObj, newObj interface{}) bool {
 	oldShard, ok := oldObj.(*corev1alpha1.Shard)
 	if !ok {
@@ -330,5 +332,8 @@ func filterShardEvent(oldObj, newObj interface{}) bool {
 	if !ok {
 		return false
 	}
-	return !reflect.DeepEqual(oldShard.Labels, newShard.Labels)
+	if !reflect.DeepEqual(oldShard.Labels, newShard.Labels) {
+		return true
+	}
+	return conditions.IsFalse(oldShard, corev1alpha1.ShardReachable) != conditions.IsFalse(newShard, corev1alpha1.ShardReachable)
 }
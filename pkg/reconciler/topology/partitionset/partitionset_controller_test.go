@@ -43,6 +43,8 @@ func TestReconcile(t *testing.T) {
 		createPartitionError     bool
 		deletePartitionError     bool
 		withMatchLabelOverlap    bool
+		withUnreachableShard     bool
+		excludeUnreachableShards bool
 
 		wantError              bool
 		wantPartitionsReady    bool
@@ -91,6 +93,19 @@ func TestReconcile(t *testing.T) {
 			wantPartitionCount:    2,
 			wantCountCreated:      2,
 		},
+		"Unreachable shards kept when not excluded": {
+			withUnreachableShard: true,
+			wantPartitionsReady:  true,
+			wantPartitionCount:   3,
+			wantCountCreated:     3,
+		},
+		"Unreachable shards excluded": {
+			withUnreachableShard:     true,
+			excludeUnreachableShards: true,
+			wantPartitionsReady:      true,
+			wantPartitionCount:       2,
+			wantCountCreated:         2,
+		},
 	}
 
 	for name, tc := range tests {
@@ -195,6 +210,9 @@ func TestReconcile(t *testing.T) {
 							},
 						})
 					}
+					if tc.withUnreachableShard {
+						conditions.MarkFalse(shards[3], corev1alpha1.ShardReachable, corev1alpha1.ShardNotReachableReason, conditionsv1alpha1.ConditionSeverityError, "")
+					}
 					return shards, nil
 				},
 
@@ -220,8 +238,9 @@ func TestReconcile(t *testing.T) {
 							Name: name,
 						},
 						Spec: topologyv1alpha1.PartitionSetSpec{
-							Dimensions:    []string{"region", "cloud"},
-							ShardSelector: shardSelector,
+							Dimensions:               []string{"region", "cloud"},
+							ShardSelector:            shardSelector,
+							ExcludeUnreachableShards: tc.excludeUnreachableShards,
 						},
 					}, nil
 				},
@@ -338,6 +357,26 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestFilterShardEvent(t *testing.T) {
+	shard := &corev1alpha1.Shard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "shard1",
+			Labels: map[string]string{"region": "Europe"},
+		},
+	}
+	reachable := shard.DeepCopy()
+	conditions.MarkTrue(reachable, corev1alpha1.ShardReachable)
+	unreachable := shard.DeepCopy()
+	conditions.MarkFalse(unreachable, corev1alpha1.ShardReachable, corev1alpha1.ShardNotReachableReason, conditionsv1alpha1.ConditionSeverityError, "")
+	relabeled := reachable.DeepCopy()
+	relabeled.Labels["region"] = "Asia"
+
+	require.False(t, filterShardEvent(shard, reachable), "a newly reachable shard does not change the partitions")
+	require.True(t, filterShardEvent(reachable, unreachable), "a shard becoming unreachable changes the partitions")
+	require.True(t, filterShardEvent(unreachable, reachable), "a shard becoming reachable again changes the partitions")
+	require.True(t, filterShardEvent(reachable, relabeled), "a label change changes the partitions")
+}
+
 // requireConditionMatches looks for a condition matching c in g. LastTransitionTime and Message
 // are not compared.
 func requireConditionMatches(t *testing.T, g conditions.Getter, c *conditionsv1alpha1.Condition) {
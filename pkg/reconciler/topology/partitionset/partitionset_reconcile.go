@@ -19,13 +19,14 @@ package partitionset
 import (
 	"context"
 	"sort"
+	"strings"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
-	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
@@ -75,6 +76,9 @@ func (c *controller) reconcile(ctx context.Context, partitionSet *topologyv1alph
 		)
 		return err
 	}
+	if partitionSet.Spec.ExcludeUnreachableShards {
+		shards = reachableShards(shards)
+	}
 
 	oldPartitions, err := c.getPartitionsByPartitionSet(partitionSet)
 	if err != nil {
@@ -88,81 +92,53 @@ func (c *controller) reconcile(ctx context.Context, partitionSet *topologyv1alph
 		return err
 	}
 
-	var matchLabelsMap map[string]map[string]string
+	dimensions := expandDimensions(partitionSet.Spec)
+	var shardSelectorLabels map[string]string
+	var shardSelectorExpressions []metav1.LabelSelectorRequirement
 	if partitionSet.Spec.ShardSelector != nil {
-		matchLabelsMap = partition(shards, partitionSet.Spec.Dimensions, partitionSet.Spec.ShardSelector.MatchLabels)
-	} else {
-		matchLabelsMap = partition(shards, partitionSet.Spec.Dimensions, nil)
+		shardSelectorLabels = partitionSet.Spec.ShardSelector.MatchLabels
+		shardSelectorExpressions = partitionSet.Spec.ShardSelector.MatchExpressions
 	}
-	partitionSet.Status.Count = uint16(len(matchLabelsMap))
-	existingMatches := map[string]struct{}{}
-	newMatchExpressions := []metav1.LabelSelectorRequirement{}
-	if partitionSet.Spec.ShardSelector != nil {
-		newMatchExpressions = partitionSet.Spec.ShardSelector.MatchExpressions
+	partitions := partition(shards, dimensions, shardSelectorLabels, normalizer(partitionSet.Spec))
+	partitionSet.Status.Count = uint16(len(partitions))
+
+	// index the desired partitions by their selector, including the expressions of the shard selector
+	desired := make(map[string]*shardPartition, len(partitions))
+	selectors := make(map[string]*metav1.LabelSelector, len(partitions))
+	for _, p := range partitions {
+		selector := p.selector(shardSelectorExpressions)
+		key := selectorKey(selector)
+		desired[key] = p
+		selectors[key] = selector
 	}
+
 	// loop through existing partitions and delete old partitions owned by the PartitionSet that are no match anymore
 	// store existing matches for not to recreate existing Partitions
+	existingMatches := map[string]struct{}{}
 	for _, oldPartition := range oldPartitions {
 		pLogger := logging.WithObject(logger, oldPartition)
-		// MatchExpressions need to be the same
-		oldMatchExpressions := []metav1.LabelSelectorRequirement{}
-		if oldPartition.Spec.Selector != nil {
-			oldMatchExpressions = oldPartition.Spec.Selector.MatchExpressions
-		}
-		if !equality.Semantic.DeepEqual(oldMatchExpressions, newMatchExpressions) {
-			pLogger.V(2).Info("deleting partition")
-			if err := c.deletePartition(ctx, logicalcluster.From(oldPartition).Path(), oldPartition.Name); err != nil && !apierrors.IsNotFound(err) {
-				conditions.MarkFalse(
-					partitionSet,
-					topologyv1alpha1.PartitionsReady,
-					topologyv1alpha1.ErrorGeneratingPartitionsReason,
-					conditionsv1alpha1.ConditionSeverityError,
-					"old partition could not get deleted",
-				)
-				return err
-			}
+		key := selectorKey(oldPartition.Spec.Selector)
+		if _, ok := desired[key]; ok {
+			existingMatches[key] = struct{}{}
 			continue
 		}
-
-		// MatchLabels need to be the same
-		partitionKey := ""
-		oldMatchLabels := map[string]string{}
-		if oldPartition.Spec.Selector != nil {
-			oldMatchLabels = oldPartition.Spec.Selector.MatchLabels
-		}
-
-		// Sorting the keys of the old partition for consistent comparison
-		sortedOldKeys := make([]string, len(oldMatchLabels))
-		i := 0
-		for k := range oldMatchLabels {
-			sortedOldKeys[i] = k
-			i++
-		}
-		sort.Strings(sortedOldKeys)
-		for _, key := range sortedOldKeys {
-			partitionKey = partitionKey + "+" + key + "=" + oldMatchLabels[key]
-		}
-		if _, ok := matchLabelsMap[partitionKey]; ok {
-			existingMatches[partitionKey] = struct{}{}
-		} else {
-			pLogger.V(2).Info("deleting partition")
-			if err := c.deletePartition(ctx, logicalcluster.From(oldPartition).Path(), oldPartition.Name); err != nil && !apierrors.IsNotFound(err) {
-				conditions.MarkFalse(
-					partitionSet,
-					topologyv1alpha1.PartitionsReady,
-					topologyv1alpha1.ErrorGeneratingPartitionsReason,
-					conditionsv1alpha1.ConditionSeverityError,
-					"old partition could not get deleted",
-				)
-				return err
-			}
+		pLogger.V(2).Info("deleting partition")
+		if err := c.deletePartition(ctx, logicalcluster.From(oldPartition).Path(), oldPartition.Name); err != nil && !apierrors.IsNotFound(err) {
+			conditions.MarkFalse(
+				partitionSet,
+				topologyv1alpha1.PartitionsReady,
+				topologyv1alpha1.ErrorGeneratingPartitionsReason,
+				conditionsv1alpha1.ConditionSeverityError,
+				"old partition could not get deleted",
+			)
+			return err
 		}
 	}
 
 	// Create partitions when no existing partition for the set has the same selector.
-	for key, matchLabels := range matchLabelsMap {
+	for key, p := range desired {
 		if _, ok := existingMatches[key]; !ok {
-			partition := generatePartition(partitionSet.Name, newMatchExpressions, matchLabels, partitionSet.Spec.Dimensions)
+			partition := generatePartition(partitionSet.Name, selectors[key], p.values, dimensions)
 			partition.OwnerReferences = []metav1.OwnerReference{
 				*metav1.NewControllerRef(partitionSet, topologyv1alpha1.SchemeGroupVersion.WithKind("PartitionSet")),
 			}
@@ -185,11 +161,109 @@ func (c *controller) reconcile(ctx context.Context, partitionSet *topologyv1alph
 	return nil
 }
 
-// partition populates shard label selectors according to dimensions.
-// It only keeps selectors that have at least one Shard matching them
-// so that Partitions not referring to any Shard would not get created.
-func partition(shards []*corev1alpha1.Shard, dimensions []string, shardSelectorLabels map[string]string) (matchLabelsMap map[string]map[string]string) {
-	matchLabelsMap = make(map[string]map[string]string)
+// expandDimensions returns the dimensions of the topology of the spec followed by the
+// explicit dimensions, without duplicates.
+func expandDimensions(spec topologyv1alpha1.PartitionSetSpec) []string {
+	var dimensions []string
+	seen := map[string]struct{}{}
+	for _, dimension := range append(spec.Topology.Dimensions(), spec.Dimensions...) {
+		if _, ok := seen[dimension]; ok {
+			continue
+		}
+		seen[dimension] = struct{}{}
+		dimensions = append(dimensions, dimension)
+	}
+	return dimensions
+}
+
+// normalizeFunc returns the normalized value of the given label value, and whether the values of
+// the label are normalized at all.
+type normalizeFunc func(label, value string) (string, bool)
+
+// normalizer returns the normalization of dimension label values specified in the spec, or nil
+// if the values are not normalized.
+func normalizer(spec topologyv1alpha1.PartitionSetSpec) normalizeFunc {
+	normalization := spec.Normalization
+	if normalization == nil {
+		return nil
+	}
+	normalized := sets.NewString(normalization.Dimensions...)
+	return func(label, value string) (string, bool) {
+		if !normalized.Has(label) {
+			return value, false
+		}
+		if normalization.Trim {
+			value = strings.Trim(value, "-_.")
+		}
+		if normalization.CaseFold {
+			value = strings.ToLower(value)
+		}
+		return value, true
+	}
+}
+
+// shardPartition is a group of shards sharing the same values for the partitioning labels.
+type shardPartition struct {
+	// values are the label values shared by the shards, normalized for normalized dimensions.
+	values map[string]string
+	// originalValues are the original label values of the shards for normalized dimensions.
+	originalValues map[string]sets.String
+}
+
+// selector returns the label selector for the shards of the partition, including the given
+// expressions. Normalized dimensions are selected by their original values.
+func (p *shardPartition) selector(matchExpressions []metav1.LabelSelectorRequirement) *metav1.LabelSelector {
+	selector := &metav1.LabelSelector{
+		MatchLabels:      map[string]string{},
+		MatchExpressions: append([]metav1.LabelSelectorRequirement{}, matchExpressions...),
+	}
+	for label, value := range p.values {
+		if _, normalized := p.originalValues[label]; !normalized {
+			selector.MatchLabels[label] = value
+		}
+	}
+	normalizedLabels := make([]string, 0, len(p.originalValues))
+	for label := range p.originalValues {
+		normalizedLabels = append(normalizedLabels, label)
+	}
+	sort.Strings(normalizedLabels)
+	for _, label := range normalizedLabels {
+		selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      label,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   p.originalValues[label].List(),
+		})
+	}
+	if len(selector.MatchLabels) == 0 {
+		selector.MatchLabels = nil
+	}
+	if len(selector.MatchExpressions) == 0 {
+		selector.MatchExpressions = nil
+	}
+	return selector
+}
+
+// selectorKey returns a canonical representation of the given selector, independent of the
+// order of its requirements.
+func selectorKey(selector *metav1.LabelSelector) string {
+	if selector == nil {
+		return ""
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		// never matches a valid selector
+		return "invalid:" + err.Error()
+	}
+	return s.String()
+}
+
+// partition groups shards by the values of the dimension labels and of the labels of the shard
+// selector. It only keeps groups that have at least one Shard in them so that Partitions not
+// referring to any Shard would not get created.
+// If normalize is not nil, dimension label values are normalized before grouping, and shards with
+// a label value that is empty after normalization are not part of any group.
+func partition(shards []*corev1alpha1.Shard, dimensions []string, shardSelectorLabels map[string]string, normalize normalizeFunc) map[string]*shardPartition {
+	partitions := make(map[string]*shardPartition)
 	labels := make([]string, len(dimensions), len(dimensions)+len(shardSelectorLabels))
 	copy(labels, dimensions)
 	for label := range shardSelectorLabels {
@@ -198,7 +272,8 @@ func partition(shards []*corev1alpha1.Shard, dimensions []string, shardSelectorL
 	sort.Strings(labels) // Sorting for consistent comparison.
 	for _, shard := range shards {
 		key := ""
-		selector := make(map[string]string)
+		values := make(map[string]string)
+		originalValues := make(map[string]string)
 		matchingLabels := true
 		for _, label := range labels {
 			labelValue, ok := shard.Labels[label]
@@ -206,12 +281,47 @@ func partition(shards []*corev1alpha1.Shard, dimensions []string, shardSelectorL
 				matchingLabels = false
 				break
 			}
+			// the values of shard selector labels are fixed by the selector
+			if _, selected := shardSelectorLabels[label]; !selected && normalize != nil {
+				if normalized, ok := normalize(label, labelValue); ok {
+					if normalized == "" {
+						matchingLabels = false
+						break
+					}
+					originalValues[label] = labelValue
+					labelValue = normalized
+				}
+			}
 			key = key + "+" + label + "=" + labelValue
-			selector[label] = labelValue
+			values[label] = labelValue
+		}
+		if !matchingLabels || len(key) == 0 {
+			continue
+		}
+		p, ok := partitions[key]
+		if !ok {
+			p = &shardPartition{values: values, originalValues: map[string]sets.String{}}
+			partitions[key] = p
 		}
-		if matchingLabels && len(key) > 0 {
-			matchLabelsMap[key] = selector
+		for label, value := range originalValues {
+			if p.originalValues[label] == nil {
+				p.originalValues[label] = sets.NewString()
+			}
+			p.originalValues[label].Insert(value)
+		}
+	}
+	return partitions
+}
+
+// reachableShards returns the shards that are not known to be unreachable. Shards that have not
+// been probed yet are kept.
+func reachableShards(shards []*corev1alpha1.Shard) []*corev1alpha1.Shard {
+	reachable := make([]*corev1alpha1.Shard, 0, len(shards))
+	for _, shard := range shards {
+		if conditions.IsFalse(shard, corev1alpha1.ShardReachable) {
+			continue
 		}
+		reachable = append(reachable, shard)
 	}
-	return matchLabelsMap
+	return reachable
 }
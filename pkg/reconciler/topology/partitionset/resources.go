@@ -26,14 +26,14 @@ import (
 )
 
 // generatePartition generates the Partition specifications based on
-// the provided matchExpressions and matchLabels.
-func generatePartition(name string, matchExpressions []metav1.LabelSelectorRequirement, matchLabels map[string]string, dimensions []string) *topologyv1alpha1.Partition {
+// the provided selector. The name is generated from the given dimension values.
+func generatePartition(name string, selector *metav1.LabelSelector, values map[string]string, dimensions []string) *topologyv1alpha1.Partition {
 	pname := name
 	labels := make([]string, len(dimensions))
 	copy(labels, dimensions)
 	sort.Strings(labels)
 	for _, label := range labels {
-		pname = pname + "-" + strings.ToLower(matchLabels[label])
+		pname = pname + "-" + strings.ToLower(values[label])
 	}
 
 	return &topologyv1alpha1.Partition{
@@ -41,10 +41,7 @@ func generatePartition(name string, matchExpressions []metav1.LabelSelectorRequi
 			GenerateName: pname + "-",
 		},
 		Spec: topologyv1alpha1.PartitionSpec{
-			Selector: &metav1.LabelSelector{
-				MatchLabels:      matchLabels,
-				MatchExpressions: matchExpressions,
-			},
+			Selector: selector,
 		},
 	}
 }
@@ -96,6 +96,8 @@ func NewController(
 		return nil, err
 	}
 
+	indexers.PlacementIndexers.ByScheduledSyncTargetKey.AddIfNotPresentOrDie(placementInformer.Informer().GetIndexer())
+
 	indexers.AddIfNotPresentOrDie(locationInformer.Informer().GetIndexer(), cache.Indexers{
 		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
 	})
@@ -280,6 +282,17 @@ func (c *controller) enqueueSyncTarget(obj interface{}, logger logr.Logger) {
 	for _, location := range locations {
 		c.enqueueLocation(location, logger)
 	}
+
+	// Enqueue placements scheduled to this SyncTarget directly, as the SyncTarget might not be
+	// selected by any Location anymore, e.g. when it or its Location has been deleted.
+	placements, err := indexers.PlacementIndexers.ByScheduledSyncTargetKey.ByKey(c.placementIndexer, workloadv1alpha1.ToSyncTargetKey(logicalcluster.From(syncTarget), syncTarget.Name))
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, placement := range placements {
+		c.enqueuePlacement(placement, logger)
+	}
 }
 
 // Start starts the controller, which stops when ctx.Done() is closed.
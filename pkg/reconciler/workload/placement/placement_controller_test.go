@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	"testing"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	schedulingv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/scheduling/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/indexers"
+)
+
+func TestEnqueueDeletedSyncTarget(t *testing.T) {
+	withCluster := func(placement *Placement, cluster string) *Placement {
+		if placement.Annotations == nil {
+			placement.Annotations = map[string]string{}
+		}
+		placement.Annotations[logicalcluster.AnnotationKey] = cluster
+		return placement
+	}
+
+	locationIndexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{kcpcache.ClusterIndexName: kcpcache.ClusterIndexFunc})
+	placementIndexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{
+		bySelectedLocationPath: indexBySelectedLocationPath,
+		indexers.PlacementIndexers.ByScheduledSyncTargetKey.Name(): indexers.PlacementIndexers.ByScheduledSyncTargetKey.IndexFunc(),
+	})
+	for _, placement := range []*Placement{
+		withCluster(newPlacement("scheduled", "test-location", "c1"), "root:org:ws1"),
+		withCluster(newPlacement("also-scheduled", "test-location", "c1"), "root:org:ws2"),
+		withCluster(newPlacement("other", "test-location", "c2"), "root:org:ws1"),
+		withCluster(newPlacement("unscheduled", "test-location", ""), "root:org:ws1"),
+	} {
+		require.NoError(t, placementIndexer.Add(placement))
+	}
+
+	c := &controller{
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		locationLister:   schedulingv1alpha1listers.NewLocationClusterLister(locationIndexer),
+		locationIndexer:  locationIndexer,
+		placementIndexer: placementIndexer,
+	}
+
+	// the SyncTarget is gone and no Location selects it anymore
+	c.enqueueSyncTarget(cache.DeletedFinalStateUnknown{Key: "c1", Obj: newSyncTarget("c1", true)}, klog.Background())
+
+	keys := sets.NewString()
+	for c.queue.Len() > 0 {
+		key, _ := c.queue.Get()
+		keys.Insert(key.(string))
+		c.queue.Done(key)
+	}
+	require.Equal(t, []string{"root:org:ws1|scheduled", "root:org:ws2|also-scheduled"}, keys.List())
+}
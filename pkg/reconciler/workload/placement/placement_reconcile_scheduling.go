@@ -18,9 +18,11 @@ package placement
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
-	"math/rand"
+	"math"
 	"strings"
 
 	"github.com/kcp-dev/logicalcluster/v3"
@@ -85,16 +87,46 @@ func (r *placementSchedulingReconciler) reconcile(ctx context.Context, placement
 		}
 	}
 
-	// 3. randomly select one as the scheduled cluster
+	// 3. select one as the scheduled cluster, proportionally to the weights of the synctargets
 	// TODO(qiujian16): we currently schedule each in each location independently. It cannot guarantee 1 cluster is scheduled per location
 	// when the same synctargets are in multiple locations, we need to rethink whether we need a better algorithm or we need location
 	// to be exclusive.
-	scheduledSyncTarget := validSyncTargets[rand.Intn(len(validSyncTargets))]
-	expectedAnnotations[workloadv1alpha1.InternalSyncTargetPlacementAnnotationKey] = workloadv1alpha1.ToSyncTargetKey(logicalcluster.From(scheduledSyncTarget), scheduledSyncTarget.Name)
+	expectedAnnotations[workloadv1alpha1.InternalSyncTargetPlacementAnnotationKey] = pickSyncTargetKey(clusterName.Path().Join(placement.Name).String(), validSyncTargets)
 	updated, err := r.patchPlacementAnnotation(ctx, clusterName.Path(), placement, expectedAnnotations)
 	return reconcileStatusStopAndRequeue, updated, err
 }
 
+// pickSyncTargetKey picks the key of one of the given SyncTargets for the placement with the given key,
+// using weighted rendezvous hashing: every SyncTarget gets a score from the hash of the placement key and
+// its SyncTarget key, scaled by its weight, and the one with the highest score is picked. Over many
+// placements, every SyncTarget gets a share of the placements proportional to its weight. The pick
+// for a placement is stable, and adding or removing a SyncTarget only moves the placements gaining
+// or losing it. Ties are broken by the SyncTarget key.
+func pickSyncTargetKey(placementKey string, syncTargets []*workloadv1alpha1.SyncTarget) string {
+	var picked string
+	var pickedScore float64
+	for _, syncTarget := range syncTargets {
+		key := workloadv1alpha1.ToSyncTargetKey(logicalcluster.From(syncTarget), syncTarget.Name)
+		score := rendezvousScore(placementKey, key, syncTarget.Spec.Weight)
+		if picked == "" || score > pickedScore || score == pickedScore && key < picked {
+			picked, pickedScore = key, score
+		}
+	}
+	return picked
+}
+
+// rendezvousScore returns weight / -ln(h) for the hash h of placementKey and syncTargetKey mapped
+// to (0, 1). The SyncTarget with the highest score wins with a probability proportional to its weight.
+func rendezvousScore(placementKey, syncTargetKey string, weight int32) float64 {
+	if weight < 1 {
+		// SyncTargets created before the weight was defaulted
+		weight = 1
+	}
+	sum := sha256.Sum256([]byte(placementKey + "/" + syncTargetKey))
+	h := (float64(binary.BigEndian.Uint64(sum[:8])>>11) + 0.5) / (1 << 53)
+	return float64(weight) / -math.Log(h)
+}
+
 func (r *placementSchedulingReconciler) getAllValidSyncTargetsForPlacement(ctx context.Context, placement *schedulingv1alpha1.Placement) ([]*workloadv1alpha1.SyncTarget, string, string, error) {
 	if placement.Status.Phase == schedulingv1alpha1.PlacementPending || placement.Status.SelectedLocation == nil {
 		return nil, schedulingv1alpha1.ScheduleLocationNotFound, "No selected location is scheduled", nil
@@ -19,6 +19,7 @@ package placement
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	jsonpatch "github.com/evanphx/json-patch"
@@ -279,6 +280,43 @@ func TestReconcileStatusConditions(t *testing.T) {
 	}
 }
 
+func TestPickSyncTargetKeyWeighted(t *testing.T) {
+	heavy := newSyncTarget("heavy", true)
+	heavy.Spec.Weight = 3
+	light := newSyncTarget("light", true)
+	light.Spec.Weight = 1
+	heavyKey := workloadv1alpha1.ToSyncTargetKey("", "heavy")
+	lightKey := workloadv1alpha1.ToSyncTargetKey("", "light")
+
+	const placements = 4000
+	picked := map[string]int{}
+	for i := 0; i < placements; i++ {
+		placementKey := fmt.Sprintf("root:org:ws%d|placement", i)
+		key := pickSyncTargetKey(placementKey, []*workloadv1alpha1.SyncTarget{heavy, light})
+		require.Equal(t, key, pickSyncTargetKey(placementKey, []*workloadv1alpha1.SyncTarget{light, heavy}), "expected the pick to be independent of the order of SyncTargets")
+		picked[key]++
+	}
+
+	require.Equal(t, placements, picked[heavyKey]+picked[lightKey])
+	share := float64(picked[heavyKey]) / placements
+	require.InDelta(t, 0.75, share, 0.03, "expected a 3:1 split, got %d:%d", picked[heavyKey], picked[lightKey])
+}
+
+func TestPickSyncTargetKeyUnsetWeight(t *testing.T) {
+	unset := newSyncTarget("unset", true)
+	one := newSyncTarget("one", true)
+	one.Spec.Weight = 1
+
+	const placements = 4000
+	picked := map[string]int{}
+	for i := 0; i < placements; i++ {
+		picked[pickSyncTargetKey(fmt.Sprintf("root:org:ws%d|placement", i), []*workloadv1alpha1.SyncTarget{unset, one})]++
+	}
+
+	share := float64(picked[workloadv1alpha1.ToSyncTargetKey("", "unset")]) / placements
+	require.InDelta(t, 0.5, share, 0.03, "expected an unset weight to count as 1")
+}
+
 func newPlacement(name, location, synctarget string) *schedulingv1alpha1.Placement {
 	placement := &schedulingv1alpha1.Placement{
 		ObjectMeta: metav1.ObjectMeta{
@@ -149,6 +149,9 @@ func NewController(
 		bySyncTargetKey: indexBySyncTargetKey,
 	})
 
+	// allows listing the Placements scheduled to a SyncTarget, e.g. before draining it.
+	indexers.PlacementIndexers.ByScheduledSyncTargetKey.AddIfNotPresentOrDie(placementInformer.Informer().GetIndexer())
+
 	syncTargetInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		DeleteFunc: func(obj interface{}) {
 			c.enqueueSyncTarget(obj)
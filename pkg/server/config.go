@@ -452,6 +452,8 @@ func NewConfig(opts *kcpserveroptions.CompletedOptions) (*Config, error) {
 		// with the default secure port, when the config is later completed.
 		kcpadmissioninitializers.NewKubeQuotaConfigurationInitializer(quotaConfiguration),
 		kcpadmissioninitializers.NewServerShutdownInitializer(c.quotaAdmissionStopCh),
+		kcpadmissioninitializers.NewKubeQuotaDeletionMonitorWorkersInitializer(opts.Extra.KubeQuotaDeletionMonitorWorkers),
+		kcpadmissioninitializers.NewAPIExportWebhooksAllowPrivateHostsInitializer(opts.Extra.APIExportWebhooksAllowPrivateHosts),
 	}
 
 	c.ShardBaseURL = func() string {
@@ -46,6 +46,7 @@ import (
 	serviceaccountcontroller "k8s.io/kubernetes/pkg/controller/serviceaccount"
 	"k8s.io/kubernetes/pkg/serviceaccount"
 
+	configshard "github.com/kcp-dev/kcp/config/shard"
 	configuniversal "github.com/kcp-dev/kcp/config/universal"
 	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
@@ -360,9 +361,17 @@ func (s *Server) installLogicalClusterDeletionController(ctx context.Context, co
 		kcpClusterClient,
 		logicalClusterAdminConfig,
 		shardExternalURL,
+		logicalclusterdeletion.FrontProxyTLSOverrides{
+			CAFile:     s.Options.Extra.ShardExternalCAFile,
+			ServerName: s.Options.Extra.ShardExternalTLSServerName,
+		},
 		metadataClusterClient,
 		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.KubeSharedInformerFactory.Core().V1().ConfigMaps(),
+		s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
 		discoverResourcesFn,
+		s.Options.Controllers.LogicalClusterDeletion.MaxRetryInterval,
 	)
 
 	return s.AddPostStartHook(postStartHookName(logicalclusterdeletion.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
@@ -463,6 +472,7 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 		workspaceShardController, err = shard.NewController(
 			kcpClusterClient,
 			s.KcpSharedInformerFactory.Core().V1alpha1().Shards(),
+			rest.AddUserAgent(rest.CopyConfig(s.LogicalClusterAdminConfig), shard.ControllerName),
 		)
 		if err != nil {
 			return err
@@ -664,9 +674,15 @@ func (s *Server) installAPIBindingController(ctx context.Context, config *rest.C
 		return err
 	}
 
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(apiBindingConfig)
+	if err != nil {
+		return err
+	}
+
 	c, err := apibinding.NewController(
 		crdClusterClient,
 		kcpClusterClient,
+		kubeClusterClient,
 		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
 		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 		s.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
@@ -724,6 +740,11 @@ func (s *Server) installAPIBindingController(ctx context.Context, config *rest.C
 		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
 		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.KubeSharedInformerFactory.Core().V1().Namespaces(),
+		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings(),
+		s.CacheKubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings(),
+		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoles(),
+		s.CacheKubeSharedInformerFactory.Rbac().V1().ClusterRoles(),
 	)
 	if err != nil {
 		return err
@@ -926,10 +947,16 @@ func (s *Server) installAPIExportController(ctx context.Context, config *rest.Co
 	c, err := apiexport.NewController(
 		kcpClusterClient,
 		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
 		kubeClusterClient,
 		s.KubeSharedInformerFactory.Core().V1().Namespaces(),
 		s.KubeSharedInformerFactory.Core().V1().Secrets(),
+		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings(),
+		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoles(),
+		s.KubeSharedInformerFactory.Rbac().V1().RoleBindings(),
+		s.KubeSharedInformerFactory.Rbac().V1().Roles(),
 	)
 	if err != nil {
 		return err
@@ -943,7 +970,8 @@ func (s *Server) installAPIExportController(ctx context.Context, config *rest.Co
 		if err := wait.PollImmediateInfiniteWithContext(goContext(hookContext), time.Millisecond*100, func(ctx context.Context) (bool, error) {
 			crdsSynced := s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().HasSynced()
 			exportsSynced := s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced()
-			return crdsSynced && exportsSynced, nil
+			schemasSynced := s.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Informer().HasSynced()
+			return crdsSynced && exportsSynced && schemasSynced, nil
 		}); err != nil {
 			logger.Error(err, "failed to finish post-start-hook")
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
@@ -1194,7 +1222,7 @@ func (s *Server) installAPIExportEndpointSliceController(ctx context.Context, co
 		return err
 	}
 
-	return s.AddPostStartHook(postStartHookName(apiexportendpointslice.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+	if err := s.AddPostStartHook(postStartHookName(apiexportendpointslice.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
 		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(apiexportendpointslice.ControllerName))
 		if err := s.waitForSync(hookContext.StopCh); err != nil {
 			logger.Error(err, "failed to finish post-start-hook")
@@ -1203,6 +1231,37 @@ func (s *Server) installAPIExportEndpointSliceController(ctx context.Context, co
 
 		go c.Start(goContext(hookContext), 2)
 
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	createConfig := rest.CopyConfig(config)
+	createConfig = rest.AddUserAgent(createConfig, apiexportendpointslice.CreateControllerName)
+
+	createKcpClusterClient, err := kcpclientset.NewForConfig(createConfig)
+	if err != nil {
+		return err
+	}
+
+	createController, err := apiexportendpointslice.NewCreateController(
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
+		createKcpClusterClient,
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.AddPostStartHook(postStartHookName(apiexportendpointslice.CreateControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(apiexportendpointslice.CreateControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go createController.Start(goContext(hookContext), 2)
+
 		return nil
 	})
 }
@@ -1608,7 +1667,15 @@ func (s *Server) installApiExportIdentityController(ctx context.Context, config
 
 func (s *Server) installReplicationController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
 	// TODO(sttts): set user agent
-	controller, err := replication.NewController(s.Options.Extra.ShardName, s.CacheDynamicClient, s.KcpSharedInformerFactory, s.CacheKcpSharedInformerFactory, s.KubeSharedInformerFactory, s.CacheKubeSharedInformerFactory)
+	controller, err := replication.NewController(s.Options.Extra.ShardName, s.Options.Cache.ReplicationMaxObjectSize, s.CacheDynamicClient, s.KubeClusterClient, s.KcpSharedInformerFactory, s.CacheKcpSharedInformerFactory, s.KubeSharedInformerFactory, s.CacheKubeSharedInformerFactory)
+	if err != nil {
+		return err
+	}
+	replicatedResources, err := replication.ParseGroupResources(s.Options.Cache.ReplicatedResources)
+	if err != nil {
+		return err
+	}
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(config)
 	if err != nil {
 		return err
 	}
@@ -1619,6 +1686,13 @@ func (s *Server) installReplicationController(ctx context.Context, config *rest.
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
 		}
 
+		// the informers of additional resources are only known after discovery, and are owned by the controller.
+		// Discovery runs against the local shard, which serves the resources to be replicated.
+		discoveryClient := s.KubeClusterClient.Cluster(configshard.SystemShardCluster.Path()).Discovery()
+		if err := controller.AddResources(klog.NewContext(goContext(hookContext), logger), discoveryClient, dynamicClusterClient, replicatedResources); err != nil {
+			return fmt.Errorf("failed to replicate --cache-replicated-resources: %w", err)
+		}
+
 		go controller.Start(goContext(hookContext), 2)
 		return nil
 	})
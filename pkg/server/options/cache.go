@@ -17,10 +17,13 @@ limitations under the License.
 package options
 
 import (
+	"fmt"
+
 	"github.com/spf13/pflag"
 
 	cacheclientoptions "github.com/kcp-dev/kcp/pkg/cache/client/options"
 	cacheoptions "github.com/kcp-dev/kcp/pkg/cache/server/options"
+	"github.com/kcp-dev/kcp/pkg/reconciler/cache/replication"
 )
 
 type cacheCompleted struct {
@@ -35,6 +38,12 @@ func (c cacheCompleted) Validate() []error {
 		errs = append(errs, err...)
 	}
 	errs = append(errs, c.Extra.Client.Validate()...)
+	if _, err := replication.ParseGroupResources(c.Extra.ReplicatedResources); err != nil {
+		errs = append(errs, fmt.Errorf("--cache-replicated-resources: %w", err))
+	}
+	if c.Extra.ReplicationMaxObjectSize < 0 {
+		errs = append(errs, fmt.Errorf("--cache-replication-max-object-size must not be negative"))
+	}
 
 	return errs
 }
@@ -49,6 +58,14 @@ type Extra struct {
 	Enabled bool
 
 	Client cacheclientoptions.Cache
+
+	// ReplicatedResources are the resources, as <resource>.<group>, that are replicated to the cache server
+	// in addition to the built-in ones.
+	ReplicatedResources []string
+
+	// ReplicationMaxObjectSize is the maximum size in bytes of objects replicated to the cache server.
+	// Larger objects are skipped. Zero means unlimited.
+	ReplicationMaxObjectSize int64
 }
 
 func NewCache(rootDir string) *Cache {
@@ -62,6 +79,12 @@ func NewCache(rootDir string) *Cache {
 
 func (c *Cache) AddFlags(fs *pflag.FlagSet) {
 	c.Client.AddFlags(fs)
+	fs.StringSliceVar(&c.ReplicatedResources, "cache-replicated-resources", c.ReplicatedResources,
+		"Additional resources to replicate to the cache server, comma separated, in the format <resource>.<group>. "+
+			"They are resolved through the discovery of the root workspace when the server starts, which fails for unknown resources.")
+	fs.Int64Var(&c.ReplicationMaxObjectSize, "cache-replication-max-object-size", c.ReplicationMaxObjectSize,
+		"Maximum size in bytes of the JSON serialization of objects replicated to the cache server. "+
+			"Larger objects are not replicated, and a warning event is emitted on them. 0 means unlimited.")
 
 	// note do not add cache server's flag c.Server.AddFlags(fs)
 	// it will cause an undefined behavior as some flags will be overwritten (also defined by the kcp server)
@@ -129,21 +129,25 @@ var (
 		"tracing-config-file", // File with apiserver tracing configuration.
 
 		// KCP flags
-		"profiler-address",                      // [Address]:port to bind the profiler to
-		"root-directory",                        // Root directory.
-		"shard-base-url",                        // Base URL to this kcp shard. Defaults to external address.
-		"shard-external-url",                    // URL used by outside clients to talk to this kcp shard. Defaults to external address.
-		"shard-virtual-workspace-ca-file",       // Path to a CA certificate file that is valid for the virtual workspace server.
-		"shard-virtual-workspace-url",           // An external URL address of a virtual workspace server associated with this shard. Defaults to shard's base address.
-		"shard-client-cert-file",                // Path to a client certificate file the shard uses to communicate with other system components.
-		"shard-client-key-file",                 // Path to a client certificate key file the shard uses to communicate with other system components.
-		"shard-name",                            // A name of this kcp shard.
-		"shard-kubeconfig-file",                 // Kubeconfig holding admin(!) credentials to peer kcp shards.
-		"root-shard-kubeconfig-file",            // Kubeconfig holding admin(!) credentials to the root kcp shard.
-		"experimental-bind-free-port",           // Bind to a free port. --secure-bind-port must be 0. Use the admin.kubeconfig to extract the chosen port.
-		"conversion-cel-transformation-timeout", // Maximum amount of time that CEL transformations may take per object conversion.
-		"batteries-included",                    // A list of batteries included (= default objects that might be unwanted in production, but very helpful in trying out kcp or development).
-		"logical-cluster-admin-kubeconfig",      // Kubeconfig holding admin(!) credentials to other shards. Defaults to the loopback client.
+		"profiler-address",                       // [Address]:port to bind the profiler to
+		"root-directory",                         // Root directory.
+		"shard-base-url",                         // Base URL to this kcp shard. Defaults to external address.
+		"shard-external-url",                     // URL used by outside clients to talk to this kcp shard. Defaults to external address.
+		"shard-external-ca-file",                 // Path to a CA certificate file that is valid for the --shard-external-url, e.g. of a front-proxy with a different CA than the shards. Defaults to the CA of the --logical-cluster-admin-kubeconfig.
+		"shard-external-tls-server-name",         // Server name used to verify the serving certificate of the --shard-external-url. Defaults to the host of the URL.
+		"shard-virtual-workspace-ca-file",        // Path to a CA certificate file that is valid for the virtual workspace server.
+		"shard-virtual-workspace-url",            // An external URL address of a virtual workspace server associated with this shard. Defaults to shard's base address.
+		"shard-client-cert-file",                 // Path to a client certificate file the shard uses to communicate with other system components.
+		"shard-client-key-file",                  // Path to a client certificate key file the shard uses to communicate with other system components.
+		"shard-name",                             // A name of this kcp shard.
+		"shard-kubeconfig-file",                  // Kubeconfig holding admin(!) credentials to peer kcp shards.
+		"root-shard-kubeconfig-file",             // Kubeconfig holding admin(!) credentials to the root kcp shard.
+		"experimental-bind-free-port",            // Bind to a free port. --secure-bind-port must be 0. Use the admin.kubeconfig to extract the chosen port.
+		"conversion-cel-transformation-timeout",  // Maximum amount of time that CEL transformations may take per object conversion.
+		"kubequota-deletion-monitor-workers",     // Number of workers tearing down the resource quota admission of deleted logical clusters concurrently.
+		"apiexport-webhooks-allow-private-hosts", // Allow the webhooks of APIExports to call the local host, cluster-internal service names and loopback, link-local or private IP addresses.
+		"batteries-included",                     // A list of batteries included (= default objects that might be unwanted in production, but very helpful in trying out kcp or development).
+		"logical-cluster-admin-kubeconfig",       // Kubeconfig holding admin(!) credentials to other shards. Defaults to the loopback client.
 
 		// secure serving flags
 		"bind-address",                     // The IP address on which to listen for the --secure-port port. The associated interface(s) must be reachable by the rest of the cluster, and by CLI/web clients. If blank or an unspecified address (0.0.0.0 or ::), all interfaces will be used.
@@ -172,16 +176,20 @@ var (
 		"home-workspaces-home-creator-groups", // Groups of users who can have their home workspaces provisioned upon first access.
 
 		// KCP Controllers flags
-		"auto-publish-apis",                      // If true, the APIs imported from physical clusters will be published automatically as CRDs
-		"apiresource-controller-threads",         // Number of threads to use for the apiresource controller.
-		"run-controllers",                        // Run the controllers in-process
-		"run-virtual-workspaces",                 // Run the virtual workspaces apiservers in-process
-		"unsupported-run-individual-controllers", // Run individual controllers in-process. The controller names can change at any time.
-		"sync-target-heartbeat-threshold",        // Amount of time to wait for a successful heartbeat before marking the cluster as not ready.
+		"auto-publish-apis",                          // If true, the APIs imported from physical clusters will be published automatically as CRDs
+		"apiresource-controller-threads",             // Number of threads to use for the apiresource controller.
+		"run-controllers",                            // Run the controllers in-process
+		"run-virtual-workspaces",                     // Run the virtual workspaces apiservers in-process
+		"virtual-workspaces-shard-name",              // The name of the shard the APIExport virtual workspace forwards to. If set, watch bookmarks carry composite resourceVersions across shards.
+		"unsupported-run-individual-controllers",     // Run individual controllers in-process. The controller names can change at any time.
+		"sync-target-heartbeat-threshold",            // Amount of time to wait for a successful heartbeat before marking the cluster as not ready.
+		"logicalcluster-deletion-max-retry-interval", // Maximum amount of time to wait before retrying the deletion of a logical cluster.
 
 		// KCP Cache Server flags
-		"cache-kubeconfig",             // Kubeconfig for the cache server this instance connects to (defaults to loopback configuration).
-		"cache-server-kubeconfig-file", // deprecated
+		"cache-kubeconfig",                  // Kubeconfig for the cache server this instance connects to (defaults to loopback configuration).
+		"cache-server-kubeconfig-file",      // deprecated
+		"cache-replicated-resources",        // Additional resources to replicate to the cache server, comma separated, in the format <resource>.<group>. They are resolved through the discovery of the root workspace when the server starts, which fails for unknown resources.
+		"cache-replication-max-object-size", // Maximum size in bytes of the JSON serialization of objects replicated to the cache server. Larger objects are not replicated, and a warning event is emitted on them. 0 means unlimited.
 
 		// generic flags
 		"cors-allowed-origins",                 // List of allowed origins for CORS, comma separated.  An allowed origin can be a regular expression to support subdomain matching. If this list is empty CORS will not be enabled.
@@ -60,6 +60,8 @@ type ExtraOptions struct {
 	RootShardKubeconfigFile            string
 	ShardBaseURL                       string
 	ShardExternalURL                   string
+	ShardExternalCAFile                string
+	ShardExternalTLSServerName         string
 	ShardName                          string
 	ShardVirtualWorkspaceURL           string
 	ShardClientCertFile                string
@@ -69,6 +71,8 @@ type ExtraOptions struct {
 	ExperimentalBindFreePort           bool
 	LogicalClusterAdminKubeconfig      string
 	ConversionCELTransformationTimeout time.Duration
+	KubeQuotaDeletionMonitorWorkers    int
+	APIExportWebhooksAllowPrivateHosts bool
 
 	BatteriesIncluded []string
 }
@@ -114,6 +118,7 @@ func NewOptions(rootDir string) *Options {
 			DiscoveryPollInterval:              60 * time.Second,
 			ExperimentalBindFreePort:           false,
 			ConversionCELTransformationTimeout: time.Second,
+			KubeQuotaDeletionMonitorWorkers:    1,
 
 			BatteriesIncluded: batteries.Defaults.List(),
 		},
@@ -178,6 +183,8 @@ func (o *Options) rawFlags() cliflag.NamedFlagSets {
 	fs.StringVar(&o.Extra.RootShardKubeconfigFile, "root-shard-kubeconfig-file", o.Extra.RootShardKubeconfigFile, "Kubeconfig holding admin(!) credentials to the root kcp shard.")
 	fs.StringVar(&o.Extra.ShardBaseURL, "shard-base-url", o.Extra.ShardBaseURL, "Base URL to this kcp shard. Defaults to external address.")
 	fs.StringVar(&o.Extra.ShardExternalURL, "shard-external-url", o.Extra.ShardExternalURL, "URL used by outside clients to talk to this kcp shard. Defaults to external address.")
+	fs.StringVar(&o.Extra.ShardExternalCAFile, "shard-external-ca-file", o.Extra.ShardExternalCAFile, "Path to a CA certificate file that is valid for the --shard-external-url, e.g. of a front-proxy with a different CA than the shards. Defaults to the CA of the --logical-cluster-admin-kubeconfig.")
+	fs.StringVar(&o.Extra.ShardExternalTLSServerName, "shard-external-tls-server-name", o.Extra.ShardExternalTLSServerName, "Server name used to verify the serving certificate of the --shard-external-url. Defaults to the host of the URL.")
 	fs.StringVar(&o.Extra.ShardName, "shard-name", o.Extra.ShardName, "A name of this kcp shard. Defaults to the \"root\" name.")
 	fs.StringVar(&o.Extra.ShardVirtualWorkspaceCAFile, "shard-virtual-workspace-ca-file", o.Extra.ShardVirtualWorkspaceCAFile, "Path to a CA certificate file that is valid for the virtual workspace server.")
 	fs.StringVar(&o.Extra.ShardVirtualWorkspaceURL, "shard-virtual-workspace-url", o.Extra.ShardVirtualWorkspaceURL, "An external URL address of a virtual workspace server associated with this shard. Defaults to shard's base address.")
@@ -191,6 +198,10 @@ func (o *Options) rawFlags() cliflag.NamedFlagSets {
 
 	fs.DurationVar(&o.Extra.ConversionCELTransformationTimeout, "conversion-cel-transformation-timeout", o.Extra.ConversionCELTransformationTimeout, "Maximum amount of time that CEL transformations may take per object conversion.")
 
+	fs.IntVar(&o.Extra.KubeQuotaDeletionMonitorWorkers, "kubequota-deletion-monitor-workers", o.Extra.KubeQuotaDeletionMonitorWorkers, "Number of workers tearing down the resource quota admission of deleted logical clusters concurrently.")
+
+	fs.BoolVar(&o.Extra.APIExportWebhooksAllowPrivateHosts, "apiexport-webhooks-allow-private-hosts", o.Extra.APIExportWebhooksAllowPrivateHosts, "Allow the webhooks of APIExports to call the local host, cluster-internal service names and loopback, link-local or private IP addresses. APIExport webhooks are called by kcp on behalf of service providers, so this lets every service provider reach what kcp can reach. Only meant for development and testing.")
+
 	fs.StringSliceVar(&o.Extra.BatteriesIncluded, "batteries-included", o.Extra.BatteriesIncluded, fmt.Sprintf(
 		`A list of batteries included (= default objects that might be unwanted in production, but are very helpful in trying out kcp or for development). These are the possible values: %s.
 
@@ -214,6 +225,10 @@ func (o *CompletedOptions) Validate() []error {
 		}
 	}
 
+	if o.Extra.KubeQuotaDeletionMonitorWorkers < 1 {
+		errs = append(errs, fmt.Errorf("--kubequota-deletion-monitor-workers must be at least 1"))
+	}
+
 	errs = append(errs, o.GenericControlPlane.Validate()...)
 	errs = append(errs, o.Controllers.Validate()...)
 	errs = append(errs, o.EmbeddedEtcd.Validate()...)
@@ -291,6 +306,12 @@ func (o *Options) Complete() (*CompletedOptions, error) {
 			return nil, err
 		}
 	}
+	if len(o.Extra.ShardExternalCAFile) > 0 && !filepath.IsAbs(o.Extra.ShardExternalCAFile) {
+		o.Extra.ShardExternalCAFile, err = filepath.Abs(o.Extra.ShardExternalCAFile)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if len(o.Extra.LogicalClusterAdminKubeconfig) > 0 && !filepath.IsAbs(o.Extra.LogicalClusterAdminKubeconfig) {
 		o.Extra.LogicalClusterAdminKubeconfig, err = filepath.Abs(o.Extra.LogicalClusterAdminKubeconfig)
 		if err != nil {
@@ -353,6 +374,11 @@ func (o *Options) Complete() (*CompletedOptions, error) {
 		o.Extra.BatteriesIncluded = bats.List()
 	}
 
+	if o.Virtual.VirtualWorkspaces.APIExport.ShardName == "" {
+		// in-process virtual workspaces forward to this shard.
+		o.Virtual.VirtualWorkspaces.APIExport.ShardName = o.Extra.ShardName
+	}
+
 	completedEmbeddedEtcd := o.EmbeddedEtcd.Complete(o.GenericControlPlane.Etcd)
 	cacheServerEtcdOptions := *o.GenericControlPlane.Etcd
 	o.Cache.Server.Etcd = &cacheServerEtcdOptions
@@ -183,18 +183,20 @@ func (s *Server) Run(ctx context.Context) error {
 		go s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().Run(hookContext.StopCh)
 		go s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().Run(hookContext.StopCh)
 		go s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().Run(hookContext.StopCh)
+		go s.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Informer().Run(hookContext.StopCh)
 
-		logger.Info("starting APIExport, APIBinding and LogicalCluster informers")
+		logger.Info("starting APIExport, APIBinding, APIResourceSchema and LogicalCluster informers")
 		if err := wait.PollInfiniteWithContext(goContext(hookContext), time.Millisecond*100, func(ctx context.Context) (bool, error) {
 			exportsSynced := s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced()
 			cacheExportsSynced := s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced()
 			logicalClusterSynced := s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced()
-			return exportsSynced && cacheExportsSynced && logicalClusterSynced, nil
+			schemasSynced := s.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Informer().HasSynced()
+			return exportsSynced && cacheExportsSynced && logicalClusterSynced && schemasSynced, nil
 		}); err != nil {
-			logger.Error(err, "failed to start some of APIExport, APIBinding and LogicalCluster informers")
+			logger.Error(err, "failed to start some of APIExport, APIBinding, APIResourceSchema and LogicalCluster informers")
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
 		}
-		logger.Info("finished starting APIExport, APIBinding and LogicalCluster informers")
+		logger.Info("finished starting APIExport, APIBinding, APIResourceSchema and LogicalCluster informers")
 
 		if s.Options.Extra.ShardName == corev1alpha1.RootShard {
 			logger.Info("bootstrapping root workspace phase 0")
@@ -18,6 +18,7 @@ package controllermanager
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -106,9 +107,12 @@ type ControllerManager struct {
 	informerSource     InformerSource
 	managedControllers map[string]ManagedController
 	startedControllers map[string]context.CancelFunc
+	// runningControllers tracks the started controllers until they have stopped.
+	runningControllers sync.WaitGroup
 }
 
-// Start starts the controller, which stops when ctx.Done() is closed.
+// Start starts the controller, which stops when ctx.Done() is closed. It returns once the
+// managed controllers have stopped too, e.g. after draining their work items in flight.
 func (c *ControllerManager) Start(ctx context.Context) {
 	defer utilruntime.HandleCrash()
 	defer c.queue.ShutDown()
@@ -117,8 +121,17 @@ func (c *ControllerManager) Start(ctx context.Context) {
 	logger.Info("Starting controller manager")
 	defer logger.Info("Shutting down controller manager")
 
-	go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	workerStopped := make(chan struct{})
+	go func() {
+		defer close(workerStopped)
+		wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}()
 	<-ctx.Done()
+
+	// no controllers are started anymore once the worker has stopped.
+	c.queue.ShutDown()
+	<-workerStopped
+	c.runningControllers.Wait()
 }
 
 func (c *ControllerManager) startWorker(ctx context.Context) {
@@ -192,7 +205,11 @@ controllerLoop:
 
 		// Start the controller
 		controllerContext, cancelFunc := context.WithCancel(ctx)
-		go start(controllerContext)
+		c.runningControllers.Add(1)
+		go func() {
+			defer c.runningControllers.Done()
+			start(controllerContext)
+		}()
 		c.startedControllers[controllerName] = cancelFunc
 	}
 }
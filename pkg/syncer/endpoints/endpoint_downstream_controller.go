@@ -20,9 +20,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
@@ -39,18 +45,42 @@ const (
 	ControllerName = "syncer-endpoint-controller"
 )
 
+var (
+	endpointsGVR  = corev1.SchemeGroupVersion.WithResource("endpoints")
+	servicesGVR   = corev1.SchemeGroupVersion.WithResource("services")
+	namespacesGVR = corev1.SchemeGroupVersion.WithResource("namespaces")
+)
+
 // NewEndpointController returns new controller which would annotate Endpoints related to synced Services, so that those Endpoints
 // would be upsynced by the UpSyncer to the upstream KCP workspace.
 // This would be useful to enable components such as a KNative controller (running against the KCP workspace) to see the Endpoint,
 // and confirm that the related Service is effective.
 func NewEndpointController(
+	syncTargetKey string,
+	drainGracePeriod time.Duration,
 	downstreamClient dynamic.Interface,
 	ddsifForDownstream *ddsif.GenericDiscoveringDynamicSharedInformerFactory[cache.SharedIndexInformer, cache.GenericLister, informers.GenericInformer],
 ) (*controller, error) {
-	endpointsGVR := corev1.SchemeGroupVersion.WithResource("endpoints")
-
 	c := &controller{
 		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+
+		getDownstreamResource: func(gvr schema.GroupVersionResource, namespace, name string) (runtime.Object, error) {
+			informer, err := ddsifForDownstream.ForResource(gvr)
+			if err != nil {
+				return nil, err
+			}
+			if namespace == "" {
+				return informer.Lister().Get(name)
+			}
+			return informer.Lister().ByNamespace(namespace).Get(name)
+		},
+		patchEndpoints: func(ctx context.Context, namespace, name string, patchType types.PatchType, data []byte) error {
+			_, err := downstreamClient.Resource(endpointsGVR).Namespace(namespace).Patch(ctx, name, patchType, data, metav1.PatchOptions{})
+			return err
+		},
+
+		syncTargetKey:    syncTargetKey,
+		drainGracePeriod: drainGracePeriod,
 	}
 
 	informers, _ := ddsifForDownstream.Informers()
@@ -58,6 +88,10 @@ func NewEndpointController(
 	if !ok {
 		return nil, errors.New("endpoints informer should be available")
 	}
+	servicesInformer, ok := informers[servicesGVR]
+	if !ok {
+		return nil, errors.New("services informer should be available")
+	}
 
 	endpointsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
@@ -71,11 +105,37 @@ func NewEndpointController(
 		},
 	})
 
+	// Endpoints have the same name as their Service, so Service events are queued under the key of the
+	// related Endpoints. This ensures the upsync label is removed when the Service goes away.
+	servicesInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueue(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.enqueue(obj)
+		},
+	})
+
 	return c, nil
 }
 
 type controller struct {
 	queue workqueue.RateLimitingInterface
+
+	getDownstreamResource func(gvr schema.GroupVersionResource, namespace, name string) (runtime.Object, error)
+	patchEndpoints        func(ctx context.Context, namespace, name string, patchType types.PatchType, data []byte) error
+
+	syncTargetKey string
+
+	// drainGracePeriod is how long in-flight work items may take to finish when the controller
+	// is shut down, before they are cancelled.
+	drainGracePeriod time.Duration
+	// draining is set when the controller is shut down, to stop the workers from picking up
+	// further work items.
+	draining atomic.Bool
+	// inFlight is read-locked by the workers while processing a work item, such that draining
+	// can wait for the work items in flight by write-locking it.
+	inFlight sync.RWMutex
 }
 
 func (c *controller) enqueue(obj interface{}) {
@@ -90,7 +150,9 @@ func (c *controller) enqueue(obj interface{}) {
 	c.queue.Add(key)
 }
 
-// Start starts N worker processes processing work items.
+// Start starts N worker processes processing work items. When ctx is done, the controller
+// stops picking up work items, and waits up to the drain grace period for the work items in
+// flight to be processed, such that Endpoints are not left half-updated.
 func (c *controller) Start(ctx context.Context, numThreads int) {
 	defer utilruntime.HandleCrash()
 	defer c.queue.ShutDown()
@@ -102,14 +164,41 @@ func (c *controller) Start(ctx context.Context, numThreads int) {
 		logger.Info("Shutting down controller")
 	}()
 
+	// the workers are only cancelled after draining, not when ctx is done.
+	workerCtx, cancelWorkers := context.WithCancel(klog.NewContext(context.Background(), logger))
+	defer cancelWorkers()
+
 	for i := 0; i < numThreads; i++ {
-		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+		go wait.UntilWithContext(workerCtx, c.startWorker, time.Second)
 	}
 
 	<-ctx.Done()
+
+	c.drain(logger)
+}
+
+// drain stops the workers from picking up further work items and waits up to the drain grace
+// period for the work items in flight to be processed.
+func (c *controller) drain(logger klog.Logger) {
+	c.draining.Store(true)
+	c.queue.ShutDown()
+
+	drained := make(chan struct{})
+	go func() {
+		c.inFlight.Lock()
+		defer c.inFlight.Unlock()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		logger.V(2).Info("drained work items in flight")
+	case <-time.After(c.drainGracePeriod):
+		logger.Info("work items in flight not processed within the drain grace period, cancelling them", "drainGracePeriod", c.drainGracePeriod)
+	}
 }
 
-// startWorker processes work items until stopCh is closed.
+// startWorker processes work items until the queue is shut down.
 func (c *controller) startWorker(ctx context.Context) {
 	for c.processNextWorkItem(ctx) {
 	}
@@ -122,6 +211,15 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 		return false
 	}
 
+	c.inFlight.RLock()
+	defer c.inFlight.RUnlock()
+
+	if c.draining.Load() {
+		// leave queued work items to the resync after the restart.
+		c.queue.Done(key)
+		return false
+	}
+
 	qk := key.(string)
 
 	logger := logging.WithQueueKey(klog.FromContext(ctx), qk)
@@ -142,20 +240,3 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 
 	return true
 }
-
-func (c *controller) process(ctx context.Context, key string) error {
-	logger := klog.FromContext(ctx)
-
-	namespace, name, err := cache.SplitMetaNamespaceKey(key)
-	if err != nil {
-		return err
-	}
-	logger = logger.WithValues(logging.NamespaceKey, namespace, logging.NameKey, name)
-
-	logger.Info("Processing endpoint")
-
-	// TODO(davidfestal): check if a service synced from KCP exists with the same name.
-	// If it's the case, then label the Endpoints resource so that it can be Upsynced.
-	// We should also cleanup the endpoint (remove the label) when the corresonding service is removed.
-	return nil
-}
@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+
+	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/syncer/shared"
+)
+
+func TestStartDrains(t *testing.T) {
+	objects := map[schema.GroupVersionResource]runtime.Object{
+		namespacesGVR: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "kcp-hcbsa8z6c2er",
+			Annotations: map[string]string{shared.NamespaceLocatorAnnotation: `{"syncTarget":{"cluster":"root:org:ws","name":"us-west1","uid":"uid"},"cluster":"root:org:ws","namespace":"test"}`},
+		}},
+		servicesGVR: &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name:      "httpecho",
+			Namespace: "kcp-hcbsa8z6c2er",
+			Labels:    map[string]string{workloadv1alpha1.InternalDownstreamClusterLabel: syncTargetKey},
+		}},
+		endpointsGVR: &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{
+			Name:      "httpecho",
+			Namespace: "kcp-hcbsa8z6c2er",
+		}},
+	}
+
+	tests := map[string]struct {
+		drainGracePeriod time.Duration
+		wantPatchErr     error
+	}{
+		"in-flight patch finishes within the grace period": {
+			drainGracePeriod: wait.ForeverTestTimeout,
+		},
+		"in-flight patch is cancelled after the grace period": {
+			drainGracePeriod: 100 * time.Millisecond,
+			wantPatchErr:     context.Canceled,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			patching := make(chan struct{})
+			release := make(chan struct{})
+			patchErr := make(chan error, 1)
+			c := &controller{
+				queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+				getDownstreamResource: func(gvr schema.GroupVersionResource, namespace, name string) (runtime.Object, error) {
+					return objects[gvr], nil
+				},
+				patchEndpoints: func(ctx context.Context, namespace, name string, patchType types.PatchType, data []byte) error {
+					close(patching)
+					select {
+					case <-release:
+					case <-ctx.Done():
+					}
+					patchErr <- ctx.Err()
+					return ctx.Err()
+				},
+				syncTargetKey:    syncTargetKey,
+				drainGracePeriod: tc.drainGracePeriod,
+			}
+			c.queue.Add("kcp-hcbsa8z6c2er/httpecho")
+
+			ctx, cancel := context.WithCancel(context.Background())
+			stopped := make(chan struct{})
+			go func() {
+				defer close(stopped)
+				c.Start(ctx, 1)
+			}()
+
+			<-patching
+			cancel()
+			if tc.wantPatchErr == nil {
+				// the drain must wait for the patch in flight.
+				select {
+				case <-stopped:
+					t.Fatal("controller stopped before the patch in flight finished")
+				case <-time.After(100 * time.Millisecond):
+				}
+				close(release)
+			}
+
+			select {
+			case <-stopped:
+			case <-time.After(wait.ForeverTestTimeout):
+				t.Fatal("controller did not stop")
+			}
+			require.Equal(t, tc.wantPatchErr, <-patchErr)
+
+			t.Log("Items added after the shutdown are not processed")
+			c.queue.Add("kcp-hcbsa8z6c2er/other")
+			require.Equal(t, 0, c.queue.Len())
+		})
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/syncer/shared"
+)
+
+func (c *controller) process(ctx context.Context, key string) error {
+	logger := klog.FromContext(ctx)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	logger = logger.WithValues(logging.NamespaceKey, namespace, logging.NameKey, name)
+
+	obj, err := c.getDownstreamResource(endpointsGVR, namespace, name)
+	if apierrors.IsNotFound(err) {
+		// nothing left to label
+		return nil
+	} else if err != nil {
+		return err
+	}
+	endpoints, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+
+	synced, err := c.isServiceSynced(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	upsyncLabel := workloadv1alpha1.ClusterResourceStateLabelPrefix + c.syncTargetKey
+	_, labeled := endpoints.GetLabels()[upsyncLabel]
+
+	var labelValue interface{}
+	switch {
+	case synced && !labeled:
+		logger.V(2).Info("labelling Endpoints of synced Service for upsync")
+		labelValue = string(workloadv1alpha1.ResourceStateUpsync)
+	case !synced && labeled:
+		logger.V(2).Info("removing upsync label from Endpoints without synced Service")
+		labelValue = nil
+	default:
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{
+				upsyncLabel: labelValue,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = c.patchEndpoints(ctx, namespace, name, types.MergePatchType, patch)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// isServiceSynced returns whether a Service with the given namespace and name exists downstream
+// and was synced from kcp by this syncer.
+func (c *controller) isServiceSynced(namespace, name string) (bool, error) {
+	obj, err := c.getDownstreamResource(servicesGVR, namespace, name)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	service, err := meta.Accessor(obj)
+	if err != nil {
+		return false, err
+	}
+	if service.GetLabels()[workloadv1alpha1.InternalDownstreamClusterLabel] != c.syncTargetKey {
+		return false, nil
+	}
+
+	// only namespaces created by the syncer carry a namespace locator
+	obj, err = c.getDownstreamResource(namespacesGVR, "", namespace)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	downstreamNamespace, err := meta.Accessor(obj)
+	if err != nil {
+		return false, err
+	}
+	_, found, err := shared.LocatorFromAnnotations(downstreamNamespace.GetAnnotations())
+	if err != nil {
+		return false, err
+	}
+	return found, nil
+}
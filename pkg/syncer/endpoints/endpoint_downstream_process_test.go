@@ -0,0 +1,178 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/syncer/shared"
+)
+
+const syncTargetKey = "6ohB8yeXhwqTQVuBzJRgqcRJTpRjX7yTZu5g5g"
+
+var upsyncLabel = workloadv1alpha1.ClusterResourceStateLabelPrefix + syncTargetKey
+
+func TestEndpointsProcess(t *testing.T) {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "kcp-hcbsa8z6c2er",
+			Annotations: map[string]string{shared.NamespaceLocatorAnnotation: `{"syncTarget":{"cluster":"root:org:ws","name":"us-west1","uid":"uid"},"cluster":"root:org:ws","namespace":"test"}`},
+			Labels:      map[string]string{workloadv1alpha1.InternalDownstreamClusterLabel: syncTargetKey},
+		},
+	}
+	syncedService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "httpecho",
+			Namespace: "kcp-hcbsa8z6c2er",
+			Labels:    map[string]string{workloadv1alpha1.InternalDownstreamClusterLabel: syncTargetKey},
+		},
+	}
+	orphanService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "httpecho",
+			Namespace: "kcp-hcbsa8z6c2er",
+			Labels:    map[string]string{workloadv1alpha1.InternalDownstreamClusterLabel: "anotherSyncTargetKey"},
+		},
+	}
+	endpoints := func(labels map[string]string) *corev1.Endpoints {
+		return &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "httpecho",
+				Namespace: "kcp-hcbsa8z6c2er",
+				Labels:    labels,
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		namespace  *corev1.Namespace
+		service    *corev1.Service
+		endpoints  *corev1.Endpoints
+		wantLabels map[string]string
+		wantPatch  bool
+	}{
+		"endpoints of a synced service get the upsync label": {
+			namespace:  namespace,
+			service:    syncedService,
+			endpoints:  endpoints(map[string]string{workloadv1alpha1.InternalDownstreamClusterLabel: syncTargetKey}),
+			wantLabels: map[string]string{workloadv1alpha1.InternalDownstreamClusterLabel: syncTargetKey, upsyncLabel: "Upsync"},
+			wantPatch:  true,
+		},
+		"endpoints already labelled are not patched": {
+			namespace:  namespace,
+			service:    syncedService,
+			endpoints:  endpoints(map[string]string{upsyncLabel: "Upsync"}),
+			wantLabels: map[string]string{upsyncLabel: "Upsync"},
+		},
+		"endpoints of a service not synced by this syncer are not labelled": {
+			namespace:  namespace,
+			service:    orphanService,
+			endpoints:  endpoints(map[string]string{workloadv1alpha1.InternalDownstreamClusterLabel: syncTargetKey}),
+			wantLabels: map[string]string{workloadv1alpha1.InternalDownstreamClusterLabel: syncTargetKey},
+		},
+		"endpoints of a service in a namespace without locator are not labelled": {
+			namespace:  &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kcp-hcbsa8z6c2er"}},
+			service:    syncedService,
+			endpoints:  endpoints(nil),
+			wantLabels: nil,
+		},
+		"upsync label is removed when the service is deleted": {
+			namespace:  namespace,
+			endpoints:  endpoints(map[string]string{workloadv1alpha1.InternalDownstreamClusterLabel: syncTargetKey, upsyncLabel: "Upsync"}),
+			wantLabels: map[string]string{workloadv1alpha1.InternalDownstreamClusterLabel: syncTargetKey},
+			wantPatch:  true,
+		},
+		"deleted endpoints are ignored": {
+			namespace: namespace,
+			service:   syncedService,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			objects := map[schema.GroupVersionResource]runtime.Object{}
+			var clientObjects []runtime.Object
+			if tc.namespace != nil {
+				objects[namespacesGVR] = tc.namespace
+			}
+			if tc.service != nil {
+				objects[servicesGVR] = tc.service
+			}
+			if tc.endpoints != nil {
+				objects[endpointsGVR] = tc.endpoints
+				clientObjects = append(clientObjects, tc.endpoints)
+			}
+
+			client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), endpointsToUnstructured(t, clientObjects)...)
+			patched := false
+			c := &controller{
+				getDownstreamResource: func(gvr schema.GroupVersionResource, namespace, name string) (runtime.Object, error) {
+					if obj, ok := objects[gvr]; ok {
+						return obj, nil
+					}
+					return nil, apierrors.NewNotFound(gvr.GroupResource(), name)
+				},
+				patchEndpoints: func(ctx context.Context, namespace, name string, patchType types.PatchType, data []byte) error {
+					patched = true
+					_, err := client.Resource(endpointsGVR).Namespace(namespace).Patch(ctx, name, patchType, data, metav1.PatchOptions{})
+					return err
+				},
+				syncTargetKey: syncTargetKey,
+			}
+
+			err := c.process(ctx, "kcp-hcbsa8z6c2er/httpecho")
+			require.NoError(t, err)
+			require.Equal(t, tc.wantPatch, patched)
+
+			if tc.endpoints == nil {
+				return
+			}
+			got, err := client.Resource(endpointsGVR).Namespace("kcp-hcbsa8z6c2er").Get(ctx, "httpecho", metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Equal(t, tc.wantLabels, got.GetLabels())
+		})
+	}
+}
+
+func endpointsToUnstructured(t *testing.T, objs []runtime.Object) []runtime.Object {
+	t.Helper()
+
+	ret := make([]runtime.Object, 0, len(objs))
+	for _, obj := range objs {
+		raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		require.NoError(t, err)
+		u := &unstructured.Unstructured{Object: raw}
+		u.SetAPIVersion("v1")
+		u.SetKind("Endpoints")
+		ret = append(ret, u)
+	}
+	return ret
+}
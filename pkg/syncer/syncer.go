@@ -78,9 +78,15 @@ type SyncerConfig struct {
 	SyncTargetUID                 string
 	DownstreamNamespaceCleanDelay time.Duration
 	DNSImage                      string
+	// EndpointDrainGracePeriod is how long the endpoint controller waits for Endpoints being
+	// updated to be finished when the syncer shuts down.
+	EndpointDrainGracePeriod time.Duration
 }
 
-func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, importPollInterval time.Duration, syncerNamespace string) error {
+// StartSyncer starts the syncer, which stops when ctx is done. The returned channel is closed once the
+// syncer has stopped, i.e. after its controllers drained their work items in flight, but at most after
+// the endpoint drain grace period.
+func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, importPollInterval time.Duration, syncerNamespace string) (<-chan struct{}, error) {
 	logger := klog.FromContext(ctx)
 	logger = logger.WithValues(SyncTargetWorkspace, cfg.SyncTargetPath, SyncTargetName, cfg.SyncTargetName)
 	logger.V(2).Info("starting syncer")
@@ -91,7 +97,7 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 	rest.AddUserAgent(bootstrapConfig, "kcp#syncer/"+kcpVersion)
 	kcpBootstrapClusterClient, err := kcpclusterclientset.NewForConfig(bootstrapConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	kcpSyncTargetClient := kcpBootstrapClusterClient.Cluster(cfg.SyncTargetPath)
 
@@ -138,7 +144,7 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 		return true, nil
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	upstreamConfig := rest.CopyConfig(cfg.UpstreamConfig)
@@ -146,7 +152,7 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 	rest.AddUserAgent(upstreamConfig, "kcp#syncing/"+kcpVersion)
 	upstreamSyncerClusterClient, err := kcpdynamic.NewForConfig(upstreamConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	upstreamUpsyncConfig := rest.CopyConfig(cfg.UpstreamConfig)
@@ -154,7 +160,7 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 	rest.AddUserAgent(upstreamUpsyncConfig, "kcp#upsyncing/"+kcpVersion)
 	upstreamUpsyncerClusterClient, err := kcpdynamic.NewForConfig(upstreamUpsyncConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Resources are accepted as a set to ensure the provision of a
@@ -177,7 +183,7 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 		resources,
 		cfg.SyncTargetPath, cfg.SyncTargetName, syncTarget.GetUID())
 	if err != nil {
-		return err
+		return nil, err
 	}
 	kcpImporterInformerFactory.Start(ctx.Done())
 
@@ -185,11 +191,11 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 	rest.AddUserAgent(downstreamConfig, "kcp#status-syncer/"+kcpVersion)
 	downstreamDynamicClient, err := dynamic.NewForConfig(downstreamConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	downstreamKubeClient, err := kubernetes.NewForConfig(downstreamConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	syncTargetKey := workloadv1alpha1.ToSyncTargetKey(logicalcluster.From(syncTarget), cfg.SyncTargetName)
@@ -213,7 +219,7 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 		syncTarget.GetUID(),
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ddsifForUpstreamSyncer, err := ddsif.NewDiscoveringDynamicSharedInformerFactory(upstreamSyncerClusterClient, nil, nil,
@@ -229,7 +235,7 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 		},
 		cache.Indexers{})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ddsifForUpstreamUpsyncer, err := ddsif.NewDiscoveringDynamicSharedInformerFactory(upstreamUpsyncerClusterClient, nil, nil,
@@ -243,7 +249,7 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 		},
 		cache.Indexers{})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ddsifForDownstream, err := ddsif.NewScopedDiscoveringDynamicSharedInformerFactory(downstreamDynamicClient, nil,
@@ -256,7 +262,7 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 		},
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Check whether we're in the Advanced Scheduling feature-gated mode.
@@ -269,12 +275,12 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 	logger.Info("Creating spec syncer")
 	upstreamURL, err := url.Parse(cfg.UpstreamConfig.Host)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	downstreamNamespaceController, err := namespace.NewDownstreamController(logger, logicalcluster.From(syncTarget), cfg.SyncTargetName, syncTargetKey, syncTarget.GetUID(), downstreamConfig, downstreamDynamicClient, ddsifForUpstreamSyncer, ddsifForDownstream, syncerNamespace, cfg.DownstreamNamespaceCleanDelay)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	specSyncer, err := spec.NewSpecSyncer(logger, logicalcluster.From(syncTarget), cfg.SyncTargetName, syncTargetKey, upstreamURL, advancedSchedulingEnabled,
@@ -282,14 +288,14 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 		syncerNamespace, syncerNamespaceInformerFactory, cfg.DNSImage)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	logger.Info("Creating status syncer")
 	statusSyncer, err := status.NewStatusSyncer(logger, logicalcluster.From(syncTarget), cfg.SyncTargetName, syncTargetKey, advancedSchedulingEnabled,
 		upstreamSyncerClusterClient, downstreamDynamicClient, ddsifForUpstreamSyncer, ddsifForDownstream, syncTarget.GetUID())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Start and sync informer factories
@@ -297,12 +303,12 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 	for _, alwaysRequired := range []string{"secrets", "namespaces"} {
 		gvr := corev1.SchemeGroupVersion.WithResource(alwaysRequired)
 		if informer, err := ddsifForUpstreamSyncer.ForResource(gvr); err != nil {
-			return err
+			return nil, err
 		} else {
 			cacheSyncsForAlwaysRequiredGVRs = append(cacheSyncsForAlwaysRequiredGVRs, informer.Informer().HasSynced)
 		}
 		if informer, err := ddsifForDownstream.ForResource(gvr); err != nil {
-			return err
+			return nil, err
 		} else {
 			cacheSyncsForAlwaysRequiredGVRs = append(cacheSyncsForAlwaysRequiredGVRs, informer.Informer().HasSynced)
 		}
@@ -381,11 +387,12 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 		map[string]controllermanager.ManagedController{
 			endpoints.ControllerName: {
 				RequiredGVRs: []schema.GroupVersionResource{
+					corev1.SchemeGroupVersion.WithResource("namespaces"),
 					corev1.SchemeGroupVersion.WithResource("services"),
 					corev1.SchemeGroupVersion.WithResource("endpoints"),
 				},
 				Create: func(ctx context.Context) (controllermanager.StartControllerFunc, error) {
-					endpointController, err := endpoints.NewEndpointController(downstreamDynamicClient, ddsifForDownstream)
+					endpointController, err := endpoints.NewEndpointController(syncTargetKey, cfg.EndpointDrainGracePeriod, downstreamDynamicClient, ddsifForDownstream)
 					if err != nil {
 						return nil, err
 					}
@@ -396,7 +403,11 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 			},
 		},
 	)
-	go downstreamSyncerControllerManager.Start(ctx)
+	downstreamControllersStopped := make(chan struct{})
+	go func() {
+		defer close(downstreamControllersStopped)
+		downstreamSyncerControllerManager.Start(ctx)
+	}()
 
 	// Start tunneler for POD access
 	if kcpfeatures.DefaultFeatureGate.Enabled(kcpfeatures.SyncerTunnel) {
@@ -405,7 +416,19 @@ func StartSyncer(ctx context.Context, cfg *SyncerConfig, numSyncerThreads int, i
 
 	StartHeartbeat(ctx, kcpSyncTargetClient, cfg.SyncTargetName, cfg.SyncTargetUID)
 
-	return nil
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+
+		select {
+		case <-downstreamControllersStopped:
+		case <-time.After(cfg.EndpointDrainGracePeriod):
+			logger.Info("downstream controllers not stopped within the endpoint drain grace period", "endpointDrainGracePeriod", cfg.EndpointDrainGracePeriod)
+		}
+	}()
+
+	return stopped, nil
 }
 
 func StartHeartbeat(ctx context.Context, kcpSyncTargetClient kcpclientset.Interface, syncTargetName, syncTargetUID string) {
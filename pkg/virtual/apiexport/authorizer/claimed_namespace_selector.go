@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorizer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
+	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+)
+
+type claimedNamespaceSelectorAuthorizer struct {
+	getAPIExport func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error)
+	claims       claimResolver
+	getNamespace func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error)
+	delegate     authorizer.Authorizer
+}
+
+// NewClaimedNamespaceSelectorAuthorizer creates an authorizer that denies requests for claimed
+// resources in namespaces of a consumer workspace whose labels do not match the namespace selector
+// of the permission claim in the requested API export. Requests across all namespaces or all
+// consumer workspaces are not denied, but the objects outside of the selected namespaces are
+// filtered by the virtual workspace storage. In all other cases the given delegate authorizer is executed.
+func NewClaimedNamespaceSelectorAuthorizer(delegate authorizer.Authorizer, apiExportInformer apisv1alpha1informers.APIExportClusterInformer, apiResourceSchemaInformer apisv1alpha1informers.APIResourceSchemaClusterInformer, namespaceInformer kcpcorev1informers.NamespaceClusterInformer) authorizer.Authorizer {
+	apiExportLister := apiExportInformer.Lister()
+	namespaceLister := namespaceInformer.Lister()
+
+	return &claimedNamespaceSelectorAuthorizer{
+		getAPIExport: func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error) {
+			return apiExportLister.Cluster(logicalcluster.Name(clusterName)).Get(apiExportName)
+		},
+		getNamespace: func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error) {
+			return namespaceLister.Cluster(clusterName).Get(name)
+		},
+		claims:   newClaimResolver(apiExportInformer, apiResourceSchemaInformer),
+		delegate: delegate,
+	}
+}
+
+func (a *claimedNamespaceSelectorAuthorizer) Authorize(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+	if !attr.IsResourceRequest() || attr.GetNamespace() == "" {
+		return a.delegate.Authorize(ctx, attr)
+	}
+
+	cluster := genericapirequest.ClusterFrom(ctx)
+	if cluster == nil || cluster.Wildcard || cluster.Name.Empty() {
+		return a.delegate.Authorize(ctx, attr)
+	}
+
+	apiDomainKey := dynamiccontext.APIDomainKeyFrom(ctx)
+	parts := strings.Split(string(apiDomainKey), "/")
+	if len(parts) < 2 {
+		return authorizer.DecisionNoOpinion, "", fmt.Errorf("invalid API domain key")
+	}
+
+	apiExportCluster, apiExportName := parts[0], parts[1]
+	apiExport, err := a.getAPIExport(apiExportCluster, apiExportName)
+	if kerrors.IsNotFound(err) {
+		return authorizer.DecisionNoOpinion, "", fmt.Errorf("API export not found: %w", err)
+	}
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "", err
+	}
+
+	claim, found, err := a.claims.getClaim(apiExport, attr)
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "", err
+	}
+	if !found || claim.NamespaceSelector == nil {
+		return a.delegate.Authorize(ctx, attr)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(claim.NamespaceSelector)
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "", fmt.Errorf("invalid namespace selector of permission claim %q: %w", claim.String(), err)
+	}
+
+	// a missing namespace has no labels.
+	var nsLabels map[string]string
+	ns, err := a.getNamespace(cluster.Name, attr.GetNamespace())
+	if err != nil && !kerrors.IsNotFound(err) {
+		return authorizer.DecisionNoOpinion, "", err
+	} else if err == nil {
+		nsLabels = ns.Labels
+	}
+
+	if !selector.Matches(labels.Set(nsLabels)) {
+		return authorizer.DecisionDeny, fmt.Sprintf("namespace %q does not match the namespace selector of permission claim %q of API export: %q, workspace: %q",
+			attr.GetNamespace(), claim.String(), apiExportName, apiExportCluster), nil
+	}
+
+	return a.delegate.Authorize(ctx, attr)
+}
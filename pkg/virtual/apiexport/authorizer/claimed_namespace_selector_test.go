@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+)
+
+func TestClaimedNamespaceSelectorAuthorizer(t *testing.T) {
+	apiExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "bar",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{
+					GroupResource:     apisv1alpha1.GroupResource{Resource: "configmaps"},
+					All:               true,
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"},
+					All:           true,
+				},
+			},
+		},
+	}
+	namespaces := map[string]*corev1.Namespace{
+		"tenant-a": {ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Labels: map[string]string{"tenant": "a"}}},
+		"tenant-b": {ObjectMeta: metav1.ObjectMeta{Name: "tenant-b", Labels: map[string]string{"tenant": "b"}}},
+	}
+
+	for _, tc := range []struct {
+		name             string
+		cluster          *genericapirequest.Cluster
+		attr             *authorizer.AttributesRecord
+		expectedDecision authorizer.Decision
+		expectedReason   string
+	}{
+		{
+			name:             "get in selected namespace",
+			cluster:          &genericapirequest.Cluster{Name: "consumer"},
+			attr:             &authorizer.AttributesRecord{Verb: "get", Resource: "configmaps", Namespace: "tenant-a", Name: "foo", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+		{
+			name:             "list in other namespace",
+			cluster:          &genericapirequest.Cluster{Name: "consumer"},
+			attr:             &authorizer.AttributesRecord{Verb: "list", Resource: "configmaps", Namespace: "tenant-b", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionDeny,
+			expectedReason:   `namespace "tenant-b" does not match the namespace selector of permission claim "configmaps" of API export: "bar", workspace: "foo"`,
+		},
+		{
+			name:             "create in missing namespace",
+			cluster:          &genericapirequest.Cluster{Name: "consumer"},
+			attr:             &authorizer.AttributesRecord{Verb: "create", Resource: "configmaps", Namespace: "missing", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionDeny,
+			expectedReason:   `namespace "missing" does not match the namespace selector of permission claim "configmaps" of API export: "bar", workspace: "foo"`,
+		},
+		{
+			name:             "list across all namespaces",
+			cluster:          &genericapirequest.Cluster{Name: "consumer"},
+			attr:             &authorizer.AttributesRecord{Verb: "list", Resource: "configmaps", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+		{
+			name:             "list across all workspaces",
+			cluster:          &genericapirequest.Cluster{Wildcard: true},
+			attr:             &authorizer.AttributesRecord{Verb: "list", Resource: "configmaps", Namespace: "tenant-b", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+		{
+			name:             "claim without namespace selector",
+			cluster:          &genericapirequest.Cluster{Name: "consumer"},
+			attr:             &authorizer.AttributesRecord{Verb: "get", Resource: "secrets", Namespace: "tenant-b", Name: "foo", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			auth := &claimedNamespaceSelectorAuthorizer{
+				getAPIExport: func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error) {
+					require.Equal(t, "foo", clusterName)
+					require.Equal(t, "bar", apiExportName)
+					return apiExport, nil
+				},
+				getNamespace: func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error) {
+					require.Equal(t, logicalcluster.Name("consumer"), clusterName)
+					if ns, ok := namespaces[name]; ok {
+						return ns, nil
+					}
+					return nil, kerrors.NewNotFound(corev1.Resource("namespaces"), name)
+				},
+				delegate: authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+					return authorizer.DecisionAllow, "delegated", nil
+				}),
+			}
+
+			tc.attr.User = &user.DefaultInfo{}
+			ctx := dynamiccontext.WithAPIDomainKey(context.Background(), dynamiccontext.APIDomainKey("foo/bar"))
+			ctx = genericapirequest.WithCluster(ctx, *tc.cluster)
+			dec, reason, err := auth.Authorize(ctx, tc.attr)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDecision, dec)
+			require.Equal(t, tc.expectedReason, reason)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorizer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
+	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+)
+
+type claimedNamespacesAuthorizer struct {
+	getAPIExport func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error)
+	claims       claimResolver
+	delegate     authorizer.Authorizer
+}
+
+// NewClaimedNamespacesAuthorizer creates an authorizer that denies create, update and patch
+// requests for claimed resources in namespaces not listed in the namespace allowlist of the
+// permission claim in the requested API export. If the claim has no allowlist, or the request
+// is not a mutating request for a claimed resource, the given delegate authorizer is executed.
+func NewClaimedNamespacesAuthorizer(delegate authorizer.Authorizer, apiExportInformer apisv1alpha1informers.APIExportClusterInformer, apiResourceSchemaInformer apisv1alpha1informers.APIResourceSchemaClusterInformer) authorizer.Authorizer {
+	apiExportLister := apiExportInformer.Lister()
+
+	return &claimedNamespacesAuthorizer{
+		getAPIExport: func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error) {
+			return apiExportLister.Cluster(logicalcluster.Name(clusterName)).Get(apiExportName)
+		},
+		claims:   newClaimResolver(apiExportInformer, apiResourceSchemaInformer),
+		delegate: delegate,
+	}
+}
+
+func (a *claimedNamespacesAuthorizer) Authorize(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+	if !attr.IsResourceRequest() || attr.GetNamespace() == "" {
+		return a.delegate.Authorize(ctx, attr)
+	}
+	switch attr.GetVerb() {
+	case "create", "update", "patch":
+	default:
+		return a.delegate.Authorize(ctx, attr)
+	}
+
+	apiDomainKey := dynamiccontext.APIDomainKeyFrom(ctx)
+	parts := strings.Split(string(apiDomainKey), "/")
+	if len(parts) < 2 {
+		return authorizer.DecisionNoOpinion, "", fmt.Errorf("invalid API domain key")
+	}
+
+	apiExportCluster, apiExportName := parts[0], parts[1]
+	apiExport, err := a.getAPIExport(apiExportCluster, apiExportName)
+	if kerrors.IsNotFound(err) {
+		return authorizer.DecisionNoOpinion, "", fmt.Errorf("API export not found: %w", err)
+	}
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "", err
+	}
+
+	claim, found, err := a.claims.getClaim(apiExport, attr)
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "", err
+	}
+	if !found || len(claim.Namespaces) == 0 {
+		return a.delegate.Authorize(ctx, attr)
+	}
+
+	if !sets.NewString(claim.Namespaces...).Has(attr.GetNamespace()) {
+		return authorizer.DecisionDeny, fmt.Sprintf("namespace %q is not allowed by permission claim %q of API export: %q, workspace: %q",
+			attr.GetNamespace(), claim.String(), apiExportName, apiExportCluster), nil
+	}
+
+	return a.delegate.Authorize(ctx, attr)
+}
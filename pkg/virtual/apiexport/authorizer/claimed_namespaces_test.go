@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+)
+
+func TestClaimedNamespacesAuthorizer(t *testing.T) {
+	apiExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "bar",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"},
+					All:           true,
+					Namespaces:    []string{"allowed"},
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"},
+					All:           true,
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Group: "wildwest.dev", Resource: "sheriffs"},
+					IdentityHash:  "unserved",
+					All:           true,
+					Namespaces:    []string{"allowed"},
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Group: "wildwest.dev", Resource: "sheriffs"},
+					IdentityHash:  "served",
+					All:           true,
+					Namespaces:    []string{"other"},
+				},
+			},
+		},
+	}
+	sheriffsExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sheriffs",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "provider"},
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			LatestResourceSchemas: []string{"today.sheriffs.wildwest.dev"},
+		},
+	}
+	sheriffsSchema := &apisv1alpha1.APIResourceSchema{
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Group: "wildwest.dev",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "sheriffs"},
+		},
+	}
+
+	for _, tc := range []struct {
+		name             string
+		attr             *authorizer.AttributesRecord
+		expectedDecision authorizer.Decision
+		expectedReason   string
+	}{
+		{
+			name:             "create in allowed namespace",
+			attr:             &authorizer.AttributesRecord{Verb: "create", Resource: "configmaps", Namespace: "allowed", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+		{
+			name:             "create outside of allowed namespaces",
+			attr:             &authorizer.AttributesRecord{Verb: "create", Resource: "configmaps", Namespace: "other", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionDeny,
+			expectedReason:   `namespace "other" is not allowed by permission claim "configmaps" of API export: "bar", workspace: "foo"`,
+		},
+		{
+			name:             "update outside of allowed namespaces",
+			attr:             &authorizer.AttributesRecord{Verb: "update", Resource: "configmaps", Namespace: "other", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionDeny,
+			expectedReason:   `namespace "other" is not allowed by permission claim "configmaps" of API export: "bar", workspace: "foo"`,
+		},
+		{
+			name:             "patch outside of allowed namespaces",
+			attr:             &authorizer.AttributesRecord{Verb: "patch", Resource: "configmaps", Namespace: "other", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionDeny,
+			expectedReason:   `namespace "other" is not allowed by permission claim "configmaps" of API export: "bar", workspace: "foo"`,
+		},
+		{
+			name:             "get outside of allowed namespaces",
+			attr:             &authorizer.AttributesRecord{Verb: "get", Resource: "configmaps", Namespace: "other", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+		{
+			name:             "create for claim without allowlist",
+			attr:             &authorizer.AttributesRecord{Verb: "create", Resource: "secrets", Namespace: "other", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+		{
+			name:             "create in namespace allowed by served claim",
+			attr:             &authorizer.AttributesRecord{Verb: "create", APIGroup: "wildwest.dev", Resource: "sheriffs", Namespace: "other", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+		{
+			name:             "create in namespace only allowed by shadowed claim",
+			attr:             &authorizer.AttributesRecord{Verb: "create", APIGroup: "wildwest.dev", Resource: "sheriffs", Namespace: "allowed", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionDeny,
+			expectedReason:   `namespace "allowed" is not allowed by permission claim "sheriffs.wildwest.dev:served" of API export: "bar", workspace: "foo"`,
+		},
+		{
+			name:             "create for unclaimed resource",
+			attr:             &authorizer.AttributesRecord{Verb: "create", APIGroup: "wildwest.dev", Resource: "cowboys", Namespace: "other", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			auth := &claimedNamespacesAuthorizer{
+				getAPIExport: func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error) {
+					require.Equal(t, "foo", clusterName)
+					require.Equal(t, "bar", apiExportName)
+					return apiExport, nil
+				},
+				claims: claimResolver{
+					getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+						if identityHash == "served" {
+							return []*apisv1alpha1.APIExport{sheriffsExport}, nil
+						}
+						return nil, nil
+					},
+					getAPIResourceSchema: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+						require.Equal(t, "provider", clusterName.String())
+						require.Equal(t, "today.sheriffs.wildwest.dev", name)
+						return sheriffsSchema, nil
+					},
+				},
+				delegate: authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+					return authorizer.DecisionAllow, "delegated", nil
+				}),
+			}
+
+			tc.attr.User = &user.DefaultInfo{}
+			ctx := dynamiccontext.WithAPIDomainKey(context.Background(), dynamiccontext.APIDomainKey("foo/bar"))
+			dec, reason, err := auth.Authorize(ctx, tc.attr)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDecision, dec)
+			require.Equal(t, tc.expectedReason, reason)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorizer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
+	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+)
+
+type claimedVerbsAuthorizer struct {
+	getAPIExport func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error)
+	claims       claimResolver
+	delegate     authorizer.Authorizer
+}
+
+// readOnlyVerbs are the only verbs allowed for claims projected read-only.
+var readOnlyVerbs = sets.NewString("get", "list", "watch")
+
+// NewClaimedVerbsAuthorizer creates an authorizer that denies requests for claimed resources
+// with verbs not listed in the verb allowlist of the permission claim in the requested API export.
+// Every verb is checked on its own, e.g. deletecollection requests are denied unless deletecollection
+// is listed, independently of delete. Requests for read-only claims are denied for all verbs but get,
+// list and watch. If the claim has no allowlist and is not read-only, or the request is not for a
+// claimed resource, the given delegate authorizer is executed.
+func NewClaimedVerbsAuthorizer(delegate authorizer.Authorizer, apiExportInformer apisv1alpha1informers.APIExportClusterInformer, apiResourceSchemaInformer apisv1alpha1informers.APIResourceSchemaClusterInformer) authorizer.Authorizer {
+	apiExportLister := apiExportInformer.Lister()
+
+	return &claimedVerbsAuthorizer{
+		getAPIExport: func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error) {
+			return apiExportLister.Cluster(logicalcluster.Name(clusterName)).Get(apiExportName)
+		},
+		claims:   newClaimResolver(apiExportInformer, apiResourceSchemaInformer),
+		delegate: delegate,
+	}
+}
+
+func (a *claimedVerbsAuthorizer) Authorize(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+	if !attr.IsResourceRequest() {
+		return a.delegate.Authorize(ctx, attr)
+	}
+
+	apiDomainKey := dynamiccontext.APIDomainKeyFrom(ctx)
+	parts := strings.Split(string(apiDomainKey), "/")
+	if len(parts) < 2 {
+		return authorizer.DecisionNoOpinion, "", fmt.Errorf("invalid API domain key")
+	}
+
+	apiExportCluster, apiExportName := parts[0], parts[1]
+	apiExport, err := a.getAPIExport(apiExportCluster, apiExportName)
+	if kerrors.IsNotFound(err) {
+		return authorizer.DecisionNoOpinion, "", fmt.Errorf("API export not found: %w", err)
+	}
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "", err
+	}
+
+	claim, found, err := a.claims.getClaim(apiExport, attr)
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "", err
+	}
+	if !found {
+		return a.delegate.Authorize(ctx, attr)
+	}
+
+	if claim.ReadOnly && !readOnlyVerbs.Has(attr.GetVerb()) {
+		return authorizer.DecisionDeny, fmt.Sprintf("verb %q is not allowed by read-only permission claim %q of API export: %q, workspace: %q",
+			attr.GetVerb(), claim.String(), apiExportName, apiExportCluster), nil
+	}
+
+	if len(claim.Verbs) == 0 {
+		return a.delegate.Authorize(ctx, attr)
+	}
+
+	if verbs := sets.NewString(claim.Verbs...); !verbs.Has("*") && !verbs.Has(attr.GetVerb()) {
+		return authorizer.DecisionDeny, fmt.Sprintf("verb %q is not allowed by permission claim %q of API export: %q, workspace: %q",
+			attr.GetVerb(), claim.String(), apiExportName, apiExportCluster), nil
+	}
+
+	return a.delegate.Authorize(ctx, attr)
+}
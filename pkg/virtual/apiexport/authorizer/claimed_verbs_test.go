@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorizer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+)
+
+func TestClaimedVerbsAuthorizer(t *testing.T) {
+	apiExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "bar",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"},
+					All:           true,
+					Verbs:         []string{"get", "list", "watch", "create", "update", "patch"},
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"},
+					All:           true,
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "services"},
+					All:           true,
+					Verbs:         []string{"*"},
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "endpoints"},
+					All:           true,
+					ReadOnly:      true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name             string
+		attr             *authorizer.AttributesRecord
+		expectedDecision authorizer.Decision
+		expectedReason   string
+	}{
+		{
+			name:             "create of allowed verb",
+			attr:             &authorizer.AttributesRecord{Verb: "create", Resource: "configmaps", Namespace: "default", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+		{
+			name:             "get of allowed verb",
+			attr:             &authorizer.AttributesRecord{Verb: "get", Resource: "configmaps", Namespace: "default", Name: "foo", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+		{
+			name:             "delete of omitted verb",
+			attr:             &authorizer.AttributesRecord{Verb: "delete", Resource: "configmaps", Namespace: "default", Name: "foo", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionDeny,
+			expectedReason:   `verb "delete" is not allowed by permission claim "configmaps" of API export: "bar", workspace: "foo"`,
+		},
+		{
+			name:             "deletecollection of omitted verb",
+			attr:             &authorizer.AttributesRecord{Verb: "deletecollection", Resource: "configmaps", Namespace: "default", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionDeny,
+			expectedReason:   `verb "deletecollection" is not allowed by permission claim "configmaps" of API export: "bar", workspace: "foo"`,
+		},
+		{
+			name:             "cluster-wide deletecollection of omitted verb",
+			attr:             &authorizer.AttributesRecord{Verb: "deletecollection", Resource: "configmaps", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionDeny,
+			expectedReason:   `verb "deletecollection" is not allowed by permission claim "configmaps" of API export: "bar", workspace: "foo"`,
+		},
+		{
+			name:             "delete for claim without allowlist",
+			attr:             &authorizer.AttributesRecord{Verb: "delete", Resource: "secrets", Namespace: "default", Name: "foo", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+		{
+			name:             "deletecollection for claim allowing all verbs",
+			attr:             &authorizer.AttributesRecord{Verb: "deletecollection", Resource: "services", Namespace: "default", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+		{
+			name:             "delete for unclaimed resource",
+			attr:             &authorizer.AttributesRecord{Verb: "delete", APIGroup: "wildwest.dev", Resource: "cowboys", Namespace: "default", Name: "foo", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+		{
+			name:             "get for read-only claim",
+			attr:             &authorizer.AttributesRecord{Verb: "get", Resource: "endpoints", Namespace: "default", Name: "foo", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+		{
+			name:             "update of status for read-only claim",
+			attr:             &authorizer.AttributesRecord{Verb: "update", Resource: "endpoints", Subresource: "status", Namespace: "default", Name: "foo", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionDeny,
+			expectedReason:   `verb "update" is not allowed by read-only permission claim "endpoints" of API export: "bar", workspace: "foo"`,
+		},
+		{
+			name:             "non-resource request",
+			attr:             &authorizer.AttributesRecord{Verb: "get", Path: "/api"},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "delegated",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			auth := newClaimedVerbsAuthorizer(t, apiExport)
+			tc.attr.User = &user.DefaultInfo{}
+			ctx := dynamiccontext.WithAPIDomainKey(context.Background(), dynamiccontext.APIDomainKey("foo/bar"))
+			dec, reason, err := auth.Authorize(ctx, tc.attr)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDecision, dec)
+			require.Equal(t, tc.expectedReason, reason)
+		})
+	}
+}
+
+func TestClaimedVerbsAuthorizerReadOnly(t *testing.T) {
+	apiExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "bar",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"},
+					All:           true,
+					ReadOnly:      true,
+				},
+			},
+		},
+	}
+
+	auth := newClaimedVerbsAuthorizer(t, apiExport)
+	ctx := dynamiccontext.WithAPIDomainKey(context.Background(), dynamiccontext.APIDomainKey("foo/bar"))
+	for _, verb := range []string{"create", "update", "patch", "delete", "deletecollection", "escalate", "bind", "impersonate", "*"} {
+		dec, reason, err := auth.Authorize(ctx, &authorizer.AttributesRecord{User: &user.DefaultInfo{}, Verb: verb, Resource: "configmaps", Namespace: "default", ResourceRequest: true})
+		require.NoError(t, err)
+		require.Equal(t, authorizer.DecisionDeny, dec, "expected verb %q to be denied", verb)
+		require.Equal(t, fmt.Sprintf("verb %q is not allowed by read-only permission claim \"configmaps\" of API export: \"bar\", workspace: \"foo\"", verb), reason)
+	}
+	for _, verb := range []string{"get", "list", "watch"} {
+		dec, _, err := auth.Authorize(ctx, &authorizer.AttributesRecord{User: &user.DefaultInfo{}, Verb: verb, Resource: "configmaps", Namespace: "default", ResourceRequest: true})
+		require.NoError(t, err)
+		require.Equal(t, authorizer.DecisionAllow, dec, "expected verb %q to be delegated", verb)
+	}
+}
+
+func newClaimedVerbsAuthorizer(t *testing.T, apiExport *apisv1alpha1.APIExport) authorizer.Authorizer {
+	t.Helper()
+
+	return &claimedVerbsAuthorizer{
+		getAPIExport: func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error) {
+			require.Equal(t, "foo", clusterName)
+			require.Equal(t, "bar", apiExportName)
+			return apiExport, nil
+		},
+		delegate: authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+			return authorizer.DecisionAllow, "delegated", nil
+		}),
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorizer
+
+import (
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/indexers"
+)
+
+// claimResolver finds the permission claim of an API export that the virtual workspace
+// serves a requested resource for.
+type claimResolver struct {
+	getAPIExportsByIdentity func(identityHash string) ([]*apisv1alpha1.APIExport, error)
+	getAPIResourceSchema    func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error)
+}
+
+func newClaimResolver(apiExportInformer apisv1alpha1informers.APIExportClusterInformer, apiResourceSchemaInformer apisv1alpha1informers.APIResourceSchemaClusterInformer) claimResolver {
+	apiExportIndexer := apiExportInformer.Informer().GetIndexer()
+	apiResourceSchemaLister := apiResourceSchemaInformer.Lister()
+
+	return claimResolver{
+		getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+			return indexers.APIExportIndexers.ByIdentityHash.ByKey(apiExportIndexer, identityHash)
+		},
+		getAPIResourceSchema: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+			return apiResourceSchemaLister.Cluster(clusterName).Get(name)
+		},
+	}
+}
+
+// getClaim returns the permission claim of the given API export for the requested group and resource.
+//
+// Claims for the same group and resource can differ in identity. The virtual workspace serves the
+// first of them whose identity is provided by an API export exporting the group and resource, and
+// shadows the others, hence the same claim is picked here.
+func (r claimResolver) getClaim(apiExport *apisv1alpha1.APIExport, attr authorizer.Attributes) (apisv1alpha1.PermissionClaim, bool, error) {
+	var candidates []apisv1alpha1.PermissionClaim
+	for _, claim := range apiExport.Spec.PermissionClaims {
+		if claim.Group == attr.GetAPIGroup() && claim.Resource == attr.GetResource() {
+			candidates = append(candidates, claim)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return apisv1alpha1.PermissionClaim{}, false, nil
+	case 1:
+		return candidates[0], true, nil
+	}
+
+	for _, claim := range candidates {
+		if claim.IdentityHash == "" {
+			// built-in and system resources are served without identity.
+			return claim, true, nil
+		}
+		served, err := r.servesIdentity(claim)
+		if err != nil {
+			return apisv1alpha1.PermissionClaim{}, false, err
+		}
+		if served {
+			return claim, true, nil
+		}
+	}
+
+	return apisv1alpha1.PermissionClaim{}, false, nil
+}
+
+// servesIdentity returns true if an API export with the identity of the claim exports the claimed resource.
+func (r claimResolver) servesIdentity(claim apisv1alpha1.PermissionClaim) (bool, error) {
+	exports, err := r.getAPIExportsByIdentity(claim.IdentityHash)
+	if err != nil {
+		return false, err
+	}
+	for _, export := range exports {
+		for _, schemaName := range export.Spec.LatestResourceSchemas {
+			apiResourceSchema, err := r.getAPIResourceSchema(logicalcluster.From(export), schemaName)
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return false, err
+			}
+			if apiResourceSchema.Spec.Group == claim.Group && apiResourceSchema.Spec.Names.Plural == claim.Resource {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
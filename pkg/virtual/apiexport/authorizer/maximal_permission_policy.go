@@ -39,6 +39,7 @@ type maximalPermissionAuthorizer struct {
 	getAPIExport            func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error)
 	newDeepSARAuthorizer    func(clusterName logicalcluster.Name) (authorizer.Authorizer, error)
 	getAPIExportsByIdentity func(identityHash string) ([]*apisv1alpha1.APIExport, error)
+	claims                  claimResolver
 }
 
 // NewMaximalPermissionAuthorizer creates an authorizer that checks the maximal permission policy
@@ -47,7 +48,7 @@ type maximalPermissionAuthorizer struct {
 //
 // If the request is a cluster request the authorizer skips authorization if the request is not for a bound resource.
 // If the request is a wildcard request this check is skipped because no unique API binding can be determined.
-func NewMaximalPermissionAuthorizer(deepSARClient kcpkubernetesclientset.ClusterInterface, apiExportInformer apisv1alpha1informers.APIExportClusterInformer) authorizer.Authorizer {
+func NewMaximalPermissionAuthorizer(deepSARClient kcpkubernetesclientset.ClusterInterface, apiExportInformer apisv1alpha1informers.APIExportClusterInformer, apiResourceSchemaInformer apisv1alpha1informers.APIResourceSchemaClusterInformer) authorizer.Authorizer {
 	apiExportLister := apiExportInformer.Lister()
 	apiExportIndexer := apiExportInformer.Informer().GetIndexer()
 
@@ -56,11 +57,12 @@ func NewMaximalPermissionAuthorizer(deepSARClient kcpkubernetesclientset.Cluster
 			return apiExportLister.Cluster(logicalcluster.Name(clusterName)).Get(apiExportName)
 		},
 		getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
-			return indexers.ByIndex[*apisv1alpha1.APIExport](apiExportIndexer, indexers.APIExportByIdentity, identityHash)
+			return indexers.APIExportIndexers.ByIdentityHash.ByKey(apiExportIndexer, identityHash)
 		},
 		newDeepSARAuthorizer: func(clusterName logicalcluster.Name) (authorizer.Authorizer, error) {
 			return delegated.NewDelegatedAuthorizer(clusterName, deepSARClient, delegated.Options{})
 		},
+		claims: newClaimResolver(apiExportInformer, apiResourceSchemaInformer),
 	}
 }
 
@@ -82,12 +84,16 @@ func (a *maximalPermissionAuthorizer) Authorize(ctx context.Context, attr author
 		return authorizer.DecisionNoOpinion, "", err
 	}
 
-	claimedIdentityHash, found := getClaimedIdentity(claimingAPIExport, attr)
+	claim, found, err := a.claims.getClaim(claimingAPIExport, attr)
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "", err
+	}
 	if !found {
 		// it's a resource in the claiming API export, hence unclaimed
 		return authorizer.DecisionAllow, fmt.Sprintf("unclaimed resource in API export: %q, workspace :%q",
 			claimingAPIExport.Name, logicalcluster.From(claimingAPIExport)), nil
 	}
+	claimedIdentityHash := claim.IdentityHash
 	if claimedIdentityHash == "" {
 		// it's a native k8s resource (secret, configmap, ...), or a system kcp CRD resource (apis.kcp.io)
 		// For neither case a maximum permission policy can exist.
@@ -139,16 +145,6 @@ func (a *maximalPermissionAuthorizer) Authorize(ctx context.Context, attr author
 	return authorizer.DecisionAllow, "all claimed API exports granted access", nil
 }
 
-func getClaimedIdentity(apiExport *apisv1alpha1.APIExport, attr authorizer.Attributes) (string, bool) {
-	for i := range apiExport.Spec.PermissionClaims {
-		if apiExport.Spec.PermissionClaims[i].Resource == attr.GetResource() &&
-			apiExport.Spec.PermissionClaims[i].Group == attr.GetAPIGroup() {
-			return apiExport.Spec.PermissionClaims[i].IdentityHash, true
-		}
-	}
-	return "", false
-}
-
 func prefixAttributes(attr authorizer.Attributes) *authorizer.AttributesRecord {
 	prefixedUser := &user.DefaultInfo{
 		Name:  apisv1alpha1.MaximalPermissionPolicyRBACUserGroupPrefix + attr.GetUser().GetName(),
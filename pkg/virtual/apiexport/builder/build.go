@@ -20,13 +20,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	kcpmetadata "github.com/kcp-dev/client-go/metadata"
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
@@ -40,10 +45,13 @@ import (
 	"github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
 	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
 	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
 	virtualapiexportauth "github.com/kcp-dev/kcp/pkg/virtual/apiexport/authorizer"
 	"github.com/kcp-dev/kcp/pkg/virtual/apiexport/controllers/apireconciler"
+	apiexportmetrics "github.com/kcp-dev/kcp/pkg/virtual/apiexport/metrics"
 	"github.com/kcp-dev/kcp/pkg/virtual/apiexport/schemas"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework"
+	virtualcontext "github.com/kcp-dev/kcp/pkg/virtual/framework/context"
 	virtualdynamic "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/apidefinition"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/apiserver"
@@ -60,6 +68,9 @@ func BuildVirtualWorkspace(
 	kubeClusterClient, deepSARClient kcpkubernetesclientset.ClusterInterface,
 	kcpClusterClient kcpclientset.ClusterInterface,
 	cachedKcpInformers kcpinformers.SharedInformerFactory,
+	namespaceInformer kcpcorev1informers.NamespaceClusterInformer,
+	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
+	shardName string,
 ) ([]rootapiserver.NamedVirtualWorkspace, error) {
 	if !strings.HasSuffix(rootPathPrefix, "/") {
 		rootPathPrefix += "/"
@@ -67,6 +78,10 @@ func BuildVirtualWorkspace(
 
 	readyCh := make(chan struct{})
 
+	namespaceLister := namespaceInformer.Lister()
+	apiBindingLister := apiBindingInformer.Lister()
+	watches := newNamespaceSelectorWatches(namespaceInformer)
+
 	boundOrClaimedWorkspaceContent := &virtualdynamic.DynamicVirtualWorkspace{
 		RootPathResolver: framework.RootPathResolverFunc(func(urlPath string, ctx context.Context) (accepted bool, prefixToStrip string, completedContext context.Context) {
 			cluster, apiDomain, prefixToStrip, ok := digestUrl(urlPath, rootPathPrefix)
@@ -93,6 +108,10 @@ func BuildVirtualWorkspace(
 			if err != nil {
 				return nil, fmt.Errorf("error creating privileged dynamic kcp client: %w", err)
 			}
+			metadataClient, err := kcpmetadata.NewForConfig(cfg)
+			if err != nil {
+				return nil, fmt.Errorf("error creating privileged metadata kcp client: %w", err)
+			}
 
 			impersonatedDynamicClientGetter := func(ctx context.Context) (kcpdynamic.ClusterInterface, error) {
 				cluster, err := genericapirequest.ValidClusterFrom(ctx)
@@ -120,6 +139,17 @@ func BuildVirtualWorkspace(
 				return impersonatedClient, nil
 			}
 
+			apiExportLister := cachedKcpInformers.Apis().V1alpha1().APIExports().Lister()
+			getAPIExport := func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+				return apiExportLister.Cluster(clusterName).Get(name)
+			}
+			getNamespace := func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error) {
+				return namespaceLister.Cluster(clusterName).Get(name)
+			}
+			listAPIBindings := func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error) {
+				return apiBindingLister.Cluster(clusterName).List(labels.Everything())
+			}
+
 			apiReconciler, err := apireconciler.NewAPIReconciler(
 				kcpClusterClient,
 				cachedKcpInformers.Apis().V1alpha1().APIResourceSchemas(),
@@ -127,14 +157,33 @@ func BuildVirtualWorkspace(
 				func(apiResourceSchema *apisv1alpha1.APIResourceSchema, version string, identityHash string, optionalLabelRequirements labels.Requirements) (apidefinition.APIDefinition, error) {
 					ctx, cancelFn := context.WithCancel(context.Background())
 
-					var wrapper forwardingregistry.StorageWrapper
+					wrapper := forwardingregistry.StorageWrappers{
+						forwardingregistry.WithFieldSelectors(supportedFieldSelectors...),
+					}
 					if len(optionalLabelRequirements) > 0 {
-						wrapper = forwardingregistry.WithLabelSelector(func(_ context.Context) labels.Requirements {
-							return optionalLabelRequirements
-						})
+						// only claimed resources have label requirements
+						claimedResource := schema.GroupVersionResource{Group: apiResourceSchema.Spec.Group, Version: version, Resource: apiResourceSchema.Spec.Names.Plural}
+						if identityHash != "" {
+							claimedResource.Resource += ":" + identityHash
+						}
+						wrapper = append(wrapper,
+							forwardingregistry.WithLabelSelector(func(_ context.Context) labels.Requirements {
+								return optionalLabelRequirements
+							}),
+							forwardingregistry.WithAnnotations(claimAuditAnnotations(getAPIExport, identityHash)),
+							forwardingregistry.WithAnnotations(claimIdentityAnnotation(getAPIExport, identityHash)),
+							forwardingregistry.WithObjectFilter(claimResourceSelectorFilter(getAPIExport, identityHash)),
+							forwardingregistry.WithObjectFilter(claimNamespaceSelectorFilter(getAPIExport, getNamespace, identityHash)),
+							forwardingregistry.WithWatchExpiration(claimNamespaceSelectorWatchExpiration(getAPIExport, watches, identityHash)),
+							forwardingregistry.WithObjectLimit(claimObjectLimit(listAPIBindings, identityHash), newClaimedObjectCounter(ctx, metadataClient, claimedResource, optionalLabelRequirements).count),
+						)
+					}
+
+					if shardName != "" {
+						wrapper = append(wrapper, forwardingregistry.WithShardResourceVersion(shardName))
 					}
 
-					storageBuilder := provideDelegatingRestStorage(ctx, impersonatedDynamicClientGetter, identityHash, wrapper)
+					storageBuilder := provideDelegatingRestStorage(ctx, impersonatedDynamicClientGetter, identityHash, &wrapper)
 					def, err := apiserver.CreateServingInfoFor(mainConfig, apiResourceSchema, version, storageBuilder)
 					if err != nil {
 						cancelFn()
@@ -169,6 +218,8 @@ func BuildVirtualWorkspace(
 				for name, informer := range map[string]cache.SharedIndexInformer{
 					"apiresourceschemas": cachedKcpInformers.Apis().V1alpha1().APIResourceSchemas().Informer(),
 					"apiexports":         cachedKcpInformers.Apis().V1alpha1().APIExports().Informer(),
+					"namespaces":         namespaceInformer.Informer(),
+					"apibindings":        apiBindingInformer.Informer(),
 				} {
 					if !cache.WaitForNamedCacheSync(name, hookContext.StopCh, informer.HasSynced) {
 						klog.Background().Error(nil, "informer not synced")
@@ -184,14 +235,50 @@ func BuildVirtualWorkspace(
 
 			return apiReconciler, nil
 		},
-		Authorizer: newAuthorizer(kubeClusterClient, deepSARClient, cachedKcpInformers),
+		Authorizer: newAuthorizer(kubeClusterClient, deepSARClient, cachedKcpInformers, namespaceInformer),
 	}
 
 	return []rootapiserver.NamedVirtualWorkspace{
-		{Name: VirtualWorkspaceName, VirtualWorkspace: boundOrClaimedWorkspaceContent},
+		{Name: VirtualWorkspaceName, VirtualWorkspace: &withLatencyTracking{VirtualWorkspace: boundOrClaimedWorkspaceContent}},
 	}, nil
 }
 
+// withLatencyTracking records the latency of the requests served by the wrapped virtual workspace.
+type withLatencyTracking struct {
+	framework.VirtualWorkspace
+}
+
+func (vw *withLatencyTracking) Register(name string, rootAPIServerConfig genericapiserver.CompletedConfig, delegateAPIServer genericapiserver.DelegationTarget) (genericapiserver.DelegationTarget, error) {
+	target, err := vw.VirtualWorkspace.Register(name, rootAPIServerConfig, delegateAPIServer)
+	if err != nil {
+		return nil, err
+	}
+	return &latencyTrackingDelegationTarget{DelegationTarget: target, name: name, longRunningCheck: rootAPIServerConfig.LongRunningFunc}, nil
+}
+
+// latencyTrackingDelegationTarget wraps the handler of a virtual workspace apiserver. Requests of other
+// virtual workspaces pass through it when they are delegated down the chain, and are not tracked.
+type latencyTrackingDelegationTarget struct {
+	genericapiserver.DelegationTarget
+	name             string
+	longRunningCheck genericapirequest.LongRunningRequestCheck
+}
+
+func (t *latencyTrackingDelegationTarget) UnprotectedHandler() http.Handler {
+	delegate := t.DelegationTarget.UnprotectedHandler()
+	if delegate == nil {
+		return nil
+	}
+	tracked := apiexportmetrics.WithLatencyTracking(delegate, t.longRunningCheck)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if name, found := virtualcontext.VirtualWorkspaceNameFrom(req.Context()); found && name == t.name {
+			tracked.ServeHTTP(w, req)
+			return
+		}
+		delegate.ServeHTTP(w, req)
+	})
+}
+
 func digestUrl(urlPath, rootPathPrefix string) (
 	cluster genericapirequest.Cluster,
 	domainKey dynamiccontext.APIDomainKey,
@@ -257,11 +344,20 @@ func digestUrl(urlPath, rootPathPrefix string) (
 	return cluster, dynamiccontext.APIDomainKey(key), strings.TrimSuffix(urlPath, realPath), true
 }
 
-func newAuthorizer(kubeClusterClient, deepSARClient kcpkubernetesclientset.ClusterInterface, cachedKcpInformers kcpinformers.SharedInformerFactory) authorizer.Authorizer {
-	maximalPermissionAuth := virtualapiexportauth.NewMaximalPermissionAuthorizer(deepSARClient, cachedKcpInformers.Apis().V1alpha1().APIExports())
+func newAuthorizer(kubeClusterClient, deepSARClient kcpkubernetesclientset.ClusterInterface, cachedKcpInformers kcpinformers.SharedInformerFactory, namespaceInformer kcpcorev1informers.NamespaceClusterInformer) authorizer.Authorizer {
+	maximalPermissionAuth := virtualapiexportauth.NewMaximalPermissionAuthorizer(deepSARClient, cachedKcpInformers.Apis().V1alpha1().APIExports(), cachedKcpInformers.Apis().V1alpha1().APIResourceSchemas())
 	maximalPermissionAuth = authorization.NewDecorator("virtual.apiexport.maxpermissionpolicy.authorization.kcp.io", maximalPermissionAuth).AddAuditLogging().AddAnonymization().AddReasonAnnotation()
 
-	apiExportsContentAuth := virtualapiexportauth.NewAPIExportsContentAuthorizer(maximalPermissionAuth, kubeClusterClient)
+	claimedNamespacesAuth := virtualapiexportauth.NewClaimedNamespacesAuthorizer(maximalPermissionAuth, cachedKcpInformers.Apis().V1alpha1().APIExports(), cachedKcpInformers.Apis().V1alpha1().APIResourceSchemas())
+	claimedNamespacesAuth = authorization.NewDecorator("virtual.apiexport.claimednamespaces.authorization.kcp.io", claimedNamespacesAuth).AddAuditLogging().AddAnonymization().AddReasonAnnotation()
+
+	claimedNamespaceSelectorAuth := virtualapiexportauth.NewClaimedNamespaceSelectorAuthorizer(claimedNamespacesAuth, cachedKcpInformers.Apis().V1alpha1().APIExports(), cachedKcpInformers.Apis().V1alpha1().APIResourceSchemas(), namespaceInformer)
+	claimedNamespaceSelectorAuth = authorization.NewDecorator("virtual.apiexport.claimednamespaceselector.authorization.kcp.io", claimedNamespaceSelectorAuth).AddAuditLogging().AddAnonymization().AddReasonAnnotation()
+
+	claimedVerbsAuth := virtualapiexportauth.NewClaimedVerbsAuthorizer(claimedNamespaceSelectorAuth, cachedKcpInformers.Apis().V1alpha1().APIExports(), cachedKcpInformers.Apis().V1alpha1().APIResourceSchemas())
+	claimedVerbsAuth = authorization.NewDecorator("virtual.apiexport.claimedverbs.authorization.kcp.io", claimedVerbsAuth).AddAuditLogging().AddAnonymization().AddReasonAnnotation()
+
+	apiExportsContentAuth := virtualapiexportauth.NewAPIExportsContentAuthorizer(claimedVerbsAuth, kubeClusterClient)
 	apiExportsContentAuth = authorization.NewDecorator("virtual.apiexport.content.authorization.kcp.io", apiExportsContentAuth).AddAuditLogging().AddAnonymization()
 
 	return apiExportsContentAuth
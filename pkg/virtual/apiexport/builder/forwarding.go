@@ -19,25 +19,44 @@ package builder
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
+	kcpmetadata "github.com/kcp-dev/client-go/metadata"
+	kcpmetadatainformer "github.com/kcp-dev/client-go/metadata/metadatainformer"
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
 	"k8s.io/apiextensions-apiserver/pkg/registry/customresource"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/validation/path"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
 	"k8s.io/kube-openapi/pkg/validation/validate"
 
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1/permissionclaims"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/apiserver"
+	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
 	registry "github.com/kcp-dev/kcp/pkg/virtual/framework/forwardingregistry"
 )
 
+// supportedFieldSelectors are the field labels that list and watch requests through the virtual
+// workspace can select on. They are supported by the shards for all resources, including those
+// served by CustomResourceDefinitions.
+var supportedFieldSelectors = []string{metav1.ObjectNameField, "metadata.namespace"}
+
 func provideAPIExportFilteredRestStorage(ctx context.Context, dynamicClusterClientFunc registry.DynamicClusterClientFunc, clusterName logicalcluster.Name, exportName string) (apiserver.RestProviderFunc, error) {
 	labelSelector := map[string]string{
 		apisv1alpha1.InternalAPIBindingExportLabelKey: permissionclaims.ToAPIBindingExportLabelValue(clusterName, exportName),
@@ -154,3 +173,367 @@ func provideDelegatingRestStorage(ctx context.Context, dynamicClusterClientFunc
 		}, subresourceStorages
 	}
 }
+
+// claimAuditAnnotations returns the audit annotations for objects of a claimed resource that
+// are created or updated through the virtual workspace, if the permission claim of the
+// requested APIExport asks for them. identityHash is the identity of the claimed resource served
+// by the storage.
+func claimAuditAnnotations(getAPIExport func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error), identityHash string) func(ctx context.Context, resource schema.GroupResource) (map[string]string, error) {
+	return func(ctx context.Context, resource schema.GroupResource) (map[string]string, error) {
+		clusterName, exportName, apiExport, err := apiExportFromContext(ctx, getAPIExport)
+		if err != nil {
+			return nil, err
+		}
+
+		claim := exportPermissionClaim(apiExport, resource, identityHash)
+		if claim == nil || !claim.AuditAnnotations {
+			return nil, nil
+		}
+
+		annotations := map[string]string{
+			apisv1alpha1.AnnotationClaimAuditAPIExportKey: clusterName.Path().Join(exportName).String(),
+		}
+		if user, ok := genericapirequest.UserFrom(ctx); ok {
+			annotations[apisv1alpha1.AnnotationClaimAuditUserKey] = user.GetName()
+		}
+		return annotations, nil
+	}
+}
+
+// claimIdentityAnnotation returns the identity annotation for objects of a claimed resource that
+// are created or updated through the virtual workspace, if the permission claim of the requested
+// APIExport asks for it. identityHash is the identity of the claimed resource served by the storage.
+func claimIdentityAnnotation(getAPIExport func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error), identityHash string) func(ctx context.Context, resource schema.GroupResource) (map[string]string, error) {
+	return func(ctx context.Context, resource schema.GroupResource) (map[string]string, error) {
+		_, _, apiExport, err := apiExportFromContext(ctx, getAPIExport)
+		if err != nil {
+			return nil, err
+		}
+
+		claim := exportPermissionClaim(apiExport, resource, identityHash)
+		if claim == nil || !claim.IdentityAnnotation {
+			return nil, nil
+		}
+		if apiExport.Status.IdentityHash == "" {
+			return nil, kerrors.NewServiceUnavailable(fmt.Sprintf("identity of APIExport %s|%s is not known yet", logicalcluster.From(apiExport), apiExport.Name))
+		}
+		return map[string]string{
+			apisv1alpha1.AnnotationClaimAPIExportIdentityKey: apiExport.Status.IdentityHash,
+		}, nil
+	}
+}
+
+// exportPermissionClaim returns the permission claim of the APIExport for the given resource with
+// the given identity, or nil if there is none. Resources of different APIExports can share a name,
+// hence claims are told apart by the identity of the claimed resource.
+func exportPermissionClaim(apiExport *apisv1alpha1.APIExport, resource schema.GroupResource, identityHash string) *apisv1alpha1.PermissionClaim {
+	for i := range apiExport.Spec.PermissionClaims {
+		claim := &apiExport.Spec.PermissionClaims[i]
+		if claim.Group == resource.Group && claim.Resource == resource.Resource && claim.IdentityHash == identityHash {
+			return claim
+		}
+	}
+	return nil
+}
+
+// claimObjectLimit returns the maximal number of objects of a claimed resource in a consumer
+// workspace, beyond which the service provider cannot create objects through the virtual workspace.
+// The limit is taken from the permission claim for the resource with the given identity that the
+// consumer accepted in its APIBinding to the requested APIExport, i.e. a limit the provider adds
+// to the APIExport only applies once the consumer accepted it.
+func claimObjectLimit(listAPIBindings func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error), identityHash string) func(ctx context.Context, resource schema.GroupResource) (*int64, error) {
+	return func(ctx context.Context, resource schema.GroupResource) (*int64, error) {
+		apiDomainKey := dynamiccontext.APIDomainKeyFrom(ctx)
+		parts := strings.SplitN(string(apiDomainKey), "/", 2)
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("invalid API domain key %q", apiDomainKey)
+		}
+		exportClusterName, exportName := parts[0], parts[1]
+
+		cluster, err := genericapirequest.ValidClusterFrom(ctx)
+		if err != nil {
+			return nil, err
+		}
+		bindings, err := listAPIBindings(cluster.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, binding := range bindings {
+			if binding.Spec.Reference.Export == nil || binding.Spec.Reference.Export.Name != exportName || binding.Status.APIExportClusterName != exportClusterName {
+				continue
+			}
+			for _, claim := range binding.Spec.PermissionClaims {
+				if claim.State != apisv1alpha1.ClaimAccepted {
+					continue
+				}
+				if claim.Group != resource.Group || claim.Resource != resource.Resource || claim.IdentityHash != identityHash {
+					continue
+				}
+				return claim.MaxObjects, nil
+			}
+		}
+
+		return nil, nil
+	}
+}
+
+// claimedObjectCounter counts the objects of a claimed resource labeled for the permission claim of
+// an APIExport per logical cluster, using a wildcard metadata informer. The informer is only started
+// when objects are counted first, i.e. for claims with an object limit.
+type claimedObjectCounter struct {
+	ctx       context.Context
+	informer  cache.SharedIndexInformer
+	startOnce sync.Once
+}
+
+// newClaimedObjectCounter returns a counter for the objects of the given resource matching the label
+// requirements of the claim. The informer stops when ctx is done.
+func newClaimedObjectCounter(ctx context.Context, metadataClient kcpmetadata.ClusterInterface, resource schema.GroupVersionResource, labelRequirements labels.Requirements) *claimedObjectCounter {
+	selector := labels.NewSelector().Add(labelRequirements...).String()
+	informer := kcpmetadatainformer.NewFilteredMetadataInformer(
+		metadataClient,
+		resource,
+		0,
+		cache.Indexers{kcpcache.ClusterIndexName: kcpcache.ClusterIndexFunc},
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = selector
+		},
+	)
+	return &claimedObjectCounter{
+		ctx:      ctx,
+		informer: informer.Informer(),
+	}
+}
+
+// count returns the number of claimed objects in the logical cluster of the request.
+func (c *claimedObjectCounter) count(ctx context.Context, resource schema.GroupResource) (int, error) {
+	c.startOnce.Do(func() {
+		go c.informer.Run(c.ctx.Done())
+	})
+	if !cache.WaitForCacheSync(ctx.Done(), c.informer.HasSynced) {
+		return 0, kerrors.NewServiceUnavailable(fmt.Sprintf("objects of %s cannot be counted", resource))
+	}
+
+	cluster, err := genericapirequest.ValidClusterFrom(ctx)
+	if err != nil {
+		return 0, err
+	}
+	objs, err := c.informer.GetIndexer().ByIndex(kcpcache.ClusterIndexName, kcpcache.ClusterIndexKey(cluster.Name))
+	if err != nil {
+		return 0, err
+	}
+	return len(objs), nil
+}
+
+// claimResourceSelectorFilter returns a filter for objects of a claimed resource that only lets
+// through objects matching the resource selectors of the permission claim of the requested APIExport.
+// identityHash is the identity of the claimed resource served by the storage. Claims of all objects
+// of a resource are not filtered.
+func claimResourceSelectorFilter(getAPIExport func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error), identityHash string) func(ctx context.Context, resource schema.GroupResource) (func(obj metav1.Object) bool, error) {
+	return func(ctx context.Context, resource schema.GroupResource) (func(obj metav1.Object) bool, error) {
+		_, _, apiExport, err := apiExportFromContext(ctx, getAPIExport)
+		if err != nil {
+			return nil, err
+		}
+
+		claim := exportPermissionClaim(apiExport, resource, identityHash)
+		if claim == nil || claim.All {
+			return nil, nil
+		}
+
+		selectors := claim.ResourceSelector
+		return func(obj metav1.Object) bool {
+			for _, s := range selectors {
+				if (s.Name == "" || s.Name == obj.GetName()) && (s.Namespace == "" || s.Namespace == obj.GetNamespace()) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+}
+
+// claimNamespaceSelectorFilter returns a filter for objects of a claimed resource that only lets
+// through objects in namespaces matching the namespace selector of the permission claim of the
+// requested APIExport. identityHash is the identity of the claimed resource served by the storage.
+// Cluster-scoped objects and claims without namespace selector are not filtered. Watches must be
+// expired through claimNamespaceSelectorWatchExpiration when namespace labels change.
+func claimNamespaceSelectorFilter(
+	getAPIExport func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error),
+	getNamespace func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error),
+	identityHash string,
+) func(ctx context.Context, resource schema.GroupResource) (func(obj metav1.Object) bool, error) {
+	return func(ctx context.Context, resource schema.GroupResource) (func(obj metav1.Object) bool, error) {
+		selector, err := claimNamespaceSelector(ctx, getAPIExport, resource, identityHash)
+		if err != nil || selector == nil {
+			return nil, err
+		}
+
+		return func(obj metav1.Object) bool {
+			if obj.GetNamespace() == "" {
+				return true
+			}
+
+			// a missing namespace has no labels.
+			var nsLabels map[string]string
+			ns, err := getNamespace(logicalcluster.From(obj), obj.GetNamespace())
+			if err != nil && !kerrors.IsNotFound(err) {
+				// fail closed
+				klog.FromContext(ctx).Error(err, "error getting namespace", "cluster", logicalcluster.From(obj), "namespace", obj.GetNamespace())
+				return false
+			} else if err == nil {
+				nsLabels = ns.Labels
+			}
+			return selector.Matches(labels.Set(nsLabels))
+		}, nil
+	}
+}
+
+// claimNamespaceSelectorWatchExpiration returns the expiration of watches of a claimed resource
+// with a namespace selector. A watch expires when a namespace in the watched logical clusters starts
+// or stops matching the selector, as the objects in that namespace become visible or invisible through
+// claimNamespaceSelectorFilter without any event of their own.
+func claimNamespaceSelectorWatchExpiration(
+	getAPIExport func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error),
+	watches *namespaceSelectorWatches,
+	identityHash string,
+) func(ctx context.Context, resource schema.GroupResource) (<-chan struct{}, func(), error) {
+	return func(ctx context.Context, resource schema.GroupResource) (<-chan struct{}, func(), error) {
+		selector, err := claimNamespaceSelector(ctx, getAPIExport, resource, identityHash)
+		if err != nil || selector == nil {
+			return nil, nil, err
+		}
+
+		cluster, err := genericapirequest.ValidClusterFrom(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		var clusterName logicalcluster.Name
+		if !cluster.Wildcard {
+			clusterName = cluster.Name
+		}
+
+		expired, release := watches.add(clusterName, selector)
+		return expired, release, nil
+	}
+}
+
+// claimNamespaceSelector returns the namespace selector of the permission claim of the requested
+// APIExport for the given resource with the given identity, or nil if there is none.
+func claimNamespaceSelector(
+	ctx context.Context,
+	getAPIExport func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error),
+	resource schema.GroupResource,
+	identityHash string,
+) (labels.Selector, error) {
+	_, _, apiExport, err := apiExportFromContext(ctx, getAPIExport)
+	if err != nil {
+		return nil, err
+	}
+
+	claim := exportPermissionClaim(apiExport, resource, identityHash)
+	if claim == nil || claim.NamespaceSelector == nil {
+		return nil, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(claim.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace selector of permission claim %s: %w", claim.String(), err)
+	}
+	return selector, nil
+}
+
+// namespaceSelectorWatches tracks the watches of claimed resources with a namespace selector, and
+// expires those whose selector starts or stops matching a namespace when its labels change.
+type namespaceSelectorWatches struct {
+	lock    sync.Mutex
+	watches map[*namespaceSelectorWatch]struct{}
+}
+
+type namespaceSelectorWatch struct {
+	// clusterName is empty for watches across all logical clusters.
+	clusterName logicalcluster.Name
+	selector    labels.Selector
+	expired     chan struct{}
+}
+
+func newNamespaceSelectorWatches(namespaceInformer kcpcorev1informers.NamespaceClusterInformer) *namespaceSelectorWatches {
+	w := &namespaceSelectorWatches{
+		watches: map[*namespaceSelectorWatch]struct{}{},
+	}
+
+	// Namespaces created later have no objects yet, and objects of deleted namespaces are deleted
+	// before the namespace, hence only label updates are relevant.
+	namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNamespace, ok := oldObj.(*corev1.Namespace)
+			if !ok {
+				return
+			}
+			newNamespace, ok := newObj.(*corev1.Namespace)
+			if !ok {
+				return
+			}
+			w.namespaceUpdated(oldNamespace, newNamespace)
+		},
+	})
+
+	return w
+}
+
+// add registers a watch in the given logical cluster, or across all logical clusters if empty. The
+// returned channel is closed when the watch expires. release must be called when the watch ends.
+func (w *namespaceSelectorWatches) add(clusterName logicalcluster.Name, selector labels.Selector) (<-chan struct{}, func()) {
+	watch := &namespaceSelectorWatch{
+		clusterName: clusterName,
+		selector:    selector,
+		expired:     make(chan struct{}),
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.watches[watch] = struct{}{}
+
+	return watch.expired, func() {
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		delete(w.watches, watch)
+	}
+}
+
+func (w *namespaceSelectorWatches) namespaceUpdated(oldNamespace, newNamespace *corev1.Namespace) {
+	if equality.Semantic.DeepEqual(oldNamespace.Labels, newNamespace.Labels) {
+		return
+	}
+	clusterName := logicalcluster.From(newNamespace)
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for watch := range w.watches {
+		if watch.clusterName != "" && watch.clusterName != clusterName {
+			continue
+		}
+		if watch.selector.Matches(labels.Set(oldNamespace.Labels)) != watch.selector.Matches(labels.Set(newNamespace.Labels)) {
+			close(watch.expired)
+			delete(w.watches, watch)
+		}
+	}
+}
+
+// apiExportFromContext returns the APIExport the virtual workspace request is targeting.
+func apiExportFromContext(ctx context.Context, getAPIExport func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error)) (logicalcluster.Name, string, *apisv1alpha1.APIExport, error) {
+	apiDomainKey := dynamiccontext.APIDomainKeyFrom(ctx)
+	parts := strings.SplitN(string(apiDomainKey), "/", 2)
+	if len(parts) < 2 {
+		return "", "", nil, fmt.Errorf("invalid API domain key %q", apiDomainKey)
+	}
+	clusterName, exportName := logicalcluster.Name(parts[0]), parts[1]
+
+	apiExport, err := getAPIExport(clusterName, exportName)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return clusterName, exportName, apiExport, nil
+}
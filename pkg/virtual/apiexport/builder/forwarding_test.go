@@ -0,0 +1,593 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"testing"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpinformers "github.com/kcp-dev/apimachinery/v2/third_party/informers"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/authentication/user"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/forwardingregistry"
+)
+
+func TestClaimResourceSelectorFilterWatch(t *testing.T) {
+	configMaps := schema.GroupResource{Resource: "configmaps"}
+	apiExport := &apisv1alpha1.APIExport{
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"},
+					ResourceSelector: []apisv1alpha1.ResourceSelector{
+						{Namespace: "claimed"},
+						{Namespace: "default", Name: "claimed"},
+					},
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"},
+					All:           true,
+				},
+				{
+					GroupResource:    apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"},
+					IdentityHash:     "sheriffs-identity",
+					ResourceSelector: []apisv1alpha1.ResourceSelector{{Namespace: "claimed"}},
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"},
+					IdentityHash:  "other-sheriffs-identity",
+					All:           true,
+				},
+			},
+		},
+	}
+	getAPIExport := func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+		require.Equal(t, logicalcluster.Name("root-org-provider"), clusterName)
+		require.Equal(t, "export", name)
+		return apiExport, nil
+	}
+
+	newObject := func(namespace, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+
+	fakeWatcher := watch.NewFakeWithChanSize(10, false)
+	storage := &forwardingregistry.StoreFuncs{
+		WatcherFunc: func(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
+			return fakeWatcher, nil
+		},
+	}
+	forwardingregistry.WithObjectFilter(claimResourceSelectorFilter(getAPIExport, "")).Decorate(configMaps, storage)
+
+	ctx := dynamiccontext.WithAPIDomainKey(context.Background(), "root-org-provider/export")
+	w, err := storage.Watch(ctx, &internalversion.ListOptions{})
+	require.NoError(t, err)
+	defer w.Stop()
+
+	fakeWatcher.Add(newObject("default", "unclaimed"))
+	fakeWatcher.Add(newObject("claimed", "foo"))
+	fakeWatcher.Modify(newObject("other", "claimed"))
+	fakeWatcher.Modify(newObject("default", "claimed"))
+	fakeWatcher.Action(watch.Bookmark, newObject("", ""))
+	fakeWatcher.Delete(newObject("other", "bar"))
+	fakeWatcher.Delete(newObject("claimed", "foo"))
+	fakeWatcher.Stop()
+
+	type event struct {
+		eventType watch.EventType
+		key       string
+	}
+	var events []event
+	for e := range w.ResultChan() {
+		obj := e.Object.(*unstructured.Unstructured)
+		events = append(events, event{e.Type, obj.GetNamespace() + "/" + obj.GetName()})
+	}
+	require.Equal(t, []event{
+		{watch.Added, "claimed/foo"},
+		{watch.Modified, "default/claimed"},
+		{watch.Bookmark, "/"},
+		{watch.Deleted, "claimed/foo"},
+	}, events, "expected only events of claimed objects")
+
+	t.Log("Claims of all objects are not filtered")
+	filter, err := claimResourceSelectorFilter(getAPIExport, "")(ctx, schema.GroupResource{Resource: "secrets"})
+	require.NoError(t, err)
+	require.Nil(t, filter)
+
+	t.Log("Unclaimed resources are not filtered")
+	filter, err = claimResourceSelectorFilter(getAPIExport, "")(ctx, schema.GroupResource{Resource: "services"})
+	require.NoError(t, err)
+	require.Nil(t, filter)
+
+	t.Log("Claims of resources of two APIExports sharing a resource name are told apart by identity")
+	sheriffs := schema.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}
+	filter, err = claimResourceSelectorFilter(getAPIExport, "sheriffs-identity")(ctx, sheriffs)
+	require.NoError(t, err)
+	require.NotNil(t, filter)
+	require.True(t, filter(newObject("claimed", "foo")))
+	require.False(t, filter(newObject("default", "foo")))
+	filter, err = claimResourceSelectorFilter(getAPIExport, "other-sheriffs-identity")(ctx, sheriffs)
+	require.NoError(t, err)
+	require.Nil(t, filter)
+}
+
+func TestClaimNamespaceSelectorFilter(t *testing.T) {
+	apiExport := &apisv1alpha1.APIExport{
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{
+					GroupResource:     apisv1alpha1.GroupResource{Resource: "configmaps"},
+					All:               true,
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"},
+					All:           true,
+				},
+				{
+					GroupResource:     apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"},
+					IdentityHash:      "sheriffs-identity",
+					All:               true,
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"},
+					IdentityHash:  "other-sheriffs-identity",
+					All:           true,
+				},
+			},
+		},
+	}
+	getAPIExport := func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+		return apiExport, nil
+	}
+
+	namespaceLabels := map[logicalcluster.Name]map[string]map[string]string{
+		"root:one": {"tenant-a": {"tenant": "a"}, "tenant-b": {"tenant": "b"}},
+		"root:two": {"tenant-a": {"tenant": "b"}},
+	}
+	getNamespace := func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error) {
+		nsLabels, ok := namespaceLabels[clusterName][name]
+		if !ok {
+			return nil, apierrors.NewNotFound(corev1.Resource("namespaces"), name)
+		}
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nsLabels}}, nil
+	}
+
+	newObject := func(clusterName, namespace, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAnnotations(map[string]string{logicalcluster.AnnotationKey: clusterName})
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+
+	ctx := dynamiccontext.WithAPIDomainKey(context.Background(), "root-org-provider/export")
+	filter, err := claimNamespaceSelectorFilter(getAPIExport, getNamespace, "")(ctx, schema.GroupResource{Resource: "configmaps"})
+	require.NoError(t, err)
+	require.NotNil(t, filter)
+
+	require.True(t, filter(newObject("root:one", "tenant-a", "foo")))
+	require.True(t, filter(newObject("root:one", "tenant-a", "bar")))
+	require.False(t, filter(newObject("root:one", "tenant-b", "foo")))
+	require.False(t, filter(newObject("root:one", "missing", "foo")))
+	require.False(t, filter(newObject("root:two", "tenant-a", "foo")), "expected namespace labels to be looked up per logical cluster")
+	require.True(t, filter(newObject("root:two", "", "cluster-scoped")))
+
+	t.Log("Namespace labels are looked up per object")
+	namespaceLabels["root:one"]["tenant-b"] = map[string]string{"tenant": "a"}
+	require.True(t, filter(newObject("root:one", "tenant-b", "foo")))
+
+	t.Log("Claims without namespace selector are not filtered")
+	filter, err = claimNamespaceSelectorFilter(getAPIExport, getNamespace, "")(ctx, schema.GroupResource{Resource: "secrets"})
+	require.NoError(t, err)
+	require.Nil(t, filter)
+
+	t.Log("Claims of resources of two APIExports sharing a resource name are told apart by identity")
+	sheriffs := schema.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}
+	filter, err = claimNamespaceSelectorFilter(getAPIExport, getNamespace, "sheriffs-identity")(ctx, sheriffs)
+	require.NoError(t, err)
+	require.NotNil(t, filter)
+	require.False(t, filter(newObject("root:two", "tenant-a", "foo")))
+	filter, err = claimNamespaceSelectorFilter(getAPIExport, getNamespace, "other-sheriffs-identity")(ctx, sheriffs)
+	require.NoError(t, err)
+	require.Nil(t, filter)
+}
+
+func TestClaimNamespaceSelectorWatchExpiration(t *testing.T) {
+	apiExport := &apisv1alpha1.APIExport{
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{
+					GroupResource:     apisv1alpha1.GroupResource{Resource: "configmaps"},
+					All:               true,
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"},
+					All:           true,
+				},
+				{
+					GroupResource:     apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"},
+					IdentityHash:      "sheriffs-identity",
+					All:               true,
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+				},
+			},
+		},
+	}
+	getAPIExport := func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+		return apiExport, nil
+	}
+	watches := &namespaceSelectorWatches{watches: map[*namespaceSelectorWatch]struct{}{}}
+	expiration := claimNamespaceSelectorWatchExpiration(getAPIExport, watches, "")
+
+	newNamespace := func(clusterName, name string, nsLabels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      nsLabels,
+			Annotations: map[string]string{logicalcluster.AnnotationKey: clusterName},
+		}}
+	}
+	isExpired := func(expired <-chan struct{}) bool {
+		select {
+		case <-expired:
+			return true
+		default:
+			return false
+		}
+	}
+
+	ctx := dynamiccontext.WithAPIDomainKey(context.Background(), "root-org-provider/export")
+	oneExpired, oneRelease, err := expiration(genericapirequest.WithCluster(ctx, genericapirequest.Cluster{Name: "root:one"}), schema.GroupResource{Resource: "configmaps"})
+	require.NoError(t, err)
+	defer oneRelease()
+	wildcardExpired, wildcardRelease, err := expiration(genericapirequest.WithCluster(ctx, genericapirequest.Cluster{Wildcard: true}), schema.GroupResource{Resource: "configmaps"})
+	require.NoError(t, err)
+	defer wildcardRelease()
+	require.Len(t, watches.watches, 2)
+
+	t.Log("Label changes not changing the selection don't expire watches")
+	watches.namespaceUpdated(newNamespace("root:one", "foo", map[string]string{"tenant": "b"}), newNamespace("root:one", "foo", map[string]string{"tenant": "c"}))
+	require.False(t, isExpired(oneExpired))
+	require.False(t, isExpired(wildcardExpired))
+
+	t.Log("Namespaces of other logical clusters only expire wildcard watches")
+	watches.namespaceUpdated(newNamespace("root:two", "foo", map[string]string{"tenant": "a"}), newNamespace("root:two", "foo", nil))
+	require.False(t, isExpired(oneExpired))
+	require.True(t, isExpired(wildcardExpired))
+
+	t.Log("A namespace starting to match expires the watch")
+	watches.namespaceUpdated(newNamespace("root:one", "foo", nil), newNamespace("root:one", "foo", map[string]string{"tenant": "a"}))
+	require.True(t, isExpired(oneExpired))
+	require.Empty(t, watches.watches)
+
+	t.Log("Released watches are removed")
+	_, release, err := expiration(genericapirequest.WithCluster(ctx, genericapirequest.Cluster{Name: "root:one"}), schema.GroupResource{Resource: "configmaps"})
+	require.NoError(t, err)
+	require.Len(t, watches.watches, 1)
+	release()
+	require.Empty(t, watches.watches)
+
+	t.Log("Claims without namespace selector don't expire")
+	expired, _, err := expiration(genericapirequest.WithCluster(ctx, genericapirequest.Cluster{Name: "root:one"}), schema.GroupResource{Resource: "secrets"})
+	require.NoError(t, err)
+	require.Nil(t, expired)
+
+	t.Log("Claims of resources with another identity don't expire")
+	sheriffs := schema.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}
+	expired, _, err = claimNamespaceSelectorWatchExpiration(getAPIExport, watches, "other-sheriffs-identity")(genericapirequest.WithCluster(ctx, genericapirequest.Cluster{Name: "root:one"}), sheriffs)
+	require.NoError(t, err)
+	require.Nil(t, expired)
+	expired, release, err = claimNamespaceSelectorWatchExpiration(getAPIExport, watches, "sheriffs-identity")(genericapirequest.WithCluster(ctx, genericapirequest.Cluster{Name: "root:one"}), sheriffs)
+	require.NoError(t, err)
+	require.NotNil(t, expired)
+	release()
+}
+
+func TestClaimAuditAnnotations(t *testing.T) {
+	apiExport := &apisv1alpha1.APIExport{
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{
+					GroupResource:    apisv1alpha1.GroupResource{Resource: "configmaps"},
+					All:              true,
+					AuditAnnotations: true,
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"},
+					All:           true,
+				},
+				{
+					GroupResource:    apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"},
+					IdentityHash:     "sheriffs-identity",
+					All:              true,
+					AuditAnnotations: true,
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"},
+					IdentityHash:  "other-sheriffs-identity",
+					All:           true,
+				},
+			},
+		},
+	}
+	getAPIExport := func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+		require.Equal(t, logicalcluster.Name("root-org-provider"), clusterName)
+		require.Equal(t, "export", name)
+		return apiExport, nil
+	}
+
+	ctx := dynamiccontext.WithAPIDomainKey(context.Background(), "root-org-provider/export")
+	ctx = genericapirequest.WithUser(ctx, &user.DefaultInfo{Name: "provider"})
+	expected := map[string]string{
+		apisv1alpha1.AnnotationClaimAuditAPIExportKey: "root-org-provider:export",
+		apisv1alpha1.AnnotationClaimAuditUserKey:      "provider",
+	}
+
+	annotations, err := claimAuditAnnotations(getAPIExport, "")(ctx, schema.GroupResource{Resource: "configmaps"})
+	require.NoError(t, err)
+	require.Equal(t, expected, annotations)
+
+	t.Log("Claims without auditAnnotations are not annotated")
+	annotations, err = claimAuditAnnotations(getAPIExport, "")(ctx, schema.GroupResource{Resource: "secrets"})
+	require.NoError(t, err)
+	require.Nil(t, annotations)
+
+	t.Log("Claims of resources of two APIExports sharing a resource name are told apart by identity")
+	sheriffs := schema.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}
+	annotations, err = claimAuditAnnotations(getAPIExport, "sheriffs-identity")(ctx, sheriffs)
+	require.NoError(t, err)
+	require.Equal(t, expected, annotations)
+	annotations, err = claimAuditAnnotations(getAPIExport, "other-sheriffs-identity")(ctx, sheriffs)
+	require.NoError(t, err)
+	require.Nil(t, annotations)
+	annotations, err = claimAuditAnnotations(getAPIExport, "unclaimed-identity")(ctx, sheriffs)
+	require.NoError(t, err)
+	require.Nil(t, annotations)
+}
+
+func TestClaimIdentityAnnotation(t *testing.T) {
+	apiExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "export",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "root-org-provider"},
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{
+					GroupResource:      apisv1alpha1.GroupResource{Resource: "configmaps"},
+					All:                true,
+					IdentityAnnotation: true,
+				},
+				{
+					GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"},
+					All:           true,
+				},
+				{
+					GroupResource:      apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"},
+					IdentityHash:       "sheriffs-identity",
+					All:                true,
+					IdentityAnnotation: true,
+				},
+			},
+		},
+		Status: apisv1alpha1.APIExportStatus{IdentityHash: "identity"},
+	}
+	getAPIExport := func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+		require.Equal(t, logicalcluster.Name("root-org-provider"), clusterName)
+		require.Equal(t, "export", name)
+		return apiExport, nil
+	}
+
+	var created *unstructured.Unstructured
+	storage := &forwardingregistry.StoreFuncs{
+		CreaterFunc: func(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+			created = obj.(*unstructured.Unstructured)
+			return obj, nil
+		},
+	}
+	forwardingregistry.WithAnnotations(claimIdentityAnnotation(getAPIExport, "")).Decorate(schema.GroupResource{Resource: "configmaps"}, storage)
+
+	ctx := dynamiccontext.WithAPIDomainKey(context.Background(), "root-org-provider/export")
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName("claimed")
+	_, err := storage.Create(ctx, obj, nil, &metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{apisv1alpha1.AnnotationClaimAPIExportIdentityKey: "identity"}, created.GetAnnotations())
+
+	t.Log("Claims without identityAnnotation are not annotated")
+	annotations, err := claimIdentityAnnotation(getAPIExport, "")(ctx, schema.GroupResource{Resource: "secrets"})
+	require.NoError(t, err)
+	require.Nil(t, annotations)
+
+	t.Log("Claims of resources with another identity are not matched")
+	sheriffs := schema.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}
+	annotations, err = claimIdentityAnnotation(getAPIExport, "other-identity")(ctx, sheriffs)
+	require.NoError(t, err)
+	require.Nil(t, annotations)
+	annotations, err = claimIdentityAnnotation(getAPIExport, "sheriffs-identity")(ctx, sheriffs)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{apisv1alpha1.AnnotationClaimAPIExportIdentityKey: "identity"}, annotations)
+
+	t.Log("Writes are rejected until the identity of the APIExport is known")
+	apiExport.Status.IdentityHash = ""
+	_, err = claimIdentityAnnotation(getAPIExport, "")(ctx, schema.GroupResource{Resource: "configmaps"})
+	require.True(t, apierrors.IsServiceUnavailable(err), "expected a service unavailable error, got %v", err)
+}
+
+func TestClaimObjectLimit(t *testing.T) {
+	accepted := func(claim apisv1alpha1.PermissionClaim) apisv1alpha1.AcceptablePermissionClaim {
+		return apisv1alpha1.AcceptablePermissionClaim{PermissionClaim: claim, State: apisv1alpha1.ClaimAccepted}
+	}
+	bindings := []*apisv1alpha1.APIBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			Spec: apisv1alpha1.APIBindingSpec{
+				Reference: apisv1alpha1.BindingReference{Export: &apisv1alpha1.ExportBindingReference{Path: "root:org:other", Name: "export"}},
+				PermissionClaims: []apisv1alpha1.AcceptablePermissionClaim{
+					accepted(apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"}, All: true, MaxObjects: pointer.Int64(1)}),
+				},
+			},
+			Status: apisv1alpha1.APIBindingStatus{APIExportClusterName: "root-org-other"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "binding"},
+			Spec: apisv1alpha1.APIBindingSpec{
+				Reference: apisv1alpha1.BindingReference{Export: &apisv1alpha1.ExportBindingReference{Path: "root:org:provider", Name: "export"}},
+				PermissionClaims: []apisv1alpha1.AcceptablePermissionClaim{
+					accepted(apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true, MaxObjects: pointer.Int64(2)}),
+					accepted(apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"}, All: true}),
+					{
+						PermissionClaim: apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "services"}, All: true, MaxObjects: pointer.Int64(1)},
+						State:           apisv1alpha1.ClaimRejected,
+					},
+					accepted(apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}, IdentityHash: "sheriffs-identity", All: true, MaxObjects: pointer.Int64(1)}),
+				},
+			},
+			Status: apisv1alpha1.APIBindingStatus{APIExportClusterName: "root-org-provider"},
+		},
+	}
+	listAPIBindings := func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error) {
+		require.Equal(t, logicalcluster.Name("root-org-consumer"), clusterName)
+		return bindings, nil
+	}
+
+	newStorage := func(resource schema.GroupResource, identityHash string) *forwardingregistry.StoreFuncs {
+		var objects []unstructured.Unstructured
+		storage := &forwardingregistry.StoreFuncs{
+			CreaterFunc: func(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+				objects = append(objects, *obj.(*unstructured.Unstructured))
+				return obj, nil
+			},
+		}
+		count := func(ctx context.Context, resource schema.GroupResource) (int, error) {
+			return len(objects), nil
+		}
+		forwardingregistry.WithObjectLimit(claimObjectLimit(listAPIBindings, identityHash), count).Decorate(resource, storage)
+		return storage
+	}
+	newObject := func(kind, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind(kind)
+		obj.SetNamespace("default")
+		obj.SetName(name)
+		return obj
+	}
+
+	ctx := dynamiccontext.WithAPIDomainKey(context.Background(), "root-org-provider/export")
+	ctx = genericapirequest.WithCluster(ctx, genericapirequest.Cluster{Name: "root-org-consumer"})
+	ctx = genericapirequest.WithNamespace(ctx, "default")
+
+	t.Log("Create configmaps up to the limit of the accepted claim")
+	configMaps := newStorage(schema.GroupResource{Resource: "configmaps"}, "")
+	for _, name := range []string{"one", "two"} {
+		_, err := configMaps.Create(ctx, newObject("ConfigMap", name), nil, &metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	t.Log("Creating a configmap beyond the limit is rejected")
+	_, err := configMaps.Create(ctx, newObject("ConfigMap", "three"), nil, &metav1.CreateOptions{})
+	require.True(t, apierrors.IsForbidden(err), "expected a forbidden error, got %v", err)
+	require.EqualError(t, err, `configmaps "three" is forbidden: exceeded quota: 2 objects exist, limited to 2`)
+
+	t.Log("Claims without limit do not restrict creation, independently of bindings to other APIExports")
+	secrets := newStorage(schema.GroupResource{Resource: "secrets"}, "")
+	for _, name := range []string{"one", "two", "three"} {
+		_, err := secrets.Create(ctx, newObject("Secret", name), nil, &metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	t.Log("The limit of rejected claims does not apply")
+	services := newStorage(schema.GroupResource{Resource: "services"}, "")
+	for _, name := range []string{"one", "two"} {
+		_, err := services.Create(ctx, newObject("Service", name), nil, &metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	t.Log("The limit of a claim only applies to the resource with the identity of the claim")
+	otherSheriffs := newStorage(schema.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}, "other-identity")
+	for _, name := range []string{"one", "two"} {
+		_, err := otherSheriffs.Create(ctx, newObject("Sheriff", name), nil, &metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	sheriffs := newStorage(schema.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}, "sheriffs-identity")
+	_, err = sheriffs.Create(ctx, newObject("Sheriff", "one"), nil, &metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = sheriffs.Create(ctx, newObject("Sheriff", "two"), nil, &metav1.CreateOptions{})
+	require.True(t, apierrors.IsForbidden(err), "expected a forbidden error, got %v", err)
+}
+
+func TestClaimedObjectCounter(t *testing.T) {
+	newObject := func(cluster, namespace, name string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{logicalcluster.AnnotationKey: cluster},
+			Namespace:   namespace,
+			Name:        name,
+		}}
+	}
+	informer := kcpinformers.NewSharedIndexInformer(&cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return &metav1.PartialObjectMetadataList{Items: []metav1.PartialObjectMetadata{
+				*newObject("root:consumer", "default", "one"),
+				*newObject("root:consumer", "other", "two"),
+				*newObject("root:other", "default", "one"),
+			}}, nil
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return watch.NewFake(), nil
+		},
+	}, &metav1.PartialObjectMetadata{}, 0, cache.Indexers{kcpcache.ClusterIndexName: kcpcache.ClusterIndexFunc})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	counter := &claimedObjectCounter{ctx: ctx, informer: informer}
+
+	configMaps := schema.GroupResource{Resource: "configmaps"}
+	for cluster, expected := range map[string]int{"root:consumer": 2, "root:other": 1, "root:empty": 0} {
+		requestCtx := genericapirequest.WithCluster(context.Background(), genericapirequest.Cluster{Name: logicalcluster.Name(cluster)})
+		count, err := counter.count(requestCtx, configMaps)
+		require.NoError(t, err)
+		require.Equal(t, expected, count, "unexpected count in %s", cluster)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+// WithLatencyTracking tracks the number of seconds it took the wrapped handler
+// to complete, by verb and resource of the request. Like for the request metrics
+// of the apiserver, long-running requests, e.g. watches, are not tracked.
+func WithLatencyTracking(delegate http.Handler, longRunningCheck genericapirequest.LongRunningRequestCheck) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		info, ok := genericapirequest.RequestInfoFrom(req.Context())
+		if ok && longRunningCheck != nil && longRunningCheck(req, info) {
+			delegate.ServeHTTP(w, req)
+			return
+		}
+
+		start := time.Now()
+		delegate.ServeHTTP(w, req)
+
+		var verb, resource string
+		if ok {
+			verb, resource = info.Verb, info.Resource
+		}
+		requestLatencies.WithLabelValues(verb, resource).Observe(time.Since(start).Seconds())
+	})
+}
+
+var (
+	requestLatencies = compbasemetrics.NewHistogramVec(
+		&compbasemetrics.HistogramOpts{
+			Name: "kcp_apiexport_vw_request_duration_seconds",
+			Help: "Response latency distribution in seconds of the APIExport virtual workspace for each verb and resource.",
+			Buckets: []float64{0.005, 0.025, 0.05, 0.1, 0.2, 0.4, 0.6, 0.8, 1.0, 1.25, 1.5, 2, 3,
+				4, 5, 6, 8, 10, 15, 20, 30, 45, 60},
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"verb", "resource"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// Register metrics.
+func Register() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(requestLatencies)
+	})
+}
+
+func init() {
+	Register()
+}
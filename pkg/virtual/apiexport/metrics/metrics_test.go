@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	genericfilters "k8s.io/apiserver/pkg/server/filters"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestWithLatencyTracking(t *testing.T) {
+	var served bool
+	handler := WithLatencyTracking(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		served = true
+		w.WriteHeader(http.StatusOK)
+	}), genericfilters.BasicLongRunningRequestCheck(sets.NewString("watch"), sets.NewString()))
+
+	request := func(verb string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/clusters/root/api/v1/configmaps", nil)
+		return req.WithContext(genericapirequest.WithRequestInfo(req.Context(), &genericapirequest.RequestInfo{
+			IsResourceRequest: true,
+			Verb:              verb,
+			APIVersion:        "v1",
+			Resource:          "configmaps",
+		}))
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), request("list"))
+	require.True(t, served, "request should have been passed to the delegate")
+
+	vec, err := testutil.GetHistogramVecFromGatherer(legacyregistry.DefaultGatherer, "kcp_apiexport_vw_request_duration_seconds", map[string]string{
+		"verb":     "list",
+		"resource": "configmaps",
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), vec.GetAggregatedSampleCount())
+
+	t.Log("Long-running requests are served, but not tracked")
+	served = false
+	handler.ServeHTTP(httptest.NewRecorder(), request("watch"))
+	require.True(t, served, "request should have been passed to the delegate")
+
+	vec, err = testutil.GetHistogramVecFromGatherer(legacyregistry.DefaultGatherer, "kcp_apiexport_vw_request_duration_seconds", map[string]string{
+		"verb": "watch",
+	})
+	require.NoError(t, err)
+	require.Zero(t, vec.GetAggregatedSampleCount(), "expected no watch latencies to be tracked")
+}
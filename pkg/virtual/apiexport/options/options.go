@@ -19,6 +19,7 @@ package options
 import (
 	"path"
 
+	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/spf13/pflag"
 
@@ -31,7 +32,11 @@ import (
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
 )
 
-type APIExport struct{}
+type APIExport struct {
+	// ShardName is the name of the shard the virtual workspace forwards to. If set, watch
+	// bookmarks carry composite resourceVersions across shards.
+	ShardName string
+}
 
 func New() *APIExport {
 	return &APIExport{}
@@ -41,6 +46,8 @@ func (o *APIExport) AddFlags(flags *pflag.FlagSet, prefix string) {
 	if o == nil {
 		return
 	}
+
+	flags.StringVar(&o.ShardName, prefix+"shard-name", o.ShardName, "The name of the shard the APIExport virtual workspace forwards to. If set, watch bookmarks carry composite resourceVersions across shards.")
 }
 
 func (o *APIExport) Validate(flagPrefix string) []error {
@@ -55,7 +62,8 @@ func (o *APIExport) Validate(flagPrefix string) []error {
 func (o *APIExport) NewVirtualWorkspaces(
 	rootPathPrefix string,
 	config *rest.Config,
-	cachedKcpInformers kcpinformers.SharedInformerFactory,
+	wildcardKubeInformers kcpkubernetesinformers.SharedInformerFactory,
+	wildcardKcpInformers, cachedKcpInformers kcpinformers.SharedInformerFactory,
 ) (workspaces []rootapiserver.NamedVirtualWorkspace, err error) {
 	config = rest.AddUserAgent(rest.CopyConfig(config), "apiexport-virtual-workspace")
 	kcpClusterClient, err := kcpclientset.NewForConfig(config)
@@ -71,5 +79,5 @@ func (o *APIExport) NewVirtualWorkspaces(
 		return nil, err
 	}
 
-	return builder.BuildVirtualWorkspace(path.Join(rootPathPrefix, builder.VirtualWorkspaceName), config, kubeClusterClient, deepSARClient, kcpClusterClient, cachedKcpInformers)
+	return builder.BuildVirtualWorkspace(path.Join(rootPathPrefix, builder.VirtualWorkspaceName), config, kubeClusterClient, deepSARClient, kcpClusterClient, cachedKcpInformers, wildcardKubeInformers.Core().V1().Namespaces(), wildcardKcpInformers.Apis().V1alpha1().APIBindings(), o.ShardName)
 }
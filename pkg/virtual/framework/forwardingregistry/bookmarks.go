@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forwardingregistry
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/utils/clock"
+)
+
+// bookmarkInterval is the interval in which bookmarks are sent on watches allowing them.
+const bookmarkInterval = time.Minute
+
+// bookmarkWatcher forwards the events of a delegate watch and periodically sends a bookmark
+// with the resourceVersion of the last event, such that a client can resume from there even
+// if it has not seen an event for a while, e.g. because events are filtered by a storage wrapper.
+//
+// The resourceVersions of the bookmarks are those of the shard the storage forwards to.
+// WithShardResourceVersion turns them into composite resourceVersions across shards.
+type bookmarkWatcher struct {
+	delegate  watch.Interface
+	newObject func() runtime.Object
+	result    chan watch.Event
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// newBookmarkWatcher returns a watch sending bookmarks every interval on top of the events of
+// the delegate. resourceVersion is the resourceVersion the watch started at, if known.
+func newBookmarkWatcher(delegate watch.Interface, newObject func() runtime.Object, resourceVersion string, interval time.Duration, clock clock.WithTicker) watch.Interface {
+	w := &bookmarkWatcher{
+		delegate:  delegate,
+		newObject: newObject,
+		result:    make(chan watch.Event),
+		stopCh:    make(chan struct{}),
+	}
+	go w.run(resourceVersion, interval, clock)
+	return w
+}
+
+func (w *bookmarkWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+		w.delegate.Stop()
+	})
+}
+
+func (w *bookmarkWatcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *bookmarkWatcher) run(resourceVersion string, interval time.Duration, clock clock.WithTicker) {
+	defer close(w.result)
+
+	ticker := clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	// the client knows about the resourceVersion it started at already
+	bookmarked := resourceVersion
+	for {
+		select {
+		case event, ok := <-w.delegate.ResultChan():
+			if !ok {
+				return
+			}
+			if event.Type != watch.Error {
+				if accessor, err := meta.Accessor(event.Object); err == nil && accessor.GetResourceVersion() != "" {
+					resourceVersion = accessor.GetResourceVersion()
+				}
+			}
+			if event.Type == watch.Bookmark {
+				bookmarked = resourceVersion
+			}
+			if !w.send(event) {
+				return
+			}
+		case <-ticker.C():
+			if resourceVersion == "" || resourceVersion == bookmarked {
+				continue
+			}
+			obj := w.newObject()
+			accessor, err := meta.Accessor(obj)
+			if err != nil {
+				continue
+			}
+			accessor.SetResourceVersion(resourceVersion)
+			if !w.send(watch.Event{Type: watch.Bookmark, Object: obj}) {
+				return
+			}
+			bookmarked = resourceVersion
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+func (w *bookmarkWatcher) send(event watch.Event) bool {
+	select {
+	case w.result <- event:
+		return true
+	case <-w.stopCh:
+		return false
+	}
+}
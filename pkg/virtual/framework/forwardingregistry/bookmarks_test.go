@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forwardingregistry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestBookmarkWatcher(t *testing.T) {
+	noxuKind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	newNoxu := func() runtime.Object {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(noxuKind)
+		return obj
+	}
+	noxu := func(name, resourceVersion string) runtime.Object {
+		obj := newNoxu().(*unstructured.Unstructured)
+		obj.SetName(name)
+		obj.SetResourceVersion(resourceVersion)
+		return obj
+	}
+
+	delegate := watch.NewFake()
+	clock := clocktesting.NewFakeClock(time.Now())
+	w := newBookmarkWatcher(delegate, newNoxu, "10", time.Minute, clock)
+	defer w.Stop()
+
+	require.Eventually(t, clock.HasWaiters, wait.ForeverTestTimeout, 10*time.Millisecond, "expected the bookmark ticker to be started")
+
+	next := func() watch.Event {
+		t.Helper()
+		select {
+		case e := <-w.ResultChan():
+			return e
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatal("timed out waiting for an event")
+			return watch.Event{}
+		}
+	}
+	requireNoEvent := func() {
+		t.Helper()
+		select {
+		case e := <-w.ResultChan():
+			t.Fatalf("unexpected event %s: %v", e.Type, e.Object)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	t.Log("No bookmark is sent for the resourceVersion the watch started at")
+	clock.Step(time.Minute)
+	requireNoEvent()
+
+	t.Log("Events are forwarded and a bookmark with the resourceVersion of the last event is sent")
+	go delegate.Add(noxu("foo", "11"))
+	require.Equal(t, watch.Event{Type: watch.Added, Object: noxu("foo", "11")}, next())
+	go delegate.Modify(noxu("foo", "12"))
+	require.Equal(t, watch.Event{Type: watch.Modified, Object: noxu("foo", "12")}, next())
+	clock.Step(time.Minute)
+	require.Equal(t, watch.Event{Type: watch.Bookmark, Object: noxu("", "12")}, next())
+
+	t.Log("No bookmark is sent again without new events")
+	clock.Step(time.Minute)
+	requireNoEvent()
+
+	t.Log("Bookmarks of the delegate are forwarded and not repeated")
+	go delegate.Action(watch.Bookmark, noxu("", "15"))
+	require.Equal(t, watch.Event{Type: watch.Bookmark, Object: noxu("", "15")}, next())
+	clock.Step(time.Minute)
+	requireNoEvent()
+
+	t.Log("The watch ends when the delegate ends")
+	delegate.Stop()
+	_, ok := <-w.ResultChan()
+	require.False(t, ok, "expected the result channel to be closed")
+}
+
+func TestBookmarkWatcherStop(t *testing.T) {
+	delegate := watch.NewFake()
+	w := newBookmarkWatcher(delegate, func() runtime.Object { return &unstructured.Unstructured{} }, "", time.Minute, clocktesting.NewFakeClock(time.Now()))
+
+	w.Stop()
+	w.Stop()
+	require.True(t, delegate.IsStopped(), "expected the delegate to be stopped")
+	for range w.ResultChan() {
+	}
+}
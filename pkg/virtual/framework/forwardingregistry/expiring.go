@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forwardingregistry
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// expiringWatcher forwards the events of a delegate watch until the expired channel is closed.
+// Then it sends an Expired error and ends the watch, such that a client like a reflector relists
+// instead of resuming from the last resourceVersion it has seen.
+type expiringWatcher struct {
+	delegate watch.Interface
+	expired  <-chan struct{}
+	resource schema.GroupResource
+	result   chan watch.Event
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// newExpiringWatcher returns a watch expiring when the expired channel is closed. release is
+// called when the watch ends.
+func newExpiringWatcher(delegate watch.Interface, expired <-chan struct{}, release func(), resource schema.GroupResource) watch.Interface {
+	w := &expiringWatcher{
+		delegate: delegate,
+		expired:  expired,
+		resource: resource,
+		result:   make(chan watch.Event),
+		stopCh:   make(chan struct{}),
+	}
+	go w.run(release)
+	return w
+}
+
+func (w *expiringWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+		w.delegate.Stop()
+	})
+}
+
+func (w *expiringWatcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *expiringWatcher) run(release func()) {
+	defer close(w.result)
+	defer release()
+
+	for {
+		select {
+		case event, ok := <-w.delegate.ResultChan():
+			if !ok {
+				return
+			}
+			if !w.send(event) {
+				return
+			}
+		case <-w.expired:
+			w.delegate.Stop()
+			status := errors.NewResourceExpired(fmt.Sprintf("the set of visible %s changed, relist required", w.resource)).ErrStatus
+			w.send(watch.Event{Type: watch.Error, Object: &status})
+			return
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+func (w *expiringWatcher) send(event watch.Event) bool {
+	select {
+	case w.result <- event:
+		return true
+	case <-w.stopCh:
+		return false
+	}
+}
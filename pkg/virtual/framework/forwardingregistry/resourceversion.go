@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forwardingregistry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// compositeResourceVersionPrefix marks a composite resourceVersion. Shard resourceVersions are
+// numbers and never start with it.
+const compositeResourceVersionPrefix = "shards."
+
+// compositeResourceVersion maps shard names to the resourceVersions of those shards. It lets a
+// client resume a watch through the virtual workspace of any shard it has watched before.
+type compositeResourceVersion map[string]string
+
+// encode returns the opaque string representation of the composite resourceVersion.
+func (rv compositeResourceVersion) encode() (string, error) {
+	bs, err := json.Marshal(rv)
+	if err != nil {
+		return "", err
+	}
+	return compositeResourceVersionPrefix + base64.RawURLEncoding.EncodeToString(bs), nil
+}
+
+// decodeCompositeResourceVersion parses a composite resourceVersion. The second return value is
+// false if the given resourceVersion is a plain shard resourceVersion.
+func decodeCompositeResourceVersion(resourceVersion string) (compositeResourceVersion, bool, error) {
+	if !strings.HasPrefix(resourceVersion, compositeResourceVersionPrefix) {
+		return nil, false, nil
+	}
+	bs, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(resourceVersion, compositeResourceVersionPrefix))
+	if err != nil {
+		return nil, true, err
+	}
+	rv := compositeResourceVersion{}
+	if err := json.Unmarshal(bs, &rv); err != nil {
+		return nil, true, err
+	}
+	return rv, true, nil
+}
@@ -41,6 +41,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -212,6 +213,28 @@ func TestWildcardListWithAPIExportIdentity(t *testing.T) {
 	require.Equal(t, "noxus:apiExportIdentityHash", fakeClient.Actions()[0].GetResource().Resource)
 }
 
+func TestWildcardListWithFieldSelector(t *testing.T) {
+	noxusGVRWithHash := noxusGVR.GroupVersion().WithResource("noxus:" + "apiExportIdentityHash")
+	fakeClient := kcpfakedynamic.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			noxusGVR:         "NoxuList",
+			noxusGVRWithHash: "NoxuList",
+		})
+
+	storage, _ := newStorage(t, fakeClient, "apiExportIdentityHash", nil)
+	ctx := request.WithNamespace(context.Background(), "")
+	ctx = request.WithCluster(ctx, request.Cluster{Wildcard: true})
+
+	lister := storage.(rest.Lister)
+	_, err := lister.List(ctx, &internalversion.ListOptions{FieldSelector: fields.ParseSelectorOrDie("metadata.name=foo,metadata.namespace=default")})
+	require.NoError(t, err)
+	require.Len(t, fakeClient.Actions(), 1)
+	action, ok := fakeClient.Actions()[0].(kcptesting.ListAction)
+	require.True(t, ok, "expected a list action, got %T", fakeClient.Actions()[0])
+	require.Equal(t, "metadata.name=foo,metadata.namespace=default", action.GetListRestrictions().Fields.String(), "expected the field selector to be passed on to the shards")
+}
+
 func checkWatchEvents(t *testing.T, addEvents func(), watchCall func() (watch.Interface, error), expectedEvents []watch.Event) {
 	t.Helper()
 
@@ -35,6 +35,7 @@ import (
 	"k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/clock"
 
 	dynamicextension "github.com/kcp-dev/kcp/pkg/virtual/framework/client/dynamic"
 )
@@ -255,7 +256,17 @@ func DefaultDynamicDelegatedStoreFuncs(
 			}
 		}()
 
-		return delegate.Watch(watchCtx, v1ListOptions)
+		w, err := delegate.Watch(watchCtx, v1ListOptions)
+		if err != nil || !options.AllowWatchBookmarks {
+			return w, err
+		}
+
+		resourceVersion := options.ResourceVersion
+		if resourceVersion == "0" {
+			// "0" means any resourceVersion, i.e. the client cannot resume from it
+			resourceVersion = ""
+		}
+		return newBookmarkWatcher(w, factory, resourceVersion, bookmarkInterval, clock.RealClock{}), nil
 	}
 	s.TableConvertorFunc = tableConvertor.ConvertToTable
 	s.CategoriesProviderFunc = func() []string {
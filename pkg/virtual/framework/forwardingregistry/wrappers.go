@@ -21,12 +21,17 @@ import (
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/watch"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
 )
 
 func WithStaticLabelSelector(labelSelector labels.Requirements) StorageWrapper {
@@ -76,3 +81,441 @@ func WithLabelSelector(labelSelectorFrom func(ctx context.Context) labels.Requir
 		}
 	})
 }
+
+// WithFieldSelectors restricts the field selectors of list and watch requests through the storage to
+// the given field labels, e.g. metadata.name and metadata.namespace. Supported field selectors are
+// passed on to the delegate, such that they are evaluated by the underlying shards, uniformly for
+// wildcard requests. Other field selectors fail with a BadRequest error, independently of what
+// the shards would support.
+func WithFieldSelectors(fieldLabels ...string) StorageWrapper {
+	supported := sets.NewString(fieldLabels...)
+	validate := func(options *internalversion.ListOptions) error {
+		if options == nil || options.FieldSelector == nil {
+			return nil
+		}
+		for _, requirement := range options.FieldSelector.Requirements() {
+			if !supported.Has(requirement.Field) {
+				return errors.NewBadRequest(fmt.Sprintf("field label not supported: %s", requirement.Field))
+			}
+		}
+		return nil
+	}
+
+	return StorageWrapperFunc(func(resource schema.GroupResource, storage *StoreFuncs) {
+		delegateLister := storage.ListerFunc
+		storage.ListerFunc = func(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+			if err := validate(options); err != nil {
+				return nil, err
+			}
+			return delegateLister.List(ctx, options)
+		}
+
+		delegateWatcher := storage.WatcherFunc
+		storage.WatcherFunc = func(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
+			if err := validate(options); err != nil {
+				return nil, err
+			}
+			return delegateWatcher.Watch(ctx, options)
+		}
+	})
+}
+
+// WithObjectFilter hides objects from requests through the storage for which the predicate
+// returned by filterFrom is false. Get, update, patch and delete requests of filtered objects fail
+// with a NotFound error, list requests and watch events skip them, and delete collection requests
+// only delete objects the predicate is true for. A nil predicate lets all objects through.
+func WithObjectFilter(filterFrom func(ctx context.Context, resource schema.GroupResource) (func(obj metav1.Object) bool, error)) StorageWrapper {
+	return StorageWrapperFunc(func(resource schema.GroupResource, storage *StoreFuncs) {
+		delegateGetter := storage.GetterFunc
+		storage.GetterFunc = func(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+			filter, err := filterFrom(ctx, resource)
+			if err != nil {
+				return nil, err
+			}
+			obj, err := delegateGetter.Get(ctx, name, options)
+			if err != nil || filter == nil {
+				return obj, err
+			}
+
+			metaObj, ok := obj.(metav1.Object)
+			if !ok {
+				return nil, fmt.Errorf("expected a metav1.Object, got %T", obj)
+			}
+			if !filter(metaObj) {
+				return nil, errors.NewNotFound(resource, name)
+			}
+			return obj, nil
+		}
+
+		delegateLister := storage.ListerFunc
+		storage.ListerFunc = func(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+			filter, err := filterFrom(ctx, resource)
+			if err != nil {
+				return nil, err
+			}
+			list, err := delegateLister.List(ctx, options)
+			if err != nil || filter == nil {
+				return list, err
+			}
+
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return nil, err
+			}
+			filtered := make([]runtime.Object, 0, len(items))
+			for _, item := range items {
+				metaObj, ok := item.(metav1.Object)
+				if !ok {
+					return nil, fmt.Errorf("expected a metav1.Object, got %T", item)
+				}
+				if filter(metaObj) {
+					filtered = append(filtered, item)
+				}
+			}
+			if err := meta.SetList(list, filtered); err != nil {
+				return nil, err
+			}
+			return list, nil
+		}
+
+		delegateUpdater := storage.UpdaterFunc
+		storage.UpdaterFunc = func(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+			filter, err := filterFrom(ctx, resource)
+			if err != nil {
+				return nil, false, err
+			}
+			if filter != nil {
+				objInfo = &filteredObjectInfo{UpdatedObjectInfo: objInfo, resource: resource, name: name, filter: filter}
+			}
+			return delegateUpdater.Update(ctx, name, objInfo, createValidation, updateValidation, forceAllowCreate, options)
+		}
+
+		delegateDeleter := storage.GracefulDeleterFunc
+		storage.GracefulDeleterFunc = func(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
+			filter, err := filterFrom(ctx, resource)
+			if err != nil {
+				return nil, false, err
+			}
+			if filter == nil {
+				return delegateDeleter.Delete(ctx, name, deleteValidation, options)
+			}
+
+			obj, err := delegateGetter.Get(ctx, name, &metav1.GetOptions{})
+			if err != nil {
+				return nil, false, err
+			}
+			metaObj, ok := obj.(metav1.Object)
+			if !ok {
+				return nil, false, fmt.Errorf("expected a metav1.Object, got %T", obj)
+			}
+			if !filter(metaObj) {
+				return nil, false, errors.NewNotFound(resource, name)
+			}
+			return delegateDeleter.Delete(ctx, name, deleteValidation, withUIDPrecondition(options, metaObj.GetUID()))
+		}
+
+		delegateCollectionDeleter := storage.CollectionDeleterFunc
+		storage.CollectionDeleterFunc = func(ctx context.Context, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions, listOptions *internalversion.ListOptions) (runtime.Object, error) {
+			filter, err := filterFrom(ctx, resource)
+			if err != nil {
+				return nil, err
+			}
+			if filter == nil {
+				return delegateCollectionDeleter.DeleteCollection(ctx, deleteValidation, options, listOptions)
+			}
+
+			// Like the generic registry, delete the matching objects one by one, such that only
+			// the objects the filter lets through are deleted.
+			list, err := delegateLister.List(ctx, listOptions)
+			if err != nil {
+				return nil, err
+			}
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return nil, err
+			}
+			deleted := make([]runtime.Object, 0, len(items))
+			for _, item := range items {
+				metaObj, ok := item.(metav1.Object)
+				if !ok {
+					return nil, fmt.Errorf("expected a metav1.Object, got %T", item)
+				}
+				if !filter(metaObj) {
+					continue
+				}
+				ctx := ctx
+				if metaObj.GetNamespace() != "" {
+					ctx = genericapirequest.WithNamespace(ctx, metaObj.GetNamespace())
+				}
+				if _, _, err := delegateDeleter.Delete(ctx, metaObj.GetName(), deleteValidation, withUIDPrecondition(options, metaObj.GetUID())); err != nil {
+					if errors.IsNotFound(err) || errors.IsConflict(err) {
+						// deleted or replaced concurrently
+						continue
+					}
+					return nil, err
+				}
+				deleted = append(deleted, item)
+			}
+			if err := meta.SetList(list, deleted); err != nil {
+				return nil, err
+			}
+			return list, nil
+		}
+
+		delegateWatcher := storage.WatcherFunc
+		storage.WatcherFunc = func(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
+			filter, err := filterFrom(ctx, resource)
+			if err != nil {
+				return nil, err
+			}
+			w, err := delegateWatcher.Watch(ctx, options)
+			if err != nil || filter == nil {
+				return w, err
+			}
+
+			return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+				if event.Type == watch.Bookmark || event.Type == watch.Error {
+					return event, true
+				}
+				metaObj, ok := event.Object.(metav1.Object)
+				if !ok {
+					// never leak objects we cannot check
+					return event, false
+				}
+				return event, filter(metaObj)
+			}), nil
+		}
+	})
+}
+
+// filteredObjectInfo fails updates of existing objects for which filter is false with a NotFound
+// error.
+type filteredObjectInfo struct {
+	rest.UpdatedObjectInfo
+	resource schema.GroupResource
+	name     string
+	filter   func(obj metav1.Object) bool
+}
+
+func (i *filteredObjectInfo) UpdatedObject(ctx context.Context, oldObj runtime.Object) (runtime.Object, error) {
+	if oldObj != nil {
+		metaObj, ok := oldObj.(metav1.Object)
+		if !ok {
+			return nil, fmt.Errorf("expected a metav1.Object, got %T", oldObj)
+		}
+		if !i.filter(metaObj) {
+			return nil, errors.NewNotFound(i.resource, i.name)
+		}
+	}
+	return i.UpdatedObjectInfo.UpdatedObject(ctx, oldObj)
+}
+
+// withUIDPrecondition returns a copy of options with a UID precondition, such that an object
+// checked before the deletion cannot be replaced by another one of the same name in between.
+func withUIDPrecondition(options *metav1.DeleteOptions, uid types.UID) *metav1.DeleteOptions {
+	if options == nil {
+		options = &metav1.DeleteOptions{}
+	}
+	options = options.DeepCopy()
+	if options.Preconditions == nil {
+		options.Preconditions = &metav1.Preconditions{}
+	}
+	if options.Preconditions.UID == nil && uid != "" {
+		options.Preconditions.UID = &uid
+	}
+	return options
+}
+
+// WithWatchExpiration ends watches through the storage with an Expired error when the channel
+// returned by expiredFrom is closed, such that clients relist. This is needed when objects become
+// visible or invisible through a filter without any event of the objects themselves. A nil channel
+// never expires the watch, otherwise release is called when the watch ends.
+func WithWatchExpiration(expiredFrom func(ctx context.Context, resource schema.GroupResource) (expired <-chan struct{}, release func(), err error)) StorageWrapper {
+	return StorageWrapperFunc(func(resource schema.GroupResource, storage *StoreFuncs) {
+		delegateWatcher := storage.WatcherFunc
+		storage.WatcherFunc = func(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
+			expired, release, err := expiredFrom(ctx, resource)
+			if err != nil {
+				return nil, err
+			}
+			if expired == nil {
+				return delegateWatcher.Watch(ctx, options)
+			}
+
+			w, err := delegateWatcher.Watch(ctx, options)
+			if err != nil {
+				release()
+				return nil, err
+			}
+			return newExpiringWatcher(w, expired, release, resource), nil
+		}
+	})
+}
+
+// WithShardResourceVersion makes watch bookmarks through the storage carry a composite
+// resourceVersion, holding the resourceVersion of the given shard next to those of other shards
+// the watch was resumed from. List and watch requests accept composite resourceVersions and
+// forward the one of the given shard. A watch resumed from a composite resourceVersion without
+// an entry for the shard fails with an Expired error, such that the client relists. It has to be
+// the last of the storage wrappers, such that the others only see resourceVersions of the shard.
+func WithShardResourceVersion(shardName string) StorageWrapper {
+	return StorageWrapperFunc(func(resource schema.GroupResource, storage *StoreFuncs) {
+		delegateLister := storage.ListerFunc
+		storage.ListerFunc = func(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+			if options == nil {
+				return delegateLister.List(ctx, options)
+			}
+			composite, ok, err := decodeCompositeResourceVersion(options.ResourceVersion)
+			if err != nil {
+				return nil, errors.NewBadRequest(fmt.Sprintf("invalid resourceVersion %q: %v", options.ResourceVersion, err))
+			}
+			if ok {
+				// without an entry for the shard, a consistent read is newer than any requested resourceVersion.
+				options = options.DeepCopy()
+				options.ResourceVersion = composite[shardName]
+			}
+			return delegateLister.List(ctx, options)
+		}
+
+		delegateWatcher := storage.WatcherFunc
+		storage.WatcherFunc = func(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
+			composite := compositeResourceVersion{}
+			if options != nil {
+				decoded, ok, err := decodeCompositeResourceVersion(options.ResourceVersion)
+				if err != nil {
+					return nil, errors.NewBadRequest(fmt.Sprintf("invalid resourceVersion %q: %v", options.ResourceVersion, err))
+				}
+				if ok {
+					rv, found := decoded[shardName]
+					if !found {
+						return nil, errors.NewResourceExpired(fmt.Sprintf("resourceVersion %q has no entry for shard %q", options.ResourceVersion, shardName))
+					}
+					composite = decoded
+					options = options.DeepCopy()
+					options.ResourceVersion = rv
+				}
+			}
+
+			w, err := delegateWatcher.Watch(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+				if event.Type != watch.Bookmark {
+					return event, true
+				}
+				accessor, err := meta.Accessor(event.Object)
+				if err != nil || accessor.GetResourceVersion() == "" {
+					return event, true
+				}
+				composite[shardName] = accessor.GetResourceVersion()
+				rv, err := composite.encode()
+				if err != nil {
+					return event, true
+				}
+				accessor.SetResourceVersion(rv)
+				return event, true
+			}), nil
+		}
+	})
+}
+
+// WithObjectLimit rejects creating objects through the storage with a Forbidden error when the
+// number of objects returned by countFrom for the logical cluster of the request reaches the limit
+// returned by limitFrom. A nil limit does not restrict creation, and the objects are not counted.
+// Concurrent creations are not serialized, i.e. the limit can be exceeded by racing requests.
+func WithObjectLimit(limitFrom func(ctx context.Context, resource schema.GroupResource) (*int64, error), countFrom func(ctx context.Context, resource schema.GroupResource) (int, error)) StorageWrapper {
+	return StorageWrapperFunc(func(resource schema.GroupResource, storage *StoreFuncs) {
+		delegateCreater := storage.CreaterFunc
+		storage.CreaterFunc = func(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+			limit, err := limitFrom(ctx, resource)
+			if err != nil {
+				return nil, err
+			}
+			if limit == nil {
+				return delegateCreater.Create(ctx, obj, createValidation, options)
+			}
+
+			count, err := countFrom(ctx, resource)
+			if err != nil {
+				return nil, err
+			}
+			if int64(count) >= *limit {
+				name := ""
+				if metaObj, ok := obj.(metav1.Object); ok {
+					name = metaObj.GetName()
+				}
+				return nil, errors.NewForbidden(resource, name, fmt.Errorf("exceeded quota: %d objects exist, limited to %d", count, *limit))
+			}
+
+			return delegateCreater.Create(ctx, obj, createValidation, options)
+		}
+	})
+}
+
+// WithAnnotations sets the annotations returned by annotationsFrom on objects that are
+// created or updated through the storage.
+func WithAnnotations(annotationsFrom func(ctx context.Context, resource schema.GroupResource) (map[string]string, error)) StorageWrapper {
+	return StorageWrapperFunc(func(resource schema.GroupResource, storage *StoreFuncs) {
+		delegateCreater := storage.CreaterFunc
+		storage.CreaterFunc = func(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+			annotations, err := annotationsFrom(ctx, resource)
+			if err != nil {
+				return nil, err
+			}
+			if err := setAnnotations(obj, annotations); err != nil {
+				return nil, err
+			}
+			return delegateCreater.Create(ctx, obj, createValidation, options)
+		}
+
+		delegateUpdater := storage.UpdaterFunc
+		storage.UpdaterFunc = func(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+			annotations, err := annotationsFrom(ctx, resource)
+			if err != nil {
+				return nil, false, err
+			}
+			if len(annotations) > 0 {
+				objInfo = &annotatedObjectInfo{UpdatedObjectInfo: objInfo, annotations: annotations}
+			}
+			return delegateUpdater.Update(ctx, name, objInfo, createValidation, updateValidation, forceAllowCreate, options)
+		}
+	})
+}
+
+// annotatedObjectInfo sets annotations on the updated object of the wrapped rest.UpdatedObjectInfo.
+type annotatedObjectInfo struct {
+	rest.UpdatedObjectInfo
+	annotations map[string]string
+}
+
+func (i *annotatedObjectInfo) UpdatedObject(ctx context.Context, oldObj runtime.Object) (runtime.Object, error) {
+	obj, err := i.UpdatedObjectInfo.UpdatedObject(ctx, oldObj)
+	if err != nil {
+		return nil, err
+	}
+	if err := setAnnotations(obj, i.annotations); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func setAnnotations(obj runtime.Object, annotations map[string]string) error {
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	metaObj, ok := obj.(metav1.Object)
+	if !ok {
+		return fmt.Errorf("expected a metav1.Object, got %T", obj)
+	}
+	existing := metaObj.GetAnnotations()
+	if existing == nil {
+		existing = make(map[string]string, len(annotations))
+	}
+	for k, v := range annotations {
+		existing[k] = v
+	}
+	metaObj.SetAnnotations(existing)
+	return nil
+}
@@ -0,0 +1,341 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forwardingregistry_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/forwardingregistry"
+)
+
+func TestWithAnnotations(t *testing.T) {
+	var stored runtime.Object
+	storage := &forwardingregistry.StoreFuncs{
+		CreaterFunc: func(ctx context.Context, obj runtime.Object, _ rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+			stored = obj
+			return obj, nil
+		},
+		UpdaterFunc: func(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, _ rest.ValidateObjectFunc, _ rest.ValidateObjectUpdateFunc, _ bool, _ *metav1.UpdateOptions) (runtime.Object, bool, error) {
+			obj, err := objInfo.UpdatedObject(ctx, stored)
+			if err != nil {
+				return nil, false, err
+			}
+			stored = obj
+			return obj, false, nil
+		},
+	}
+
+	annotations := map[string]string{"audit": "yes"}
+	forwardingregistry.WithAnnotations(func(_ context.Context, resource schema.GroupResource) (map[string]string, error) {
+		if resource != noxusGVR.GroupResource() {
+			return nil, nil
+		}
+		return annotations, nil
+	}).Decorate(noxusGVR.GroupResource(), storage)
+
+	ctx := context.Background()
+
+	t.Log("Create an object, expecting the annotations to be added next to the existing ones")
+	created, err := storage.Create(ctx, createResource("default", "foo"), rest.ValidateAllObjectFunc, &metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "yes", created.(metav1.Object).GetAnnotations()["audit"])
+	require.Len(t, created.(metav1.Object).GetAnnotations(), 2)
+
+	t.Log("Update the object without the annotation, expecting it to be set again")
+	updated := createResource("default", "foo")
+	annotations = map[string]string{"audit": "again"}
+	result, _, err := storage.Update(ctx, "foo", rest.DefaultUpdatedObjectInfo(updated), rest.ValidateAllObjectFunc, rest.ValidateAllObjectUpdateFunc, false, &metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "again", result.(metav1.Object).GetAnnotations()["audit"])
+
+	t.Log("Without annotations, objects are passed through unchanged")
+	annotations = nil
+	result, _, err = storage.Update(ctx, "foo", rest.DefaultUpdatedObjectInfo(createResource("default", "foo")), rest.ValidateAllObjectFunc, rest.ValidateAllObjectUpdateFunc, false, &metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, result.(metav1.Object).GetAnnotations(), "audit")
+}
+
+func TestWithObjectFilter(t *testing.T) {
+	var updated, deleted []string
+	var deletePreconditions []*metav1.Preconditions
+	storage := &forwardingregistry.StoreFuncs{
+		GetterFunc: func(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+			obj := createResource("default", name)
+			obj.SetUID(types.UID(name + "-uid"))
+			return obj, nil
+		},
+		ListerFunc: func(ctx context.Context, _ *internalversion.ListOptions) (runtime.Object, error) {
+			return &unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+				*createResource("default", "foo"),
+				*createResource("default", "bar"),
+			}}, nil
+		},
+		UpdaterFunc: func(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, _ rest.ValidateObjectFunc, _ rest.ValidateObjectUpdateFunc, _ bool, _ *metav1.UpdateOptions) (runtime.Object, bool, error) {
+			obj, err := objInfo.UpdatedObject(ctx, createResource("default", name))
+			if err != nil {
+				return nil, false, err
+			}
+			updated = append(updated, name)
+			return obj, false, nil
+		},
+		GracefulDeleterFunc: func(ctx context.Context, name string, _ rest.ValidateObjectFunc, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
+			deleted = append(deleted, name)
+			deletePreconditions = append(deletePreconditions, options.Preconditions)
+			return createResource("default", name), true, nil
+		},
+		CollectionDeleterFunc: func(ctx context.Context, _ rest.ValidateObjectFunc, _ *metav1.DeleteOptions, _ *internalversion.ListOptions) (runtime.Object, error) {
+			t.Fatal("unexpected delete collection request to the delegate")
+			return nil, nil
+		},
+	}
+
+	forwardingregistry.WithObjectFilter(func(_ context.Context, resource schema.GroupResource) (func(obj metav1.Object) bool, error) {
+		return func(obj metav1.Object) bool {
+			return obj.GetName() == "foo"
+		}, nil
+	}).Decorate(noxusGVR.GroupResource(), storage)
+
+	ctx := context.Background()
+
+	t.Log("Filtered objects are not found")
+	_, err := storage.Get(ctx, "foo", &metav1.GetOptions{})
+	require.NoError(t, err)
+	_, err = storage.Get(ctx, "bar", &metav1.GetOptions{})
+	require.True(t, errors.IsNotFound(err), "expected filtered object to be not found, got %v", err)
+
+	t.Log("Filtered objects are not listed")
+	list, err := storage.List(ctx, &internalversion.ListOptions{})
+	require.NoError(t, err)
+	items := list.(*unstructured.UnstructuredList).Items
+	require.Len(t, items, 1)
+	require.Equal(t, "foo", items[0].GetName())
+
+	t.Log("Filtered objects cannot be updated")
+	_, _, err = storage.Update(ctx, "foo", rest.DefaultUpdatedObjectInfo(createResource("default", "foo")), nil, nil, false, &metav1.UpdateOptions{})
+	require.NoError(t, err)
+	_, _, err = storage.Update(ctx, "bar", rest.DefaultUpdatedObjectInfo(createResource("default", "bar")), nil, nil, false, &metav1.UpdateOptions{})
+	require.True(t, errors.IsNotFound(err), "expected filtered object to be not found, got %v", err)
+	require.Equal(t, []string{"foo"}, updated)
+
+	t.Log("Filtered objects cannot be deleted, and deletion is bound to the checked object")
+	_, _, err = storage.Delete(ctx, "foo", nil, &metav1.DeleteOptions{})
+	require.NoError(t, err)
+	_, _, err = storage.Delete(ctx, "bar", nil, &metav1.DeleteOptions{})
+	require.True(t, errors.IsNotFound(err), "expected filtered object to be not found, got %v", err)
+	require.Equal(t, []string{"foo"}, deleted)
+	require.Equal(t, types.UID("foo-uid"), *deletePreconditions[0].UID)
+
+	t.Log("Delete collection only deletes objects the filter lets through")
+	deleted = nil
+	list, err = storage.DeleteCollection(ctx, nil, &metav1.DeleteOptions{}, &internalversion.ListOptions{})
+	require.NoError(t, err)
+	items = list.(*unstructured.UnstructuredList).Items
+	require.Len(t, items, 1)
+	require.Equal(t, "foo", items[0].GetName())
+	require.Equal(t, []string{"foo"}, deleted)
+}
+
+func TestWithWatchExpiration(t *testing.T) {
+	delegate := watch.NewFake()
+	storage := &forwardingregistry.StoreFuncs{
+		WatcherFunc: func(ctx context.Context, _ *internalversion.ListOptions) (watch.Interface, error) {
+			return delegate, nil
+		},
+	}
+
+	expired := make(chan struct{})
+	released := make(chan struct{})
+	forwardingregistry.WithWatchExpiration(func(_ context.Context, resource schema.GroupResource) (<-chan struct{}, func(), error) {
+		return expired, func() { close(released) }, nil
+	}).Decorate(noxusGVR.GroupResource(), storage)
+
+	w, err := storage.Watch(context.Background(), &internalversion.ListOptions{})
+	require.NoError(t, err)
+
+	t.Log("Events are forwarded until the watch expires")
+	go delegate.Add(createResource("default", "foo"))
+	event := <-w.ResultChan()
+	require.Equal(t, watch.Added, event.Type)
+
+	close(expired)
+	event = <-w.ResultChan()
+	require.Equal(t, watch.Error, event.Type)
+	require.True(t, errors.IsResourceExpired(errors.FromObject(event.Object)), "expected an Expired error, got %v", event.Object)
+	_, ok := <-w.ResultChan()
+	require.False(t, ok, "expected the result channel to be closed")
+	require.True(t, delegate.IsStopped(), "expected the delegate to be stopped")
+	<-released
+
+	t.Log("A nil channel returns the delegate watch")
+	delegate = watch.NewFake()
+	storage = &forwardingregistry.StoreFuncs{
+		WatcherFunc: func(ctx context.Context, _ *internalversion.ListOptions) (watch.Interface, error) {
+			return delegate, nil
+		},
+	}
+	forwardingregistry.WithWatchExpiration(func(_ context.Context, resource schema.GroupResource) (<-chan struct{}, func(), error) {
+		return nil, nil, nil
+	}).Decorate(noxusGVR.GroupResource(), storage)
+	w, err = storage.Watch(context.Background(), &internalversion.ListOptions{})
+	require.NoError(t, err)
+	require.Equal(t, delegate, w)
+}
+
+func TestWithFieldSelectors(t *testing.T) {
+	var listed, watched *internalversion.ListOptions
+	storage := &forwardingregistry.StoreFuncs{
+		ListerFunc: func(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+			listed = options
+			return &unstructured.UnstructuredList{}, nil
+		},
+		WatcherFunc: func(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
+			watched = options
+			return watch.NewEmptyWatch(), nil
+		},
+	}
+	forwardingregistry.WithFieldSelectors("metadata.name", "metadata.namespace").Decorate(noxusGVR.GroupResource(), storage)
+
+	ctx := context.Background()
+
+	t.Log("Supported field selectors are passed on")
+	options := &internalversion.ListOptions{FieldSelector: fields.ParseSelectorOrDie("metadata.name=foo,metadata.namespace!=default")}
+	_, err := storage.List(ctx, options)
+	require.NoError(t, err)
+	require.Equal(t, options, listed)
+	_, err = storage.Watch(ctx, options)
+	require.NoError(t, err)
+	require.Equal(t, options, watched)
+
+	t.Log("Requests without field selector are passed on")
+	_, err = storage.List(ctx, &internalversion.ListOptions{})
+	require.NoError(t, err)
+
+	t.Log("Other field selectors are rejected")
+	listed, watched = nil, nil
+	options = &internalversion.ListOptions{FieldSelector: fields.ParseSelectorOrDie("metadata.name=foo,spec.replicas=7")}
+	_, err = storage.List(ctx, options)
+	require.True(t, errors.IsBadRequest(err), "expected a BadRequest error, got %v", err)
+	require.EqualError(t, err, "field label not supported: spec.replicas")
+	require.Nil(t, listed)
+	_, err = storage.Watch(ctx, options)
+	require.True(t, errors.IsBadRequest(err), "expected a BadRequest error, got %v", err)
+	require.Nil(t, watched)
+}
+
+func TestWithShardResourceVersion(t *testing.T) {
+	var listed, watched *internalversion.ListOptions
+	var delegate *watch.FakeWatcher
+	storage := &forwardingregistry.StoreFuncs{
+		ListerFunc: func(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+			listed = options
+			return &unstructured.UnstructuredList{}, nil
+		},
+		WatcherFunc: func(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
+			watched = options
+			delegate = watch.NewFake()
+			return delegate, nil
+		},
+	}
+	forwardingregistry.WithShardResourceVersion("one").Decorate(noxusGVR.GroupResource(), storage)
+
+	ctx := context.Background()
+	bookmark := func(w watch.Interface, resourceVersion string) string {
+		obj := &unstructured.Unstructured{}
+		obj.SetResourceVersion(resourceVersion)
+		go delegate.Action(watch.Bookmark, obj)
+		event := <-w.ResultChan()
+		require.Equal(t, watch.Bookmark, event.Type)
+		return event.Object.(*unstructured.Unstructured).GetResourceVersion()
+	}
+
+	t.Log("Plain resourceVersions are passed on and bookmarks get a composite resourceVersion")
+	w, err := storage.Watch(ctx, &internalversion.ListOptions{ResourceVersion: "42", AllowWatchBookmarks: true})
+	require.NoError(t, err)
+	require.Equal(t, "42", watched.ResourceVersion)
+	composite := bookmark(w, "50")
+	require.NotEqual(t, "50", composite)
+
+	t.Log("Other events are passed on unchanged")
+	go delegate.Add(createResource("default", "foo"))
+	event := <-w.ResultChan()
+	require.Equal(t, watch.Added, event.Type)
+	w.Stop()
+
+	t.Log("Watches resume from the resourceVersion of the shard")
+	w, err = storage.Watch(ctx, &internalversion.ListOptions{ResourceVersion: composite, AllowWatchBookmarks: true})
+	require.NoError(t, err)
+	require.Equal(t, "50", watched.ResourceVersion)
+	w.Stop()
+
+	t.Log("Lists are served from the resourceVersion of the shard")
+	_, err = storage.List(ctx, &internalversion.ListOptions{ResourceVersion: composite})
+	require.NoError(t, err)
+	require.Equal(t, "50", listed.ResourceVersion)
+
+	t.Log("The resourceVersions of other shards are kept in bookmarks")
+	// {"one":"7","two":"13"}
+	composite = "shards.eyJvbmUiOiI3IiwidHdvIjoiMTMifQ"
+	w, err = storage.Watch(ctx, &internalversion.ListOptions{ResourceVersion: composite, AllowWatchBookmarks: true})
+	require.NoError(t, err)
+	require.Equal(t, "7", watched.ResourceVersion)
+	composite = bookmark(w, "8")
+	w.Stop()
+
+	other := &forwardingregistry.StoreFuncs{
+		WatcherFunc: func(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
+			watched = options
+			return watch.NewEmptyWatch(), nil
+		},
+	}
+	forwardingregistry.WithShardResourceVersion("two").Decorate(noxusGVR.GroupResource(), other)
+	_, err = other.Watch(ctx, &internalversion.ListOptions{ResourceVersion: composite})
+	require.NoError(t, err)
+	require.Equal(t, "13", watched.ResourceVersion)
+
+	t.Log("Watches from a composite resourceVersion without the shard expire")
+	watched = nil
+	other = &forwardingregistry.StoreFuncs{
+		WatcherFunc: func(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
+			watched = options
+			return watch.NewEmptyWatch(), nil
+		},
+	}
+	forwardingregistry.WithShardResourceVersion("three").Decorate(noxusGVR.GroupResource(), other)
+	_, err = other.Watch(ctx, &internalversion.ListOptions{ResourceVersion: composite})
+	require.True(t, errors.IsResourceExpired(err), "expected an Expired error, got %v", err)
+	require.Nil(t, watched)
+
+	t.Log("Invalid composite resourceVersions are rejected")
+	_, err = storage.Watch(ctx, &internalversion.ListOptions{ResourceVersion: "shards.!"})
+	require.True(t, errors.IsBadRequest(err), "expected a BadRequest error, got %v", err)
+	_, err = storage.List(ctx, &internalversion.ListOptions{ResourceVersion: "shards.!"})
+	require.True(t, errors.IsBadRequest(err), "expected a BadRequest error, got %v", err)
+}
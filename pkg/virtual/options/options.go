@@ -58,6 +58,7 @@ func (o *Options) Validate() []error {
 }
 
 func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	o.APIExport.AddFlags(fs, virtualWorkspacesFlagPrefix)
 	o.InitializingWorkspaces.AddFlags(fs, virtualWorkspacesFlagPrefix)
 }
 
@@ -72,7 +73,7 @@ func (o *Options) NewVirtualWorkspaces(
 		return nil, err
 	}
 
-	apiexports, err := o.APIExport.NewVirtualWorkspaces(rootPathPrefix, config, cachedKcpInformers)
+	apiexports, err := o.APIExport.NewVirtualWorkspaces(rootPathPrefix, config, wildcardKubeInformers, wildcardKcpInformers, cachedKcpInformers)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,141 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/kcp-dev/kcp/config/helpers"
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestAPIBindingConflictingBindings(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	provider1Path, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("service-provider-1"))
+	provider2Path, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("service-provider-2"))
+	consumerPath, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("consumer"))
+
+	cfg := server.BaseConfig(t)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client for server")
+
+	for _, providerPath := range []logicalcluster.Path{provider1Path, provider2Path} {
+		t.Logf("Install today cowboys APIResourceSchema and APIExport into %q", providerPath)
+		mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kcpClusterClient.Cluster(providerPath).Discovery()))
+		err = helpers.CreateResourceFromFS(ctx, dynamicClusterClient.Cluster(providerPath), mapper, nil, "apiresourceschema_cowboys.yaml", testFiles)
+		require.NoError(t, err)
+
+		_, err = kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIExports().Create(ctx, &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "today-cowboys"},
+			Spec: apisv1alpha1.APIExportSpec{
+				LatestResourceSchemas: []string{"today.cowboys.wildwest.dev"},
+			},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	bind := func(name string, providerPath logicalcluster.Path) {
+		t.Logf("Create an APIBinding %q in %q that points to the today-cowboys export from %q", name, consumerPath, providerPath)
+		apiBinding := &apisv1alpha1.APIBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apisv1alpha1.APIBindingSpec{
+				Reference: apisv1alpha1.BindingReference{
+					Export: &apisv1alpha1.ExportBindingReference{
+						Path: providerPath.String(),
+						Name: "today-cowboys",
+					},
+				},
+			},
+		}
+		framework.Eventually(t, func() (bool, string) {
+			_, err := kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Create(ctx, apiBinding, metav1.CreateOptions{})
+			return err == nil, fmt.Sprintf("Error creating APIBinding: %v", err)
+		}, wait.ForeverTestTimeout, time.Millisecond*100)
+	}
+
+	bind("cowboys", provider1Path)
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Get(ctx, "cowboys", metav1.GetOptions{})
+	}, framework.Is(apisv1alpha1.InitialBindingCompleted), "expected cowboys to be bound")
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Get(ctx, "cowboys", metav1.GetOptions{})
+	}, framework.Is(apisv1alpha1.NoConflictingBindings), "expected no conflict before the second APIBinding exists")
+
+	bind("cowboys2", provider2Path)
+
+	requireConflictWith := func(name, other string) {
+		t.Helper()
+		framework.Eventually(t, func() (bool, string) {
+			binding, err := kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return false, err.Error()
+			}
+			cond := conditions.Get(binding, apisv1alpha1.NoConflictingBindings)
+			if cond == nil {
+				return false, fmt.Sprintf("APIBinding %q has no %s condition", name, apisv1alpha1.NoConflictingBindings)
+			}
+			if cond.Reason != apisv1alpha1.NamingConflictsReason {
+				return false, fmt.Sprintf("APIBinding %q has %s condition with reason %q", name, apisv1alpha1.NoConflictingBindings, cond.Reason)
+			}
+			return strings.Contains(cond.Message, fmt.Sprintf("APIBinding %q", other)), fmt.Sprintf("expected conflict with %q, got: %s", other, cond.Message)
+		}, wait.ForeverTestTimeout, time.Millisecond*100, "expected APIBinding %q to report a conflict with %q", name, other)
+	}
+
+	t.Logf("Make sure the conflict is reported on the already bound APIBinding")
+	requireConflictWith("cowboys", "cowboys2")
+
+	t.Logf("Make sure the conflict is reported on the new APIBinding")
+	requireConflictWith("cowboys2", "cowboys")
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Get(ctx, "cowboys2", metav1.GetOptions{})
+	}, framework.IsNot(apisv1alpha1.InitialBindingCompleted).WithReason(apisv1alpha1.NamingConflictsReason), "expected cowboys2 not to be bound")
+
+	t.Logf("Delete the conflicting APIBinding and make sure the conflict is no longer reported")
+	err = kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Delete(ctx, "cowboys2", metav1.DeleteOptions{})
+	require.NoError(t, err)
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Get(ctx, "cowboys", metav1.GetOptions{})
+	}, framework.Is(apisv1alpha1.NoConflictingBindings), "expected the conflict to be resolved")
+}
@@ -231,4 +231,7 @@ func TestAPIBindingDeletion(t *testing.T) {
 		_, err := kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Get(ctx, apiBinding.Name, metav1.GetOptions{})
 		return apierrors.IsNotFound(err)
 	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+
+	t.Logf("cowboys should not be served anymore in consumer workspace %q", consumerPath)
+	framework.RequireDiscoveryLacksResource(ctx, t, consumerWorkspaceClient.Cluster(consumerPath).Discovery(), wildwestv1alpha1.SchemeGroupVersion.WithResource("cowboys"))
 }
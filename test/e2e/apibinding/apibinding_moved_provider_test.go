@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/kcp-dev/kcp/config/helpers"
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	wildwestv1alpha1 "github.com/kcp-dev/kcp/test/e2e/fixtures/wildwest/apis/wildwest/v1alpha1"
+	wildwestclientset "github.com/kcp-dev/kcp/test/e2e/fixtures/wildwest/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// TestAPIBindingFollowsMovedProvider simulates moving the workspace of a provider to another shard by
+// recreating it under the same path on another shard. Workspaces cannot be moved between shards yet.
+func TestAPIBindingFollowsMovedProvider(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cfg := server.BaseConfig(t)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client for server")
+
+	wildwestClusterClient, err := wildwestclientset.NewForConfig(rest.CopyConfig(cfg))
+	require.NoError(t, err, "failed to construct wildwest cluster client for server")
+
+	shards, err := kcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err, "failed to list shards")
+	var otherShard string
+	for _, shard := range shards.Items {
+		if shard.Name != corev1alpha1.RootShard {
+			otherShard = shard.Name
+			break
+		}
+	}
+	if otherShard == "" {
+		t.Skip("Test requires at least two shards")
+	}
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server, framework.WithRootShard())
+	consumerPath, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithRootShard())
+
+	setupProvider := func(providerPath logicalcluster.Path) {
+		t.Helper()
+
+		t.Logf("Install today cowboys APIResourceSchema into service provider workspace %q", providerPath)
+		mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kcpClusterClient.Cluster(providerPath).Discovery()))
+		err := helpers.CreateResourceFromFS(ctx, dynamicClusterClient.Cluster(providerPath), mapper, nil, "apiresourceschema_cowboys.yaml", testFiles)
+		require.NoError(t, err)
+
+		t.Logf("Create an APIExport today-cowboys in %q", providerPath)
+		cowboysAPIExport := &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "today-cowboys",
+			},
+			Spec: apisv1alpha1.APIExportSpec{
+				LatestResourceSchemas: []string{"today.cowboys.wildwest.dev"},
+			},
+		}
+		_, err = kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIExports().Create(ctx, cowboysAPIExport, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	requireConsumerWorks := func(providerClusterName logicalcluster.Name, cowboyName string) {
+		t.Helper()
+
+		t.Logf("Wait for the APIBinding in %q to be bound to the APIExport in logical cluster %s", consumerPath, providerClusterName)
+		framework.Eventually(t, func() (bool, string) {
+			binding, err := kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Get(ctx, "cowboys", metav1.GetOptions{})
+			if err != nil {
+				return false, err.Error()
+			}
+			if binding.Status.APIExportClusterName != providerClusterName.String() {
+				return false, fmt.Sprintf("APIBinding is bound to logical cluster %q", binding.Status.APIExportClusterName)
+			}
+			if !conditions.IsTrue(binding, apisv1alpha1.BindingUpToDate) {
+				return false, fmt.Sprintf("APIBinding is not up to date: %s", conditions.GetMessage(binding, apisv1alpha1.BindingUpToDate))
+			}
+			return true, ""
+		}, wait.ForeverTestTimeout, 100*time.Millisecond)
+
+		t.Logf("Create cowboy %q in consumer workspace %q", cowboyName, consumerPath)
+		framework.Eventually(t, func() (bool, string) {
+			_, err := wildwestClusterClient.Cluster(consumerPath).WildwestV1alpha1().Cowboys("default").Create(ctx, &wildwestv1alpha1.Cowboy{
+				ObjectMeta: metav1.ObjectMeta{Name: cowboyName, Namespace: "default"},
+			}, metav1.CreateOptions{})
+			if err != nil && !apierrors.IsAlreadyExists(err) {
+				return false, err.Error()
+			}
+			return true, ""
+		}, wait.ForeverTestTimeout, 100*time.Millisecond)
+	}
+
+	providerPath, provider := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("provider"), framework.WithRootShard())
+	setupProvider(providerPath)
+
+	t.Logf("Create an APIBinding in %q that points to the today-cowboys export by path %q", consumerPath, providerPath)
+	apiBinding := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cowboys",
+		},
+		Spec: apisv1alpha1.APIBindingSpec{
+			Reference: apisv1alpha1.BindingReference{
+				Export: &apisv1alpha1.ExportBindingReference{
+					Path: providerPath.String(),
+					Name: "today-cowboys",
+				},
+			},
+		},
+	}
+	framework.Eventually(t, func() (bool, string) {
+		_, err := kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Create(ctx, apiBinding, metav1.CreateOptions{})
+		return err == nil, fmt.Sprintf("Error creating APIBinding: %v", err)
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+
+	requireConsumerWorks(logicalcluster.Name(provider.Spec.Cluster), "before-move")
+
+	t.Logf("Move the provider workspace %q to shard %q", providerPath, otherShard)
+	err = kcpClusterClient.Cluster(orgPath).TenancyV1alpha1().Workspaces().Delete(ctx, provider.Name, metav1.DeleteOptions{})
+	require.NoError(t, err, "failed to delete provider workspace %q", providerPath)
+	framework.Eventually(t, func() (bool, string) {
+		_, err := kcpClusterClient.Cluster(orgPath).TenancyV1alpha1().Workspaces().Get(ctx, provider.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, ""
+		}
+		return false, fmt.Sprintf("provider workspace still exists: %v", err)
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+
+	movedProviderPath, movedProvider := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("provider"), framework.WithShard(otherShard))
+	require.Equal(t, providerPath, movedProviderPath)
+	setupProvider(movedProviderPath)
+
+	requireConsumerWorks(logicalcluster.Name(movedProvider.Spec.Cluster), "after-move")
+}
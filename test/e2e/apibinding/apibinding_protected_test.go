@@ -135,13 +135,10 @@ func TestProtectedAPIFromServiceExports(t *testing.T) {
 	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
 	require.NoError(t, err, "failed to construct kcp cluster client for server")
 
-	t.Logf("Get tenancy APIExport from root workspace")
-	workloadsRoot, err := kcpClusterClient.ApisV1alpha1().APIExports().Cluster(rootWorkspace).Get(ctx, workload.GroupName, metav1.GetOptions{})
-	require.NoError(t, err)
+	t.Logf("Get the virtual workspace URL of the workload APIExport in the root workspace")
+	vwURL := framework.WaitForAPIExportVWURL(ctx, t, kcpClusterClient, rootWorkspace, workload.GroupName)
 
 	t.Logf("Construct VirtualWorkspace client")
-	//nolint:staticcheck // SA1019 VirtualWorkspaces is deprecated but not removed yet
-	vwURL := workloadsRoot.Status.VirtualWorkspaces[0].URL
 	cfgVW := server.RootShardSystemMasterBaseConfig(t)
 	cfgVW.Host = vwURL
 
@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	kcpdiscovery "github.com/kcp-dev/client-go/discovery"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/utils/pointer"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/test/e2e/fixtures/apifixtures"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestAPIBindingShortNames(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	providerPath, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("provider"))
+	consumerPath, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("consumer"))
+
+	cfg := server.BaseConfig(t)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	discoveryClusterClient, err := kcpdiscovery.NewForConfig(rest.CopyConfig(cfg))
+	require.NoError(t, err, "failed to construct discovery cluster client for server")
+
+	group := framework.UniqueGroup(".shortnames.dev")
+	apiResourceSchema := &apisv1alpha1.APIResourceSchema{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "today.widgets." + group,
+		},
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:     "widgets",
+				Singular:   "widget",
+				ShortNames: []string{"wdgt"},
+				Kind:       "Widget",
+				ListKind:   "WidgetList",
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apisv1alpha1.APIResourceVersion{
+				{
+					Name:    "v1",
+					Served:  true,
+					Storage: true,
+					Schema: runtime.RawExtension{
+						Raw: encodeJSON(t, &apiextensionsv1.JSONSchemaProps{
+							Type:                   "object",
+							XPreserveUnknownFields: pointer.Bool(true),
+						}),
+					},
+				},
+			},
+		},
+	}
+	t.Logf("Creating APIResourceSchema %s|%s with short names %v", providerPath, apiResourceSchema.Name, apiResourceSchema.Spec.Names.ShortNames)
+	_, err = kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIResourceSchemas().Create(ctx, apiResourceSchema, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	apiExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: group,
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			LatestResourceSchemas: []string{apiResourceSchema.Name},
+		},
+	}
+	t.Logf("Creating APIExport %s|%s", providerPath, apiExport.Name)
+	_, err = kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIExports().Create(ctx, apiExport, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	apifixtures.BindToExport(ctx, t, providerPath, apiExport.Name, consumerPath, kcpClusterClient)
+
+	t.Logf("Expect the short name to be served in discovery of %q", consumerPath)
+	consumerDiscovery := discoveryClusterClient.Cluster(consumerPath)
+	framework.Eventually(t, func() (bool, string) {
+		resources, err := consumerDiscovery.ServerResourcesForGroupVersion(group + "/v1")
+		if err != nil {
+			return false, fmt.Sprintf("error retrieving discovery for %s/v1: %v", group, err)
+		}
+		for _, resource := range resources.APIResources {
+			if resource.Name == "widgets" {
+				return len(resource.ShortNames) == 1 && resource.ShortNames[0] == "wdgt", fmt.Sprintf("unexpected short names %v", resource.ShortNames)
+			}
+		}
+		return false, "widgets not found in discovery"
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "expected widgets to be served with short names in %q", consumerPath)
+
+	t.Logf("Expect the short name to resolve via the RESTMapper of %q", consumerPath)
+	mapper := restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(consumerDiscovery)), consumerDiscovery)
+	gvr, err := mapper.ResourceFor(schema.GroupVersionResource{Resource: "wdgt"})
+	require.NoError(t, err)
+	require.Equal(t, schema.GroupVersionResource{Group: group, Version: "v1", Resource: "widgets"}, gvr)
+}
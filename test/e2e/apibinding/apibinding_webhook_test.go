@@ -22,6 +22,7 @@ import (
 	"fmt"
 	gohttp "net/http"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -355,3 +356,130 @@ func TestAPIBindingValidatingWebhook(t *testing.T) {
 	t.Logf("Check that the in-workspace webhook was NOT called")
 	require.Zero(t, testWebhooks[targetPath].Calls(), "in-workspace webhook should not have been called")
 }
+
+func TestAPIExportValidatingWebhook(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	// the test webhook listens on localhost, which APIExport webhooks may only call when allowed.
+	tokenAuthFile := framework.WriteTokenAuthFile(t)
+	args := append(framework.TestServerArgsWithTokenAuthFile(tokenAuthFile), "--apiexport-webhooks-allow-private-hosts")
+	server := framework.PrivateKcpServer(t, framework.WithCustomArguments(args...))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	sourcePath, _ := framework.NewWorkspaceFixture(t, server, orgPath)
+	targetPath, _ := framework.NewWorkspaceFixture(t, server, orgPath)
+
+	cfg := server.BaseConfig(t)
+
+	kcpClients, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client for server")
+
+	cowbyClusterClient, err := wildwestclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct cowboy client for server")
+
+	scheme := runtime.NewScheme()
+	err = admissionregistrationv1.AddToScheme(scheme)
+	require.NoError(t, err, "failed to add admission registration v1 scheme")
+	err = v1.AddToScheme(scheme)
+	require.NoError(t, err, "failed to add admission v1 scheme")
+	err = v1alpha1.AddToScheme(scheme)
+	require.NoError(t, err, "failed to add cowboy v1alpha1 to scheme")
+	codecs := serializer.NewCodecFactory(scheme)
+	deserializer := codecs.UniversalDeserializer()
+
+	t.Logf("Create test server rejecting cowboys")
+	testWebhook := &webhookserver.AdmissionWebhookServer{
+		Response: v1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Code:    gohttp.StatusForbidden,
+				Message: "cowboys are not welcome in this town",
+			},
+		},
+		ObjectGVK: schema.GroupVersionKind{
+			Group:   "wildwest.dev",
+			Version: "v1alpha1",
+			Kind:    "Cowboy",
+		},
+		Deserializer: deserializer,
+	}
+	port, err := framework.GetFreePort(t)
+	require.NoError(t, err, "failed to get free port for test webhook")
+	dirPath := filepath.Dir(server.KubeconfigPath())
+	testWebhook.StartTLS(t, filepath.Join(dirPath, "apiserver.crt"), filepath.Join(dirPath, "apiserver.key"), port)
+
+	t.Logf("Install a cowboys APIResourceSchema into workspace %q", sourcePath)
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kcpClients.Cluster(sourcePath).Discovery()))
+	err = helpers.CreateResourceFromFS(ctx, dynamicClusterClient.Cluster(sourcePath), mapper, nil, "apiresourceschema_cowboys.yaml", testFiles)
+	require.NoError(t, err)
+
+	t.Logf("Create an APIExport for it with a validating webhook")
+	cowboysAPIExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "today-cowboys",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			LatestResourceSchemas: []string{"today.cowboys.wildwest.dev"},
+			Webhooks: []apisv1alpha1.APIExportWebhook{
+				{
+					Name: "validate-cowboys.wildwest.dev",
+					Type: apisv1alpha1.APIExportWebhookValidating,
+					ClientConfig: apisv1alpha1.WebhookClientConfig{
+						URL:      testWebhook.GetURL(),
+						CABundle: cfg.CAData,
+					},
+				},
+			},
+		},
+	}
+	_, err = kcpClients.Cluster(sourcePath).ApisV1alpha1().APIExports().Create(ctx, cowboysAPIExport, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	t.Logf("Create an APIBinding in workspace %q that points to the today-cowboys export", targetPath)
+	apiBinding := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cowboys",
+		},
+		Spec: apisv1alpha1.APIBindingSpec{
+			Reference: apisv1alpha1.BindingReference{
+				Export: &apisv1alpha1.ExportBindingReference{
+					Path: sourcePath.String(),
+					Name: cowboysAPIExport.Name,
+				},
+			},
+		},
+	}
+	framework.Eventually(t, func() (bool, string) {
+		_, err := kcpClients.Cluster(targetPath).ApisV1alpha1().APIBindings().Create(ctx, apiBinding, metav1.CreateOptions{})
+		return err == nil, fmt.Sprintf("Error creating APIBinding: %v", err)
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+
+	t.Logf("Ensure cowboys are served")
+	require.Eventually(t, func() bool {
+		_, err := cowbyClusterClient.Cluster(targetPath).WildwestV1alpha1().Cowboys("default").List(ctx, metav1.ListOptions{})
+		return err == nil
+	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+
+	t.Logf("Creating an invalid cowboy in workspace %q is rejected by the webhook of the APIExport", targetPath)
+	cowboy := v1alpha1.Cowboy{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "testing",
+		},
+		Spec: v1alpha1.CowboySpec{},
+	}
+	framework.Eventually(t, func() (bool, string) {
+		_, err := cowbyClusterClient.Cluster(targetPath).WildwestV1alpha1().Cowboys("default").Create(ctx, &cowboy, metav1.CreateOptions{})
+		if err == nil {
+			return false, "expected the cowboy to be rejected"
+		}
+		return errors.IsForbidden(err) && strings.Contains(err.Error(), "cowboys are not welcome in this town"), err.Error()
+	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+	require.GreaterOrEqual(t, testWebhook.Calls(), 1, "expected the webhook of the APIExport to be called")
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/kcp-dev/kcp/config/helpers"
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexport"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/initialization"
+	"github.com/kcp-dev/kcp/test/e2e/fixtures/apifixtures"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestAPIExportIdentityRotation(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	sheriffsPath, _ := framework.NewWorkspaceFixture(t, server, orgPath)
+	cowboysPath, _ := framework.NewWorkspaceFixture(t, server, orgPath)
+	consumerPath, _ := framework.NewWorkspaceFixture(t, server, orgPath)
+
+	cfg := server.BaseConfig(t)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kube cluster client for server")
+
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client for server")
+
+	apifixtures.CreateSheriffsSchemaAndExport(ctx, t, sheriffsPath, kcpClusterClient, "wild.wild.west", "board the wanderer")
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return kcpClusterClient.Cluster(sheriffsPath).ApisV1alpha1().APIExports().Get(ctx, "wild.wild.west", metav1.GetOptions{})
+	}, framework.Is(apisv1alpha1.APIExportIdentityValid), "could not wait for APIExport to be valid with identity hash")
+
+	sheriffsExport, err := kcpClusterClient.Cluster(sheriffsPath).ApisV1alpha1().APIExports().Get(ctx, "wild.wild.west", metav1.GetOptions{})
+	require.NoError(t, err)
+	oldIdentityHash := sheriffsExport.Status.IdentityHash
+
+	t.Logf("Create a cowboys APIExport in %q claiming sheriffs with identity hash %s", cowboysPath, oldIdentityHash)
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kcpClusterClient.Cluster(cowboysPath).Discovery()))
+	err = helpers.CreateResourceFromFS(ctx, dynamicClusterClient.Cluster(cowboysPath), mapper, nil, "apiresourceschema_cowboys.yaml", testFiles)
+	require.NoError(t, err)
+	cowboysExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "today-cowboys",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			LatestResourceSchemas: []string{"today.cowboys.wildwest.dev"},
+			PermissionClaims: []apisv1alpha1.PermissionClaim{
+				{GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"}, IdentityHash: oldIdentityHash, All: true},
+			},
+		},
+	}
+	cowboysExport, err = kcpClusterClient.Cluster(cowboysPath).ApisV1alpha1().APIExports().Create(ctx, cowboysExport, metav1.CreateOptions{})
+	require.NoError(t, err)
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return kcpClusterClient.Cluster(cowboysPath).ApisV1alpha1().APIExports().Get(ctx, "today-cowboys", metav1.GetOptions{})
+	}, framework.Is(apisv1alpha1.APIExportPermissionClaimsValid), "could not wait for the permission claims of the cowboys APIExport to be valid")
+
+	t.Logf("Bind to the cowboys APIExport in %q accepting all claims", consumerPath)
+	binding := initialization.NewAPIBindingAcceptingAllClaims(cowboysPath.String(), cowboysExport.Name, cowboysExport)
+	framework.Eventually(t, func() (bool, string) {
+		_, err := kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Create(ctx, binding, metav1.CreateOptions{})
+		return err == nil, fmt.Sprintf("Error creating APIBinding: %v", err)
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Get(ctx, binding.Name, metav1.GetOptions{})
+	}, framework.Is(apisv1alpha1.PermissionClaimsValid), "could not wait for the permission claims of the APIBinding to be valid")
+
+	t.Logf("Change the identity secret of the sheriffs APIExport")
+	secretRef := sheriffsExport.Spec.Identity.SecretRef
+	secret, err := kubeClusterClient.Cluster(sheriffsPath).CoreV1().Secrets(secretRef.Namespace).Get(ctx, secretRef.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	secret.Data[apisv1alpha1.SecretKeyAPIExportIdentity] = []byte(strings.Repeat("x", 64))
+	secret, err = kubeClusterClient.Cluster(sheriffsPath).CoreV1().Secrets(secretRef.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	newIdentityHash, err := apiexport.IdentityHash(secret)
+	require.NoError(t, err)
+
+	t.Logf("Without the rotation annotation the identity is not valid anymore")
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return kcpClusterClient.Cluster(sheriffsPath).ApisV1alpha1().APIExports().Get(ctx, "wild.wild.west", metav1.GetOptions{})
+	}, framework.IsNot(apisv1alpha1.APIExportIdentityValid).WithReason(apisv1alpha1.IdentityVerificationFailedReason), "could not wait for the identity of the APIExport to be invalid")
+
+	t.Logf("Annotate the sheriffs APIExport to rotate its identity to %s", newIdentityHash)
+	framework.Eventually(t, func() (bool, string) {
+		export, err := kcpClusterClient.Cluster(sheriffsPath).ApisV1alpha1().APIExports().Get(ctx, "wild.wild.west", metav1.GetOptions{})
+		require.NoError(t, err)
+		if export.Annotations == nil {
+			export.Annotations = map[string]string{}
+		}
+		export.Annotations[apisv1alpha1.AnnotationAPIExportRotateIdentityKey] = newIdentityHash
+		_, err = kcpClusterClient.Cluster(sheriffsPath).ApisV1alpha1().APIExports().Update(ctx, export, metav1.UpdateOptions{})
+		if err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "error annotating the APIExport")
+
+	framework.Eventually(t, func() (bool, string) {
+		export, err := kcpClusterClient.Cluster(sheriffsPath).ApisV1alpha1().APIExports().Get(ctx, "wild.wild.west", metav1.GetOptions{})
+		require.NoError(t, err)
+		return export.Status.IdentityHash == newIdentityHash && conditions.IsTrue(export, apisv1alpha1.APIExportIdentityValid),
+			fmt.Sprintf("identity hash is %s", export.Status.IdentityHash)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "could not wait for the identity to be rotated")
+
+	t.Logf("The claiming APIExport and the APIBinding report the claim of the previous identity")
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return kcpClusterClient.Cluster(cowboysPath).ApisV1alpha1().APIExports().Get(ctx, "today-cowboys", metav1.GetOptions{})
+	}, framework.IsNot(apisv1alpha1.APIExportPermissionClaimsValid).WithReason(apisv1alpha1.PermissionClaimIdentityNotFoundReason), "could not wait for the cowboys APIExport to report the unknown identity")
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Get(ctx, binding.Name, metav1.GetOptions{})
+	}, framework.IsNot(apisv1alpha1.PermissionClaimsValid).WithReason(apisv1alpha1.PermissionClaimIdentityNotFoundReason), "could not wait for the APIBinding to report the unknown identity")
+
+	t.Logf("Update the claim of the cowboys APIExport to the new identity")
+	framework.Eventually(t, func() (bool, string) {
+		export, err := kcpClusterClient.Cluster(cowboysPath).ApisV1alpha1().APIExports().Get(ctx, "today-cowboys", metav1.GetOptions{})
+		require.NoError(t, err)
+		export.Spec.PermissionClaims[0].IdentityHash = newIdentityHash
+		_, err = kcpClusterClient.Cluster(cowboysPath).ApisV1alpha1().APIExports().Update(ctx, export, metav1.UpdateOptions{})
+		if err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "error updating the claim of the cowboys APIExport")
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return kcpClusterClient.Cluster(cowboysPath).ApisV1alpha1().APIExports().Get(ctx, "today-cowboys", metav1.GetOptions{})
+	}, framework.Is(apisv1alpha1.APIExportPermissionClaimsValid), "could not wait for the permission claims of the cowboys APIExport to be valid again")
+}
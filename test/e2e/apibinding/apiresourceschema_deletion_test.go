@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kcp-dev/kcp/config/helpers"
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestAPIResourceSchemaDeletionBlockedByAPIExport(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	providerPath, _ := framework.NewWorkspaceFixture(t, server, orgPath)
+
+	cfg := server.BaseConfig(t)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client for server")
+
+	t.Logf("Install today cowboys APIResourceSchema into service provider workspace %q", providerPath)
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kcpClusterClient.Cluster(providerPath).Discovery()))
+	err = helpers.CreateResourceFromFS(ctx, dynamicClusterClient.Cluster(providerPath), mapper, nil, "apiresourceschema_cowboys.yaml", testFiles)
+	require.NoError(t, err)
+
+	t.Logf("Create an APIExport referencing it")
+	cowboysAPIExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "today-cowboys",
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			LatestResourceSchemas: []string{"today.cowboys.wildwest.dev"},
+		},
+	}
+	_, err = kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIExports().Create(ctx, cowboysAPIExport, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	t.Logf("Deleting the APIResourceSchema should be forbidden while it is referenced")
+	framework.Eventually(t, func() (bool, string) {
+		err := kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIResourceSchemas().Delete(ctx, "today.cowboys.wildwest.dev", metav1.DeleteOptions{})
+		return apierrors.IsForbidden(err), fmt.Sprintf("expected a forbidden error, got: %v", err)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+
+	_, err = kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIResourceSchemas().Get(ctx, "today.cowboys.wildwest.dev", metav1.GetOptions{})
+	require.NoError(t, err, "APIResourceSchema should still exist")
+
+	t.Logf("Update the APIExport to stop referencing the APIResourceSchema")
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		export, err := kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIExports().Get(ctx, cowboysAPIExport.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		export.Spec.LatestResourceSchemas = nil
+		_, err = kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIExports().Update(ctx, export, metav1.UpdateOptions{})
+		return err
+	})
+	require.NoError(t, err)
+
+	t.Logf("Deleting the APIResourceSchema should succeed now")
+	framework.Eventually(t, func() (bool, string) {
+		err := kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIResourceSchemas().Delete(ctx, "today.cowboys.wildwest.dev", metav1.DeleteOptions{})
+		return err == nil || apierrors.IsNotFound(err), fmt.Sprintf("error deleting APIResourceSchema: %v", err)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+}
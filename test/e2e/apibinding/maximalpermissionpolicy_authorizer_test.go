@@ -173,6 +173,11 @@ func TestMaximalPermissionPolicyAuthorizer(t *testing.T) {
 	serviceProviderClusterNames := []logicalcluster.Path{rbacServiceProviderPath, serviceProvider2Workspace}
 	framework.AdmitWorkspaceAccess(ctx, t, kubeClusterClient, orgPath, []string{"user-1", "user-2", "user-3"}, nil, false)
 
+	// cleanups run in reverse order, i.e. consumer APIBindings are deleted before the service provider APIExports.
+	for _, path := range []logicalcluster.Path{rbacServiceProviderPath, serviceProvider2Workspace, consumer1Path, consumer2Path} {
+		framework.CleanupAPIs(ctx, t, kcpClusterClient, path)
+	}
+
 	// Set up service provider workspace.
 	for _, serviceProviderPath := range serviceProviderClusterNames {
 		setUpServiceProvider(ctx, t, dynamicClients, kcpClusterClient, kubeClusterClient, serviceProviderPath, rbacServiceProviderPath, cfg)
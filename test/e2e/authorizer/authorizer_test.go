@@ -69,28 +69,26 @@ func TestAuthorizer(t *testing.T) {
 	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
 	require.NoError(t, err)
 
-	org1, _ := framework.NewOrganizationFixture(t, server, framework.WithNameSuffix("org1"))
-	org2, _ := framework.NewPrivilegedOrganizationFixture(t, server, framework.PrivilegedWorkspaceOption(framework.WithNameSuffix("org2")), framework.WithRequiredGroups("empty-group"))
-
-	framework.NewWorkspaceFixture(t, server, org1, framework.WithName("workspace1"))
-	framework.NewWorkspaceFixture(t, server, org1, framework.WithName("workspace2"), framework.WithRootShard())                      // on root for system:admin ClusterRole test
-	_, org2Workspace1 := framework.NewWorkspaceFixture(t, server, org2, framework.WithName("workspace1"), framework.WithRootShard()) // on root for deep SAR test
-	framework.NewWorkspaceFixture(t, server, org2, framework.WithName("workspace2"))
-
-	createResources(ctx, t, dynamicClusterClient, kubeDiscoveryClient, org1.Join("workspace1"), "workspace1-resources.yaml")
-	createResources(ctx, t, dynamicClusterClient, kubeDiscoveryClient, org2.Join("workspace1"), "workspace1-resources.yaml")
-
-	framework.AdmitWorkspaceAccess(ctx, t, kubeClusterClient, org1, []string{"user-1", "user-2", "user-3"}, nil, false)
-
-	framework.AdmitWorkspaceAccess(ctx, t, kubeClusterClient, org2.Join("workspace1"), []string{"user-1"}, nil, true)
-	framework.AdmitWorkspaceAccess(ctx, t, kubeClusterClient, org2.Join("workspace1"), []string{"user-2"}, nil, false)
-	framework.AdmitWorkspaceAccess(ctx, t, kubeClusterClient, org2.Join("workspace2"), []string{"user-3"}, nil, false)
-	framework.AdmitWorkspaceAccess(ctx, t, kubeClusterClient, org2.Join("workspace2"), []string{"user-2"}, nil, true)
-
-	framework.AdmitWorkspaceAccess(ctx, t, kubeClusterClient, org1.Join("workspace1"), []string{"user-1"}, nil, true)
-	framework.AdmitWorkspaceAccess(ctx, t, kubeClusterClient, org1.Join("workspace1"), []string{"user-2"}, nil, false)
-	framework.AdmitWorkspaceAccess(ctx, t, kubeClusterClient, org1.Join("workspace2"), []string{"user-3"}, nil, false)
-	framework.AdmitWorkspaceAccess(ctx, t, kubeClusterClient, org1.Join("workspace2"), []string{"user-2"}, nil, true) // last, to be used for priming below
+	org2, org2Workspaces := framework.NewScenario(t, server, framework.ScenarioSpec{
+		OrgOptions:           []framework.UnprivilegedWorkspaceOption{framework.WithNameSuffix("org2")},
+		PrivilegedOrgOptions: []framework.PrivilegedWorkspaceOption{framework.WithRequiredGroups("empty-group")},
+		Workspaces: []framework.ScenarioWorkspaceSpec{
+			{Name: "workspace1", Options: []framework.UnprivilegedWorkspaceOption{framework.WithRootShard()}, Members: []string{"user-2"}, Admins: []string{"user-1"}}, // on root for deep SAR test
+			{Name: "workspace2", Members: []string{"user-3"}, Admins: []string{"user-2"}},
+		},
+	})
+	org1, _ := framework.NewScenario(t, server, framework.ScenarioSpec{
+		OrgOptions: []framework.UnprivilegedWorkspaceOption{framework.WithNameSuffix("org1")},
+		Members:    []string{"user-1", "user-2", "user-3"},
+		Workspaces: []framework.ScenarioWorkspaceSpec{
+			{Name: "workspace1", Members: []string{"user-2"}, Admins: []string{"user-1"}},
+			{Name: "workspace2", Options: []framework.UnprivilegedWorkspaceOption{framework.WithRootShard()}, Members: []string{"user-3"}, Admins: []string{"user-2"}}, // on root for system:admin ClusterRole test
+		},
+	})
+	org2Workspace1 := org2Workspaces["workspace1"].Workspace
+
+	createResources(ctx, t, dynamicClusterClient, kubeDiscoveryClient, org1.Path.Join("workspace1"), "workspace1-resources.yaml")
+	createResources(ctx, t, dynamicClusterClient, kubeDiscoveryClient, org2.Path.Join("workspace1"), "workspace1-resources.yaml")
 
 	user1KubeClusterClient, err := kcpkubernetesclientset.NewForConfig(framework.StaticTokenUserConfig("user-1", cfg))
 	require.NoError(t, err)
@@ -103,8 +101,8 @@ func TestAuthorizer(t *testing.T) {
 
 	t.Logf("Priming the authorization cache")
 	require.Eventually(t, func() bool {
-		// test *last* of the admitted permissions
-		_, err := user2KubeClusterClient.Cluster(org1.Join("workspace2")).CoreV1().Secrets("default").List(ctx, metav1.ListOptions{})
+		// test *last* of the admitted permissions, user-2 as admin of org1 workspace2
+		_, err := user2KubeClusterClient.Cluster(org1.Path.Join("workspace2")).CoreV1().Secrets("default").List(ctx, metav1.ListOptions{})
 		return err == nil
 	}, 2*wait.ForeverTestTimeout, 100*time.Millisecond)
 
@@ -113,22 +111,22 @@ func TestAuthorizer(t *testing.T) {
 		run  func(t *testing.T)
 	}{
 		{"as org member, workspace admin user-1 can access everything", func(t *testing.T) {
-			_, err := user1KubeClusterClient.Cluster(org1.Join("workspace1")).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
+			_, err := user1KubeClusterClient.Cluster(org1.Path.Join("workspace1")).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
 			require.NoError(t, err)
-			_, err = user1KubeClusterClient.Cluster(org1.Join("workspace1")).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, metav1.CreateOptions{})
+			_, err = user1KubeClusterClient.Cluster(org1.Path.Join("workspace1")).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, metav1.CreateOptions{})
 			require.NoError(t, err)
-			_, err = user1KubeClusterClient.Cluster(org1.Join("workspace1")).CoreV1().ConfigMaps("test").Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, metav1.CreateOptions{})
+			_, err = user1KubeClusterClient.Cluster(org1.Path.Join("workspace1")).CoreV1().ConfigMaps("test").Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, metav1.CreateOptions{})
 			require.NoError(t, err)
 		}},
 		{"with org access, workspace1 non-admin user-2 can access according to local policy", func(t *testing.T) {
-			_, err := user2KubeClusterClient.Cluster(org1.Join("workspace1")).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, metav1.CreateOptions{})
-			require.Errorf(t, err, "user-2 should not be able to create namespace in %s", org1.Join("workspace1"))
-			_, err = user2KubeClusterClient.Cluster(org1.Join("workspace1")).CoreV1().Secrets("default").List(ctx, metav1.ListOptions{})
-			require.NoErrorf(t, err, "user-2 should be able to list secrets in %s as defined in the local policy", org1.Join("workspace1"))
+			_, err := user2KubeClusterClient.Cluster(org1.Path.Join("workspace1")).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, metav1.CreateOptions{})
+			require.Errorf(t, err, "user-2 should not be able to create namespace in %s", org1.Path.Join("workspace1"))
+			_, err = user2KubeClusterClient.Cluster(org1.Path.Join("workspace1")).CoreV1().Secrets("default").List(ctx, metav1.ListOptions{})
+			require.NoErrorf(t, err, "user-2 should be able to list secrets in %s as defined in the local policy", org1.Path.Join("workspace1"))
 		}},
 		{"with org access, workspace1 non-admin user-2 can access /healthz, /livez, /readyz etc", func(t *testing.T) {
 			cl := user2KubeClusterClient.RESTClient()
-			requestPath := org1.RequestPath()
+			requestPath := org1.Path.RequestPath()
 			{
 				for endpoint := range sets.NewString("/healthz", "/readyz", "/livez") {
 					req := cl.Get().AbsPath(requestPath + endpoint)
@@ -148,20 +146,20 @@ func TestAuthorizer(t *testing.T) {
 			}
 		}},
 		{"without org access, org1 workspace1 admin user-1 cannot access org2, not even discovery", func(t *testing.T) {
-			_, err := user1KubeClusterClient.Cluster(org2.Join("workspace1")).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
-			require.Errorf(t, err, "user-1 should not be able to list configmaps in a different org (%s)", org2.Join("workspace1"))
-			_, err = user1KubeDiscoveryClient.Cluster(org2.Join("workspace1")).ServerResourcesForGroupVersion("rbac.authorization.k8s.io/v1") // can't be core because that always returns nil
-			require.Errorf(t, err, "user-1 should not be able to list server resources in a different org (%s)", org2.Join("workspace1"))
+			_, err := user1KubeClusterClient.Cluster(org2.Path.Join("workspace1")).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
+			require.Errorf(t, err, "user-1 should not be able to list configmaps in a different org (%s)", org2.Path.Join("workspace1"))
+			_, err = user1KubeDiscoveryClient.Cluster(org2.Path.Join("workspace1")).ServerResourcesForGroupVersion("rbac.authorization.k8s.io/v1") // can't be core because that always returns nil
+			require.Errorf(t, err, "user-1 should not be able to list server resources in a different org (%s)", org2.Path.Join("workspace1"))
 		}},
 		{"as org member, workspace1 admin user-1 cannot access workspace2, not even discovery", func(t *testing.T) {
-			_, err := user1KubeClusterClient.Cluster(org1.Join("workspace2")).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
-			require.Errorf(t, err, "user-1 should not be able to list configmaps in a different workspace (%s)", org1.Join("workspace2"))
-			_, err = user1KubeDiscoveryClient.Cluster(org2.Join("workspace1")).ServerResourcesForGroupVersion("rbac.authorization.k8s.io/v1") // can't be core because that always returns nil
-			require.Errorf(t, err, "user-1 should not be able to list server resources in a different workspace (%s)", org1.Join("workspace2"))
+			_, err := user1KubeClusterClient.Cluster(org1.Path.Join("workspace2")).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
+			require.Errorf(t, err, "user-1 should not be able to list configmaps in a different workspace (%s)", org1.Path.Join("workspace2"))
+			_, err = user1KubeDiscoveryClient.Cluster(org2.Path.Join("workspace1")).ServerResourcesForGroupVersion("rbac.authorization.k8s.io/v1") // can't be core because that always returns nil
+			require.Errorf(t, err, "user-1 should not be able to list server resources in a different workspace (%s)", org1.Path.Join("workspace2"))
 		}},
 		{"with org access, workspace2 admin user-2 can access workspace2", func(t *testing.T) {
-			_, err := user2KubeClusterClient.Cluster(org1.Join("workspace2")).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
-			require.NoError(t, err, "user-2 should be able to list configmaps in workspace2 (%s)", org1.Join("workspace2"))
+			_, err := user2KubeClusterClient.Cluster(org1.Path.Join("workspace2")).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
+			require.NoError(t, err, "user-2 should be able to list configmaps in workspace2 (%s)", org1.Path.Join("workspace2"))
 		}},
 		{"cluster admins can use wildcard clusters, non-cluster admin cannot", func(t *testing.T) {
 			// create client talking directly to root shard to test wildcard requests
@@ -176,8 +174,8 @@ func TestAuthorizer(t *testing.T) {
 			require.Error(t, err, "Only cluster admins can use all clusters at once")
 		}},
 		{"with system:admin permissions, workspace2 non-admin user-3 can list Namespaces with a bootstrap ClusterRole", func(t *testing.T) {
-			t.Logf("User-3 cannot access namespaces in %s", org1.Join("workspace2"))
-			_, err = user3KubeClusterClient.Cluster(org1.Join("workspace2")).CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+			t.Logf("User-3 cannot access namespaces in %s", org1.Path.Join("workspace2"))
+			_, err = user3KubeClusterClient.Cluster(org1.Path.Join("workspace2")).CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 			require.Error(t, err, "User-3 shouldn't be able to list Namespaces")
 
 			bootstrapClusterRole := &rbacv1.ClusterRole{
@@ -211,8 +209,8 @@ func TestAuthorizer(t *testing.T) {
 				},
 			}
 
-			t.Logf("Creating ClusterRoleBinding %s in %s", localAuthorizerClusterRoleBinding.Name, org1.Join("workspace2"))
-			_, err = user2KubeClusterClient.Cluster(org1.Join("workspace2")).RbacV1().ClusterRoleBindings().Create(ctx, localAuthorizerClusterRoleBinding, metav1.CreateOptions{})
+			t.Logf("Creating ClusterRoleBinding %s in %s", localAuthorizerClusterRoleBinding.Name, org1.Path.Join("workspace2"))
+			_, err = user2KubeClusterClient.Cluster(org1.Path.Join("workspace2")).RbacV1().ClusterRoleBindings().Create(ctx, localAuthorizerClusterRoleBinding, metav1.CreateOptions{})
 			require.NoError(t, err)
 
 			t.Logf("Creating matching ClusterRole %s in %s", bootstrapClusterRole.Name, genericcontrolplane.LocalAdminCluster)
@@ -222,7 +220,7 @@ func TestAuthorizer(t *testing.T) {
 			require.NoError(t, err)
 
 			framework.Eventually(t, func() (bool, string) {
-				if _, err := user3KubeClusterClient.Cluster(org1.Join("workspace2")).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+				if _, err := user3KubeClusterClient.Cluster(org1.Path.Join("workspace2")).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: fmt.Sprintf("kcp-authorizer-test-namespace-%d", rand.Uint32()),
 					},
@@ -230,12 +228,12 @@ func TestAuthorizer(t *testing.T) {
 					return false, fmt.Sprintf("failed to create test namespace: %v", err)
 				}
 				return true, ""
-			}, wait.ForeverTestTimeout, time.Millisecond*100, "User-3 should now be able to list Namespaces in %s", org1.Join("workspace2"))
+			}, wait.ForeverTestTimeout, time.Millisecond*100, "User-3 should now be able to list Namespaces in %s", org1.Path.Join("workspace2"))
 		}},
 		{"without org access, a deep SAR with user-1 against org2 succeeds even without org access for user-1", func(t *testing.T) {
-			t.Logf("try to list ConfigMap as user-1 in %q without access, should fail", org2.Join("workspace1"))
-			_, err := user1KubeClusterClient.Cluster(org2.Join("workspace1")).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
-			require.Errorf(t, err, "user-1 should not be able to list configmaps in %q", org2.Join("workspace1"))
+			t.Logf("try to list ConfigMap as user-1 in %q without access, should fail", org2.Path.Join("workspace1"))
+			_, err := user1KubeClusterClient.Cluster(org2.Path.Join("workspace1")).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
+			require.Errorf(t, err, "user-1 should not be able to list configmaps in %q", org2.Path.Join("workspace1"))
 
 			sar := &authorizationv1.SubjectAccessReview{
 				Spec: authorizationv1.SubjectAccessReviewSpec{
@@ -245,18 +243,18 @@ func TestAuthorizer(t *testing.T) {
 				},
 			}
 
-			t.Logf("ask with normal SAR that user-1 cannot access %q because it has no access", org2.Join("workspace1"))
-			resp, err := kubeClusterClient.Cluster(org2.Join("workspace1")).AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+			t.Logf("ask with normal SAR that user-1 cannot access %q because it has no access", org2.Path.Join("workspace1"))
+			resp, err := kubeClusterClient.Cluster(org2.Path.Join("workspace1")).AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
 			require.NoError(t, err)
-			require.Equalf(t, "access denied", resp.Status.Reason, "SAR should answer that user-1 has no workspace access in %q", org2.Join("workspace1"))
-			require.Falsef(t, resp.Status.Allowed, "SAR should correctly answer that user-1 CANNOT list configmaps in %q because it has no access to it", org2.Join("workspace1"))
+			require.Equalf(t, "access denied", resp.Status.Reason, "SAR should answer that user-1 has no workspace access in %q", org2.Path.Join("workspace1"))
+			require.Falsef(t, resp.Status.Allowed, "SAR should correctly answer that user-1 CANNOT list configmaps in %q because it has no access to it", org2.Path.Join("workspace1"))
 
-			t.Logf("ask with normal SAR that user-1 can access %q because it has access", org1.Join("workspace1"))
-			resp, err = kubeClusterClient.Cluster(org1.Join("workspace1")).AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+			t.Logf("ask with normal SAR that user-1 can access %q because it has access", org1.Path.Join("workspace1"))
+			resp, err = kubeClusterClient.Cluster(org1.Path.Join("workspace1")).AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
 			require.NoError(t, err)
-			require.Truef(t, resp.Status.Allowed, "SAR should correctly answer that user-1 CAN list configmaps in %q because it has access to %q", org2.Join("workspace1"), org1.Join("workspace1"))
+			require.Truef(t, resp.Status.Allowed, "SAR should correctly answer that user-1 CAN list configmaps in %q because it has access to %q", org2.Path.Join("workspace1"), org1.Path.Join("workspace1"))
 
-			t.Logf("ask with deep SAR that user-1 hypothetically could list configmaps in %q if it had access", org2.Join("workspace1"))
+			t.Logf("ask with deep SAR that user-1 hypothetically could list configmaps in %q if it had access", org2.Path.Join("workspace1"))
 			deepSARClient, err := kcpkubernetesclientset.NewForConfig(authorization.WithDeepSARConfig(rest.CopyConfig(server.RootShardSystemMasterBaseConfig(t))))
 			require.NoError(t, err)
 			framework.Eventually(t, func() (bool, string) {
@@ -265,7 +263,7 @@ func TestAuthorizer(t *testing.T) {
 					return false, fmt.Sprintf("failed to create SAR: %v", err)
 				}
 				return resp.Status.Allowed, resp.Status.Reason
-			}, wait.ForeverTestTimeout, time.Millisecond*100, "SAR should answer hypothetically that user-1 could list configmaps in %q if it had access", org2.Join("workspace1"))
+			}, wait.ForeverTestTimeout, time.Millisecond*100, "SAR should answer hypothetically that user-1 could list configmaps in %q if it had access", org2.Path.Join("workspace1"))
 		}},
 	}
 
@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestApplyUnstructuredReportsNoOp(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	wsPath, _ := framework.NewWorkspaceFixture(t, server, orgPath)
+
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct dynamic cluster client")
+	client := dynamicClusterClient.Cluster(wsPath).Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("default")
+
+	configMap := func(value string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "applied",
+				"namespace": "default",
+			},
+			"data": map[string]interface{}{
+				"key": value,
+			},
+		}}
+	}
+
+	t.Logf("Applying the configmap for the first time")
+	created, changed, err := framework.ApplyUnstructured(ctx, client, configMap("value"), "e2e-test-runner")
+	require.NoError(t, err)
+	require.True(t, changed, "expected the first apply to create the configmap")
+
+	t.Logf("Applying the same configmap again")
+	reapplied, changed, err := framework.ApplyUnstructured(ctx, client, configMap("value"), "e2e-test-runner")
+	require.NoError(t, err)
+	require.False(t, changed, "expected the second apply to be a no-op")
+	require.Equal(t, created.Object["data"], reapplied.Object["data"])
+
+	t.Logf("Applying a changed configmap")
+	updated, changed, err := framework.ApplyUnstructured(ctx, client, configMap("other"), "e2e-test-runner")
+	require.NoError(t, err)
+	require.True(t, changed, "expected the apply of a changed configmap to update it")
+	require.NotEqual(t, reapplied.GetResourceVersion(), updated.GetResourceVersion())
+}
+
+func TestApplyManifestsAppliesClusterScopedFirst(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	wsPath, _ := framework.NewWorkspaceFixture(t, server, orgPath)
+
+	cfg := server.BaseConfig(t)
+
+	t.Logf("Applying a configmap before the namespace it lives in")
+	err := framework.ApplyManifests(ctx, t, cfg, wsPath, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: applied
+  namespace: applied
+data:
+  key: value
+`, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "applied"}})
+	require.NoError(t, err)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kube cluster client")
+	configMap, err := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("applied").Get(ctx, "applied", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "value", configMap.Data["key"])
+	require.Equal(t, framework.ApplyManifestsFieldManager, configMap.ManagedFields[0].Manager)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+)
+
+// apiExportIdentityPollInterval is the interval WaitForAPIExportIdentity polls the APIExport with.
+const apiExportIdentityPollInterval = 100 * time.Millisecond
+
+// APIExportGetter gets APIExports of one logical cluster, e.g. the typed APIExport client of a workspace.
+type APIExportGetter interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*apisv1alpha1.APIExport, error)
+}
+
+// GetAPIExportIdentity returns the identity hash of the given APIExport, or an error if its identity
+// is not valid yet.
+func GetAPIExportIdentity(ctx context.Context, client APIExportGetter, name string) (string, error) {
+	export, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if !conditions.IsTrue(export, apisv1alpha1.APIExportIdentityValid) || export.Status.IdentityHash == "" {
+		msg := conditions.GetMessage(export, apisv1alpha1.APIExportIdentityValid)
+		if msg == "" {
+			msg = "not reconciled yet"
+		}
+		return "", fmt.Errorf("identity of APIExport %s|%s is not valid: %s", logicalcluster.From(export), name, msg)
+	}
+	return export.Status.IdentityHash, nil
+}
+
+// WaitForAPIExportIdentity waits for the identity of the given APIExport to become valid and returns
+// its identity hash. Errors getting the APIExport, e.g. because it does not exist yet, are retried.
+// It gives up when the context is done, returning the last error observed.
+func WaitForAPIExportIdentity(ctx context.Context, client APIExportGetter, name string) (string, error) {
+	var identityHash string
+	var lastErr error
+	err := wait.PollImmediateUntilWithContext(ctx, apiExportIdentityPollInterval, func(ctx context.Context) (bool, error) {
+		identityHash, lastErr = GetAPIExportIdentity(ctx, client, name)
+		return lastErr == nil, nil
+	})
+	if err != nil {
+		if lastErr != nil {
+			return "", lastErr
+		}
+		return "", err
+	}
+	return identityHash, nil
+}
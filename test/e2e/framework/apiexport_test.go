@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+)
+
+// fakeAPIExportGetter serves the APIExports it holds by name.
+type fakeAPIExportGetter struct {
+	lock    sync.Mutex
+	exports map[string]*apisv1alpha1.APIExport
+}
+
+func (g *fakeAPIExportGetter) Get(_ context.Context, name string, _ metav1.GetOptions) (*apisv1alpha1.APIExport, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	export, ok := g.exports[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiexports"), name)
+	}
+	return export, nil
+}
+
+func TestAPIExportIdentity(t *testing.T) {
+	valid := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "valid", Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org"}},
+		Status:     apisv1alpha1.APIExportStatus{IdentityHash: "hash"},
+	}
+	conditions.MarkTrue(valid, apisv1alpha1.APIExportIdentityValid)
+	invalid := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid", Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org"}},
+	}
+	conditions.MarkFalse(invalid, apisv1alpha1.APIExportIdentityValid, apisv1alpha1.IdentityVerificationFailedReason, conditionsv1alpha1.ConditionSeverityError, "secret not found")
+	unreconciled := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "unreconciled", Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org"}},
+	}
+	client := &fakeAPIExportGetter{exports: map[string]*apisv1alpha1.APIExport{
+		"valid":        valid,
+		"invalid":      invalid,
+		"unreconciled": unreconciled,
+	}}
+
+	tests := []struct {
+		name     string
+		export   string
+		wantHash string
+		wantErr  string
+	}{
+		{name: "valid identity", export: "valid", wantHash: "hash"},
+		{name: "invalid identity", export: "invalid", wantErr: "identity of APIExport root:org|invalid is not valid: secret not found"},
+		{name: "not reconciled", export: "unreconciled", wantErr: "identity of APIExport root:org|unreconciled is not valid: not reconciled yet"},
+		{name: "not found", export: "missing", wantErr: `apiexports.apis.kcp.io "missing" not found`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := GetAPIExportIdentity(context.Background(), client, tt.export)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantHash, hash)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			hash, err = WaitForAPIExportIdentity(ctx, client, tt.export)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr, "expected the last error after the timeout")
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantHash, hash)
+		})
+	}
+}
+
+func TestWaitForAPIExportIdentity(t *testing.T) {
+	client := &fakeAPIExportGetter{exports: map[string]*apisv1alpha1.APIExport{}}
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		export := &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "export"},
+			Status:     apisv1alpha1.APIExportStatus{IdentityHash: "hash"},
+		}
+		conditions.MarkTrue(export, apisv1alpha1.APIExportIdentityValid)
+		client.lock.Lock()
+		defer client.lock.Unlock()
+		client.exports["export"] = export
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	hash, err := WaitForAPIExportIdentity(ctx, client, "export")
+	require.NoError(t, err)
+	require.Equal(t, "hash", hash)
+}
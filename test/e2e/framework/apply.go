@@ -0,0 +1,241 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	extensionsapiserver "k8s.io/apiextensions-apiserver/pkg/apiserver"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/utils/pointer"
+
+	kcpscheme "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
+)
+
+// ApplyManifestsFieldManager is the field manager ApplyManifests applies with.
+const ApplyManifestsFieldManager = "e2e-test-runner"
+
+// applyScheme knows the typed objects that can be passed to ApplyManifests.
+var applyScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(kubernetesscheme.AddToScheme(applyScheme))
+	utilruntime.Must(kcpscheme.AddToScheme(applyScheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(applyScheme))
+}
+
+// ApplyUnstructured server-side applies obj with the given field manager and returns the
+// applied object and whether the apply changed it.
+//
+// Server-side apply reports success for no-op applies too, so to tell both apart the existing
+// and the applied object are compared without their managedFields and resourceVersion, which
+// an apply can touch without changing the object's content.
+func ApplyUnstructured(ctx context.Context, client dynamic.ResourceInterface, obj *unstructured.Unstructured, fieldManager string) (*unstructured.Unstructured, bool, error) {
+	existing, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		existing = nil
+	case err != nil:
+		return nil, false, err
+	}
+
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal %s: %w", obj.GetName(), err)
+	}
+
+	applied, err := client.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{FieldManager: fieldManager, Force: pointer.Bool(true)})
+	if err != nil {
+		return nil, false, applyError(obj, err)
+	}
+
+	if existing == nil {
+		return applied, true, nil
+	}
+	return applied, !equality.Semantic.DeepEqual(withoutApplyMetadata(existing), withoutApplyMetadata(applied)), nil
+}
+
+// withoutApplyMetadata returns a copy of obj without the metadata that changes on every apply.
+func withoutApplyMetadata(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+	return obj
+}
+
+// ApplyManifests server-side applies the given manifests to the given workspace and returns the
+// first error encountered. Field ownership conflicts are reported with the conflicting field
+// managers and the fields they own. A manifest is either a YAML or JSON string, or a typed object of a
+// kube, kcp or apiextensions API.
+//
+// A single REST mapper and dynamic client is used for all manifests, such that discovery is only
+// done once per call, and is only refreshed when a kind is not found. Cluster-scoped objects are
+// applied before namespaced ones. Objects of a CRD applied in the same call cannot be mapped
+// before the CRD is established, hence they have to be applied in a later call.
+func ApplyManifests(ctx context.Context, t *testing.T, cfg *rest.Config, workspace logicalcluster.Path, manifests ...any) error {
+	t.Helper()
+
+	discoveryClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := kcpdynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient.Cluster(workspace).Discovery()))
+
+	type object struct {
+		obj     *unstructured.Unstructured
+		mapping *meta.RESTMapping
+	}
+	var clusterScoped, namespaced []object
+	for _, manifest := range manifests {
+		obj, err := manifestToUnstructured(manifest)
+		if err != nil {
+			return err
+		}
+
+		gvk := obj.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if meta.IsNoMatchError(err) {
+			mapper.Reset()
+			mapping, err = mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		}
+		if err != nil {
+			return fmt.Errorf("error getting REST mapping for %s: %w", gvk, err)
+		}
+
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			namespaced = append(namespaced, object{obj: obj, mapping: mapping})
+		} else {
+			clusterScoped = append(clusterScoped, object{obj: obj, mapping: mapping})
+		}
+	}
+
+	for _, o := range append(clusterScoped, namespaced...) {
+		var client dynamic.ResourceInterface
+		if o.mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			t.Logf("Applying %s %s/%s to workspace %q", o.obj.GroupVersionKind(), o.obj.GetNamespace(), o.obj.GetName(), workspace)
+			client = dynamicClient.Cluster(workspace).Resource(o.mapping.Resource).Namespace(o.obj.GetNamespace())
+		} else {
+			t.Logf("Applying %s %s to workspace %q", o.obj.GroupVersionKind(), o.obj.GetName(), workspace)
+			client = dynamicClient.Cluster(workspace).Resource(o.mapping.Resource)
+		}
+
+		data, err := o.obj.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", o.obj.GetName(), err)
+		}
+		if _, err := client.Patch(ctx, o.obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{FieldManager: ApplyManifestsFieldManager}); err != nil {
+			return applyError(o.obj, err)
+		}
+	}
+
+	return nil
+}
+
+// applyError wraps a server-side apply conflict of obj with a message naming the conflicting field
+// managers and the fields they own. Other errors are returned as is.
+func applyError(obj *unstructured.Unstructured, err error) error {
+	if !apierrors.IsConflict(err) {
+		return err
+	}
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || status.Status().Details == nil {
+		return err
+	}
+
+	fields := map[string][]string{}
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		// the message is of the form `conflict with "manager" using v1`
+		manager := cause.Message
+		if _, err := fmt.Sscanf(strings.TrimPrefix(cause.Message, "conflict with "), "%q", &manager); err != nil {
+			manager = cause.Message
+		}
+		fields[manager] = append(fields[manager], cause.Field)
+	}
+	if len(fields) == 0 {
+		return err
+	}
+
+	managers := make([]string, 0, len(fields))
+	for manager := range fields {
+		managers = append(managers, manager)
+	}
+	sort.Strings(managers)
+	conflicts := make([]string, 0, len(managers))
+	for _, manager := range managers {
+		conflicts = append(conflicts, fmt.Sprintf("field manager %q owns %s", manager, strings.Join(fields[manager], ", ")))
+	}
+
+	name := obj.GetName()
+	if obj.GetNamespace() != "" {
+		name = obj.GetNamespace() + "/" + name
+	}
+	return fmt.Errorf("failed to apply %s %s: conflicts with other field managers: %s: %w", obj.GroupVersionKind().Kind, name, strings.Join(conflicts, "; "), err)
+}
+
+func manifestToUnstructured(manifest any) (*unstructured.Unstructured, error) {
+	switch manifest := manifest.(type) {
+	case string:
+		obj := &unstructured.Unstructured{}
+		if _, _, err := extensionsapiserver.Codecs.UniversalDeserializer().Decode([]byte(manifest), nil, obj); err != nil {
+			return nil, fmt.Errorf("failed to decode manifest: %w", err)
+		}
+		return obj, nil
+	case runtime.Object:
+		gvks, _, err := applyScheme.ObjectKinds(manifest)
+		if err != nil {
+			return nil, err
+		}
+		raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(manifest)
+		if err != nil {
+			return nil, err
+		}
+		obj := &unstructured.Unstructured{Object: raw}
+		obj.SetGroupVersionKind(gvks[0])
+		return obj, nil
+	default:
+		return nil, fmt.Errorf("unsupported manifest type %T", manifest)
+	}
+}
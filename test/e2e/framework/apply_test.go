@@ -0,0 +1,56 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestApplyError(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("apps/v1")
+	obj.SetKind("Deployment")
+	obj.SetNamespace("default")
+	obj.SetName("nginx")
+
+	conflict := apierrors.NewApplyConflict([]metav1.StatusCause{
+		{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "kube-controller-manager" using apps/v1`, Field: ".spec.replicas"},
+		{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "e2e-test-runner"`, Field: ".metadata.labels.app"},
+		{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "kube-controller-manager" using apps/v1`, Field: ".spec.template.spec.containers[name=\"nginx\"].image"},
+	}, "Apply failed with 3 conflicts")
+
+	err := applyError(obj, conflict)
+	require.EqualError(t, err, `failed to apply Deployment default/nginx: conflicts with other field managers: `+
+		`field manager "e2e-test-runner" owns .metadata.labels.app; `+
+		`field manager "kube-controller-manager" owns .spec.replicas, .spec.template.spec.containers[name="nginx"].image: `+
+		`Apply failed with 3 conflicts`)
+	require.True(t, apierrors.IsConflict(err), "expected the conflict to be preserved")
+
+	plainConflict := apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "nginx", errors.New("the object has been modified"))
+	require.Equal(t, plainConflict, applyError(obj, plainConflict), "expected conflicts without field manager causes to be returned as is")
+
+	notFound := apierrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "nginx")
+	require.Equal(t, notFound, applyError(obj, notFound))
+}
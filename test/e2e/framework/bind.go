@@ -17,12 +17,16 @@ limitations under the License.
 package framework
 
 import (
+	"context"
 	"testing"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/stretchr/testify/require"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
 )
 
 type BindComputeOption func(t *testing.T, w *bindCompute)
@@ -125,3 +129,16 @@ func WithLocationSelectorWorkloadBindOption(selectors ...metav1.LabelSelector) B
 		}
 	}
 }
+
+// RequirePlacementScheduledTo requires the given placement to be scheduled to the SyncTarget with the given
+// name in the given logical cluster, i.e. its internal SyncTarget annotation holds the key of that SyncTarget.
+func RequirePlacementScheduledTo(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, path logicalcluster.Path, name string, cluster logicalcluster.Name, syncTargetName string) {
+	t.Helper()
+
+	placement, err := client.Cluster(path).SchedulingV1alpha1().Placements().Get(ctx, name, metav1.GetOptions{})
+	require.NoError(t, err, "failed to get placement %s|%s", path, name)
+
+	expected := workloadv1alpha1.ToSyncTargetKey(cluster, syncTargetName)
+	require.Equal(t, expected, placement.Annotations[workloadv1alpha1.InternalSyncTargetPlacementAnnotationKey],
+		"expected placement %s|%s to be scheduled to SyncTarget %s|%s", path, name, cluster, syncTargetName)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// ReplicationScenario gives access to resources of one kind in a logical cluster, both in the shard
+// they are created in and in the cache server they are replicated to.
+type ReplicationScenario interface {
+	// GetSourceResource returns the resource with the given name from the shard.
+	GetSourceResource(ctx context.Context, name string) (*unstructured.Unstructured, error)
+	// GetCachedResource returns the resource with the given name from the cache server.
+	GetCachedResource(ctx context.Context, name string) (*unstructured.Unstructured, error)
+}
+
+// RequireAllReplicated waits until every named resource of the scenario is replicated to the cache
+// server and matches its source, except for the resource version and the shard annotation. It fails
+// with the first mismatch that persists until the timeout.
+func RequireAllReplicated(ctx context.Context, t *testing.T, scenario ReplicationScenario, names []string) {
+	t.Helper()
+
+	Eventually(t, func() (bool, string) {
+		for _, name := range names {
+			if mismatch := replicationMismatch(ctx, scenario, name); mismatch != "" {
+				return false, mismatch
+			}
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "%d resources were not replicated to the cache server", len(names))
+}
+
+// replicationMismatch returns why the named resource of the scenario doesn't match its replica in
+// the cache server, or an empty string if it does.
+func replicationMismatch(ctx context.Context, scenario ReplicationScenario, name string) string {
+	source, err := scenario.GetSourceResource(ctx, name)
+	if err != nil {
+		return fmt.Sprintf("failed to get source resource %s: %v", name, err)
+	}
+	cached, err := scenario.GetCachedResource(ctx, name)
+	if err != nil {
+		return fmt.Sprintf("failed to get cached resource %s: %v", name, err)
+	}
+	if _, found := cached.GetAnnotations()[genericapirequest.AnnotationKey]; !found {
+		return fmt.Sprintf("cached resource %s doesn't have the %s annotation", name, genericapirequest.AnnotationKey)
+	}
+
+	source, cached = source.DeepCopy(), cached.DeepCopy()
+	unstructured.RemoveNestedField(source.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(cached.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(cached.Object, "metadata", "annotations", genericapirequest.AnnotationKey)
+	if len(cached.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(cached.Object, "metadata", "annotations")
+	}
+	if len(source.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(source.Object, "metadata", "annotations")
+	}
+	if diff := cmp.Diff(source.Object, cached.Object); diff != "" {
+		return fmt.Sprintf("cached resource %s is different from the source: %s", name, diff)
+	}
+	return ""
+}
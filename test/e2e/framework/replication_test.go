@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+type fakeReplicationScenario struct {
+	source, cached map[string]*unstructured.Unstructured
+}
+
+func (s *fakeReplicationScenario) GetSourceResource(_ context.Context, name string) (*unstructured.Unstructured, error) {
+	return get(s.source, name)
+}
+
+func (s *fakeReplicationScenario) GetCachedResource(_ context.Context, name string) (*unstructured.Unstructured, error) {
+	return get(s.cached, name)
+}
+
+func get(objs map[string]*unstructured.Unstructured, name string) (*unstructured.Unstructured, error) {
+	obj, found := objs[name]
+	if !found {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: "things"}, name)
+	}
+	return obj, nil
+}
+
+func newThing(name, resourceVersion, value string, annotations map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{"value": value}}}
+	obj.SetName(name)
+	obj.SetResourceVersion(resourceVersion)
+	obj.SetAnnotations(annotations)
+	return obj
+}
+
+func TestReplicationMismatch(t *testing.T) {
+	shard := map[string]string{genericapirequest.AnnotationKey: "root"}
+	scenario := &fakeReplicationScenario{
+		source: map[string]*unstructured.Unstructured{
+			"replicated":     newThing("replicated", "1", "a", nil),
+			"different":      newThing("different", "1", "a", nil),
+			"unannotated":    newThing("unannotated", "1", "a", nil),
+			"not-replicated": newThing("not-replicated", "1", "a", nil),
+		},
+		cached: map[string]*unstructured.Unstructured{
+			"replicated":  newThing("replicated", "2", "a", shard),
+			"different":   newThing("different", "2", "b", shard),
+			"unannotated": newThing("unannotated", "2", "a", nil),
+		},
+	}
+
+	ctx := context.Background()
+	require.Empty(t, replicationMismatch(ctx, scenario, "replicated"))
+	require.True(t, strings.HasPrefix(replicationMismatch(ctx, scenario, "different"), "cached resource different is different from the source"))
+	require.Equal(t, "cached resource unannotated doesn't have the kcp.io/shard annotation", replicationMismatch(ctx, scenario, "unannotated"))
+	require.Equal(t, `failed to get cached resource not-replicated: things "not-replicated" not found`, replicationMismatch(ctx, scenario, "not-replicated"))
+	require.Equal(t, `failed to get source resource unknown: things "unknown" not found`, replicationMismatch(ctx, scenario, "unknown"))
+
+	require.Equal(t, "root", scenario.cached["replicated"].GetAnnotations()[genericapirequest.AnnotationKey], "the cached resource must not be mutated")
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"testing"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+)
+
+// ScenarioSpec declaratively describes an organization, its workspaces and the users admitted to them.
+type ScenarioSpec struct {
+	// OrgOptions are applied to the organization workspace.
+	OrgOptions []UnprivilegedWorkspaceOption
+	// PrivilegedOrgOptions are applied to the organization workspace in addition to OrgOptions. If set,
+	// the organization is created with system:masters privileges.
+	PrivilegedOrgOptions []PrivilegedWorkspaceOption
+
+	// Members are users admitted to the organization.
+	Members []string
+	// Admins are users admitted to the organization as admins.
+	Admins []string
+
+	// Workspaces are created in the organization, in order.
+	Workspaces []ScenarioWorkspaceSpec
+}
+
+// ScenarioWorkspaceSpec describes a workspace of a scenario and the users admitted to it.
+type ScenarioWorkspaceSpec struct {
+	// Name is the name of the workspace, and the key of the workspace in the returned handles.
+	Name string
+	// Options are applied to the workspace.
+	Options []UnprivilegedWorkspaceOption
+
+	// Members are users admitted to the workspace.
+	Members []string
+	// Admins are users admitted to the workspace as admins.
+	Admins []string
+}
+
+// WorkspaceHandle references a workspace created by NewScenario.
+type WorkspaceHandle struct {
+	// Path is the logical cluster path of the workspace.
+	Path logicalcluster.Path
+	// Workspace is the created workspace.
+	Workspace *tenancyv1alpha1.Workspace
+}
+
+// NewScenario creates the organization and workspaces described by spec and admits the given users.
+// Users are admitted in the order of the spec, first to the organization, then to each workspace,
+// members before admins. It returns the handle of the organization and the workspace handles by name.
+func NewScenario(t *testing.T, server RunningServer, spec ScenarioSpec) (WorkspaceHandle, map[string]WorkspaceHandle) {
+	t.Helper()
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct client for server")
+
+	var org WorkspaceHandle
+	if len(spec.PrivilegedOrgOptions) > 0 {
+		options := make([]PrivilegedWorkspaceOption, 0, len(spec.OrgOptions)+len(spec.PrivilegedOrgOptions))
+		for _, o := range spec.OrgOptions {
+			options = append(options, PrivilegedWorkspaceOption(o))
+		}
+		options = append(options, spec.PrivilegedOrgOptions...)
+		org.Path, org.Workspace = NewPrivilegedOrganizationFixture(t, server, options...)
+	} else {
+		org.Path, org.Workspace = NewOrganizationFixture(t, server, spec.OrgOptions...)
+	}
+
+	workspaces := make(map[string]WorkspaceHandle, len(spec.Workspaces))
+	for _, ws := range spec.Workspaces {
+		require.NotContains(t, workspaces, ws.Name, "duplicate workspace in scenario")
+		var handle WorkspaceHandle
+		handle.Path, handle.Workspace = NewWorkspaceFixture(t, server, org.Path, append([]UnprivilegedWorkspaceOption{WithName(ws.Name)}, ws.Options...)...)
+		workspaces[ws.Name] = handle
+	}
+
+	admit := func(path logicalcluster.Path, members, admins []string) {
+		if len(members) > 0 {
+			AdmitWorkspaceAccess(ctx, t, kubeClusterClient, path, members, nil, false)
+		}
+		if len(admins) > 0 {
+			AdmitWorkspaceAccess(ctx, t, kubeClusterClient, path, admins, nil, true)
+		}
+	}
+	admit(org.Path, spec.Members, spec.Admins)
+	for _, ws := range spec.Workspaces {
+		admit(workspaces[ws.Name].Path, ws.Members, ws.Admins)
+	}
+
+	return org, workspaces
+}
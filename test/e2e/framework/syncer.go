@@ -39,11 +39,14 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/dynamic"
 	kubernetesclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
+	watchtools "k8s.io/client-go/tools/watch"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/yaml"
@@ -72,6 +75,8 @@ func NewSyncerFixture(t *testing.T, server RunningServer, path logicalcluster.Pa
 		upstreamServer: server,
 		syncTargetPath: path,
 		syncTargetName: "psyncer-01",
+
+		syncerReadyTimeout: wait.ForeverTestTimeout,
 	}
 	for _, opt := range opts {
 		opt(t, sf)
@@ -91,6 +96,8 @@ type syncerFixture struct {
 	extraResourcesToSync []string
 	apiExports           []string
 	prepareDownstream    func(config *rest.Config, isFakePCluster bool)
+
+	syncerReadyTimeout time.Duration
 }
 
 func WithSyncTargetName(name string) SyncerOption {
@@ -130,6 +137,15 @@ func WithDownstreamPreparation(prepare func(config *rest.Config, isFakePCluster
 	}
 }
 
+// WithSyncerReadyTimeout sets how long to wait for the SyncTarget to become ready
+// after the syncer was started. It defaults to wait.ForeverTestTimeout.
+func WithSyncerReadyTimeout(d time.Duration) SyncerOption {
+	return func(t *testing.T, sf *syncerFixture) {
+		t.Helper()
+		sf.syncerReadyTimeout = d
+	}
+}
+
 // CreateSyncTargetAndApplyToDownstream creates a SyncTarget resource through the `workload sync` CLI command,
 // applies the syncer-related resources in the physical cluster.
 // No resource will be effectively synced after calling this method.
@@ -451,7 +467,7 @@ func (sf *appliedSyncerFixture) StartSyncer(t *testing.T) *StartedSyncerFixture
 	} else {
 		// Start an in-process syncer
 		sf.SyncerConfig.DNSImage = "TODO"
-		err := syncer.StartSyncer(ctx, sf.SyncerConfig, 2, 5*time.Second, sf.SyncerID)
+		_, err := syncer.StartSyncer(ctx, sf.SyncerConfig, 2, 5*time.Second, sf.SyncerID)
 		require.NoError(t, err, "syncer failed to start")
 
 		_, err = sf.DownstreamKubeClient.RbacV1().ClusterRoles().Create(ctx, &rbacv1.ClusterRole{
@@ -643,6 +659,8 @@ func (sf *StartedSyncerFixture) StopHeartBeat(t *testing.T) {
 // WaitForSyncTargetReady waits for the SyncTarget to be ready.
 // The SyncTarget becoming ready indicates that the syncer on the related
 // physical cluster is healthy and has successfully sent a heartbeat to kcp.
+// If the SyncTarget does not become ready within the configured timeout, the
+// test fails with the last observed Ready condition.
 func (sf *StartedSyncerFixture) WaitForSyncTargetReady(ctx context.Context, t *testing.T) {
 	t.Helper()
 
@@ -650,9 +668,42 @@ func (sf *StartedSyncerFixture) WaitForSyncTargetReady(ctx context.Context, t *t
 
 	kcpClusterClient, err := kcpclientset.NewForConfig(cfg.UpstreamConfig)
 	require.NoError(t, err)
-	EventuallyReady(t, func() (conditions.Getter, error) {
-		return kcpClusterClient.Cluster(cfg.SyncTargetPath).WorkloadV1alpha1().SyncTargets().Get(ctx, cfg.SyncTargetName, metav1.GetOptions{})
-	}, "Waiting for cluster %q condition %q", cfg.SyncTargetName, conditionsv1alpha1.ReadyCondition)
+	syncTargets := kcpClusterClient.Cluster(cfg.SyncTargetPath).WorkloadV1alpha1().SyncTargets()
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", cfg.SyncTargetName).String()
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return syncTargets.List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return syncTargets.Watch(ctx, options)
+		},
+	}
+
+	timeout := sf.syncerReadyTimeout
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	last := "no condition present"
+	_, err = watchtools.UntilWithSync(waitCtx, lw, &workloadv1alpha1.SyncTarget{}, nil, func(event watch.Event) (bool, error) {
+		syncTarget, ok := event.Object.(*workloadv1alpha1.SyncTarget)
+		if !ok {
+			return false, nil
+		}
+		if event.Type == watch.Deleted {
+			return false, fmt.Errorf("SyncTarget %q was deleted", cfg.SyncTargetName)
+		}
+		condition := conditions.Get(syncTarget, conditionsv1alpha1.ReadyCondition)
+		if condition == nil {
+			return false, nil
+		}
+		last = fmt.Sprintf("%s: %s: %s", condition.Status, condition.Reason, condition.Message)
+		return condition.Status == corev1.ConditionTrue, nil
+	})
+	if err != nil {
+		t.Fatalf("SyncTarget %q did not become %s within %s: %v, last observed condition: %s", cfg.SyncTargetName, conditionsv1alpha1.ReadyCondition, timeout, err, last)
+	}
 	t.Logf("Cluster %q is %s", cfg.SyncTargetName, conditionsv1alpha1.ReadyCondition)
 }
 
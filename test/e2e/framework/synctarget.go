@@ -0,0 +1,152 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+)
+
+// SyncTargetBuilder builds SyncTargets for tests that do not need a syncer, validating the
+// referenced APIExports before the SyncTarget is created instead of waiting for the
+// scheduling controllers to report them as invalid.
+type SyncTargetBuilder struct {
+	name       string
+	labels     map[string]string
+	cells      map[string]string
+	apiExports []string
+}
+
+// NewSyncTargetBuilder returns a builder for a SyncTarget with the given name.
+func NewSyncTargetBuilder(name string) *SyncTargetBuilder {
+	return &SyncTargetBuilder{name: name}
+}
+
+// WithLabels adds the given labels to the SyncTarget.
+func (b *SyncTargetBuilder) WithLabels(labels map[string]string) *SyncTargetBuilder {
+	if b.labels == nil {
+		b.labels = map[string]string{}
+	}
+	for k, v := range labels {
+		b.labels[k] = v
+	}
+	return b
+}
+
+// WithCells adds the given cells to the SyncTarget.
+func (b *SyncTargetBuilder) WithCells(cells map[string]string) *SyncTargetBuilder {
+	if b.cells == nil {
+		b.cells = map[string]string{}
+	}
+	for k, v := range cells {
+		b.cells[k] = v
+	}
+	return b
+}
+
+// WithAPIExports adds supported APIExports to the SyncTarget. Like the --apiexports flag of
+// the workload sync command, an export is referenced as <workspace path>:<name>, or just
+// <name> for an APIExport in the workspace of the SyncTarget. Without supported APIExports,
+// the kubernetes APIExport in the workspace of the SyncTarget is used.
+func (b *SyncTargetBuilder) WithAPIExports(exports ...string) *SyncTargetBuilder {
+	b.apiExports = append(b.apiExports, exports...)
+	return b
+}
+
+// Build validates the name and the APIExport references of the builder and returns the SyncTarget.
+func (b *SyncTargetBuilder) Build() (*workloadv1alpha1.SyncTarget, error) {
+	var errs []error
+	if msgs := validation.IsDNS1123Subdomain(b.name); len(msgs) > 0 {
+		errs = append(errs, fmt.Errorf("invalid SyncTarget name %q: %s", b.name, strings.Join(msgs, ", ")))
+	}
+
+	var supportedAPIExports []tenancyv1alpha1.APIExportReference
+	for _, export := range b.apiExports {
+		path, name := logicalcluster.NewPath(export).Split()
+		if msgs := validation.IsDNS1123Subdomain(name); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("invalid APIExport reference %q: invalid name %q: %s", export, name, strings.Join(msgs, ", ")))
+			continue
+		}
+		if !path.Empty() && (path == logicalcluster.Wildcard || !path.IsValid()) {
+			errs = append(errs, fmt.Errorf("invalid APIExport reference %q: invalid workspace path %q", export, path))
+			continue
+		}
+		supportedAPIExports = append(supportedAPIExports, tenancyv1alpha1.APIExportReference{
+			Path:   path.String(),
+			Export: name,
+		})
+	}
+	if len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+
+	return &workloadv1alpha1.SyncTarget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   b.name,
+			Labels: b.labels,
+		},
+		Spec: workloadv1alpha1.SyncTargetSpec{
+			SupportedAPIExports: supportedAPIExports,
+			Cells:               b.cells,
+		},
+	}, nil
+}
+
+// Create builds the SyncTarget, verifies that every referenced APIExport exists and exports
+// at least one API, and creates the SyncTarget in the given workspace.
+func (b *SyncTargetBuilder) Create(ctx context.Context, client kcpclientset.ClusterInterface, path logicalcluster.Path) (*workloadv1alpha1.SyncTarget, error) {
+	syncTarget, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, ref := range syncTarget.Spec.SupportedAPIExports {
+		exportPath := logicalcluster.NewPath(ref.Path)
+		if exportPath.Empty() {
+			exportPath = path
+		}
+		export, err := client.Cluster(exportPath).ApisV1alpha1().APIExports().Get(ctx, ref.Export, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("APIExport %s|%s not found", exportPath, ref.Export))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(export.Spec.LatestResourceSchemas) == 0 {
+			errs = append(errs, fmt.Errorf("APIExport %s|%s does not export any APIs", exportPath, ref.Export))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+
+	return client.Cluster(path).WorkloadV1alpha1().SyncTargets().Create(ctx, syncTarget, metav1.CreateOptions{})
+}
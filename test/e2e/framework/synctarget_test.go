@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	kcpfakeclient "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster/fake"
+)
+
+func TestSyncTargetBuilderBuild(t *testing.T) {
+	tests := []struct {
+		name       string
+		syncTarget string
+		exports    []string
+		want       []tenancyv1alpha1.APIExportReference
+		wantErr    string
+	}{
+		{
+			name:       "no exports",
+			syncTarget: "st",
+		},
+		{
+			name:       "export in another workspace",
+			syncTarget: "st",
+			exports:    []string{"root:compute:kubernetes"},
+			want:       []tenancyv1alpha1.APIExportReference{{Path: "root:compute", Export: "kubernetes"}},
+		},
+		{
+			name:       "export in the same workspace",
+			syncTarget: "st",
+			exports:    []string{"kubernetes", "root:org:cowboys"},
+			want: []tenancyv1alpha1.APIExportReference{
+				{Export: "kubernetes"},
+				{Path: "root:org", Export: "cowboys"},
+			},
+		},
+		{
+			name:       "invalid sync target name",
+			syncTarget: "Invalid_Name",
+			wantErr:    `invalid SyncTarget name "Invalid_Name"`,
+		},
+		{
+			name:       "empty export",
+			syncTarget: "st",
+			exports:    []string{""},
+			wantErr:    `invalid APIExport reference "": invalid name ""`,
+		},
+		{
+			name:       "export without name",
+			syncTarget: "st",
+			exports:    []string{"root:compute:"},
+			wantErr:    `invalid APIExport reference "root:compute:": invalid name ""`,
+		},
+		{
+			name:       "invalid workspace path",
+			syncTarget: "st",
+			exports:    []string{"root:Compute:kubernetes"},
+			wantErr:    `invalid APIExport reference "root:Compute:kubernetes": invalid workspace path "root:Compute"`,
+		},
+		{
+			name:       "wildcard workspace path",
+			syncTarget: "st",
+			exports:    []string{"*:kubernetes"},
+			wantErr:    `invalid APIExport reference "*:kubernetes": invalid workspace path "*"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncTarget, err := NewSyncTargetBuilder(tt.syncTarget).
+				WithLabels(map[string]string{"region": "us"}).
+				WithAPIExports(tt.exports...).
+				Build()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.syncTarget, syncTarget.Name)
+			require.Equal(t, map[string]string{"region": "us"}, syncTarget.Labels)
+			require.Equal(t, tt.want, syncTarget.Spec.SupportedAPIExports)
+		})
+	}
+}
+
+func TestSyncTargetBuilderCreate(t *testing.T) {
+	apiExport := func(cluster, name string, schemas ...string) *apisv1alpha1.APIExport {
+		return &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{logicalcluster.AnnotationKey: cluster},
+			},
+			Spec: apisv1alpha1.APIExportSpec{
+				LatestResourceSchemas: schemas,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		exports []string
+		wantErr string
+	}{
+		{
+			name:    "existing exports",
+			exports: []string{"root:compute:kubernetes", "cowboys"},
+		},
+		{
+			name:    "missing export in another workspace",
+			exports: []string{"root:compute:missing"},
+			wantErr: "APIExport root:compute|missing not found",
+		},
+		{
+			name:    "missing export in the same workspace",
+			exports: []string{"kubernetes"},
+			wantErr: "APIExport root:org:ws|kubernetes not found",
+		},
+		{
+			name:    "export without APIs",
+			exports: []string{"root:compute:empty"},
+			wantErr: "APIExport root:compute|empty does not export any APIs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := kcpfakeclient.NewSimpleClientset(
+				apiExport("root:compute", "kubernetes", "v1.services.core"),
+				apiExport("root:compute", "empty"),
+				apiExport("root:org:ws", "cowboys", "today.cowboys.wildwest.dev"),
+			)
+
+			path := logicalcluster.NewPath("root:org:ws")
+			syncTarget, err := NewSyncTargetBuilder("st").WithAPIExports(tt.exports...).Create(context.Background(), client, path)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				_, err := client.Cluster(path).WorkloadV1alpha1().SyncTargets().Get(context.Background(), "st", metav1.GetOptions{})
+				require.Error(t, err, "expected no SyncTarget to be created")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "st", syncTarget.Name)
+		})
+	}
+}
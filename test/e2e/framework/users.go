@@ -20,14 +20,20 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/stretchr/testify/require"
 
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
 
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
 )
 
@@ -76,3 +82,89 @@ func AdmitWorkspaceAccess(ctx context.Context, t *testing.T, kubeClusterClient k
 	_, err := kubeClusterClient.Cluster(clusterName).RbacV1().ClusterRoleBindings().Create(ctx, binding, metav1.CreateOptions{})
 	require.NoError(t, err)
 }
+
+// GrantMaximalPermission creates a ClusterRole with the given rules in the workspace of an APIExport with a local
+// maximal permission policy, and binds it to the given user as seen by the policy, i.e. prefixed with "apis.kcp.io:binding:".
+func GrantMaximalPermission(ctx context.Context, t *testing.T, kubeClusterClient kcpkubernetesclientset.ClusterInterface, providerPath logicalcluster.Path, user string, rules []rbacv1.PolicyRule) {
+	t.Helper()
+	t.Logf("Granting user %q maximal permissions in workspace %q", user, providerPath)
+
+	name := user + "-maximal-permission-policy"
+	_, err := kubeClusterClient.Cluster(providerPath).RbacV1().ClusterRoles().Create(ctx, &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Rules: rules,
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = kubeClusterClient.Cluster(providerPath).RbacV1().ClusterRoleBindings().Create(ctx, &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "User",
+				Name:     apisv1alpha1.MaximalPermissionPolicyRBACUserGroupPrefix + user,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			Kind:     "ClusterRole",
+			APIGroup: "rbac.authorization.k8s.io",
+			Name:     name,
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+// RequireWorkspaceIsolation asserts that the given user, who has access to the workspace pathA, can not
+// see objects in the workspace pathB. cfg must be privileged enough to create a config map in pathB and
+// to impersonate the user.
+func RequireWorkspaceIsolation(ctx context.Context, t *testing.T, cfg *rest.Config, pathA, pathB logicalcluster.Path, user string) {
+	t.Helper()
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct client for server")
+	userCfg := rest.CopyConfig(cfg)
+	userCfg.Impersonate = rest.ImpersonationConfig{UserName: user}
+	userKubeClusterClient, err := kcpkubernetesclientset.NewForConfig(userCfg)
+	require.NoError(t, err, "failed to construct client for user %q", user)
+
+	t.Logf("Creating a config map in workspace %q invisible to user %q", pathB, user)
+	var cm *corev1.ConfigMap
+	Eventually(t, func() (bool, string) {
+		// the default namespace might not exist yet in a fresh workspace
+		cm, err = kubeClusterClient.Cluster(pathB).CoreV1().ConfigMaps("default").Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "isolation-",
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "failed to create config map in workspace %q", pathB)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), wait.ForeverTestTimeout)
+		defer cancel()
+		err := kubeClusterClient.Cluster(pathB).CoreV1().ConfigMaps("default").Delete(ctx, cm.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			t.Errorf("failed to delete config map %s|default/%s: %v", pathB, cm.Name, err)
+		}
+	})
+
+	t.Logf("Waiting for user %q to have access to workspace %q", user, pathA)
+	Eventually(t, func() (bool, string) {
+		if _, err := userKubeClusterClient.Cluster(pathA).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{}); err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "user %q has no access to workspace %q", user, pathA)
+
+	t.Logf("Verifying that user %q can not see objects in workspace %q", user, pathB)
+	_, err = userKubeClusterClient.Cluster(pathB).CoreV1().ConfigMaps("default").Get(ctx, cm.Name, metav1.GetOptions{})
+	require.Truef(t, apierrors.IsForbidden(err), "expected user %q to be forbidden to get config map in workspace %q, got: %v", user, pathB, err)
+	_, err = userKubeClusterClient.Cluster(pathB).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
+	require.Truef(t, apierrors.IsForbidden(err), "expected user %q to be forbidden to list config maps in workspace %q, got: %v", user, pathB, err)
+}
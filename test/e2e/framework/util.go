@@ -47,10 +47,14 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	kcpapiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/kcp/clientset/versioned"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -422,6 +426,64 @@ func EventuallyCondition(t *testing.T, getter func() (conditions.Getter, error),
 	}, wait.ForeverTestTimeout, 100*time.Millisecond, msgAndArgs...)
 }
 
+// RequireConditionReason asserts that the given object has a condition of the given type with the given reason,
+// independent of the condition status.
+func RequireConditionReason(t *testing.T, obj conditions.Getter, conditionType conditionsv1alpha1.ConditionType, reason string) {
+	t.Helper()
+	condition := conditions.Get(obj, conditionType)
+	require.NotNil(t, condition, "expected object to have condition %s", conditionType)
+	require.Equal(t, reason, condition.Reason, "unexpected reason for condition %s with status %s: %s", conditionType, condition.Status, condition.Message)
+}
+
+// WaitForResourceServable waits until the resource identified by gvr can be listed in the given
+// logical cluster, e.g. after an APIBinding for it got bound.
+func WaitForResourceServable(ctx context.Context, t *testing.T, dynamicClusterClient kcpdynamic.ClusterInterface, clusterName logicalcluster.Path, gvr schema.GroupVersionResource) {
+	t.Helper()
+	Eventually(t, func() (bool, string) {
+		_, err := dynamicClusterClient.Cluster(clusterName).Resource(gvr).List(ctx, metav1.ListOptions{Limit: 1})
+		if apierrors.IsNotFound(err) {
+			return false, fmt.Sprintf("%s is not served yet in %s", gvr, clusterName)
+		} else if err != nil {
+			return false, fmt.Sprintf("error listing %s in %s: %v", gvr, clusterName, err)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "waiting for %s to be servable in %s", gvr, clusterName)
+}
+
+// RequireDiscoveryLacksResource waits until the resource identified by gvr is not served anymore
+// according to the given discovery client, e.g. after the APIBinding for it got deleted. A group
+// version that is not served at all lacks the resource too.
+func RequireDiscoveryLacksResource(ctx context.Context, t *testing.T, discoveryClient discovery.DiscoveryInterface, gvr schema.GroupVersionResource) {
+	t.Helper()
+	var reason string
+	err := wait.PollImmediateWithContext(ctx, 100*time.Millisecond, wait.ForeverTestTimeout, func(ctx context.Context) (bool, error) {
+		resources, err := discoveryClient.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		} else if err != nil {
+			reason = fmt.Sprintf("error retrieving discovery for %s: %v", gvr.GroupVersion(), err)
+			return false, nil
+		}
+		for _, r := range resources.APIResources {
+			if r.Name == gvr.Resource {
+				reason = fmt.Sprintf("%s is still served", gvr)
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	require.NoError(t, err, "expected %s not to be served: %s", gvr, reason)
+}
+
+// RequireShardAnnotation asserts that the given object, e.g. one replicated to the cache server,
+// carries the shard annotation with the expected shard name.
+func RequireShardAnnotation(t *testing.T, obj metav1.Object, expectedShard string) {
+	t.Helper()
+	shardName, found := obj.GetAnnotations()[genericapirequest.AnnotationKey]
+	require.True(t, found, "%s|%s/%s doesn't have the %s annotation", logicalcluster.From(obj), obj.GetNamespace(), obj.GetName(), genericapirequest.AnnotationKey)
+	require.Equal(t, expectedShard, shardName, "%s|%s/%s has an unexpected %s annotation", logicalcluster.From(obj), obj.GetNamespace(), obj.GetName(), genericapirequest.AnnotationKey)
+}
+
 // ClientCAUserConfig returns a config based on a dynamically created client certificate.
 // The returned client CA is signed by "test/e2e/framework/client-ca.crt".
 func ClientCAUserConfig(t *testing.T, cfg *rest.Config, clientCAConfigDirectory, username string, groups ...string) *rest.Config {
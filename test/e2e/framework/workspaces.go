@@ -28,8 +28,10 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/rest"
 
 	"github.com/kcp-dev/kcp/pkg/admission/workspace"
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
@@ -289,3 +291,125 @@ func ExportVirtualWorkspaceURLs(export *apisv1alpha1.APIExport) []string {
 	}
 	return urls
 }
+
+// WaitForAPIExportVWURL waits for the APIExport with the given name to publish a virtual workspace
+// URL and returns the first one. It fails with the conditions of the APIExport on timeout.
+func WaitForAPIExportVWURL(ctx context.Context, t *testing.T, kcpClusterClient kcpclientset.ClusterInterface, path logicalcluster.Path, name string) string {
+	t.Helper()
+
+	var export *apisv1alpha1.APIExport
+	var getErr error
+	err := wait.PollImmediateWithContext(ctx, 100*time.Millisecond, wait.ForeverTestTimeout, func(ctx context.Context) (bool, error) {
+		export, getErr = kcpClusterClient.Cluster(path).ApisV1alpha1().APIExports().Get(ctx, name, metav1.GetOptions{})
+		if getErr != nil {
+			return false, nil
+		}
+		return len(ExportVirtualWorkspaceURLs(export)) > 0, nil
+	})
+	if err != nil {
+		if getErr != nil {
+			require.NoError(t, getErr, "failed to get APIExport %s|%s", path, name)
+		}
+		var conditions []string
+		for _, c := range export.Status.Conditions {
+			conditions = append(conditions, fmt.Sprintf("%s=%s (%s: %s)", c.Type, c.Status, c.Reason, c.Message))
+		}
+		require.NoError(t, err, "APIExport %s|%s has no virtual workspace URL, conditions: [%s]", path, name, strings.Join(conditions, ", "))
+	}
+
+	return ExportVirtualWorkspaceURLs(export)[0]
+}
+
+// APIExportVWConfig returns a copy of the base config pointing to the virtual workspace URL
+// of the given APIExport that is served by the shard with the given virtual workspace URL
+// (i.e. shard.Spec.VirtualWorkspaceURL). If shard is empty, the first URL is used. An error
+// is returned if no matching virtual workspace URL is found in the APIExport status.
+func APIExportVWConfig(base *rest.Config, export *apisv1alpha1.APIExport, shard string) (*rest.Config, error) {
+	for _, url := range ExportVirtualWorkspaceURLs(export) {
+		if strings.HasPrefix(url, shard) {
+			cfg := rest.CopyConfig(base)
+			cfg.Host = url
+			return cfg, nil
+		}
+	}
+	return nil, fmt.Errorf("no virtual workspace URL found for APIExport %s|%s on shard %q, found: %v", logicalcluster.From(export), export.Name, shard, ExportVirtualWorkspaceURLs(export))
+}
+
+// CleanupAPIs registers a cleanup deleting the APIBindings, APIExports and APIResourceSchemas created in the given
+// workspace after this call, in that order, such that tests do not leave APIs behind on a shared server. APIs that
+// exist when calling CleanupAPIs, e.g. the default APIBindings of the workspace type, are kept.
+func CleanupAPIs(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, path logicalcluster.Path) {
+	t.Helper()
+
+	bindings, err := client.Cluster(path).ApisV1alpha1().APIBindings().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err, "failed to list APIBindings in %s", path)
+	existingBindings := sets.NewString()
+	for _, binding := range bindings.Items {
+		existingBindings.Insert(binding.Name)
+	}
+	exports, err := client.Cluster(path).ApisV1alpha1().APIExports().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err, "failed to list APIExports in %s", path)
+	existingExports := sets.NewString()
+	for _, export := range exports.Items {
+		existingExports.Insert(export.Name)
+	}
+	schemas, err := client.Cluster(path).ApisV1alpha1().APIResourceSchemas().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err, "failed to list APIResourceSchemas in %s", path)
+	existingSchemas := sets.NewString()
+	for _, schema := range schemas.Items {
+		existingSchemas.Insert(schema.Name)
+	}
+
+	t.Cleanup(func() {
+		// the context of the test might be cancelled already
+		ctx, cancel := context.WithTimeout(context.Background(), wait.ForeverTestTimeout)
+		defer cancel()
+
+		t.Logf("Cleaning up APIBindings in %s", path)
+		bindings, err := client.Cluster(path).ApisV1alpha1().APIBindings().List(ctx, metav1.ListOptions{})
+		require.NoError(t, err, "failed to list APIBindings in %s", path)
+		for _, binding := range bindings.Items {
+			if existingBindings.Has(binding.Name) {
+				continue
+			}
+			err := client.Cluster(path).ApisV1alpha1().APIBindings().Delete(ctx, binding.Name, metav1.DeleteOptions{})
+			require.True(t, err == nil || apierrors.IsNotFound(err), "failed to delete APIBinding %s|%s: %v", path, binding.Name, err)
+		}
+		// APIBindings are only gone after their bound resources are deleted.
+		Eventually(t, func() (bool, string) {
+			bindings, err := client.Cluster(path).ApisV1alpha1().APIBindings().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return false, fmt.Sprintf("failed to list APIBindings in %s: %v", path, err)
+			}
+			var remaining []string
+			for _, binding := range bindings.Items {
+				if !existingBindings.Has(binding.Name) {
+					remaining = append(remaining, binding.Name)
+				}
+			}
+			return len(remaining) == 0, fmt.Sprintf("APIBindings %v in %s are not deleted yet", remaining, path)
+		}, wait.ForeverTestTimeout, 100*time.Millisecond)
+
+		t.Logf("Cleaning up APIExports in %s", path)
+		exports, err := client.Cluster(path).ApisV1alpha1().APIExports().List(ctx, metav1.ListOptions{})
+		require.NoError(t, err, "failed to list APIExports in %s", path)
+		for _, export := range exports.Items {
+			if existingExports.Has(export.Name) {
+				continue
+			}
+			err := client.Cluster(path).ApisV1alpha1().APIExports().Delete(ctx, export.Name, metav1.DeleteOptions{})
+			require.True(t, err == nil || apierrors.IsNotFound(err), "failed to delete APIExport %s|%s: %v", path, export.Name, err)
+		}
+
+		t.Logf("Cleaning up APIResourceSchemas in %s", path)
+		schemas, err := client.Cluster(path).ApisV1alpha1().APIResourceSchemas().List(ctx, metav1.ListOptions{})
+		require.NoError(t, err, "failed to list APIResourceSchemas in %s", path)
+		for _, schema := range schemas.Items {
+			if existingSchemas.Has(schema.Name) {
+				continue
+			}
+			err := client.Cluster(path).ApisV1alpha1().APIResourceSchemas().Delete(ctx, schema.Name, metav1.DeleteOptions{})
+			require.True(t, err == nil || apierrors.IsNotFound(err), "failed to delete APIResourceSchema %s|%s: %v", path, schema.Name, err)
+		}
+	})
+}
@@ -19,6 +19,7 @@ package apiexportendpointslice
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
@@ -361,3 +363,101 @@ func TestAPIExportEndpointSliceWithPartitionPrivate(t *testing.T) {
 		return false, fmt.Sprintf("expected 1 endpoint, but got: %#v", sliceWithAll.Status.APIExportEndpoints)
 	}, wait.ForeverTestTimeout, 100*time.Millisecond, "expecting a single endpoint for the root shard, got %d", len(sliceWithAll.Status.APIExportEndpoints))
 }
+
+func TestAPIExportEndpointSliceForAPIExportPrivate(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// shards are added and removed, hence a private server to avoid side effects on other e2e tests.
+	server := framework.PrivateKcpServer(t)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	exportClusterPath, _ := framework.NewWorkspaceFixture(t, server, orgPath)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	servingShards := func() sets.String {
+		shards, err := kcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().List(ctx, metav1.ListOptions{})
+		require.NoError(t, err, "error listing shards")
+		urls := sets.NewString()
+		for _, shard := range shards.Items {
+			if shard.Spec.VirtualWorkspaceURL != "" {
+				urls.Insert(shard.Spec.VirtualWorkspaceURL)
+			}
+		}
+		return urls
+	}
+	requireEndpointsForShards := func(msg string) {
+		t.Helper()
+		framework.Eventually(t, func() (bool, string) {
+			expected := servingShards()
+			slice, err := kcpClusterClient.Cluster(exportClusterPath).ApisV1alpha1().APIExportEndpointSlices().Get(ctx, "my-export", metav1.GetOptions{})
+			if err != nil {
+				return false, err.Error()
+			}
+			if len(slice.Status.APIExportEndpoints) != expected.Len() {
+				return false, fmt.Sprintf("expected %d endpoints for shards %v, got: %#v", expected.Len(), expected.List(), slice.Status.APIExportEndpoints)
+			}
+			for _, shardURL := range expected.List() {
+				found := false
+				for _, endpoint := range slice.Status.APIExportEndpoints {
+					if strings.HasPrefix(endpoint.URL, strings.TrimSuffix(shardURL, "/")+"/") {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false, fmt.Sprintf("expected an endpoint for shard %s, got: %#v", shardURL, slice.Status.APIExportEndpoints)
+				}
+			}
+			return true, ""
+		}, wait.ForeverTestTimeout, 100*time.Millisecond, msg)
+	}
+
+	t.Logf("Creating an APIExport without opting in to an APIExportEndpointSlice")
+	other := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "other-export",
+		},
+	}
+	_, err = kcpClusterClient.Cluster(exportClusterPath).ApisV1alpha1().APIExports().Create(ctx, other, metav1.CreateOptions{})
+	require.NoError(t, err, "error creating APIExport")
+
+	t.Logf("Creating an APIExport opting in to an APIExportEndpointSlice")
+	export := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-export",
+			Annotations: map[string]string{
+				apisv1alpha1.AnnotationAPIExportEndpointSliceKey: "true",
+			},
+		},
+	}
+	_, err = kcpClusterClient.Cluster(exportClusterPath).ApisV1alpha1().APIExports().Create(ctx, export, metav1.CreateOptions{})
+	require.NoError(t, err, "error creating APIExport")
+
+	t.Logf("Waiting for the APIExportEndpointSlice of the APIExport to list one endpoint per shard")
+	requireEndpointsForShards("expected an endpoint per shard")
+
+	t.Logf("Verifying that no APIExportEndpointSlice was created for the APIExport without opt-in")
+	_, err = kcpClusterClient.Cluster(exportClusterPath).ApisV1alpha1().APIExportEndpointSlices().Get(ctx, other.Name, metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err), "expected no APIExportEndpointSlice for %s, got: %v", other.Name, err)
+
+	t.Logf("Adding a shard")
+	shard := &corev1alpha1.Shard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "apiexportendpointslice-test-shard",
+		},
+		Spec: corev1alpha1.ShardSpec{
+			BaseURL: "https://base.kcp.test.dev",
+		},
+	}
+	_, err = kcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().Create(ctx, shard, metav1.CreateOptions{})
+	require.NoError(t, err, "error creating Shard")
+	requireEndpointsForShards("expected an endpoint for the added shard")
+
+	t.Logf("Removing the shard")
+	err = kcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().Delete(ctx, shard.Name, metav1.DeleteOptions{})
+	require.NoError(t, err, "error deleting Shard")
+	requireEndpointsForShards("expected the endpoint of the removed shard to be gone")
+}
@@ -28,10 +28,17 @@ import (
 	"testing"
 	"time"
 
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/stretchr/testify/require"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	apimachineryerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -193,3 +200,114 @@ func unreadyComponentsFromError(err error) string {
 	}
 	return strings.Join(unreadyComponents, ", ")
 }
+
+// ShardFailover simulates a shard that replicates objects to the cache server, goes away and comes back.
+//
+// While the shard is away it doesn't replicate anything, i.e. its objects in the cache server are neither
+// updated nor repaired. When it comes back it replicates all of its objects again, the same way a
+// restarted replication controller does with its initial list.
+type ShardFailover struct {
+	shardName   string
+	cacheClient kcpdynamic.ClusterInterface
+
+	lock    sync.Mutex
+	away    bool
+	objects map[replicatedObjectKey]*unstructured.Unstructured
+}
+
+type replicatedObjectKey struct {
+	gvr     schema.GroupVersionResource
+	cluster logicalcluster.Name
+	// namespace and name of the object
+	namespace, name string
+}
+
+// NewShardFailover returns a simulated shard with the given name replicating via the given cache client.
+func NewShardFailover(shardName string, cacheClient kcpdynamic.ClusterInterface) *ShardFailover {
+	return &ShardFailover{
+		shardName:   shardName,
+		cacheClient: cacheClient,
+		objects:     map[replicatedObjectKey]*unstructured.Unstructured{},
+	}
+}
+
+// ShardName returns the name of the simulated shard.
+func (s *ShardFailover) ShardName() string {
+	return s.shardName
+}
+
+// Replicate records the given object as owned by the shard and, unless the shard is away, replicates it to the cache server.
+func (s *ShardFailover) Replicate(ctx context.Context, t *testing.T, gvr schema.GroupVersionResource, cluster logicalcluster.Name, obj *unstructured.Unstructured) {
+	t.Helper()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := replicatedObjectKey{gvr: gvr, cluster: cluster, namespace: obj.GetNamespace(), name: obj.GetName()}
+	s.objects[key] = obj.DeepCopy()
+	if s.away {
+		return
+	}
+	require.NoError(t, s.replicate(ctx, key, obj), "failed to replicate %s %s|%s/%s from shard %q", gvr, cluster, obj.GetNamespace(), obj.GetName(), s.shardName)
+}
+
+// GoAway makes the shard unavailable. Nothing is replicated until ComeBack is called.
+func (s *ShardFailover) GoAway(t *testing.T) {
+	t.Helper()
+	t.Logf("Shard %q goes away", s.shardName)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.away = true
+}
+
+// ComeBack makes the shard available again and replicates all of its objects to the cache server.
+func (s *ShardFailover) ComeBack(ctx context.Context, t *testing.T) {
+	t.Helper()
+	t.Logf("Shard %q comes back", s.shardName)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.away = false
+
+	framework.Eventually(t, func() (bool, string) {
+		var errs []error
+		for key, obj := range s.objects {
+			if err := s.replicate(ctx, key, obj); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if err := apimachineryerrors.NewAggregate(errs); err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "shard %q failed to replicate its objects after coming back", s.shardName)
+}
+
+// replicate creates or updates the cached copy of the given object. It must be called with the lock held.
+func (s *ShardFailover) replicate(ctx context.Context, key replicatedObjectKey, obj *unstructured.Unstructured) error {
+	ctx = cacheclient.WithShardInContext(ctx, shard.New(s.shardName))
+	client := s.cacheClient.Resource(key.gvr).Cluster(key.cluster.Path()).Namespace(key.namespace)
+
+	// like the replication controller, annotate the replicated object with its shard
+	updated := obj.DeepCopy()
+	annotations := updated.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[genericapirequest.AnnotationKey] = s.shardName
+	updated.SetAnnotations(annotations)
+
+	cached, err := client.Get(ctx, key.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = client.Create(ctx, updated, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	updated.SetResourceVersion(cached.GetResourceVersion())
+	updated.SetUID(cached.GetUID())
+	_, err = client.Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
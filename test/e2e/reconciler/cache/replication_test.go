@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math/rand"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,6 +31,8 @@ import (
 	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/stretchr/testify/require"
 
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -60,10 +63,12 @@ type testScenario struct {
 var scenarios = []testScenario{
 	{"TestReplicateAPIExport", replicateAPIExportScenario},
 	{"TestReplicateAPIExportNegative", replicateAPIExportNegativeScenario},
+	{"TestReplicateAPIExportBatch", replicateAPIExportBatchScenario},
 	{"TestReplicateAPIResourceSchema", replicateAPIResourceSchemaScenario},
 	{"TestReplicateAPIResourceSchemaNegative", replicateAPIResourceSchemaNegativeScenario},
 	{"TestReplicateWorkspaceType", replicateWorkspaceTypeScenario},
 	{"TestReplicateWorkspaceTypeNegative", replicateWorkspaceTypeNegativeScenario},
+	{"TestReplicateShardFailover", replicateShardFailoverScenario},
 }
 
 // disruptiveScenarios contains a list of scenarios that will be run in a private environment
@@ -73,6 +78,16 @@ var disruptiveScenarios = []testScenario{
 	{"TestReplicateShardNegative", replicateShardNegativeScenario},
 }
 
+// additionalResourceScenarios contains a list of scenarios for resources that are only replicated
+// when requested with --cache-replicated-resources. They are run in a private environment replicating
+// additionalReplicatedResources.
+var additionalResourceScenarios = []testScenario{
+	{"TestReplicateRole", replicateRoleScenario},
+}
+
+// additionalReplicatedResources are the resources replicated in the environment of additionalResourceScenarios.
+var additionalReplicatedResources = []string{"roles.rbac.authorization.k8s.io"}
+
 // replicateAPIResourceSchemaScenario tests if an APIResourceSchema is propagated to the cache server.
 // The test exercises creation, modification and removal of the APIResourceSchema object.
 func replicateAPIResourceSchemaScenario(ctx context.Context, t *testing.T, server framework.RunningServer, kcpShardClusterDynamicClient kcpdynamic.ClusterInterface, cacheKcpClusterDynamicClient kcpdynamic.ClusterInterface) {
@@ -195,6 +210,33 @@ func replicateAPIExportScenario(ctx context.Context, t *testing.T, server framew
 	)
 }
 
+// replicateAPIExportBatchScenario tests if many APIExports created at once are all propagated to the cache server.
+func replicateAPIExportBatchScenario(ctx context.Context, t *testing.T, server framework.RunningServer, kcpShardClusterDynamicClient kcpdynamic.ClusterInterface, cacheKcpClusterDynamicClient kcpdynamic.ClusterInterface) {
+	t.Helper()
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	_, ws := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithRootShard())
+	scenario := &replicateResourceScenario{
+		cluster:                      logicalcluster.Name(ws.Spec.Cluster),
+		gvr:                          apisv1alpha1.SchemeGroupVersion.WithResource("apiexports"),
+		kind:                         "APIExport",
+		server:                       server,
+		kcpShardClusterDynamicClient: kcpShardClusterDynamicClient,
+		cacheKcpClusterDynamicClient: cacheKcpClusterDynamicClient,
+	}
+
+	var names []string
+	for i := 0; i < 20; i++ {
+		name := withPseudoRandomSuffix(fmt.Sprintf("wild.wild.west-%d", i))
+		t.Logf("Create source APIExport %s/%s on the root shard for replication", scenario.cluster, name)
+		scenario.CreateSourceResource(ctx, t, &apisv1alpha1.APIExport{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		names = append(names, name)
+	}
+
+	t.Logf("Verify that all %d APIExports are replicated to the cache server", len(names))
+	framework.RequireAllReplicated(ctx, t, scenario, names)
+}
+
 // replicateAPIExportNegativeScenario checks if modified or even deleted cached APIExport will be reconciled to match the original object.
 func replicateAPIExportNegativeScenario(ctx context.Context, t *testing.T, server framework.RunningServer, kcpShardClusterDynamicClient kcpdynamic.ClusterInterface, cacheKcpClusterDynamicClient kcpdynamic.ClusterInterface) {
 	t.Helper()
@@ -310,11 +352,105 @@ func replicateWorkspaceTypeNegativeScenario(ctx context.Context, t *testing.T, s
 	)
 }
 
+// replicateRoleScenario tests if a namespaced Role is propagated to the cache server, with its namespace preserved.
+// The test exercises creation, modification and removal of the Role object.
+func replicateRoleScenario(ctx context.Context, t *testing.T, server framework.RunningServer, kcpShardClusterDynamicClient kcpdynamic.ClusterInterface, cacheKcpClusterDynamicClient kcpdynamic.ClusterInterface) {
+	t.Helper()
+	replicateResource(ctx,
+		t,
+		server,
+		kcpShardClusterDynamicClient,
+		cacheKcpClusterDynamicClient,
+		"",
+		"Role",
+		rbacv1.SchemeGroupVersion.WithResource("roles"),
+		&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      withPseudoRandomSuffix("replicate-role"),
+				Namespace: withPseudoRandomSuffix("replicate"),
+			},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}},
+			},
+		},
+		nil,
+	)
+}
+
+// replicateShardFailoverScenario simulates a second shard going away and coming back.
+// It checks that objects replicated by other shards are not affected by the failover
+// and that the objects of the shard are replicated again when the shard comes back.
+func replicateShardFailoverScenario(ctx context.Context, t *testing.T, server framework.RunningServer, kcpShardClusterDynamicClient kcpdynamic.ClusterInterface, cacheKcpClusterDynamicClient kcpdynamic.ClusterInterface) {
+	t.Helper()
+
+	gvr := apisv1alpha1.SchemeGroupVersion.WithResource("apiexports")
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	_, ws := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithRootShard())
+	clusterName := logicalcluster.Name(ws.Spec.Cluster)
+
+	export := &apisv1alpha1.APIExport{ObjectMeta: metav1.ObjectMeta{Name: withPseudoRandomSuffix("failover")}}
+	scenario := &replicateResourceScenario{resourceName: export.Name, kind: "APIExport", gvr: gvr, cluster: clusterName, server: server, kcpShardClusterDynamicClient: kcpShardClusterDynamicClient, cacheKcpClusterDynamicClient: cacheKcpClusterDynamicClient}
+	t.Logf("Create source APIExport %s/%s on the root shard for replication", clusterName, export.Name)
+	scenario.CreateSourceResource(ctx, t, export)
+	scenario.VerifyReplication(ctx, t)
+
+	failover := NewShardFailover(withPseudoRandomSuffix("failover-shard"), cacheKcpClusterDynamicClient)
+	failoverCtx := cacheclient.WithShardInContext(ctx, shard.New(failover.ShardName()))
+	failoverClient := cacheKcpClusterDynamicClient.Resource(gvr).Cluster(clusterName.Path())
+	t.Cleanup(func() {
+		ctx := cacheclient.WithShardInContext(context.Background(), shard.New(failover.ShardName()))
+		if err := failoverClient.Delete(ctx, export.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			t.Errorf("failed to delete APIExport %s/%s of shard %q from the cache server: %v", clusterName, export.Name, failover.ShardName(), err)
+		}
+	})
+
+	t.Logf("Replicate APIExport %s/%s from shard %q", clusterName, export.Name, failover.ShardName())
+	failoverExport, err := toUnstructured(&apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: export.Name},
+		Spec:       apisv1alpha1.APIExportSpec{LatestResourceSchemas: []string{"failover.foo.bar"}},
+	}, "APIExport", gvr)
+	require.NoError(t, err)
+	failover.Replicate(ctx, t, gvr, clusterName, failoverExport)
+
+	failover.GoAway(t)
+	t.Logf("Delete the cached APIExport %s/%s of shard %q while the shard is away", clusterName, export.Name, failover.ShardName())
+	require.NoError(t, failoverClient.Delete(failoverCtx, export.Name, metav1.DeleteOptions{}))
+
+	t.Logf("Verify that the APIExport %s/%s replicated from the root shard is not affected by the failover", clusterName, export.Name)
+	scenario.UpdateMetaSourceResource(ctx, t)
+	scenario.VerifyReplication(ctx, t)
+	_, err = failoverClient.Get(failoverCtx, export.Name, metav1.GetOptions{})
+	require.True(t, errors.IsNotFound(err), "expected the APIExport of the away shard %q not to be replicated, got: %v", failover.ShardName(), err)
+
+	failover.ComeBack(ctx, t)
+	t.Logf("Verify that the APIExport %s/%s was replicated again from shard %q", clusterName, export.Name, failover.ShardName())
+	scenario.eventually(t, func() (bool, string) {
+		cached, err := failoverClient.Get(failoverCtx, export.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err.Error()
+		}
+		schemas, _, err := unstructured.NestedStringSlice(cached.Object, "spec", "latestResourceSchemas")
+		if err != nil {
+			return false, err.Error()
+		}
+		if diff := cmp.Diff([]string{"failover.foo.bar"}, schemas); diff != "" {
+			return false, fmt.Sprintf("unexpected replicated APIExport of shard %q: %s", failover.ShardName(), diff)
+		}
+		return true, ""
+	})
+	cached, err := failoverClient.Get(failoverCtx, export.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	framework.RequireShardAnnotation(t, cached, failover.ShardName())
+
+	t.Logf("Verify that the APIExport %s/%s replicated from the root shard is still the same", clusterName, export.Name)
+	scenario.VerifyReplication(ctx, t)
+}
+
 // replicateResource tests if the given resource is propagated to the cache server.
 // The test exercises creation, modification and removal of the resource.
 //
 // note that adding a new scenario requires providing a resource along with its type information
-// to this function, i.e.:
+// to this function. For namespaced resources the namespace of the resource is created on the fly, i.e.:
 //
 //	replicateResource(
 //	  ...
@@ -341,7 +477,7 @@ func replicateResource(ctx context.Context, t *testing.T,
 	resMeta, err := meta.Accessor(res)
 	require.NoError(t, err)
 	resourceName := resMeta.GetName()
-	scenario := &replicateResourceScenario{resourceName: resourceName, kind: kind, gvr: gvr, cluster: clusterName, server: server, kcpShardClusterDynamicClient: kcpShardClusterDynamicClient, cacheKcpClusterDynamicClient: cacheKcpClusterDynamicClient}
+	scenario := &replicateResourceScenario{resourceName: resourceName, namespace: resMeta.GetNamespace(), kind: kind, gvr: gvr, cluster: clusterName, server: server, kcpShardClusterDynamicClient: kcpShardClusterDynamicClient, cacheKcpClusterDynamicClient: cacheKcpClusterDynamicClient}
 
 	t.Logf("Create source %s %s/%s on the root shard for replication", kind, clusterName, resourceName)
 	scenario.CreateSourceResource(ctx, t, res)
@@ -394,7 +530,7 @@ func replicateResourceNegative(ctx context.Context, t *testing.T,
 	resMeta, err := meta.Accessor(res)
 	require.NoError(t, err)
 	resourceName := resMeta.GetName()
-	scenario := &replicateResourceScenario{resourceName: resourceName, kind: kind, gvr: gvr, cluster: clusterName, server: server, kcpShardClusterDynamicClient: kcpShardClusterDynamicClient, cacheKcpClusterDynamicClient: cacheKcpClusterDynamicClient}
+	scenario := &replicateResourceScenario{resourceName: resourceName, namespace: resMeta.GetNamespace(), kind: kind, gvr: gvr, cluster: clusterName, server: server, kcpShardClusterDynamicClient: kcpShardClusterDynamicClient, cacheKcpClusterDynamicClient: cacheKcpClusterDynamicClient}
 
 	t.Logf("Create source %s %s/%s on the root shard for replication", kind, clusterName, resourceName)
 	scenario.CreateSourceResource(ctx, t, res)
@@ -473,10 +609,40 @@ func TestReplicationDisruptive(t *testing.T) {
 	}
 }
 
+// TestReplicationAdditionalResources runs the scenarios of resources that are only replicated on request
+// in a private environment replicating them.
+func TestReplicationAdditionalResources(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	tokenAuthFile := framework.WriteTokenAuthFile(t)
+	args := append(framework.TestServerArgsWithTokenAuthFile(tokenAuthFile), "--cache-replicated-resources="+strings.Join(additionalReplicatedResources, ","))
+	server := framework.PrivateKcpServer(t, framework.WithCustomArguments(args...))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	kcpRootShardConfig := server.RootShardSystemMasterBaseConfig(t)
+	kcpRootShardDynamicClient, err := kcpdynamic.NewForConfig(kcpRootShardConfig)
+	require.NoError(t, err)
+	cacheClientRT := ClientRoundTrippersFor(kcpRootShardConfig)
+	cacheKcpClusterDynamicClient, err := kcpdynamic.NewForConfig(cacheClientRT)
+	require.NoError(t, err)
+
+	for _, scenario := range additionalResourceScenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(t *testing.T) {
+			t.Parallel()
+			scenario.work(ctx, t, server, kcpRootShardDynamicClient, cacheKcpClusterDynamicClient)
+		})
+	}
+}
+
 // replicateResourceScenario an auxiliary struct that is used by all test scenarios defined in this pkg.
 type replicateResourceScenario struct {
 	resourceName string
-	cluster      logicalcluster.Name
+	// namespace of the resource, empty for cluster-scoped resources.
+	namespace string
+	cluster   logicalcluster.Name
 
 	gvr  schema.GroupVersionResource
 	kind string
@@ -484,20 +650,57 @@ type replicateResourceScenario struct {
 	server                       framework.RunningServer
 	kcpShardClusterDynamicClient kcpdynamic.ClusterInterface
 	cacheKcpClusterDynamicClient kcpdynamic.ClusterInterface
+
+	// pollTimeout and pollInterval configure how long and how often the scenario waits for
+	// the replication. They default to wait.ForeverTestTimeout and 100ms respectively.
+	pollTimeout  time.Duration
+	pollInterval time.Duration
+}
+
+// eventually calls framework.Eventually with the poll timeout and interval of the scenario.
+func (b *replicateResourceScenario) eventually(t *testing.T, condition func() (bool, string)) {
+	t.Helper()
+
+	timeout, interval := b.pollTimeout, b.pollInterval
+	if timeout == 0 {
+		timeout = wait.ForeverTestTimeout
+	}
+	if interval == 0 {
+		interval = 100 * time.Millisecond
+	}
+	framework.Eventually(t, condition, timeout, interval)
 }
 
 func (b *replicateResourceScenario) CreateSourceResource(ctx context.Context, t *testing.T, res runtime.Object) {
 	t.Helper()
+	if b.namespace != "" {
+		ns := &unstructured.Unstructured{}
+		ns.SetAPIVersion("v1")
+		ns.SetKind("Namespace")
+		ns.SetName(b.namespace)
+		namespaces := b.kcpShardClusterDynamicClient.Resource(corev1.SchemeGroupVersion.WithResource("namespaces")).Cluster(b.cluster.Path())
+		_, err := namespaces.Create(ctx, ns, metav1.CreateOptions{})
+		if !errors.IsAlreadyExists(err) {
+			require.NoError(t, err)
+		}
+		t.Cleanup(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), wait.ForeverTestTimeout)
+			defer cancel()
+			if err := namespaces.Delete(ctx, b.namespace, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				t.Errorf("failed to delete namespace %s|%s: %v", b.cluster, b.namespace, err)
+			}
+		})
+	}
 	resUnstructured, err := toUnstructured(res, b.kind, b.gvr)
 	require.NoError(t, err)
-	_, err = b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Create(ctx, resUnstructured, metav1.CreateOptions{})
+	_, err = b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Create(ctx, resUnstructured, metav1.CreateOptions{})
 	require.NoError(t, err)
 }
 
 func (b *replicateResourceScenario) UpdateMetaSourceResource(ctx context.Context, t *testing.T) {
 	t.Helper()
 	b.resourceUpdateHelper(ctx, t, func(ctx context.Context) (*unstructured.Unstructured, error) {
-		return b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Get(ctx, b.resourceName, metav1.GetOptions{})
+		return b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Get(ctx, b.resourceName, metav1.GetOptions{})
 	}, func(res *unstructured.Unstructured) error {
 		if err := b.changeMetadataFor(res); err != nil {
 			return err
@@ -506,7 +709,7 @@ func (b *replicateResourceScenario) UpdateMetaSourceResource(ctx context.Context
 		if err != nil {
 			return err
 		}
-		_, err = b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Update(ctx, resUnstructured, metav1.UpdateOptions{})
+		_, err = b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Update(ctx, resUnstructured, metav1.UpdateOptions{})
 		return err
 	})
 }
@@ -514,7 +717,7 @@ func (b *replicateResourceScenario) UpdateMetaSourceResource(ctx context.Context
 func (b *replicateResourceScenario) UpdateSpecSourceResource(ctx context.Context, t *testing.T, resWithModifiedSpec runtime.Object) {
 	t.Helper()
 	b.resourceUpdateHelper(ctx, t, func(ctx context.Context) (*unstructured.Unstructured, error) {
-		return b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Get(ctx, b.resourceName, metav1.GetOptions{})
+		return b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Get(ctx, b.resourceName, metav1.GetOptions{})
 	}, func(res *unstructured.Unstructured) error {
 		unstructuredResWithModSpec, err := toUnstructured(resWithModifiedSpec, b.kind, b.gvr)
 		require.NoError(t, err)
@@ -539,7 +742,7 @@ func (b *replicateResourceScenario) UpdateSpecSourceResource(ctx context.Context
 		}
 		err = unstructured.SetNestedField(res.Object, newSpec, "spec")
 		require.NoError(t, err)
-		_, err = b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Update(ctx, res, metav1.UpdateOptions{})
+		_, err = b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Update(ctx, res, metav1.UpdateOptions{})
 		return err
 	})
 }
@@ -547,7 +750,7 @@ func (b *replicateResourceScenario) UpdateSpecSourceResource(ctx context.Context
 func (b *replicateResourceScenario) UpdateMetaCachedResource(ctx context.Context, t *testing.T) {
 	t.Helper()
 	b.resourceUpdateHelper(ctx, t, func(ctx context.Context) (*unstructured.Unstructured, error) {
-		return b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Get(cacheclient.WithShardInContext(ctx, shard.New("root")), b.resourceName, metav1.GetOptions{})
+		return b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Get(cacheclient.WithShardInContext(ctx, shard.New("root")), b.resourceName, metav1.GetOptions{})
 	}, func(res *unstructured.Unstructured) error {
 		if err := b.changeMetadataFor(res); err != nil {
 			return err
@@ -556,7 +759,7 @@ func (b *replicateResourceScenario) UpdateMetaCachedResource(ctx context.Context
 		if err != nil {
 			return err
 		}
-		_, err = b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Update(cacheclient.WithShardInContext(ctx, shard.New("root")), resUnstructured, metav1.UpdateOptions{})
+		_, err = b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Update(cacheclient.WithShardInContext(ctx, shard.New("root")), resUnstructured, metav1.UpdateOptions{})
 		return err
 	})
 }
@@ -564,7 +767,7 @@ func (b *replicateResourceScenario) UpdateMetaCachedResource(ctx context.Context
 func (b *replicateResourceScenario) UpdateSpecCachedResource(ctx context.Context, t *testing.T, resWithModifiedSpec runtime.Object) {
 	t.Helper()
 	b.resourceUpdateHelper(ctx, t, func(ctx context.Context) (*unstructured.Unstructured, error) {
-		return b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Get(cacheclient.WithShardInContext(ctx, shard.New("root")), b.resourceName, metav1.GetOptions{})
+		return b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Get(cacheclient.WithShardInContext(ctx, shard.New("root")), b.resourceName, metav1.GetOptions{})
 	}, func(res *unstructured.Unstructured) error {
 		unstructuredResWithModSpec, err := toUnstructured(resWithModifiedSpec, b.kind, b.gvr)
 		require.NoError(t, err)
@@ -589,16 +792,16 @@ func (b *replicateResourceScenario) UpdateSpecCachedResource(ctx context.Context
 		}
 		err = unstructured.SetNestedField(res.Object, newSpec, "spec")
 		require.NoError(t, err)
-		_, err = b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Update(cacheclient.WithShardInContext(ctx, shard.New("root")), res, metav1.UpdateOptions{})
+		_, err = b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Update(cacheclient.WithShardInContext(ctx, shard.New("root")), res, metav1.UpdateOptions{})
 		return err
 	})
 }
 
 func (b *replicateResourceScenario) DeleteSourceResourceAndVerify(ctx context.Context, t *testing.T) {
 	t.Helper()
-	require.NoError(t, b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Delete(ctx, b.resourceName, metav1.DeleteOptions{}))
-	framework.Eventually(t, func() (bool, string) {
-		_, err := b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Get(cacheclient.WithShardInContext(ctx, shard.New("root")), b.resourceName, metav1.GetOptions{})
+	require.NoError(t, b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Delete(ctx, b.resourceName, metav1.DeleteOptions{}))
+	b.eventually(t, func() (bool, string) {
+		_, err := b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Get(cacheclient.WithShardInContext(ctx, shard.New("root")), b.resourceName, metav1.GetOptions{})
 		if errors.IsNotFound(err) {
 			return true, ""
 		}
@@ -606,15 +809,25 @@ func (b *replicateResourceScenario) DeleteSourceResourceAndVerify(ctx context.Co
 			return false, err.Error()
 		}
 		return false, fmt.Sprintf("replicated %s %s/%s wasn't removed", b.gvr, b.cluster, b.resourceName)
-	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+	})
 }
 
 func (b *replicateResourceScenario) DeleteCachedResource(ctx context.Context, t *testing.T) {
 	t.Helper()
-	err := b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Delete(cacheclient.WithShardInContext(ctx, shard.New("root")), b.resourceName, metav1.DeleteOptions{})
+	err := b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Delete(cacheclient.WithShardInContext(ctx, shard.New("root")), b.resourceName, metav1.DeleteOptions{})
 	require.NoError(t, err)
 }
 
+// GetSourceResource returns the resource with the given name from the root shard.
+func (b *replicateResourceScenario) GetSourceResource(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	return b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// GetCachedResource returns the resource with the given name replicated from the root shard to the cache server.
+func (b *replicateResourceScenario) GetCachedResource(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	return b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Get(cacheclient.WithShardInContext(ctx, shard.New("root")), name, metav1.GetOptions{})
+}
+
 func (b *replicateResourceScenario) VerifyReplication(ctx context.Context, t *testing.T) {
 	t.Helper()
 	b.verifyResourceReplicationHelper(ctx, t)
@@ -636,7 +849,7 @@ func (b *replicateResourceScenario) changeMetadataFor(originalResource *unstruct
 
 func (b *replicateResourceScenario) resourceUpdateHelper(ctx context.Context, t *testing.T, resourceGetter func(ctx context.Context) (*unstructured.Unstructured, error), resourceUpdater func(*unstructured.Unstructured) error) {
 	t.Helper()
-	framework.Eventually(t, func() (bool, string) {
+	b.eventually(t, func() (bool, string) {
 		resource, err := resourceGetter(ctx)
 		if err != nil {
 			return false, err.Error()
@@ -649,19 +862,19 @@ func (b *replicateResourceScenario) resourceUpdateHelper(ctx context.Context, t
 			return false, err.Error() // try again
 		}
 		return true, ""
-	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+	})
 }
 
 func (b *replicateResourceScenario) verifyResourceReplicationHelper(ctx context.Context, t *testing.T) {
 	t.Helper()
 	cluster := b.cluster.Path()
 	t.Logf("Get %s %s/%s from the root shard and the cache server for comparison", b.gvr, cluster, b.resourceName)
-	framework.Eventually(t, func() (bool, string) {
-		originalResource, err := b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Get(ctx, b.resourceName, metav1.GetOptions{})
+	b.eventually(t, func() (bool, string) {
+		originalResource, err := b.kcpShardClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Get(ctx, b.resourceName, metav1.GetOptions{})
 		if err != nil {
 			return false, err.Error()
 		}
-		cachedResource, err := b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Get(cacheclient.WithShardInContext(ctx, shard.New("root")), b.resourceName, metav1.GetOptions{})
+		cachedResource, err := b.cacheKcpClusterDynamicClient.Resource(b.gvr).Cluster(b.cluster.Path()).Namespace(b.namespace).Get(cacheclient.WithShardInContext(ctx, shard.New("root")), b.resourceName, metav1.GetOptions{})
 		if err != nil {
 			if !errors.IsNotFound(err) {
 				return true, err.Error()
@@ -673,22 +886,33 @@ func (b *replicateResourceScenario) verifyResourceReplicationHelper(ctx context.
 		if err != nil {
 			return false, err.Error()
 		}
-		if _, found := cachedResourceMeta.GetAnnotations()[genericapirequest.AnnotationKey]; !found {
-			t.Fatalf("replicated %s root|%s/%s, doesn't have %s annotation", b.gvr, cluster, cachedResourceMeta.GetName(), genericapirequest.AnnotationKey)
+		framework.RequireShardAnnotation(t, cachedResourceMeta, "root")
+		if cachedResourceMeta.GetNamespace() != b.namespace {
+			return false, fmt.Sprintf("replicated %s root|%s/%s has namespace %q, expected %q", b.gvr, cluster, cachedResourceMeta.GetName(), cachedResourceMeta.GetNamespace(), b.namespace)
 		}
 		unstructured.RemoveNestedField(originalResource.Object, "metadata", "resourceVersion")
 		unstructured.RemoveNestedField(cachedResource.Object, "metadata", "resourceVersion")
 		unstructured.RemoveNestedField(cachedResource.Object, "metadata", "annotations", genericapirequest.AnnotationKey)
-		if cachedStatus, ok := cachedResource.Object["status"]; ok && cachedStatus == nil || (cachedStatus != nil && len(cachedStatus.(map[string]interface{})) == 0) {
-			// TODO: worth investigating:
-			// for some reason cached resources have an empty status set whereas the original resources don't
-			unstructured.RemoveNestedField(cachedResource.Object, "status")
-		}
 		if diff := cmp.Diff(cachedResource.Object, originalResource.Object); len(diff) > 0 {
 			return false, fmt.Sprintf("replicated %s root|%s/%s is different from the original", b.gvr, cluster, cachedResourceMeta.GetName())
 		}
 		return true, ""
-	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+	})
+}
+
+func TestReplicateResourceScenarioPollInterval(t *testing.T) {
+	t.Parallel()
+
+	scenario := &replicateResourceScenario{pollInterval: time.Millisecond}
+
+	calls := 0
+	start := time.Now()
+	scenario.eventually(t, func() (bool, string) {
+		calls++
+		return calls == 10, fmt.Sprintf("%d calls", calls)
+	})
+	require.Equal(t, 10, calls)
+	require.Less(t, time.Since(start), 9*100*time.Millisecond, "expected the overridden interval to be used instead of the default")
 }
 
 func toUnstructured(obj interface{}, kind string, gvr schema.GroupVersionResource) (*unstructured.Unstructured, error) {
@@ -73,17 +73,10 @@ func TestSchedulingOnSupportedAPI(t *testing.T) {
 	).Bind(t)
 
 	t.Logf("First sync target hash: %s", workloadv1alpha1.ToSyncTargetKey(logicalcluster.Name(locationWS.Spec.Cluster), firstSyncTargetName))
-	scheduledSyncTargetKey := workloadv1alpha1.ToSyncTargetKey(logicalcluster.Name(locationWS.Spec.Cluster), secondSyncTargetName)
 
 	t.Logf("check placement should be scheduled to synctarget with supported API")
 	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
 		return kcpClusterClient.Cluster(userPath).SchedulingV1alpha1().Placements().Get(ctx, placementName, metav1.GetOptions{})
 	}, framework.Is(schedulingv1alpha1.PlacementScheduled))
-	placement, err := kcpClusterClient.Cluster(userPath).SchedulingV1alpha1().Placements().Get(ctx, placementName, metav1.GetOptions{})
-	require.NoError(t, err)
-
-	if value := placement.Annotations[workloadv1alpha1.InternalSyncTargetPlacementAnnotationKey]; value != scheduledSyncTargetKey {
-		t.Errorf("Internal synctarget annotation for placement should be %s since it is the only SyncTarget with compatible API, but got %q",
-			scheduledSyncTargetKey, value)
-	}
+	framework.RequirePlacementScheduledTo(ctx, t, kcpClusterClient, userPath, placementName, logicalcluster.Name(locationWS.Spec.Cluster), secondSyncTargetName)
 }
@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	schedulingv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestSchedulingOnSyncTargetDeletion(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "transparent-multi-cluster")
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	source := framework.SharedKcpServer(t)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, source, framework.TODO_WithoutMultiShardSupport())
+	locationPath, _ := framework.NewWorkspaceFixture(t, source, orgPath, framework.TODO_WithoutMultiShardSupport())
+	userPath, userWorkspace := framework.NewWorkspaceFixture(t, source, orgPath, framework.TODO_WithoutMultiShardSupport())
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(source.BaseConfig(t))
+	require.NoError(t, err)
+
+	t.Logf("Creating two SyncTargets with heartbeats in %s", locationPath)
+	syncTargets := map[string]*framework.StartedSyncerFixture{}
+	for _, name := range []string{"synctarget-1", "synctarget-2"} {
+		syncerFixture := framework.NewSyncerFixture(t, source, locationPath,
+			framework.WithSyncTargetName(name),
+			framework.WithSyncedUserWorkspaces(userWorkspace),
+		).CreateSyncTargetAndApplyToDownstream(t).StartAPIImporter(t).StartHeartBeat(t)
+		syncTargets[syncerFixture.ToSyncTargetKey()] = syncerFixture
+	}
+
+	placementName := "placement-synctarget-deletion"
+	t.Logf("Bind user workspace to location workspace")
+	framework.NewBindCompute(t, userPath, source,
+		framework.WithLocationWorkspaceWorkloadBindOption(locationPath),
+		framework.WithPlacementNameBindOption(placementName),
+	).Bind(t)
+
+	scheduledSyncTarget := func() (string, error) {
+		placement, err := kcpClusterClient.Cluster(userPath).SchedulingV1alpha1().Placements().Get(ctx, placementName, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		return placement.Annotations[workloadv1alpha1.InternalSyncTargetPlacementAnnotationKey], nil
+	}
+
+	t.Logf("Wait for the placement to be scheduled")
+	var firstKey string
+	framework.Eventually(t, func() (bool, string) {
+		firstKey, err = scheduledSyncTarget()
+		if err != nil {
+			return false, fmt.Sprintf("Failed to get placement: %v", err)
+		}
+		_, found := syncTargets[firstKey]
+		return found, fmt.Sprintf("placement is scheduled to %q", firstKey)
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+
+	deleteSyncTarget := func(key string) {
+		syncerFixture := syncTargets[key]
+		t.Logf("Deleting the scheduled SyncTarget %s", syncerFixture.SyncerConfig.SyncTargetName)
+		syncerFixture.StopHeartBeat(t)
+		err := kcpClusterClient.Cluster(locationPath).WorkloadV1alpha1().SyncTargets().Delete(ctx, syncerFixture.SyncerConfig.SyncTargetName, metav1.DeleteOptions{})
+		require.NoError(t, err)
+		delete(syncTargets, key)
+	}
+
+	deleteSyncTarget(firstKey)
+
+	t.Logf("Wait for the placement to be rescheduled to the remaining SyncTarget")
+	var secondKey string
+	framework.Eventually(t, func() (bool, string) {
+		secondKey, err = scheduledSyncTarget()
+		if err != nil {
+			return false, fmt.Sprintf("Failed to get placement: %v", err)
+		}
+		_, found := syncTargets[secondKey]
+		return found, fmt.Sprintf("placement is scheduled to %q", secondKey)
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+
+	deleteSyncTarget(secondKey)
+
+	t.Logf("Wait for the placement to be unscheduled")
+	framework.Eventually(t, func() (bool, string) {
+		key, err := scheduledSyncTarget()
+		if err != nil {
+			return false, fmt.Sprintf("Failed to get placement: %v", err)
+		}
+		return key == "", fmt.Sprintf("placement is still scheduled to %q", key)
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return kcpClusterClient.Cluster(userPath).SchedulingV1alpha1().Placements().Get(ctx, placementName, metav1.GetOptions{})
+	}, framework.IsNot(schedulingv1alpha1.PlacementScheduled))
+}
@@ -29,6 +29,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/yaml"
@@ -311,6 +313,118 @@ func TestWorkspaceDeletion(t *testing.T) {
 	}
 }
 
+// TestWorkspaceDeletionWithOwnerOnOtherShard deletes a workspace whose logical cluster is scheduled
+// to a different shard than the workspace object, i.e. the owner of the logical cluster. The finalizer
+// of the owner is removed by the logical cluster deletion controller of the other shard via the front-proxy.
+func TestWorkspaceDeletionWithOwnerOnOtherShard(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := framework.SharedKcpServer(t)
+	cfg := server.BaseConfig(t)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct client for server")
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	shards, err := kcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err, "failed to list shards")
+	var otherShard string
+	for _, shard := range shards.Items {
+		if shard.Name != corev1alpha1.RootShard {
+			otherShard = shard.Name
+			break
+		}
+	}
+	if otherShard == "" {
+		t.Skip("Test requires at least two shards")
+	}
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server, framework.WithRootShard())
+
+	otherShardKcpClusterClient, err := kcpclientset.NewForConfig(server.ShardSystemMasterBaseConfig(t, otherShard))
+	require.NoError(t, err, "failed to construct client for shard %q", otherShard)
+
+	testCases := []struct {
+		name string
+		// owner UID to set on the logical cluster before deleting the workspace, if not empty
+		ownerUID types.UID
+	}{
+		{
+			name: "matching owner",
+		},
+		{
+			name:     "owner UID mismatch",
+			ownerUID: "wrong-uid",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Logf("Create a workspace on shard %q in org %q on the root shard", otherShard, orgPath)
+			_, ws := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithShard(otherShard))
+			wsClusterName := logicalcluster.Name(ws.Spec.Cluster)
+
+			logicalCluster, err := otherShardKcpClusterClient.Cluster(wsClusterName.Path()).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+			require.NoError(t, err, "failed to get logical cluster %s on shard %q", wsClusterName, otherShard)
+			require.NotNil(t, logicalCluster.Spec.Owner, "expected logical cluster %s to have an owner", wsClusterName)
+			require.Equal(t, orgPath.String(), logicalCluster.Spec.Owner.Cluster, "expected the owner of logical cluster %s to live in the org", wsClusterName)
+			originalUID := logicalCluster.Spec.Owner.UID
+
+			setOwnerUID := func(uid types.UID) {
+				t.Helper()
+				err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+					logicalCluster, err := otherShardKcpClusterClient.Cluster(wsClusterName.Path()).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+					if err != nil {
+						return err
+					}
+					logicalCluster.Spec.Owner.UID = uid
+					_, err = otherShardKcpClusterClient.Cluster(wsClusterName.Path()).CoreV1alpha1().LogicalClusters().Update(ctx, logicalCluster, metav1.UpdateOptions{})
+					return err
+				})
+				require.NoError(t, err, "failed to set the owner UID of logical cluster %s", wsClusterName)
+			}
+			if testCase.ownerUID != "" {
+				t.Logf("Set the owner UID of logical cluster %s to %q", wsClusterName, testCase.ownerUID)
+				setOwnerUID(testCase.ownerUID)
+			}
+
+			t.Logf("Delete workspace %s|%s", orgPath, ws.Name)
+			err = kcpClusterClient.Cluster(orgPath).TenancyV1alpha1().Workspaces().Delete(ctx, ws.Name, metav1.DeleteOptions{})
+			require.NoError(t, err, "failed to delete workspace %s", ws.Name)
+
+			if testCase.ownerUID != "" {
+				t.Logf("Ensure the finalizer of workspace %s|%s is not removed because of the owner UID mismatch", orgPath, ws.Name)
+				require.Never(t, func() bool {
+					workspace, err := kcpClusterClient.Cluster(orgPath).TenancyV1alpha1().Workspaces().Get(ctx, ws.Name, metav1.GetOptions{})
+					if err != nil {
+						return true
+					}
+					return !sets.NewString(workspace.Finalizers...).Has(corev1alpha1.LogicalClusterFinalizer)
+				}, 5*time.Second, 100*time.Millisecond, "expected the owner finalizer not to be removed")
+
+				t.Logf("Restore the owner UID of logical cluster %s", wsClusterName)
+				setOwnerUID(originalUID)
+			}
+
+			t.Logf("Ensure workspace %s|%s is finalized and deleted", orgPath, ws.Name)
+			framework.Eventually(t, func() (bool, string) {
+				workspace, err := kcpClusterClient.Cluster(orgPath).TenancyV1alpha1().Workspaces().Get(ctx, ws.Name, metav1.GetOptions{})
+				if apierrors.IsNotFound(err) {
+					return true, ""
+				}
+				if err != nil {
+					return false, err.Error()
+				}
+				return false, toYAML(t, workspace)
+			}, wait.ForeverTestTimeout, 100*time.Millisecond, "expected workspace %s to be deleted", ws.Name)
+		})
+	}
+}
+
 func toYAML(t *testing.T, obj interface{}) string {
 	t.Helper()
 	bs, err := yaml.Marshal(obj)
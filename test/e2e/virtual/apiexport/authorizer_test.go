@@ -18,7 +18,6 @@ package apiexport
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -29,21 +28,18 @@ import (
 	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/stretchr/testify/require"
 
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apihelpers"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	extensionsapiserver "k8s.io/apiextensions-apiserver/pkg/apiserver"
 	kcpapiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/kcp/clientset/versioned"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/discovery/cached/memory"
-	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 
@@ -95,8 +91,18 @@ func TestAPIExportAuthorizers(t *testing.T) {
 	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, tenantPath, []string{"tenant-user"}, nil, true)
 	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, tenantShadowCRDPath, []string{"tenant-user"}, nil, true)
 
+	// cleanups run in reverse order, i.e. tenant APIBindings are deleted before the service provider APIExports.
+	for _, path := range []logicalcluster.Path{serviceProvider1Path, serviceProvider2Path, tenantPath, tenantShadowCRDPath} {
+		framework.CleanupAPIs(ctx, t, kcpClient, path)
+	}
+
+	t.Logf("Verify that tenant and service provider workspaces are isolated from each other")
+	framework.RequireWorkspaceIsolation(ctx, t, cfg, tenantPath, serviceProvider1Path, "tenant-user")
+	framework.RequireWorkspaceIsolation(ctx, t, cfg, serviceProvider1Path, tenantPath, "service-provider-1-admin")
+	framework.RequireWorkspaceIsolation(ctx, t, cfg, serviceProvider1Path, serviceProvider2Path, "service-provider-1-admin")
+
 	t.Logf("install sherriffs API resource schema, API export, permissions for tenant-user to be able to bind to the export in service provider workspace %q", serviceProvider1Path)
-	require.NoError(t, apply(t, ctx, serviceProvider1Path, serviceProvider1Admin,
+	require.NoError(t, framework.ApplyManifests(ctx, t, serviceProvider1Admin, serviceProvider1Path,
 		&apisv1alpha1.APIResourceSchema{
 			ObjectMeta: metav1.ObjectMeta{Name: "today.sheriffs.wild.wild.west"},
 			Spec: apisv1alpha1.APIResourceSchemaSpec{
@@ -132,17 +138,14 @@ func TestAPIExportAuthorizers(t *testing.T) {
 	t.Logf("get the sheriffs apiexport's generated identity hash")
 	serviceProvider1AdminClient, err := kcpclientset.NewForConfig(serviceProvider1Admin)
 	require.NoError(t, err)
-	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
-		return serviceProvider1AdminClient.Cluster(serviceProvider1Path).ApisV1alpha1().APIExports().Get(ctx, "wild.wild.west", metav1.GetOptions{})
-	}, framework.Is(apisv1alpha1.APIExportIdentityValid))
-
-	sheriffExport, err := serviceProvider1AdminClient.Cluster(serviceProvider1Path).ApisV1alpha1().APIExports().Get(ctx, "wild.wild.west", metav1.GetOptions{})
+	identityCtx, cancelIdentity := context.WithTimeout(ctx, wait.ForeverTestTimeout)
+	defer cancelIdentity()
+	sherriffsIdentityHash, err := framework.WaitForAPIExportIdentity(identityCtx, serviceProvider1AdminClient.Cluster(serviceProvider1Path).ApisV1alpha1().APIExports(), "wild.wild.west")
 	require.NoError(t, err)
-	sherriffsIdentityHash := sheriffExport.Status.IdentityHash
 	t.Logf("Found identity hash: %v", sherriffsIdentityHash)
 
 	t.Logf("install cowboys API resource schema, API export, and permissions for tenant-user to be able to bind to the export in second service provider workspace %q", serviceProvider2Path)
-	require.NoError(t, apply(t, ctx, serviceProvider2Path, serviceProvider2Admin,
+	require.NoError(t, framework.ApplyManifests(ctx, t, serviceProvider2Admin, serviceProvider2Path,
 		&apisv1alpha1.APIResourceSchema{
 			ObjectMeta: metav1.ObjectMeta{Name: "today.cowboys.wildwest.dev"},
 			Spec: apisv1alpha1.APIResourceSchemaSpec{
@@ -200,7 +203,7 @@ func TestAPIExportAuthorizers(t *testing.T) {
 
 	t.Logf("bind cowboys and claimed sherriffs in the tenant workspace %q", tenantPath)
 	framework.Eventually(t, func() (success bool, reason string) {
-		err := apply(t, ctx, tenantPath, tenantUser,
+		err := framework.ApplyManifests(ctx, t, tenantUser, tenantPath,
 			&apisv1alpha1.APIBinding{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "wild.wild.west",
@@ -263,7 +266,7 @@ func TestAPIExportAuthorizers(t *testing.T) {
 	}, wait.ForeverTestTimeout, 100*time.Millisecond, "discovery failed")
 
 	t.Logf("Install cowboys CRD and also bind the conflicting cowboys API export in tenant workspace %q", tenantShadowCRDPath)
-	require.NoError(t, apply(t, ctx, tenantShadowCRDPath, tenantUser,
+	require.NoError(t, framework.ApplyManifests(ctx, t, tenantUser, tenantShadowCRDPath,
 		&apiextensionsv1.CustomResourceDefinition{
 			ObjectMeta: metav1.ObjectMeta{Name: "cowboys.wildwest.dev"},
 			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
@@ -299,7 +302,7 @@ func TestAPIExportAuthorizers(t *testing.T) {
 
 	t.Logf("Create a cowboys APIBinding in consumer workspace %q that points to the today-cowboys export from %q but shadows a local cowboys CRD at the same time", tenantShadowCRDPath, serviceProvider2Path)
 	framework.Eventually(t, func() (bool, string) {
-		err := apply(t, ctx, tenantShadowCRDPath, tenantUser,
+		err := framework.ApplyManifests(ctx, t, tenantUser, tenantShadowCRDPath,
 			&apisv1alpha1.APIBinding{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "cowboys",
@@ -344,11 +347,16 @@ func TestAPIExportAuthorizers(t *testing.T) {
 		return tenantUserKcpClient.Cluster(tenantShadowCRDPath).ApisV1alpha1().APIBindings().Get(ctx, "cowboys", metav1.GetOptions{})
 	}, framework.IsNot(apisv1alpha1.BindingUpToDate).WithReason(apisv1alpha1.NamingConflictsReason))
 
+	t.Logf("Waiting for cowboys to be servable in %q", tenantPath)
+	tenantUserDynamicClusterClient, err := kcpdynamic.NewForConfig(tenantUser)
+	require.NoError(t, err)
+	framework.WaitForResourceServable(ctx, t, tenantUserDynamicClusterClient, tenantPath, schema.GroupVersionResource{Version: "v1alpha1", Resource: "cowboys", Group: "wildwest.dev"})
+
 	// Have to do this with Eventually because the RBAC for the maximal permission policy can be slow to propagate via
 	// the cache server.
 	t.Logf("Creating cowboy (via APIBinding) in %q", tenantPath)
 	framework.Eventually(t, func() (bool, string) {
-		err := apply(t, ctx, tenantPath, tenantUser, `
+		err := framework.ApplyManifests(ctx, t, tenantUser, tenantPath, `
 apiVersion: wildwest.dev/v1alpha1
 kind: Cowboy
 metadata:
@@ -362,7 +370,7 @@ metadata:
 	}, wait.ForeverTestTimeout, 100*time.Millisecond, "unable to create cowboy (via APIBinding)")
 
 	t.Logf("Creating cowboy (via CRD) in %q", tenantShadowCRDPath)
-	require.NoError(t, apply(t, ctx, tenantShadowCRDPath, tenantUser, `
+	require.NoError(t, framework.ApplyManifests(ctx, t, tenantUser, tenantShadowCRDPath, `
 apiVersion: wildwest.dev/v1alpha1
 kind: Cowboy
 metadata:
@@ -371,18 +379,7 @@ metadata:
 `))
 
 	t.Logf("Create virtual workspace client for \"today-cowboys\" APIExport in workspace %q covering APIBinding from workspace %q", serviceProvider2Path, tenantPath)
-	serviceProvider2AdminApiExportVWCfg := rest.CopyConfig(serviceProvider2Admin)
-	serviceProvider2AdminClient, err := kcpclientset.NewForConfig(serviceProvider2Admin)
-	require.NoError(t, err)
-	framework.Eventually(t, func() (bool, string) {
-		apiExport, err := serviceProvider2AdminClient.Cluster(serviceProvider2Path).ApisV1alpha1().APIExports().Get(ctx, "today-cowboys", metav1.GetOptions{})
-		require.NoError(t, err)
-		var found bool
-		serviceProvider2AdminApiExportVWCfg.Host, found, err = framework.VirtualWorkspaceURL(ctx, kcpClient, tenantWorkspace, framework.ExportVirtualWorkspaceURLs(apiExport))
-		require.NoError(t, err)
-		//nolint:staticcheck // SA1019 VirtualWorkspaces is deprecated but not removed yet
-		return found, fmt.Sprintf("waiting for virtual workspace URLs to be available: %v", apiExport.Status.VirtualWorkspaces)
-	}, wait.ForeverTestTimeout, time.Millisecond*100)
+	serviceProvider2AdminApiExportVWCfg := vwConfig(t, serviceProvider2Admin, kcpClient, serviceProvider2Path, "today-cowboys", tenantWorkspace, tenantPath)
 
 	serviceProvider2DynamicVWClientForTenantWorkspace, err := kcpdynamic.NewForConfig(serviceProvider2AdminApiExportVWCfg)
 	require.NoError(t, err)
@@ -404,19 +401,9 @@ metadata:
 		return true, ""
 	}, wait.ForeverTestTimeout, 100*time.Millisecond, "service-provider-2-admin must be allowed to list native types")
 
-	require.NoError(t, apply(t, ctx, serviceProvider1Path, serviceProvider1Admin,
-		&rbacv1.ClusterRole{
-			ObjectMeta: metav1.ObjectMeta{Name: "service-provider-2-admin-maximum-permission-policy"},
-			Rules: []rbacv1.PolicyRule{
-				{APIGroups: []string{"wild.wild.west"}, Resources: []string{"sheriffs"}, Verbs: []string{"delete", "create", "list", "watch", "get", "patch"}},
-			},
-		},
-		&rbacv1.ClusterRoleBinding{
-			ObjectMeta: metav1.ObjectMeta{Name: "service-provider-2-admin-maximum-permission-policy"},
-			Subjects:   []rbacv1.Subject{{Kind: "User", Name: "apis.kcp.io:binding:service-provider-2-admin"}},
-			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.SchemeGroupVersion.Group, Kind: "ClusterRole", Name: "service-provider-2-admin-maximum-permission-policy"},
-		},
-	))
+	framework.GrantMaximalPermission(ctx, t, kubeClient, serviceProvider1Path, "service-provider-2-admin", []rbacv1.PolicyRule{
+		{APIGroups: []string{"wild.wild.west"}, Resources: []string{"sheriffs"}, Verbs: []string{"delete", "create", "list", "watch", "get", "patch"}},
+	})
 
 	t.Logf("verify that service-provider-2-admin can lists all claimed resources using a wildcard request")
 	claimedGVRs := []schema.GroupVersionResource{
@@ -432,6 +419,17 @@ metadata:
 		return true, ""
 	}, wait.ForeverTestTimeout, 100*time.Millisecond, "listing claimed resources failed")
 
+	t.Logf("verify that claimed resources resolve via a RESTMapper over the discovery of the tenant workspace %q", tenantPath)
+	framework.Eventually(t, func() (success bool, reason string) {
+		mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(tenantUserWorkspaceKcpClient.Cluster(tenantPath).Discovery()))
+		for _, gvr := range claimedGVRs {
+			if _, err := mapper.KindFor(gvr); err != nil {
+				return false, fmt.Sprintf("claimed resource %q is not discoverable in consumer workspace %q: %v", gvr, tenantPath, err)
+			}
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "claimed resources are not discoverable")
+
 	t.Logf("verify that service-provider-2-admin can lists sherriffs resources in the tenant workspace %q via the virtual apiexport apiserver", tenantPath)
 	framework.Eventually(t, func() (success bool, reason string) {
 		_, err = serviceProvider2DynamicVWClientForTenantWorkspace.Cluster(logicalcluster.Name(tenantWorkspace.Spec.Cluster).Path()).Resource(schema.GroupVersionResource{Version: "v1alpha1", Resource: "sheriffs", Group: "wild.wild.west"}).List(ctx, metav1.ListOptions{})
@@ -442,18 +440,7 @@ metadata:
 	}, wait.ForeverTestTimeout, 100*time.Millisecond, "listing claimed resources failed")
 
 	t.Logf("Create virtual workspace client for \"today-cowboys\" APIExport in workspace %q covering APIBinding from shadow workspace %q", serviceProvider2Path, tenantShadowCRDPath)
-	shadowVWCfg := rest.CopyConfig(serviceProvider2Admin)
-	shadowVWClient, err := kcpclientset.NewForConfig(serviceProvider2Admin)
-	require.NoError(t, err)
-	framework.Eventually(t, func() (bool, string) {
-		apiExport, err := shadowVWClient.Cluster(serviceProvider2Path).ApisV1alpha1().APIExports().Get(ctx, "today-cowboys", metav1.GetOptions{})
-		require.NoError(t, err)
-		var found bool
-		shadowVWCfg.Host, found, err = framework.VirtualWorkspaceURL(ctx, kcpClient, tenantShadowCRDWorkspace, framework.ExportVirtualWorkspaceURLs(apiExport))
-		require.NoError(t, err)
-		//nolint:staticcheck // SA1019 VirtualWorkspaces is deprecated but not removed yet
-		return found, fmt.Sprintf("waiting for virtual workspace URLs to be available: %v", apiExport.Status.VirtualWorkspaces)
-	}, wait.ForeverTestTimeout, time.Millisecond*100)
+	shadowVWCfg := vwConfig(t, serviceProvider2Admin, kcpClient, serviceProvider2Path, "today-cowboys", tenantShadowCRDWorkspace, tenantShadowCRDPath)
 
 	serviceProvider2DynamicVWClientForShadowTenantWorkspace, err := kcpdynamic.NewForConfig(shadowVWCfg)
 	require.NoError(t, err)
@@ -471,75 +458,6 @@ metadata:
 	}, wait.ForeverTestTimeout, 100*time.Millisecond, "expected service-provider-2-admin to get a not-found for shadowed cowboy resources")
 }
 
-var scheme *runtime.Scheme
-
-func init() {
-	scheme = runtime.NewScheme()
-	_ = apisv1alpha1.AddToScheme(scheme)
-	_ = rbacv1.AddToScheme(scheme)
-	_ = apiextensionsv1.AddToScheme(scheme)
-}
-
-func apply(t *testing.T, ctx context.Context, workspace logicalcluster.Path, cfg *rest.Config, manifests ...any) error {
-	t.Helper()
-	discoveryClient, err := kcpkubernetesclientset.NewForConfig(cfg)
-	require.NoError(t, err)
-	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient.Cluster(workspace).Discovery()))
-
-	for _, manifest := range manifests {
-		obj, gvk := func() (*unstructured.Unstructured, *schema.GroupVersionKind) {
-			switch value := manifest.(type) {
-			case string:
-				result, gvk, err := extensionsapiserver.Codecs.UniversalDeserializer().Decode([]byte(value), nil, &unstructured.Unstructured{})
-				require.NoError(t, err)
-
-				obj, ok := result.(*unstructured.Unstructured)
-				if !ok {
-					t.Fatalf("decoded into incorrect type, got %T, wanted %T", obj, &unstructured.Unstructured{})
-				}
-				return obj, gvk
-			case runtime.Object:
-				ro := manifest.(runtime.Object)
-				gvks, _, err := scheme.ObjectKinds(ro)
-				require.NoError(t, err)
-				ro.GetObjectKind().SetGroupVersionKind(gvks[0])
-				o, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ro)
-				require.NoError(t, err)
-				return &unstructured.Unstructured{Object: o}, &gvks[0]
-			default:
-				panic("unsupported type")
-			}
-		}()
-
-		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
-		if err != nil {
-			return fmt.Errorf("error getting REST mapping: %w", err)
-		}
-
-		dynamicClient, err := kcpdynamic.NewForConfig(cfg)
-		require.NoError(t, err)
-		var dynamicResource dynamic.ResourceInterface
-		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
-			t.Logf(`applying %q workspace %q namespace %q name %q`, gvk.String(), workspace.String(), obj.GetNamespace(), obj.GetName())
-			dynamicResource = dynamicClient.Cluster(workspace).Resource(mapping.Resource).Namespace(obj.GetNamespace())
-		} else {
-			t.Logf(`applying %q workspace %q name %q`, gvk.String(), workspace.String(), obj.GetName())
-			dynamicResource = dynamicClient.Cluster(workspace).Resource(mapping.Resource)
-		}
-
-		bytes, err := json.Marshal(obj)
-		require.NoError(t, err)
-
-		_, err = dynamicResource.Patch(ctx, obj.GetName(), types.ApplyPatchType, bytes, metav1.PatchOptions{
-			FieldManager: t.Name(),
-		})
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
 func TestRootAPIExportAuthorizers(t *testing.T) {
 	t.Parallel()
 	framework.Suite(t, "control-plane")
@@ -564,10 +482,16 @@ func TestRootAPIExportAuthorizers(t *testing.T) {
 
 	providerUser := "user-1"
 	consumerUser := "user-2"
+	// unprivilegedConsumerUser can access the consumer workspace, but has no RBAC on the claimed resources.
+	unprivilegedConsumerUser := "user-3"
 
-	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, orgPath, []string{providerUser, consumerUser}, nil, false)
+	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, orgPath, []string{providerUser, consumerUser, unprivilegedConsumerUser}, nil, false)
 	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, servicePath, []string{providerUser}, nil, true)
 	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, userPath, []string{consumerUser}, nil, true)
+	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, userPath, []string{unprivilegedConsumerUser}, nil, false)
+
+	framework.CleanupAPIs(ctx, t, kcpClient, servicePath)
+	framework.CleanupAPIs(ctx, t, kcpClient, userPath)
 
 	serviceKcpClient, err := kcpclientset.NewForConfig(framework.StaticTokenUserConfig(providerUser, rest.CopyConfig(cfg)))
 	require.NoError(t, err)
@@ -576,6 +500,8 @@ func TestRootAPIExportAuthorizers(t *testing.T) {
 
 	userKcpClient, err := kcpclientset.NewForConfig(framework.StaticTokenUserConfig(consumerUser, rest.CopyConfig(cfg)))
 	require.NoError(t, err)
+	unprivilegedUserKcpClient, err := kcpclientset.NewForConfig(framework.StaticTokenUserConfig(unprivilegedConsumerUser, rest.CopyConfig(cfg)))
+	require.NoError(t, err)
 
 	t.Logf("Install APIResourceSchema into service provider workspace %q", servicePath)
 	serviceProviderKcpClient, err := kcpclientset.NewForConfig(framework.StaticTokenUserConfig(providerUser, rest.CopyConfig(cfg)))
@@ -585,14 +511,10 @@ func TestRootAPIExportAuthorizers(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Logf("Get the root scheduling APIExport's identity hash")
-	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
-		return kcpClient.Cluster(core.RootCluster.Path()).ApisV1alpha1().APIExports().Get(ctx, "scheduling.kcp.io", metav1.GetOptions{})
-	}, framework.Is(apisv1alpha1.APIExportIdentityValid))
-
-	schedulingAPIExport, err := kcpClient.Cluster(core.RootCluster.Path()).ApisV1alpha1().APIExports().Get(ctx, "scheduling.kcp.io", metav1.GetOptions{})
+	identityCtx, cancelIdentity := context.WithTimeout(ctx, wait.ForeverTestTimeout)
+	defer cancelIdentity()
+	identityHash, err := framework.WaitForAPIExportIdentity(identityCtx, kcpClient.Cluster(core.RootCluster.Path()).ApisV1alpha1().APIExports(), "scheduling.kcp.io")
 	require.NoError(t, err)
-	identityHash := schedulingAPIExport.Status.IdentityHash
-	require.NotNil(t, identityHash)
 
 	t.Logf("Create an APIExport for APIResourceSchema in service provider %q", servicePath)
 	apiExport := &apisv1alpha1.APIExport{
@@ -603,9 +525,10 @@ func TestRootAPIExportAuthorizers(t *testing.T) {
 			LatestResourceSchemas: []string{"today.cowboys.wildwest.dev"},
 			PermissionClaims: []apisv1alpha1.PermissionClaim{
 				{
-					GroupResource: apisv1alpha1.GroupResource{Group: scheduling.GroupName, Resource: "placements"},
-					IdentityHash:  identityHash,
-					All:           true,
+					GroupResource:    apisv1alpha1.GroupResource{Group: scheduling.GroupName, Resource: "placements"},
+					IdentityHash:     identityHash,
+					All:              true,
+					AuditAnnotations: true,
 				},
 			},
 		},
@@ -640,9 +563,10 @@ func TestRootAPIExportAuthorizers(t *testing.T) {
 			PermissionClaims: []apisv1alpha1.AcceptablePermissionClaim{
 				{
 					PermissionClaim: apisv1alpha1.PermissionClaim{
-						GroupResource: apisv1alpha1.GroupResource{Group: scheduling.GroupName, Resource: "placements"},
-						IdentityHash:  identityHash,
-						All:           true,
+						GroupResource:    apisv1alpha1.GroupResource{Group: scheduling.GroupName, Resource: "placements"},
+						IdentityHash:     identityHash,
+						All:              true,
+						AuditAnnotations: true,
 					},
 					State: apisv1alpha1.ClaimAccepted,
 				},
@@ -660,8 +584,7 @@ func TestRootAPIExportAuthorizers(t *testing.T) {
 	}, framework.Is(apisv1alpha1.InitialBindingCompleted))
 
 	t.Logf("Get virtual workspace client for service APIExport in workspace %q", servicePath)
-	serviceAPIExportVWCfg := framework.StaticTokenUserConfig(providerUser, rest.CopyConfig(cfg))
-	serviceAPIExportVWCfg.Host = vwURL(t, kcpClient, servicePath, apiExport.Name, userWorkspace, userPath)
+	serviceAPIExportVWCfg := vwConfig(t, framework.StaticTokenUserConfig(providerUser, rest.CopyConfig(cfg)), kcpClient, servicePath, apiExport.Name, userWorkspace, userPath)
 	serviceDynamicVWClient, err := kcpdynamic.NewForConfig(serviceAPIExportVWCfg)
 	require.NoError(t, err)
 
@@ -691,26 +614,431 @@ func TestRootAPIExportAuthorizers(t *testing.T) {
 	}, wait.ForeverTestTimeout, time.Millisecond*100, "error creating placement")
 
 	t.Logf("Verify that consumer user can get the created resource in user workspace")
-	_, err = userKcpClient.Cluster(userClusterName.Path()).SchedulingV1alpha1().Placements().Get(ctx, placement.GetName(), metav1.GetOptions{})
+	created, err := userKcpClient.Cluster(userClusterName.Path()).SchedulingV1alpha1().Placements().Get(ctx, placement.GetName(), metav1.GetOptions{})
+	require.NoError(t, err)
+
+	t.Logf("Verify that the created resource carries the audit annotations of the claim")
+	require.Equal(t, logicalcluster.From(apiExport).Path().Join(apiExport.Name).String(), created.Annotations[apisv1alpha1.AnnotationClaimAuditAPIExportKey])
+	require.Equal(t, providerUser, created.Annotations[apisv1alpha1.AnnotationClaimAuditUserKey])
+
+	t.Logf("Verify that a consumer user without RBAC on the claimed resource cannot read it, although the provider created it via the claim")
+	_, err = unprivilegedUserKcpClient.Cluster(userClusterName.Path()).SchedulingV1alpha1().Placements().Get(ctx, placement.GetName(), metav1.GetOptions{})
+	require.Error(t, err, "%s must not be allowed to get the claimed placement", unprivilegedConsumerUser)
+	require.True(t, apierrors.IsForbidden(err), "expected a forbidden error, got: %v", err)
+	_, err = unprivilegedUserKcpClient.Cluster(userClusterName.Path()).SchedulingV1alpha1().Placements().List(ctx, metav1.ListOptions{})
+	require.Error(t, err, "%s must not be allowed to list the claimed placements", unprivilegedConsumerUser)
+	require.True(t, apierrors.IsForbidden(err), "expected a forbidden error, got: %v", err)
+}
+
+// TestAPIExportMaximalPermissionPolicyRestrictsVerbs verifies that a maximal permission policy granting only
+// read verbs denies writes to the bound and claimed resources, even if the RBAC in the consumer workspace is
+// broader than the policy.
+func TestAPIExportMaximalPermissionPolicyRestrictsVerbs(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	sheriffProviderPath, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("sheriff-provider"))
+	claimerPath, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("claimer"))
+	tenantPath, tenantWorkspace := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("tenant"))
+
+	cfg := server.BaseConfig(t)
+
+	sheriffProviderAdmin := server.ClientCAUserConfig(t, rest.CopyConfig(cfg), "sheriff-provider-admin")
+	claimerAdmin := server.ClientCAUserConfig(t, rest.CopyConfig(cfg), "claimer-admin")
+	tenantUser := server.ClientCAUserConfig(t, rest.CopyConfig(cfg), "tenant-user")
+
+	kubeClient, err := kcpkubernetesclientset.NewForConfig(rest.CopyConfig(cfg))
+	require.NoError(t, err)
+	kcpClient, err := kcpclientset.NewForConfig(rest.CopyConfig(cfg))
+	require.NoError(t, err)
+
+	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, orgPath, []string{"sheriff-provider-admin", "claimer-admin", "tenant-user"}, nil, false)
+	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, sheriffProviderPath, []string{"sheriff-provider-admin"}, nil, true)
+	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, claimerPath, []string{"claimer-admin"}, nil, true)
+	// the tenant user is admin in the tenant workspace, i.e. its local RBAC is broader than the maximal permission policy.
+	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, tenantPath, []string{"tenant-user"}, nil, true)
+
+	// cleanups run in reverse order, i.e. tenant APIBindings are deleted before the service provider APIExports.
+	for _, path := range []logicalcluster.Path{sheriffProviderPath, claimerPath, tenantPath} {
+		framework.CleanupAPIs(ctx, t, kcpClient, path)
+	}
+
+	t.Logf("Install sheriffs API with a maximal permission policy granting only read verbs in %q", sheriffProviderPath)
+	readOnly := []string{"get", "list", "watch"}
+	require.NoError(t, framework.ApplyManifests(ctx, t, sheriffProviderAdmin, sheriffProviderPath,
+		&apisv1alpha1.APIResourceSchema{
+			ObjectMeta: metav1.ObjectMeta{Name: "today.sheriffs.wild.wild.west"},
+			Spec: apisv1alpha1.APIResourceSchemaSpec{
+				Group: "wild.wild.west",
+				Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "sheriffs", Singular: "sheriff", Kind: "Sheriff", ListKind: "SheriffList"},
+				Scope: "Namespaced",
+				Versions: []apisv1alpha1.APIResourceVersion{
+					{Name: "v1alpha1", Served: true, Storage: true, Schema: runtime.RawExtension{Raw: []byte(`{"type":"object"}`)}},
+				},
+			},
+		},
+		&apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "wild.wild.west"},
+			Spec: apisv1alpha1.APIExportSpec{
+				LatestResourceSchemas:   []string{"today.sheriffs.wild.wild.west"},
+				MaximalPermissionPolicy: &apisv1alpha1.MaximalPermissionPolicy{Local: &apisv1alpha1.LocalAPIExportPolicy{}},
+			},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-user-bind-apiexport"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{"apis.kcp.io"}, ResourceNames: []string{"wild.wild.west"}, Resources: []string{"apiexports"}, Verbs: []string{"bind"}},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-user-bind-apiexport"},
+			Subjects:   []rbacv1.Subject{{Kind: "User", Name: "tenant-user"}},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.SchemeGroupVersion.Group, Kind: "ClusterRole", Name: "tenant-user-bind-apiexport"},
+		},
+	))
+	for _, user := range []string{"tenant-user", "claimer-admin"} {
+		framework.GrantMaximalPermission(ctx, t, kubeClient, sheriffProviderPath, user, []rbacv1.PolicyRule{
+			{APIGroups: []string{"wild.wild.west"}, Resources: []string{"sheriffs"}, Verbs: readOnly},
+		})
+	}
+
+	t.Logf("Get the sheriffs APIExport's generated identity hash")
+	sheriffProviderAdminClient, err := kcpclientset.NewForConfig(sheriffProviderAdmin)
+	require.NoError(t, err)
+	identityCtx, cancelIdentity := context.WithTimeout(ctx, wait.ForeverTestTimeout)
+	defer cancelIdentity()
+	sheriffsIdentityHash, err := framework.WaitForAPIExportIdentity(identityCtx, sheriffProviderAdminClient.Cluster(sheriffProviderPath).ApisV1alpha1().APIExports(), "wild.wild.west")
+	require.NoError(t, err)
+
+	t.Logf("Install an APIExport claiming sheriffs in %q", claimerPath)
+	require.NoError(t, framework.ApplyManifests(ctx, t, claimerAdmin, claimerPath,
+		&apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "sheriff-claimer"},
+			Spec: apisv1alpha1.APIExportSpec{
+				PermissionClaims: []apisv1alpha1.PermissionClaim{
+					{
+						GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"},
+						IdentityHash:  sheriffsIdentityHash,
+						All:           true,
+					},
+				},
+			},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-user-bind"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{"apis.kcp.io"}, ResourceNames: []string{"sheriff-claimer"}, Resources: []string{"apiexports"}, Verbs: []string{"bind"}},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-user-bind"},
+			Subjects:   []rbacv1.Subject{{Kind: "User", Name: "tenant-user"}},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.SchemeGroupVersion.Group, Kind: "ClusterRole", Name: "tenant-user-bind"},
+		},
+	))
+
+	t.Logf("Bind sheriffs and accept the sheriffs claim in the tenant workspace %q", tenantPath)
+	framework.Eventually(t, func() (bool, string) {
+		err := framework.ApplyManifests(ctx, t, tenantUser, tenantPath,
+			&apisv1alpha1.APIBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "wild.wild.west"},
+				Spec: apisv1alpha1.APIBindingSpec{
+					Reference: apisv1alpha1.BindingReference{
+						Export: &apisv1alpha1.ExportBindingReference{Path: sheriffProviderPath.String(), Name: "wild.wild.west"},
+					},
+				},
+			},
+			&apisv1alpha1.APIBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "sheriff-claimer"},
+				Spec: apisv1alpha1.APIBindingSpec{
+					PermissionClaims: []apisv1alpha1.AcceptablePermissionClaim{
+						{
+							PermissionClaim: apisv1alpha1.PermissionClaim{
+								GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"},
+								IdentityHash:  sheriffsIdentityHash,
+								All:           true,
+							},
+							State: apisv1alpha1.ClaimAccepted,
+						},
+					},
+					Reference: apisv1alpha1.BindingReference{
+						Export: &apisv1alpha1.ExportBindingReference{Path: claimerPath.String(), Name: "sheriff-claimer"},
+					},
+				},
+			},
+		)
+		if err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "error binding sheriffs in the tenant workspace")
+
+	sheriffsGVR := schema.GroupVersionResource{Group: "wild.wild.west", Version: "v1alpha1", Resource: "sheriffs"}
+	sheriff := func(name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "wild.wild.west/v1alpha1",
+			"kind":       "Sheriff",
+			"metadata":   map[string]interface{}{"name": name},
+		}}
+	}
+
+	tenantUserDynamicClusterClient, err := kcpdynamic.NewForConfig(tenantUser)
 	require.NoError(t, err)
+	framework.WaitForResourceServable(ctx, t, tenantUserDynamicClusterClient, tenantPath, sheriffsGVR)
+
+	// Have to do this with Eventually because the RBAC for the maximal permission policy can be slow to propagate via
+	// the cache server.
+	t.Logf("Verify that tenant-user can list sheriffs in %q", tenantPath)
+	framework.Eventually(t, func() (bool, string) {
+		_, err := tenantUserDynamicClusterClient.Cluster(tenantPath).Resource(sheriffsGVR).Namespace("default").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "tenant-user must be allowed to list sheriffs")
+
+	t.Logf("Verify that tenant-user cannot create sheriffs in %q although being admin there", tenantPath)
+	_, err = tenantUserDynamicClusterClient.Cluster(tenantPath).Resource(sheriffsGVR).Namespace("default").Create(ctx, sheriff("by-tenant-user"), metav1.CreateOptions{})
+	require.Error(t, err, "tenant-user must not be allowed to create sheriffs beyond the maximal permission policy")
+	require.True(t, apierrors.IsForbidden(err), "expected a forbidden error, got: %v", err)
+
+	t.Logf("Create virtual workspace client for the \"sheriff-claimer\" APIExport in %q", claimerPath)
+	claimerVWCfg := vwConfig(t, claimerAdmin, kcpClient, claimerPath, "sheriff-claimer", tenantWorkspace, tenantPath)
+	claimerVWClient, err := kcpdynamic.NewForConfig(claimerVWCfg)
+	require.NoError(t, err)
+	tenantClusterPath := logicalcluster.Name(tenantWorkspace.Spec.Cluster).Path()
+
+	t.Logf("Verify that claimer-admin can list claimed sheriffs through the virtual workspace")
+	framework.Eventually(t, func() (bool, string) {
+		_, err := claimerVWClient.Cluster(tenantClusterPath).Resource(sheriffsGVR).Namespace("default").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "claimer-admin must be allowed to list claimed sheriffs")
+
+	t.Logf("Verify that claimer-admin cannot create or delete claimed sheriffs through the virtual workspace")
+	_, err = claimerVWClient.Cluster(tenantClusterPath).Resource(sheriffsGVR).Namespace("default").Create(ctx, sheriff("by-claimer-admin"), metav1.CreateOptions{})
+	require.Error(t, err, "claimer-admin must not be allowed to create sheriffs beyond the maximal permission policy")
+	require.True(t, apierrors.IsForbidden(err), "expected a forbidden error, got: %v", err)
+	err = claimerVWClient.Cluster(tenantClusterPath).Resource(sheriffsGVR).Namespace("default").Delete(ctx, "by-tenant-user", metav1.DeleteOptions{})
+	require.Error(t, err, "claimer-admin must not be allowed to delete sheriffs beyond the maximal permission policy")
+	require.True(t, apierrors.IsForbidden(err), "expected a forbidden error, got: %v", err)
 }
 
-func vwURL(t *testing.T, kcpClusterClient kcpclientset.ClusterInterface, path logicalcluster.Path, export string, ws *tenancyv1alpha1.Workspace, wsPath logicalcluster.Path) string {
+// vwConfig returns a config for the virtual workspace of the given APIExport
+// on the shard of the given workspace, waiting for the URL to be published.
+func vwConfig(t *testing.T, base *rest.Config, kcpClusterClient kcpclientset.ClusterInterface, path logicalcluster.Path, export string, ws *tenancyv1alpha1.Workspace, wsPath logicalcluster.Path) *rest.Config {
 	t.Helper()
 
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	t.Cleanup(cancelFunc)
 
-	var vwURL string
+	shard := framework.WorkspaceShardOrDie(t, kcpClusterClient, ws)
+
+	var vwCfg *rest.Config
 	framework.Eventually(t, func() (bool, string) {
 		export, err := kcpClusterClient.Cluster(path).ApisV1alpha1().APIExports().Get(ctx, export, metav1.GetOptions{})
 		require.NoError(t, err)
-		urls := framework.ExportVirtualWorkspaceURLs(export)
-		var found bool
-		vwURL, found, err = framework.VirtualWorkspaceURL(ctx, kcpClusterClient, ws, urls)
-		require.NoError(t, err)
-		return found, fmt.Sprintf("waiting on virtual workspace URL for workspace %s, found: %v", wsPath, urls)
+		vwCfg, err = framework.APIExportVWConfig(base, export, shard.Spec.VirtualWorkspaceURL)
+		if err != nil {
+			return false, fmt.Sprintf("waiting on virtual workspace URL for workspace %s: %v", wsPath, err)
+		}
+		return true, ""
 	}, wait.ForeverTestTimeout, 100*time.Millisecond, "waiting on virtual workspace to be ready")
 
-	return vwURL
+	return vwCfg
+}
+
+func TestAPIExportClaimNamespaceSelector(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	serviceProviderPath, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("service-provider"))
+	tenantPath, tenantWorkspace := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("tenant"))
+
+	cfg := server.BaseConfig(t)
+
+	serviceProviderAdmin := server.ClientCAUserConfig(t, rest.CopyConfig(cfg), "service-provider-admin")
+	tenantUser := server.ClientCAUserConfig(t, rest.CopyConfig(cfg), "tenant-user")
+
+	kubeClient, err := kcpkubernetesclientset.NewForConfig(rest.CopyConfig(cfg))
+	require.NoError(t, err)
+	kcpClient, err := kcpclientset.NewForConfig(rest.CopyConfig(cfg))
+	require.NoError(t, err)
+
+	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, orgPath, []string{"service-provider-admin", "tenant-user"}, nil, false)
+	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, serviceProviderPath, []string{"service-provider-admin"}, nil, true)
+	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, tenantPath, []string{"tenant-user"}, nil, true)
+
+	for _, path := range []logicalcluster.Path{serviceProviderPath, tenantPath} {
+		framework.CleanupAPIs(ctx, t, kcpClient, path)
+	}
+
+	configMapsClaim := apisv1alpha1.PermissionClaim{
+		GroupResource:     apisv1alpha1.GroupResource{Resource: "configmaps"},
+		All:               true,
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+	}
+
+	t.Logf("Install cowboys API resource schema and an API export claiming configmaps in namespaces labeled tenant=a in service provider workspace %q", serviceProviderPath)
+	require.NoError(t, framework.ApplyManifests(ctx, t, serviceProviderAdmin, serviceProviderPath,
+		&apisv1alpha1.APIResourceSchema{
+			ObjectMeta: metav1.ObjectMeta{Name: "today.cowboys.wildwest.dev"},
+			Spec: apisv1alpha1.APIResourceSchemaSpec{
+				Group: "wildwest.dev",
+				Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "cowboys", Singular: "cowboy", Kind: "Cowboy", ListKind: "CowboyList"},
+				Scope: "Namespaced",
+				Versions: []apisv1alpha1.APIResourceVersion{
+					{Name: "v1alpha1", Served: true, Storage: true, Schema: runtime.RawExtension{Raw: []byte(`{"type":"object"}`)}},
+				},
+			},
+		},
+		&apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "today-cowboys"},
+			Spec: apisv1alpha1.APIExportSpec{
+				LatestResourceSchemas: []string{"today.cowboys.wildwest.dev"},
+				PermissionClaims:      []apisv1alpha1.PermissionClaim{configMapsClaim},
+			},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-user-bind"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{"apis.kcp.io"}, ResourceNames: []string{"today-cowboys"}, Resources: []string{"apiexports"}, Verbs: []string{"bind"}},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-user-bind"},
+			Subjects:   []rbacv1.Subject{{Kind: "User", Name: "tenant-user"}},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.SchemeGroupVersion.Group, Kind: "ClusterRole", Name: "tenant-user-bind"},
+		},
+	))
+
+	t.Logf("Bind cowboys and accept the configmaps claim in tenant workspace %q", tenantPath)
+	framework.Eventually(t, func() (success bool, reason string) {
+		err := framework.ApplyManifests(ctx, t, tenantUser, tenantPath,
+			&apisv1alpha1.APIBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "cowboys"},
+				Spec: apisv1alpha1.APIBindingSpec{
+					PermissionClaims: []apisv1alpha1.AcceptablePermissionClaim{
+						{PermissionClaim: configMapsClaim, State: apisv1alpha1.ClaimAccepted},
+					},
+					Reference: apisv1alpha1.BindingReference{
+						Export: &apisv1alpha1.ExportBindingReference{
+							Path: serviceProviderPath.String(),
+							Name: "today-cowboys",
+						},
+					},
+				},
+			},
+		)
+		if err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+
+	t.Logf("Waiting for the APIBinding to report that no namespace matches the namespace selector")
+	tenantUserKcpClient, err := kcpclientset.NewForConfig(tenantUser)
+	require.NoError(t, err)
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return tenantUserKcpClient.Cluster(tenantPath).ApisV1alpha1().APIBindings().Get(ctx, "cowboys", metav1.GetOptions{})
+	}, framework.IsNot(apisv1alpha1.PermissionClaimNamespaceSelectorsSatisfied).WithReason(apisv1alpha1.NoMatchingNamespacesReason))
+
+	t.Logf("Create a selected and an unselected namespace with a configmap each in tenant workspace %q", tenantPath)
+	tenantUserKubeClient, err := kcpkubernetesclientset.NewForConfig(tenantUser)
+	require.NoError(t, err)
+	for _, ns := range []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Labels: map[string]string{"tenant": "a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "tenant-b", Labels: map[string]string{"tenant": "b"}}},
+	} {
+		_, err := tenantUserKubeClient.Cluster(tenantPath).CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+		require.NoError(t, err)
+		_, err = tenantUserKubeClient.Cluster(tenantPath).CoreV1().ConfigMaps(ns.Name).Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "claimed"}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	t.Logf("Waiting for the APIBinding to report that the namespace selector is satisfied")
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return tenantUserKcpClient.Cluster(tenantPath).ApisV1alpha1().APIBindings().Get(ctx, "cowboys", metav1.GetOptions{})
+	}, framework.Is(apisv1alpha1.PermissionClaimNamespaceSelectorsSatisfied))
+
+	t.Logf("Create virtual workspace client for \"today-cowboys\" APIExport in workspace %q", serviceProviderPath)
+	vwCfg := vwConfig(t, serviceProviderAdmin, kcpClient, serviceProviderPath, "today-cowboys", tenantWorkspace, tenantPath)
+	vwClient, err := kcpdynamic.NewForConfig(vwCfg)
+	require.NoError(t, err)
+	configMapsGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	tenantCluster := logicalcluster.Name(tenantWorkspace.Spec.Cluster).Path()
+
+	t.Logf("Verify that only claimed configmaps in the selected namespace are visible through a wildcard list")
+	framework.Eventually(t, func() (success bool, reason string) {
+		for _, list := range []func() (*unstructured.UnstructuredList, error){
+			func() (*unstructured.UnstructuredList, error) {
+				return vwClient.Resource(configMapsGVR).List(ctx, metav1.ListOptions{})
+			},
+			func() (*unstructured.UnstructuredList, error) {
+				return vwClient.Cluster(tenantCluster).Resource(configMapsGVR).List(ctx, metav1.ListOptions{})
+			},
+		} {
+			cms, err := list()
+			if err != nil {
+				return false, fmt.Sprintf("error listing configmaps: %v", err)
+			}
+			found := false
+			for _, cm := range cms.Items {
+				if cm.GetNamespace() != "tenant-a" {
+					return false, fmt.Sprintf("unexpected configmap %s/%s outside of the selected namespaces", cm.GetNamespace(), cm.GetName())
+				}
+				if cm.GetName() == "claimed" {
+					found = true
+				}
+			}
+			if !found {
+				return false, "claimed configmap in the selected namespace is not visible yet"
+			}
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "expected only configmaps of the selected namespace")
+
+	t.Logf("Verify that a claimed configmap in the unselected namespace cannot be read")
+	_, err = vwClient.Cluster(tenantCluster).Resource(configMapsGVR).Namespace("tenant-b").Get(ctx, "claimed", metav1.GetOptions{})
+	require.True(t, apierrors.IsForbidden(err), "expected a forbidden error, got: %v", err)
+
+	t.Logf("Verify that a watch does not deliver configmaps of the unselected namespace")
+	w, err := vwClient.Resource(configMapsGVR).Watch(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	defer w.Stop()
+	_, err = tenantUserKubeClient.Cluster(tenantPath).CoreV1().ConfigMaps("tenant-b").Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "claimed-later"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = tenantUserKubeClient.Cluster(tenantPath).CoreV1().ConfigMaps("tenant-a").Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "claimed-later"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	timeout := time.After(wait.ForeverTestTimeout)
+	for {
+		select {
+		case e, ok := <-w.ResultChan():
+			require.True(t, ok, "watch closed unexpectedly")
+			cm, ok := e.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			require.Equal(t, "tenant-a", cm.GetNamespace(), "unexpected watch event for configmap %s/%s", cm.GetNamespace(), cm.GetName())
+			if cm.GetName() == "claimed-later" {
+				return
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for the watch event of the claimed configmap in the selected namespace")
+		}
+	}
 }
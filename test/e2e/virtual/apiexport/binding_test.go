@@ -58,7 +58,7 @@ func TestBinding(t *testing.T) {
 
 	framework.AdmitWorkspaceAccess(ctx, t, kubeClient, serviceWorkspacePath, []string{"service-provider"}, nil, true)
 
-	require.NoError(t, apply(t, ctx, serviceWorkspacePath, cfg, `
+	require.NoError(t, framework.ApplyManifests(ctx, t, cfg, serviceWorkspacePath, `
 apiVersion: apis.kcp.io/v1alpha1
 kind: APIExport
 metadata:
@@ -96,7 +96,7 @@ subjects:
   name: service-provider
 `))
 
-	require.NoError(t, apply(t, ctx, restrictedWorkspacePath, cfg, `
+	require.NoError(t, framework.ApplyManifests(ctx, t, cfg, restrictedWorkspacePath, `
 apiVersion: apis.kcp.io/v1alpha1
 kind: APIExport
 metadata:
@@ -105,7 +105,7 @@ spec: {}
 `))
 
 	framework.Eventually(t, func() (bool, string) {
-		err := apply(t, ctx, consumerWorkspacePath, cfg, fmt.Sprintf(`
+		err := framework.ApplyManifests(ctx, t, cfg, consumerWorkspacePath, fmt.Sprintf(`
 apiVersion: apis.kcp.io/v1alpha1
 kind: APIBinding
 metadata:
@@ -141,7 +141,7 @@ spec:
 	}, wait.ForeverTestTimeout, 100*time.Millisecond, "waiting on virtual workspace to be ready")
 
 	framework.Eventually(t, func() (success bool, reason string) {
-		err = apply(t, ctx, logicalcluster.Name(consumerWorkspace.Spec.Cluster).Path(), serviceProviderVirtualWorkspaceConfig, fmt.Sprintf(`
+		err = framework.ApplyManifests(ctx, t, serviceProviderVirtualWorkspaceConfig, logicalcluster.Name(consumerWorkspace.Spec.Cluster).Path(), fmt.Sprintf(`
 apiVersion: apis.kcp.io/v1alpha1
 kind: APIBinding
 metadata:
@@ -159,7 +159,7 @@ spec:
 		return true, ""
 	}, wait.ForeverTestTimeout, 1000*time.Millisecond, "waiting on virtual workspace to be ready")
 
-	require.NoError(t, apply(t, ctx, restrictedWorkspacePath, cfg, `
+	require.NoError(t, framework.ApplyManifests(ctx, t, cfg, restrictedWorkspacePath, `
 apiVersion: rbac.authorization.k8s.io/v1
 kind: ClusterRole
 metadata:
@@ -188,7 +188,7 @@ roleRef:
 `))
 
 	framework.Eventually(t, func() (bool, string) {
-		err := apply(t, ctx, logicalcluster.Name(consumerWorkspace.Spec.Cluster).Path(), serviceProviderVirtualWorkspaceConfig, fmt.Sprintf(`
+		err := framework.ApplyManifests(ctx, t, serviceProviderVirtualWorkspaceConfig, logicalcluster.Name(consumerWorkspace.Spec.Cluster).Path(), fmt.Sprintf(`
 apiVersion: apis.kcp.io/v1alpha1
 kind: APIBinding
 metadata:
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexport
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/pkg/clientutils"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestAPIExportVirtualWorkspaceConsumers(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cfg := server.BaseConfig(t)
+
+	kcpClients, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client for server")
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	serviceProviderPath, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("provider"))
+	consumer1Path, consumer1 := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("consumer1"), framework.WithRootShard())
+	consumer2Path, consumer2 := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("consumer2"), framework.WithRootShard())
+
+	setUpServiceProvider(ctx, t, dynamicClusterClient, kcpClients, serviceProviderPath, cfg)
+	bindConsumerToProvider(ctx, t, consumer1Path, serviceProviderPath, kcpClients, cfg)
+	bindConsumerToProvider(ctx, t, consumer2Path, serviceProviderPath, kcpClients, cfg)
+
+	t.Logf("Waiting for APIExport to have a virtual workspace URL for the bound workspace %q", consumer1Path)
+	apiExportVWCfg := rest.CopyConfig(cfg)
+	framework.Eventually(t, func() (bool, string) {
+		apiExport, err := kcpClients.Cluster(serviceProviderPath).ApisV1alpha1().APIExports().Get(ctx, "today-cowboys", metav1.GetOptions{})
+		require.NoError(t, err)
+		var found bool
+		apiExportVWCfg.Host, found, err = framework.VirtualWorkspaceURL(ctx, kcpClients, consumer1, framework.ExportVirtualWorkspaceURLs(apiExport))
+		require.NoError(t, err)
+		//nolint:staticcheck // SA1019 VirtualWorkspaces is deprecated but not removed yet
+		return found, fmt.Sprintf("waiting for virtual workspace URLs to be available: %v", apiExport.Status.VirtualWorkspaces)
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+
+	vwClient, err := kcpclientset.NewForConfig(apiExportVWCfg)
+	require.NoError(t, err)
+
+	// both consumers are on the root shard, such that its virtual workspace URL serves both of them
+	t.Logf("Verify that both consumer workspaces are listed as consumers of the APIExport")
+	expected := []logicalcluster.Name{logicalcluster.Name(consumer1.Spec.Cluster), logicalcluster.Name(consumer2.Spec.Cluster)}
+	if expected[1] < expected[0] {
+		expected[0], expected[1] = expected[1], expected[0]
+	}
+	framework.Eventually(t, func() (bool, string) {
+		consumers, err := clientutils.ListAPIExportConsumers(ctx, vwClient.ApisV1alpha1().APIBindings())
+		if err != nil {
+			return false, err.Error()
+		}
+		return reflect.DeepEqual(consumers, expected), fmt.Sprintf("expected consumers %v, got %v", expected, consumers)
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestAPIExportPermissionClaimResourceSelectorWrites(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	providerPath, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("provider"))
+	consumerPath, consumer := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("consumer"))
+
+	cfg := server.BaseConfig(t)
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kube cluster client for server")
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client for server")
+
+	t.Logf("Create an APIExport in %q claiming a single configmap", providerPath)
+	setUpServiceProvider(ctx, t, dynamicClusterClient, kcpClusterClient, providerPath, cfg, apisv1alpha1.PermissionClaim{
+		GroupResource:    apisv1alpha1.GroupResource{Resource: "configmaps"},
+		ResourceSelector: []apisv1alpha1.ResourceSelector{{Namespace: "default", Name: "claimed"}},
+	})
+
+	t.Logf("Bind to the APIExport in %q and accept the claim", consumerPath)
+	bindConsumerToProvider(ctx, t, consumerPath, providerPath, kcpClusterClient, cfg)
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		binding, err := kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Get(ctx, "cowboys", metav1.GetOptions{})
+		require.NoError(t, err)
+		if len(binding.Status.ExportPermissionClaims) == 0 {
+			return apierrors.NewConflict(apisv1alpha1.Resource("apibindings"), binding.Name, fmt.Errorf("export permission claims not reported yet"))
+		}
+		binding.Spec.PermissionClaims = nil
+		for _, claim := range binding.Status.ExportPermissionClaims {
+			binding.Spec.PermissionClaims = append(binding.Spec.PermissionClaims, apisv1alpha1.AcceptablePermissionClaim{
+				PermissionClaim: claim,
+				State:           apisv1alpha1.ClaimAccepted,
+			})
+		}
+		_, err = kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Update(ctx, binding, metav1.UpdateOptions{})
+		return err
+	})
+	require.NoError(t, err, "error accepting the permission claims")
+
+	t.Logf("Create a claimed and an unclaimed configmap in %q", consumerPath)
+	for _, name := range []string{"claimed", "unclaimed"} {
+		_, err := kubeClusterClient.Cluster(consumerPath).CoreV1().ConfigMaps("default").Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Data:       map[string]string{"owner": "consumer"},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	t.Logf("Waiting for the APIExport to have a virtual workspace URL for %q", consumerPath)
+	vwCfg := rest.CopyConfig(cfg)
+	framework.Eventually(t, func() (bool, string) {
+		apiExport, err := kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIExports().Get(ctx, "today-cowboys", metav1.GetOptions{})
+		require.NoError(t, err)
+		var found bool
+		vwCfg.Host, found, err = framework.VirtualWorkspaceURL(ctx, kcpClusterClient, consumer, framework.ExportVirtualWorkspaceURLs(apiExport))
+		require.NoError(t, err)
+		//nolint:staticcheck // SA1019 VirtualWorkspaces is deprecated but not removed yet
+		return found, fmt.Sprintf("waiting for virtual workspace URLs to be available: %v", apiExport.Status.VirtualWorkspaces)
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+
+	vwClusterClient, err := kcpdynamic.NewForConfig(vwCfg)
+	require.NoError(t, err)
+	configMaps := vwClusterClient.Cluster(logicalcluster.Name(consumer.Spec.Cluster).Path()).Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("default")
+
+	t.Logf("Waiting for the claimed configmap to be visible through the virtual workspace")
+	framework.Eventually(t, func() (bool, string) {
+		_, err := configMaps.Get(ctx, "claimed", metav1.GetOptions{})
+		return err == nil, fmt.Sprintf("waiting for the claimed configmap: %v", err)
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+
+	t.Logf("Verify that the unclaimed configmap can neither be updated, patched nor deleted through the virtual workspace")
+	unclaimed, err := kubeClusterClient.Cluster(consumerPath).CoreV1().ConfigMaps("default").Get(ctx, "unclaimed", metav1.GetOptions{})
+	require.NoError(t, err)
+	claimed, err := configMaps.Get(ctx, "claimed", metav1.GetOptions{})
+	require.NoError(t, err)
+	claimed.SetName("unclaimed")
+	claimed.SetResourceVersion(unclaimed.ResourceVersion)
+	claimed.SetUID(unclaimed.UID)
+	_, err = configMaps.Update(ctx, claimed, metav1.UpdateOptions{})
+	require.True(t, apierrors.IsNotFound(err), "expected update of the unclaimed configmap to fail with NotFound, got %v", err)
+	_, err = configMaps.Patch(ctx, "unclaimed", types.MergePatchType, []byte(`{"data":{"owner":"provider"}}`), metav1.PatchOptions{})
+	require.True(t, apierrors.IsNotFound(err), "expected patch of the unclaimed configmap to fail with NotFound, got %v", err)
+	err = configMaps.Delete(ctx, "unclaimed", metav1.DeleteOptions{})
+	require.True(t, apierrors.IsNotFound(err), "expected delete of the unclaimed configmap to fail with NotFound, got %v", err)
+
+	t.Logf("Delete all configmaps in the namespace through the virtual workspace")
+	err = configMaps.DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{})
+	require.NoError(t, err)
+
+	t.Logf("Verify that only the claimed configmap is deleted, and the unclaimed one is untouched")
+	framework.Eventually(t, func() (bool, string) {
+		_, err := kubeClusterClient.Cluster(consumerPath).CoreV1().ConfigMaps("default").Get(ctx, "claimed", metav1.GetOptions{})
+		return apierrors.IsNotFound(err), fmt.Sprintf("waiting for the claimed configmap to be deleted: %v", err)
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+	current, err := kubeClusterClient.Cluster(consumerPath).CoreV1().ConfigMaps("default").Get(ctx, "unclaimed", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, unclaimed.ResourceVersion, current.ResourceVersion, "expected the unclaimed configmap not to be modified")
+	require.Equal(t, "consumer", current.Data["owner"])
+}
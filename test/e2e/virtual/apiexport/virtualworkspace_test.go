@@ -674,7 +674,8 @@ func TestAPIExportPermissionClaims(t *testing.T) {
 		require.NoError(t, err)
 		for _, claim := range binding.Status.AppliedPermissionClaims {
 			if claim.IdentityHash == identityHash {
-				return true, "found applied claim for identity"
+				// the sheriffs APIExport has no maximal permission policy.
+				return claim.Authorized, "found applied claim for identity, but it is not authorized"
 			}
 		}
 		return false, "unable to find applied claim for identity"
@@ -773,6 +774,178 @@ func TestAPIExportPermissionClaims(t *testing.T) {
 	}, wait.ForeverTestTimeout, 100*time.Millisecond, "expected to eventually get 0 sheriffs")
 }
 
+func TestAPIExportPermissionClaimChains(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := framework.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	sheriffsProviderPath, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("sheriffs-provider"))
+	cowboysProviderPath, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("cowboys-provider"))
+	marshalsProviderPath, _ := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("marshals-provider"))
+	consumerPath, consumer := framework.NewWorkspaceFixture(t, server, orgPath, framework.WithName("consumer"))
+
+	cfg := server.BaseConfig(t)
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client for server")
+	wildwestClusterClient, err := wildwestclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct wildwest cluster client for server")
+
+	getIdentityHash := func(path logicalcluster.Path, name string) string {
+		t.Helper()
+		framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+			return kcpClusterClient.Cluster(path).ApisV1alpha1().APIExports().Get(ctx, name, metav1.GetOptions{})
+		}, framework.Is(apisv1alpha1.APIExportIdentityValid))
+		export, err := kcpClusterClient.Cluster(path).ApisV1alpha1().APIExports().Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return export.Status.IdentityHash
+	}
+
+	addClaim := func(path logicalcluster.Path, name string, claim apisv1alpha1.PermissionClaim) {
+		t.Helper()
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			export, err := kcpClusterClient.Cluster(path).ApisV1alpha1().APIExports().Get(ctx, name, metav1.GetOptions{})
+			require.NoError(t, err)
+			export.Spec.PermissionClaims = append(export.Spec.PermissionClaims, claim)
+			_, err = kcpClusterClient.Cluster(path).ApisV1alpha1().APIExports().Update(ctx, export, metav1.UpdateOptions{})
+			return err
+		})
+		require.NoError(t, err, "error adding permission claim to APIExport %s|%s", path, name)
+	}
+
+	t.Logf("Create sheriffs APIExport in %s", sheriffsProviderPath)
+	apifixtures.CreateSheriffsSchemaAndExport(ctx, t, sheriffsProviderPath, kcpClusterClient, "wild.wild.west", "sheriffs at the bottom of the chain")
+	sheriffsIdentityHash := getIdentityHash(sheriffsProviderPath, "wild.wild.west")
+	sheriffsClaim := apisv1alpha1.PermissionClaim{
+		GroupResource: apisv1alpha1.GroupResource{Group: "wild.wild.west", Resource: "sheriffs"},
+		IdentityHash:  sheriffsIdentityHash,
+		All:           true,
+	}
+
+	t.Logf("Create cowboys APIExport in %s claiming sheriffs", cowboysProviderPath)
+	setUpServiceProvider(ctx, t, dynamicClusterClient, kcpClusterClient, cowboysProviderPath, cfg, sheriffsClaim)
+	cowboysIdentityHash := getIdentityHash(cowboysProviderPath, "today-cowboys")
+	cowboysClaim := apisv1alpha1.PermissionClaim{
+		GroupResource: apisv1alpha1.GroupResource{Group: wildwest.GroupName, Resource: "cowboys"},
+		IdentityHash:  cowboysIdentityHash,
+		All:           true,
+	}
+
+	t.Logf("Create marshals APIExport in %s claiming cowboys", marshalsProviderPath)
+	apifixtures.CreateSheriffsSchemaAndExport(ctx, t, marshalsProviderPath, kcpClusterClient, "marshals.wild.west", "sheriffs at the top of the chain")
+	addClaim(marshalsProviderPath, "marshals.wild.west", cowboysClaim)
+	marshalsIdentityHash := getIdentityHash(marshalsProviderPath, "marshals.wild.west")
+
+	t.Logf("Bind the whole chain into %s and accept all claims", consumerPath)
+	apifixtures.BindToExport(ctx, t, sheriffsProviderPath, "wild.wild.west", consumerPath, kcpClusterClient)
+	apifixtures.CreateSheriff(ctx, t, dynamicClusterClient, consumerPath, "wild.wild.west", "sheriff")
+	bindConsumerToProvider(ctx, t, consumerPath, cowboysProviderPath, kcpClusterClient, cfg, apisv1alpha1.AcceptablePermissionClaim{
+		PermissionClaim: sheriffsClaim,
+		State:           apisv1alpha1.ClaimAccepted,
+	})
+	createCowboyInConsumer(ctx, t, consumerPath, wildwestClusterClient)
+	marshalsBinding := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "marshals",
+		},
+		Spec: apisv1alpha1.APIBindingSpec{
+			Reference: apisv1alpha1.BindingReference{
+				Export: &apisv1alpha1.ExportBindingReference{
+					Path: marshalsProviderPath.String(),
+					Name: "marshals.wild.west",
+				},
+			},
+			PermissionClaims: []apisv1alpha1.AcceptablePermissionClaim{
+				{PermissionClaim: cowboysClaim, State: apisv1alpha1.ClaimAccepted},
+			},
+		},
+	}
+	framework.Eventually(t, func() (bool, string) {
+		_, err := kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Create(ctx, marshalsBinding, metav1.CreateOptions{})
+		return err == nil, fmt.Sprintf("error creating APIBinding: %v", err)
+	}, wait.ForeverTestTimeout, time.Millisecond*100)
+	framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Get(ctx, marshalsBinding.Name, metav1.GetOptions{})
+	}, framework.Is(apisv1alpha1.InitialBindingCompleted))
+
+	vwClient := func(path logicalcluster.Path, name string) kcpdynamic.ClusterInterface {
+		t.Helper()
+		vwCfg := rest.CopyConfig(cfg)
+		framework.Eventually(t, func() (bool, string) {
+			apiExport, err := kcpClusterClient.Cluster(path).ApisV1alpha1().APIExports().Get(ctx, name, metav1.GetOptions{})
+			require.NoError(t, err)
+			var found bool
+			vwCfg.Host, found, err = framework.VirtualWorkspaceURL(ctx, kcpClusterClient, consumer, framework.ExportVirtualWorkspaceURLs(apiExport))
+			require.NoError(t, err)
+			//nolint:staticcheck // SA1019 VirtualWorkspaces is deprecated but not removed yet
+			return found, fmt.Sprintf("waiting for virtual workspace URLs to be available: %v", apiExport.Status.VirtualWorkspaces)
+		}, wait.ForeverTestTimeout, time.Millisecond*100)
+		client, err := kcpdynamic.NewForConfig(vwCfg)
+		require.NoError(t, err)
+		return client
+	}
+
+	expectOneItem := func(client kcpdynamic.ClusterInterface, gvr schema.GroupVersionResource) func() (bool, string) {
+		return func() (bool, string) {
+			list, err := client.Resource(gvr).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return false, err.Error()
+			}
+			var names []string
+			for _, item := range list.Items {
+				names = append(names, item.GetName())
+			}
+			return len(names) == 1, fmt.Sprintf("waiting for exactly one %s, got %v", gvr.Resource, names)
+		}
+	}
+
+	t.Logf("Verify that sheriffs are visible through the cowboys virtual workspace")
+	cowboysVWClient := vwClient(cowboysProviderPath, "today-cowboys")
+	framework.Eventually(t, expectOneItem(cowboysVWClient, schema.GroupVersionResource{Group: "wild.wild.west", Version: "v1", Resource: "sheriffs"}),
+		wait.ForeverTestTimeout, 100*time.Millisecond, "expected to see the sheriff through the cowboys virtual workspace")
+
+	t.Logf("Verify that cowboys are visible through the marshals virtual workspace")
+	marshalsVWClient := vwClient(marshalsProviderPath, "marshals.wild.west")
+	framework.Eventually(t, expectOneItem(marshalsVWClient, wildwestv1alpha1.SchemeGroupVersion.WithResource("cowboys")),
+		wait.ForeverTestTimeout, 100*time.Millisecond, "expected to see the cowboy through the marshals virtual workspace")
+
+	chain := []struct {
+		path logicalcluster.Path
+		name string
+	}{
+		{sheriffsProviderPath, "wild.wild.west"},
+		{cowboysProviderPath, "today-cowboys"},
+		{marshalsProviderPath, "marshals.wild.west"},
+	}
+
+	t.Logf("Verify that the claims of all APIExports of the chain are valid")
+	for _, export := range chain {
+		framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+			return kcpClusterClient.Cluster(export.path).ApisV1alpha1().APIExports().Get(ctx, export.name, metav1.GetOptions{})
+		}, framework.Is(apisv1alpha1.APIExportPermissionClaimsValid))
+	}
+
+	t.Logf("Close the chain into a cycle by letting sheriffs claim marshals")
+	addClaim(sheriffsProviderPath, "wild.wild.west", apisv1alpha1.PermissionClaim{
+		GroupResource: apisv1alpha1.GroupResource{Group: "marshals.wild.west", Resource: "sheriffs"},
+		IdentityHash:  marshalsIdentityHash,
+		All:           true,
+	})
+
+	t.Logf("Verify that the cycle is detected on all APIExports of the chain")
+	for _, export := range chain {
+		framework.EventuallyCondition(t, func() (conditions.Getter, error) {
+			return kcpClusterClient.Cluster(export.path).ApisV1alpha1().APIExports().Get(ctx, export.name, metav1.GetOptions{})
+		}, framework.IsNot(apisv1alpha1.APIExportPermissionClaimsValid).WithReason(apisv1alpha1.PermissionClaimCycleReason))
+	}
+}
+
 func TestAPIExportInternalAPIsDrift(t *testing.T) {
 	t.Parallel()
 	framework.Suite(t, "control-plane")
@@ -322,6 +322,108 @@ func TestWorkspaceTypes(t *testing.T) {
 				}, wait.ForeverTestTimeout, 100*time.Millisecond, "workspace should be ready")
 			},
 		},
+		{
+			name: "create a workspace with a type that has multiple initializers",
+			work: func(ctx context.Context, t *testing.T, server runningServer) {
+				t.Helper()
+
+				universalPath, _ := framework.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+				t.Logf("Create type Bar with an initializer")
+				bar, err := server.kcpClusterClient.Cluster(universalPath).TenancyV1alpha1().WorkspaceTypes().Create(ctx, &tenancyv1alpha1.WorkspaceType{
+					ObjectMeta: metav1.ObjectMeta{Name: "bar"},
+					Spec: tenancyv1alpha1.WorkspaceTypeSpec{
+						Initializer: true,
+					},
+				}, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create workspace type")
+
+				t.Logf("Create type Foo with an initializer, extending Bar")
+				foo, err := server.kcpClusterClient.Cluster(universalPath).TenancyV1alpha1().WorkspaceTypes().Create(ctx, &tenancyv1alpha1.WorkspaceType{
+					ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+					Spec: tenancyv1alpha1.WorkspaceTypeSpec{
+						Initializer: true,
+						Extend: tenancyv1alpha1.WorkspaceTypeExtension{
+							With: []tenancyv1alpha1.WorkspaceTypeReference{
+								{Name: "bar", Path: universalPath.String()},
+							},
+						},
+					},
+				}, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create workspace type")
+				server.Artifact(t, func() (runtime.Object, error) {
+					return server.kcpClusterClient.Cluster(universalPath).TenancyV1alpha1().WorkspaceTypes().Get(ctx, "foo", metav1.GetOptions{})
+				})
+				for _, wtName := range []string{bar.Name, foo.Name} {
+					wtName := wtName
+					t.Logf("Wait for type %s to be usable", wtName)
+					framework.EventuallyReady(t, func() (conditions.Getter, error) {
+						return server.kcpClusterClient.Cluster(universalPath).TenancyV1alpha1().WorkspaceTypes().Get(ctx, wtName, metav1.GetOptions{})
+					}, "could not wait for readiness on WorkspaceType %s|%s", universalPath.String(), wtName)
+				}
+
+				t.Logf("Create workspace with explicit type Foo")
+				var workspace *tenancyv1alpha1.Workspace
+				require.Eventually(t, func() bool {
+					// note: admission is informer based and hence would race with this create call
+					workspace, err = server.kcpClusterClient.TenancyV1alpha1().Workspaces().Cluster(universalPath).Create(ctx, &tenancyv1alpha1.Workspace{
+						ObjectMeta: metav1.ObjectMeta{Name: "myapp"},
+						Spec: tenancyv1alpha1.WorkspaceSpec{
+							Type: tenancyv1alpha1.WorkspaceTypeReference{
+								Name: "foo",
+								Path: logicalcluster.From(foo).String(),
+							},
+						},
+					}, metav1.CreateOptions{})
+					if err != nil {
+						t.Logf("error creating workspace: %v", err)
+					}
+					return err == nil
+				}, wait.ForeverTestTimeout, time.Millisecond*100, "failed to create workspace even with type")
+				server.Artifact(t, func() (runtime.Object, error) {
+					return server.kcpClusterClient.TenancyV1alpha1().Workspaces().Cluster(universalPath).Get(ctx, "myapp", metav1.GetOptions{})
+				})
+
+				t.Logf("Wait for the workspace to be initializing")
+				framework.Eventually(t, func() (success bool, reason string) {
+					workspace, err = server.kcpClusterClient.TenancyV1alpha1().Workspaces().Cluster(universalPath).Get(ctx, workspace.Name, metav1.GetOptions{})
+					if err != nil {
+						return false, err.Error()
+					}
+					if actual, expected := workspace.Status.Phase, corev1alpha1.LogicalClusterPhaseInitializing; actual != expected {
+						return false, fmt.Sprintf("workspace phase was %s, not %s", actual, expected)
+					}
+					return true, ""
+				}, wait.ForeverTestTimeout, time.Millisecond*100, "failed to wait for new workspace to be initializing")
+
+				t.Logf("Remove the initializer of type Bar")
+				clusterPath := logicalcluster.Name(workspace.Spec.Cluster).Path()
+				err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+					logicalCluster, err := server.kcpClusterClient.CoreV1alpha1().LogicalClusters().Cluster(clusterPath).Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+					require.NoError(t, err)
+					logicalCluster.Status.Initializers = initialization.EnsureInitializerAbsent(initialization.InitializerForType(bar), logicalCluster.Status.Initializers)
+					_, err = server.kcpClusterClient.CoreV1alpha1().LogicalClusters().Cluster(clusterPath).UpdateStatus(ctx, logicalCluster, metav1.UpdateOptions{})
+					return err
+				})
+				require.NoError(t, err)
+
+				t.Logf("Expect workspace to stay initializing with the initializer of Foo pending and the one of Bar completed")
+				expectedMessage := fmt.Sprintf("Initializers still exist: [%s], completed: [%s]", initialization.InitializerForType(foo), initialization.InitializerForType(bar))
+				framework.Eventually(t, func() (success bool, reason string) {
+					workspace, err = server.kcpClusterClient.TenancyV1alpha1().Workspaces().Cluster(universalPath).Get(ctx, workspace.Name, metav1.GetOptions{})
+					if err != nil {
+						return false, err.Error()
+					}
+					if actual, expected := workspace.Status.Phase, corev1alpha1.LogicalClusterPhaseInitializing; actual != expected {
+						return false, fmt.Sprintf("workspace phase was %s, not %s", actual, expected)
+					}
+					if actual, expected := conditions.GetMessage(workspace, tenancyv1alpha1.WorkspaceInitialized), expectedMessage; actual != expected {
+						return false, fmt.Sprintf("workspace initialized condition message was %q, not %q", actual, expected)
+					}
+					return true, ""
+				}, wait.ForeverTestTimeout, time.Millisecond*100, "failed to wait for workspace to report the completed initializer")
+				require.Equal(t, []corev1alpha1.LogicalClusterInitializer{initialization.InitializerForType(foo)}, workspace.Status.Initializers)
+			},
+		},
 	}
 
 	server := framework.SharedKcpServer(t)